@@ -56,7 +56,7 @@ func (f *FPSController) Update(deltaTime float32) {
 	}
 
 	// Mouse look
-	mouseDelta := rl.GetMouseDelta()
+	mouseDelta := engine.Input.GetMouseDelta()
 	f.Yaw += mouseDelta.X * f.LookSpeed
 	f.Pitch -= mouseDelta.Y * f.LookSpeed
 
@@ -73,19 +73,19 @@ func (f *FPSController) Update(deltaTime float32) {
 
 	// Build horizontal movement from input
 	var moveDirX, moveDirZ float32
-	if rl.IsKeyDown(rl.KeyW) {
+	if engine.Input.IsKeyDown(rl.KeyW) {
 		moveDirX += forward.X
 		moveDirZ += forward.Z
 	}
-	if rl.IsKeyDown(rl.KeyS) {
+	if engine.Input.IsKeyDown(rl.KeyS) {
 		moveDirX -= forward.X
 		moveDirZ -= forward.Z
 	}
-	if rl.IsKeyDown(rl.KeyA) {
+	if engine.Input.IsKeyDown(rl.KeyA) {
 		moveDirX += right.X
 		moveDirZ += right.Z
 	}
-	if rl.IsKeyDown(rl.KeyD) {
+	if engine.Input.IsKeyDown(rl.KeyD) {
 		moveDirX -= right.X
 		moveDirZ -= right.Z
 	}
@@ -107,7 +107,7 @@ func (f *FPSController) Update(deltaTime float32) {
 		}
 
 		// Jump
-		if rl.IsKeyPressed(rl.KeySpace) && f.charController.IsGrounded() {
+		if engine.Input.IsKeyPressed(rl.KeySpace) && f.charController.IsGrounded() {
 			f.charController.SetVelocityY(f.JumpStrength)
 		}
 
@@ -17,12 +17,12 @@ type Shooter struct {
 }
 
 func (s *Shooter) Update(deltaTime float32) {
-	if rl.IsMouseButtonDown(rl.MouseLeftButton) && rl.GetTime()-s.lastShotTime >= s.Cooldown {
+	if engine.Input.IsMouseButtonDown(rl.MouseLeftButton) && engine.Time.ElapsedTime-s.lastShotTime >= s.Cooldown {
 		s.Shoot()
-		s.lastShotTime = rl.GetTime()
+		s.lastShotTime = engine.Time.ElapsedTime
 	}
 
-	if rl.IsMouseButtonPressed(rl.MouseRightButton) {
+	if engine.Input.IsMouseButtonPressed(rl.MouseRightButton) {
 		s.DeleteTarget()
 	}
 }
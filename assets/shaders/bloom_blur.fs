@@ -0,0 +1,22 @@
+#version 330
+
+// Bloom blur: one direction of a separable 9-tap Gaussian
+
+in vec2 fragTexCoord;
+
+uniform sampler2D texture0;
+uniform vec2 direction; // texel offset, (1/width, 0) or (0, 1/height)
+
+out vec4 finalColor;
+
+const float weights[5] = float[](0.227027, 0.1945946, 0.1216216, 0.054054, 0.016216);
+
+void main() {
+    vec3 result = texture(texture0, fragTexCoord).rgb * weights[0];
+    for (int i = 1; i < 5; i++) {
+        vec2 offset = direction * float(i);
+        result += texture(texture0, fragTexCoord + offset).rgb * weights[i];
+        result += texture(texture0, fragTexCoord - offset).rgb * weights[i];
+    }
+    finalColor = vec4(result, 1.0);
+}
@@ -0,0 +1,17 @@
+#version 330
+
+// Bloom bright pass: keeps only the part of each pixel above the threshold
+
+in vec2 fragTexCoord;
+
+uniform sampler2D texture0;
+uniform float threshold;
+
+out vec4 finalColor;
+
+void main() {
+    vec3 color = texture(texture0, fragTexCoord).rgb;
+    float luminance = dot(color, vec3(0.2126, 0.7152, 0.0722));
+    float bright = max(luminance - threshold, 0.0) / max(luminance, 1e-4);
+    finalColor = vec4(color * bright, 1.0);
+}
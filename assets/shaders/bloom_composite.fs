@@ -0,0 +1,17 @@
+#version 330
+
+// Bloom composite: adds the blurred bright pass back onto the scene
+
+in vec2 fragTexCoord;
+
+uniform sampler2D texture0;    // scene
+uniform sampler2D bloomTexture;
+uniform float intensity;
+
+out vec4 finalColor;
+
+void main() {
+    vec3 scene = texture(texture0, fragTexCoord).rgb;
+    vec3 bloom = texture(bloomTexture, fragTexCoord).rgb;
+    finalColor = vec4(scene + bloom * intensity, 1.0);
+}
@@ -15,13 +15,18 @@ uniform sampler2D shadowMap;
 uniform vec4 colDiffuse;
 uniform vec3 viewPos;
 uniform vec4 ambient;
-uniform vec3 lightDir;
-uniform vec4 lightColor;
+
+// Directional lights (up to 4); only the first casts shadows
+#define MAX_DIR_LIGHTS 4
+uniform int dirLightCount;
+uniform vec3 dirLightDir[MAX_DIR_LIGHTS];
+uniform vec3 dirLightColor[MAX_DIR_LIGHTS];
 
 // Material properties
 uniform float metallic;   // 0 = diffuse, 1 = metallic
 uniform float roughness;  // 0 = shiny, 1 = rough
 uniform float emissive;   // emission intensity
+uniform float alphaCutoff; // alpha-test threshold (0 = opaque)
 
 // Point lights (up to 4)
 #define MAX_POINT_LIGHTS 4
@@ -30,6 +35,23 @@ uniform vec3 pointLightPos[MAX_POINT_LIGHTS];
 uniform vec3 pointLightColor[MAX_POINT_LIGHTS];
 uniform float pointLightRadius[MAX_POINT_LIGHTS];
 
+// Spot lights (up to 4): point lights limited to a cone
+#define MAX_SPOT_LIGHTS 4
+uniform int spotLightCount;
+uniform vec3 spotLightPos[MAX_SPOT_LIGHTS];
+uniform vec3 spotLightDir[MAX_SPOT_LIGHTS];
+uniform vec3 spotLightColor[MAX_SPOT_LIGHTS];
+uniform float spotLightRange[MAX_SPOT_LIGHTS];
+uniform float spotLightCosInner[MAX_SPOT_LIGHTS]; // cos of the full-strength angle
+uniform float spotLightCosOuter[MAX_SPOT_LIGHTS]; // cos of the cone edge
+
+// Distance fog (fogMode 0 = off, 1 = linear, 2 = exponential)
+uniform int fogMode;
+uniform vec3 fogColor;
+uniform float fogStart;
+uniform float fogEnd;
+uniform float fogDensity;
+
 out vec4 finalColor;
 
 float calculateShadow(vec3 normal, vec3 lightDirection)
@@ -65,25 +87,18 @@ void main()
     vec3 normal = normalize(fragNormal);
 
     vec3 viewDir = normalize(viewPos - fragPosition);
-    vec3 lightDirection = normalize(-lightDir);
-
-    // Calculate shadow factor (pass normal and light dir for slope-scaled bias)
-    float shadow = calculateShadow(normal, lightDirection);
 
     // Base color - sample albedo texture and multiply by vertex color and diffuse
     vec4 texColor = texture(texture0, fragTexCoord);
-    vec3 baseColor = texColor.rgb * colDiffuse.rgb;
 
-    // Diffuse lighting
-    float NdotL = dot(normal, lightDirection);
-    float diff = max(NdotL, 0.0);
+    // Alpha cutout: drop fragments below the threshold (also cuts their shadow)
+    if (alphaCutoff > 0.0 && texColor.a * colDiffuse.a < alphaCutoff) {
+        discard;
+    }
+    vec3 baseColor = texColor.rgb * colDiffuse.rgb;
 
-    // Specular (Blinn-Phong) - shininess based on roughness
-    vec3 halfwayDir = normalize(lightDirection + viewDir);
-    float NdotH = max(dot(normal, halfwayDir), 0.0);
     // Roughness affects specular power: rough = wide soft highlight, smooth = tight sharp highlight
     float shininess = mix(64.0, 4.0, roughness);
-    float spec = pow(NdotH, shininess);
     // Roughness also affects specular intensity
     float specIntensity = mix(1.0, 0.1, roughness);
 
@@ -91,20 +106,39 @@ void main()
     float fresnel = pow(1.0 - max(dot(normal, viewDir), 0.0), 3.0);
     fresnel = mix(fresnel * 0.5, fresnel, metallic);
 
-    // Rim lighting
-    float rim = fresnel * max(dot(normal, lightDirection), 0.0);
-
     // Metallic affects how specular color is derived
     // Non-metals: white specular, Metals: specular tinted by base color
     vec3 specColor = mix(vec3(1.0), baseColor, metallic);
 
-    // Combine lighting components - apply shadow to diffuse and specular
-    vec3 diffuseLight = diff * lightColor.rgb * shadow;
-    // Metals have reduced diffuse (energy conservation)
-    diffuseLight *= (1.0 - metallic * 0.8);
+    // Directional lights contribution
+    vec3 dirLighting = vec3(0.0);
+    for (int i = 0; i < dirLightCount && i < MAX_DIR_LIGHTS; i++) {
+        vec3 lightDirection = normalize(-dirLightDir[i]);
+
+        // Shadow factor (pass normal and light dir for slope-scaled bias)
+        float shadow = (i == 0) ? calculateShadow(normal, lightDirection) : 1.0;
+
+        // Diffuse lighting
+        float diff = max(dot(normal, lightDirection), 0.0);
+
+        // Specular (Blinn-Phong) - shininess based on roughness
+        vec3 halfwayDir = normalize(lightDirection + viewDir);
+        float spec = pow(max(dot(normal, halfwayDir), 0.0), shininess);
+
+        // Rim lighting
+        float rim = fresnel * diff;
+
+        // Combine lighting components - apply shadow to diffuse and specular
+        vec3 diffuseLight = diff * dirLightColor[i] * shadow;
+        // Metals have reduced diffuse (energy conservation)
+        diffuseLight *= (1.0 - metallic * 0.8);
+
+        vec3 specularLight = spec * specColor * dirLightColor[i] * specIntensity * shadow;
+        vec3 rimLight = rim * dirLightColor[i] * 0.5 * shadow;
+
+        dirLighting += diffuseLight + specularLight + rimLight;
+    }
 
-    vec3 specularLight = spec * specColor * lightColor.rgb * specIntensity * shadow;
-    vec3 rimLight = rim * lightColor.rgb * 0.5 * shadow;
     vec3 fresnelLight = fresnel * ambient.rgb * 0.3;
 
     // Point lights contribution
@@ -131,13 +165,38 @@ void main()
         pointLighting += pointDiffuse + pointSpecular;
     }
 
+    // Spot lights contribution
+    vec3 spotLighting = vec3(0.0);
+    for (int i = 0; i < spotLightCount && i < MAX_SPOT_LIGHTS; i++) {
+        vec3 toLight = spotLightPos[i] - fragPosition;
+        float distance = length(toLight);
+        vec3 spotLightDirection = normalize(toLight);
+
+        // Same distance falloff as point lights
+        float attenuation = clamp(1.0 - distance / spotLightRange[i], 0.0, 1.0);
+        attenuation *= attenuation;
+
+        // Smooth cone falloff from the inner to the outer angle
+        float theta = dot(-spotLightDirection, normalize(spotLightDir[i]));
+        attenuation *= smoothstep(spotLightCosOuter[i], spotLightCosInner[i], theta);
+
+        float spotDiff = max(dot(normal, spotLightDirection), 0.0);
+        vec3 spotHalfway = normalize(spotLightDirection + viewDir);
+        float spotSpec = pow(max(dot(normal, spotHalfway), 0.0), shininess);
+
+        vec3 spotDiffuse = spotDiff * spotLightColor[i] * attenuation;
+        vec3 spotSpecular = spotSpec * specColor * spotLightColor[i] * specIntensity * attenuation;
+
+        spotLighting += spotDiffuse + spotSpecular;
+    }
+
     // Fake ambient occlusion - darken objects near ground (Y=0)
     float aoHeight = 2.0;  // height over which AO fades out
     float aoStrength = 0.4;  // how dark at ground level (0 = black, 1 = no effect)
     float ao = mix(aoStrength, 1.0, clamp(fragPosition.y / aoHeight, 0.0, 1.0));
 
     // Final lighting (ambient is not affected by shadow, but is affected by AO)
-    vec3 lighting = ambient.rgb * ao + diffuseLight + specularLight + rimLight + fresnelLight + pointLighting;
+    vec3 lighting = ambient.rgb * ao + dirLighting + fresnelLight + pointLighting + spotLighting;
 
     // Apply material color
     vec3 result = lighting * baseColor;
@@ -151,6 +210,15 @@ void main()
     // Gamma correction
     result = pow(result, vec3(1.0/2.2));
 
+    // Distance fog, blended in display space so it matches the clear color
+    if (fogMode > 0) {
+        float dist = length(viewPos - fragPosition);
+        float fog = (fogMode == 1)
+            ? (dist - fogStart) / max(fogEnd - fogStart, 0.001)
+            : 1.0 - exp(-fogDensity * dist);
+        result = mix(result, fogColor, clamp(fog, 0.0, 1.0));
+    }
+
     // DEBUG: Uncomment ONE of these to visualize:
     // result = normal * 0.5 + 0.5;  // Normals as color
     // result = texture(texture1, fragTexCoord).rgb;  // Normal map texture
@@ -9,6 +9,9 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -22,6 +25,8 @@ type FieldInfo struct {
 	Name     string
 	Type     string
 	JSONName string
+	Range    string // "min,max" from a `mirgo:"range:min,max"` tag, empty if unbounded
+	Options  string // "a,b,c" from a `// @options: a,b,c` comment, empty if any string is allowed
 }
 
 func main() {
@@ -120,6 +125,7 @@ func parseScript(content string) (*ScriptInfo, error) {
 	}
 
 	var scriptInfo *ScriptInfo
+	var tagErr error
 
 	// Walk the AST to find struct declarations
 	ast.Inspect(node, func(n ast.Node) bool {
@@ -131,6 +137,7 @@ func parseScript(content string) (*ScriptInfo, error) {
 					Name:   typeSpec.Name.Name,
 					Fields: []FieldInfo{},
 				}
+				tagErr = nil
 
 				// Extract fields
 				for _, field := range structType.Fields.List {
@@ -147,21 +154,29 @@ func parseScript(content string) (*ScriptInfo, error) {
 
 						fieldType := exprToString(field.Type)
 
-						// Skip embedded structs with package qualifiers (except GameObjectRef)
-						if strings.Contains(fieldType, ".") && fieldType != "engine.GameObjectRef" {
+						// Strip package prefix for known types (also inside []GameObjectRef)
+						cleanType := strings.ReplaceAll(fieldType, "engine.GameObjectRef", "GameObjectRef")
+
+						// Skip other types with package qualifiers
+						if strings.Contains(cleanType, ".") {
 							continue
 						}
 
-						// Strip package prefix for known types
-						cleanType := fieldType
-						if fieldType == "engine.GameObjectRef" {
-							cleanType = "GameObjectRef"
+						fieldRange, err := parseRangeTag(field.Tag, cleanType)
+						if err != nil && tagErr == nil {
+							tagErr = fmt.Errorf("field %s: %w", name.Name, err)
+						}
+						options, err := parseOptionsComment(field, cleanType)
+						if err != nil && tagErr == nil {
+							tagErr = fmt.Errorf("field %s: %w", name.Name, err)
 						}
 
 						scriptInfo.Fields = append(scriptInfo.Fields, FieldInfo{
 							Name:     name.Name,
 							Type:     cleanType,
 							JSONName: toSnakeCase(name.Name),
+							Range:    fieldRange,
+							Options:  options,
 						})
 					}
 				}
@@ -175,10 +190,85 @@ func parseScript(content string) (*ScriptInfo, error) {
 	if scriptInfo == nil {
 		return nil, fmt.Errorf("no struct definition found")
 	}
+	if tagErr != nil {
+		return nil, tagErr
+	}
 
 	return scriptInfo, nil
 }
 
+// parseRangeTag reads a `mirgo:"range:min,max"` struct tag and returns
+// "min,max". Options in the mirgo tag are separated by ';'.
+func parseRangeTag(tag *ast.BasicLit, fieldType string) (string, error) {
+	if tag == nil {
+		return "", nil
+	}
+	raw, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return "", fmt.Errorf("invalid struct tag %s", tag.Value)
+	}
+	for _, opt := range strings.Split(reflect.StructTag(raw).Get("mirgo"), ";") {
+		spec, ok := strings.CutPrefix(strings.TrimSpace(opt), "range:")
+		if !ok {
+			continue
+		}
+		if fieldType != "float32" && fieldType != "float64" {
+			return "", fmt.Errorf("range tag needs a float field, got %s", fieldType)
+		}
+		lo, hi, ok := strings.Cut(spec, ",")
+		if !ok {
+			return "", fmt.Errorf("range tag %q should be range:min,max", spec)
+		}
+		minVal, err1 := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+		maxVal, err2 := strconv.ParseFloat(strings.TrimSpace(hi), 64)
+		if err1 != nil || err2 != nil {
+			return "", fmt.Errorf("range tag %q has a non-numeric bound", spec)
+		}
+		if minVal >= maxVal {
+			return "", fmt.Errorf("range tag %q has min >= max", spec)
+		}
+		return strconv.FormatFloat(minVal, 'g', -1, 64) + "," + strconv.FormatFloat(maxVal, 'g', -1, 64), nil
+	}
+	return "", nil
+}
+
+// parseOptionsComment reads a `// @options: a,b,c` comment above or after a
+// string field and returns "a,b,c": the only values the field may hold, shown
+// as a dropdown in the inspector.
+func parseOptionsComment(field *ast.Field, fieldType string) (string, error) {
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			spec, ok := strings.CutPrefix(text, "@options:")
+			if !ok {
+				continue
+			}
+			if fieldType != "string" {
+				return "", fmt.Errorf("@options needs a string field, got %s", fieldType)
+			}
+			var options []string
+			for _, opt := range strings.Split(spec, ",") {
+				opt = strings.TrimSpace(opt)
+				if opt == "" {
+					return "", fmt.Errorf("@options %q has an empty option", strings.TrimSpace(spec))
+				}
+				if strings.ContainsAny(opt, `;"\`) {
+					return "", fmt.Errorf("@options value %q can't contain ; \" or \\", opt)
+				}
+				if slices.Contains(options, opt) {
+					return "", fmt.Errorf("@options lists %q twice", opt)
+				}
+				options = append(options, opt)
+			}
+			return strings.Join(options, ","), nil
+		}
+	}
+	return "", nil
+}
+
 func exprToString(expr ast.Expr) string {
 	switch t := expr.(type) {
 	case *ast.Ident:
@@ -192,6 +282,10 @@ func exprToString(expr ast.Expr) string {
 			return "[]" + exprToString(t.Elt)
 		}
 		return fmt.Sprintf("[%s]%s", exprToString(t.Len), exprToString(t.Elt))
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", exprToString(t.Key), exprToString(t.Value))
+	case *ast.BasicLit:
+		return t.Value
 	default:
 		return "unknown"
 	}
@@ -225,20 +319,24 @@ func generateScriptFile(script *ScriptInfo, sourceContent []byte, outputPath str
 
 	f.WriteString("\n// --- Generated boilerplate below ---\n\n")
 
-	// Generate field types map if there are any GameObjectRef fields
-	hasGameObjectRef := false
+	// Generate field types map if there are any GameObjectRef, ranged or option fields
+	hasMetadata := false
 	for _, field := range script.Fields {
-		if field.Type == "GameObjectRef" {
-			hasGameObjectRef = true
+		if isObjectRefType(field.Type) || field.Range != "" || field.Options != "" {
+			hasMetadata = true
 			break
 		}
 	}
 
-	if hasGameObjectRef {
+	if hasMetadata {
 		f.WriteString(fmt.Sprintf("var %sFieldTypes = map[string]string{\n", nameLower))
 		for _, field := range script.Fields {
-			if field.Type == "GameObjectRef" {
-				f.WriteString(fmt.Sprintf("\t\"%s\": \"GameObjectRef\",\n", field.JSONName))
+			if isObjectRefType(field.Type) {
+				f.WriteString(fmt.Sprintf("\t\"%s\": \"%s\",\n", field.JSONName, field.Type))
+			} else if field.Range != "" {
+				f.WriteString(fmt.Sprintf("\t\"%s\": \"range:%s\",\n", field.JSONName, field.Range))
+			} else if field.Options != "" {
+				f.WriteString(fmt.Sprintf("\t\"%s\": \"options:%s\",\n", field.JSONName, field.Options))
 			}
 		}
 		f.WriteString("}\n\n")
@@ -253,10 +351,21 @@ func generateScriptFile(script *ScriptInfo, sourceContent []byte, outputPath str
 	f.WriteString(fmt.Sprintf("func %sFactory(props map[string]any) engine.Component {\n", nameLower))
 	f.WriteString(fmt.Sprintf("\tscript := &%s{}\n", script.Name))
 
+	// Option fields start at their first option
+	for _, field := range script.Fields {
+		if field.Options != "" {
+			first, _, _ := strings.Cut(field.Options, ",")
+			f.WriteString(fmt.Sprintf("\tscript.%s = %q\n", field.Name, first))
+		}
+	}
+
 	for _, field := range script.Fields {
-		goType, conversion := getTypeConversion(field.Type)
+		goType, decode := decodeField(field.Type, "script."+field.Name, "\t\t")
+		if field.Options != "" {
+			decode = optionsGuard(field.Options, decode, "\t\t")
+		}
 		f.WriteString(fmt.Sprintf("\tif v, ok := props[\"%s\"].(%s); ok {\n", field.JSONName, goType))
-		f.WriteString(fmt.Sprintf("\t\tscript.%s = %s\n\t}\n", field.Name, fmt.Sprintf(conversion, "v")))
+		f.WriteString(decode + "\t}\n")
 	}
 
 	f.WriteString("\treturn script\n}\n\n")
@@ -268,12 +377,22 @@ func generateScriptFile(script *ScriptInfo, sourceContent []byte, outputPath str
 	} else {
 		f.WriteString(fmt.Sprintf("\t_, ok := c.(*%s)\n\tif !ok {\n\t\treturn nil\n\t}\n", script.Name))
 	}
+	// Slices of GameObjectRef serialize as a list of UIDs
+	for _, field := range script.Fields {
+		if field.Type == "[]GameObjectRef" {
+			uids := lowerFirst(field.Name) + "UIDs"
+			f.WriteString(fmt.Sprintf("\t%s := make([]float64, len(s.%s))\n", uids, field.Name))
+			f.WriteString(fmt.Sprintf("\tfor i, ref := range s.%s {\n\t\t%s[i] = float64(ref.UID)\n\t}\n", field.Name, uids))
+		}
+	}
 	f.WriteString("\treturn map[string]any{\n")
 
 	for _, field := range script.Fields {
 		// Special handling for GameObjectRef - serialize as UID
 		if field.Type == "GameObjectRef" {
 			f.WriteString(fmt.Sprintf("\t\t\"%s\": float64(s.%s.UID),\n", field.JSONName, field.Name))
+		} else if field.Type == "[]GameObjectRef" {
+			f.WriteString(fmt.Sprintf("\t\t\"%s\": %sUIDs,\n", field.JSONName, lowerFirst(field.Name)))
 		} else {
 			f.WriteString(fmt.Sprintf("\t\t\"%s\": s.%s,\n", field.JSONName, field.Name))
 		}
@@ -291,10 +410,16 @@ func generateScriptFile(script *ScriptInfo, sourceContent []byte, outputPath str
 	f.WriteString("\tswitch propName {\n")
 
 	for _, field := range script.Fields {
-		goType, conversion := getTypeConversion(field.Type)
+		// Slices and maps are rebuilt whole from the incoming value
+		goType, decode := decodeField(field.Type, "s."+field.Name, "\t\t\t")
 		f.WriteString(fmt.Sprintf("\tcase \"%s\":\n", field.JSONName))
 		f.WriteString(fmt.Sprintf("\t\tif v, ok := value.(%s); ok {\n", goType))
-		f.WriteString(fmt.Sprintf("\t\t\ts.%s = %s\n\t\t\treturn true\n\t\t}\n", field.Name, fmt.Sprintf(conversion, "v")))
+		if field.Options != "" {
+			// Values outside the options are rejected
+			f.WriteString(optionsGuard(field.Options, decode+"\t\t\treturn true\n", "\t\t\t") + "\t\t}\n")
+			continue
+		}
+		f.WriteString(decode + "\t\t\treturn true\n\t\t}\n")
 	}
 
 	f.WriteString("\t}\n\treturn false\n}\n")
@@ -332,6 +457,76 @@ func getTypeConversion(fieldType string) (goType, conversion string) {
 	}
 }
 
+// decodeField returns the type to assert a decoded JSON value v to and the
+// statements, each starting with indent, that set target from it. JSON arrays
+// and objects decode as []any and map[string]any, so slices and maps are
+// rebuilt element by element, skipping elements of the wrong type.
+func decodeField(fieldType, target, indent string) (goType, code string) {
+	if elem, ok := strings.CutPrefix(fieldType, "[]"); ok {
+		if elemGoType, conv, ok := elementConversion(elem); ok {
+			code = fmt.Sprintf("%s%s = make(%s, 0, len(v))\n", indent, target, qualifiedType(fieldType)) +
+				fmt.Sprintf("%sfor _, e := range v {\n", indent) +
+				fmt.Sprintf("%s\tif e, ok := e.(%s); ok {\n", indent, elemGoType) +
+				fmt.Sprintf("%s\t\t%s = append(%s, %s)\n", indent, target, target, fmt.Sprintf(conv, "e")) +
+				fmt.Sprintf("%s\t}\n%s}\n", indent, indent)
+			return "[]any", code
+		}
+	}
+	if elem, ok := strings.CutPrefix(fieldType, "map[string]"); ok {
+		if elemGoType, conv, ok := elementConversion(elem); ok && elem != "GameObjectRef" {
+			code = fmt.Sprintf("%s%s = make(%s, len(v))\n", indent, target, fieldType) +
+				fmt.Sprintf("%sfor k, e := range v {\n", indent) +
+				fmt.Sprintf("%s\tif e, ok := e.(%s); ok {\n", indent, elemGoType) +
+				fmt.Sprintf("%s\t\t%s[k] = %s\n", indent, target, fmt.Sprintf(conv, "e")) +
+				fmt.Sprintf("%s\t}\n%s}\n", indent, indent)
+			return "map[string]any", code
+		}
+	}
+	goType, conversion := getTypeConversion(fieldType)
+	return goType, fmt.Sprintf("%s%s = %s\n", indent, target, fmt.Sprintf(conversion, "v"))
+}
+
+// optionsGuard wraps code (already indented by indent) in a switch that only
+// runs it when v is one of options
+func optionsGuard(options, code, indent string) string {
+	var cases []string
+	for _, opt := range strings.Split(options, ",") {
+		cases = append(cases, strconv.Quote(opt))
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%sswitch v {\n%scase %s:\n", indent, indent, strings.Join(cases, ", ")))
+	for _, line := range strings.SplitAfter(code, "\n") {
+		if line != "" {
+			b.WriteString("\t" + line)
+		}
+	}
+	b.WriteString(indent + "}\n")
+	return b.String()
+}
+
+// elementConversion is getTypeConversion for the elements of a slice or map,
+// reporting false for element types it can't decode
+func elementConversion(elem string) (goType, conversion string, ok bool) {
+	goType, conversion = getTypeConversion(elem)
+	return goType, conversion, goType != "any"
+}
+
+// qualifiedType puts the engine package back on GameObjectRef for a type
+// written into generated code
+func qualifiedType(fieldType string) string {
+	return strings.ReplaceAll(fieldType, "GameObjectRef", "engine.GameObjectRef")
+}
+
+// isObjectRefType reports whether a field holds GameObject references, which
+// the editor and scene loader treat specially
+func isObjectRefType(fieldType string) bool {
+	return fieldType == "GameObjectRef" || fieldType == "[]GameObjectRef"
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
 func needsRegeneration(sourceContent []byte, outputPath string) bool {
 	// Hash the source content
 	h := sha256.New()
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -251,3 +255,226 @@ type SecondScript struct {
 		t.Errorf("Expected 'FirstScript' or 'SecondScript', got '%s'", script.Name)
 	}
 }
+
+func TestParseScriptRangeTag(t *testing.T) {
+	source := `package scripts
+
+type Health struct {
+	Max   float32 ` + "`mirgo:\"range:1, 500\"`" + `
+	Regen float64 ` + "`json:\"regen\" mirgo:\"range:-2.5,10\"`" + `
+	Armor float32
+}
+`
+
+	script, err := parseScript(source)
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+
+	want := map[string]string{"Max": "1,500", "Regen": "-2.5,10", "Armor": ""}
+	for _, field := range script.Fields {
+		if field.Range != want[field.Name] {
+			t.Errorf("%s: expected range %q, got %q", field.Name, want[field.Name], field.Range)
+		}
+	}
+}
+
+func TestParseScriptRangeTagErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+	}{
+		{"non-float field", "Count int `mirgo:\"range:0,10\"`"},
+		{"missing max", "Speed float32 `mirgo:\"range:5\"`"},
+		{"non-numeric", "Speed float32 `mirgo:\"range:a,b\"`"},
+		{"min above max", "Speed float32 `mirgo:\"range:10,0\"`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := "package scripts\n\ntype S struct {\n\t" + tt.field + "\n}\n"
+			if _, err := parseScript(source); err == nil {
+				t.Errorf("Expected an error for %s", tt.field)
+			}
+		})
+	}
+}
+
+func TestParseScriptOptionsComment(t *testing.T) {
+	source := `package scripts
+
+type Guard struct {
+	// @options: idle, patrol, chase
+	State string
+	Mode  string // @options: walk,run
+	Name  string // the guard's name
+}
+`
+
+	script, err := parseScript(source)
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+
+	want := map[string]string{"State": "idle,patrol,chase", "Mode": "walk,run", "Name": ""}
+	for _, field := range script.Fields {
+		if field.Options != want[field.Name] {
+			t.Errorf("%s: expected options %q, got %q", field.Name, want[field.Name], field.Options)
+		}
+	}
+}
+
+func TestParseScriptOptionsCommentErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+	}{
+		{"non-string field", "Speed float32 // @options: slow,fast"},
+		{"empty option", "State string // @options: idle,,chase"},
+		{"duplicate option", "State string // @options: idle,idle"},
+		{"separator in option", "State string // @options: idle;patrol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := "package scripts\n\ntype S struct {\n\t" + tt.field + "\n}\n"
+			if _, err := parseScript(source); err == nil {
+				t.Errorf("Expected an error for %s", tt.field)
+			}
+		})
+	}
+}
+
+func TestParseScriptSliceAndMapFields(t *testing.T) {
+	source := `package scripts
+
+import "test3d/internal/engine"
+
+type Spawner struct {
+	Waypoints []float32
+	Targets   []engine.GameObjectRef
+	Counts    map[string]int
+	Offset    rl.Vector3
+}
+`
+
+	script, err := parseScript(source)
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+
+	want := []string{"[]float32", "[]GameObjectRef", "map[string]int"}
+	if len(script.Fields) != len(want) {
+		t.Fatalf("Expected %d fields (qualified non-engine types skipped), got %+v", len(want), script.Fields)
+	}
+	for i, field := range script.Fields {
+		if field.Type != want[i] {
+			t.Errorf("%s: expected type '%s', got '%s'", field.Name, want[i], field.Type)
+		}
+	}
+}
+
+func TestDecodeFieldSlicesAndMaps(t *testing.T) {
+	tests := []struct {
+		fieldType  string
+		wantGoType string
+		wantCode   []string
+	}{
+		{"float32", "float64", []string{"x.F = float32(v)"}},
+		{"[]float32", "[]any", []string{"x.F = make([]float32, 0, len(v))", "e.(float64)", "x.F = append(x.F, float32(e))"}},
+		{"[]string", "[]any", []string{"e.(string)", "x.F = append(x.F, e)"}},
+		{"[]int", "[]any", []string{"x.F = append(x.F, int(e))"}},
+		{"[]GameObjectRef", "[]any", []string{"make([]engine.GameObjectRef, 0, len(v))", "engine.GameObjectRef{UID: uint64(e)}"}},
+		{"map[string]int", "map[string]any", []string{"x.F = make(map[string]int, len(v))", "x.F[k] = int(e)"}},
+	}
+
+	for _, test := range tests {
+		goType, code := decodeField(test.fieldType, "x.F", "\t")
+		if goType != test.wantGoType {
+			t.Errorf("decodeField(%s): expected goType '%s', got '%s'", test.fieldType, test.wantGoType, goType)
+		}
+		for _, want := range test.wantCode {
+			if !strings.Contains(code, want) {
+				t.Errorf("decodeField(%s): code missing %q:\n%s", test.fieldType, want, code)
+			}
+		}
+	}
+}
+
+func TestGenerateScriptFileWithSlices(t *testing.T) {
+	source := `package scripts
+
+import "test3d/internal/engine"
+
+type Patrol struct {
+	engine.BaseComponent
+	Points  []float32
+	Targets []engine.GameObjectRef
+}
+`
+
+	script, err := parseScript(source)
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+	outputPath := filepath.Join(t.TempDir(), "patrol.go")
+	if err := generateScriptFile(script, []byte(source), outputPath); err != nil {
+		t.Fatalf("generateScriptFile failed: %v", err)
+	}
+	generated, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "", generated, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, generated)
+	}
+	for _, want := range []string{
+		`"targets": "[]GameObjectRef",`,
+		`"targets": targetsUIDs,`,
+		`targetsUIDs[i] = float64(ref.UID)`,
+		`if v, ok := value.([]any); ok {`,
+	} {
+		if !strings.Contains(string(generated), want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+}
+
+func TestGenerateScriptFileWithOptions(t *testing.T) {
+	source := `package scripts
+
+import "test3d/internal/engine"
+
+type Guard struct {
+	engine.BaseComponent
+	State string // @options: idle,patrol,chase
+}
+`
+
+	script, err := parseScript(source)
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+	outputPath := filepath.Join(t.TempDir(), "guard.go")
+	if err := generateScriptFile(script, []byte(source), outputPath); err != nil {
+		t.Fatalf("generateScriptFile failed: %v", err)
+	}
+	generated, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "", generated, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, generated)
+	}
+	for _, want := range []string{
+		`"state": "options:idle,patrol,chase",`,
+		`script.State = "idle"`,
+		`case "idle", "patrol", "chase":`,
+	} {
+		if !strings.Contains(string(generated), want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+}
@@ -1,31 +1,138 @@
-// Stress test comparing CPU vs GPU broad-phase collision detection
+// Stress test comparing CPU vs GPU broad-phase collision detection, and the
+// sphere narrow-phase on the pairs it finds
+//
+// Usage:
+//
+//	physics_stress            # human-readable table
+//	physics_stress -csv       # count,gpu_us,gpu_pairs,cpu_us,cpu_pairs,speedup,narrow_gpu_us,narrow_cpu_us,narrow_speedup rows
+//	physics_stress -json -o results.json
+//	physics_stress -workgroup 64
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
+	"strconv"
 	"time"
 
 	"test3d/internal/compute"
 )
 
+// result is one row of the stress test
+type result struct {
+	Count    int     `json:"count"`
+	GPUMicro int64   `json:"gpu_us"`
+	GPUPairs int     `json:"gpu_pairs"`
+	CPUMicro int64   `json:"cpu_us"`
+	CPUPairs int     `json:"cpu_pairs"`
+	Speedup  float64 `json:"speedup"`
+
+	// Narrow-phase contacts for the pairs found (ResolveSpheres vs SphereContacts)
+	NarrowGPUMicro int64   `json:"narrow_gpu_us"`
+	NarrowCPUMicro int64   `json:"narrow_cpu_us"`
+	NarrowSpeedup  float64 `json:"narrow_speedup"`
+}
+
 func main() {
+	csvOut := flag.Bool("csv", false, "emit CSV rows instead of the table")
+	jsonOut := flag.Bool("json", false, "emit a JSON array instead of the table")
+	outPath := flag.String("o", "", "write output to this file instead of stdout")
+	workgroup := flag.Uint("workgroup", compute.DefaultWorkgroupSize, "GPU threads per workgroup (1-256)")
+	flag.Parse()
+
+	if *csvOut && *jsonOut {
+		fmt.Fprintln(os.Stderr, "physics_stress: -csv and -json are mutually exclusive")
+		os.Exit(2)
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "physics_stress: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+	table := !*csvOut && !*jsonOut
+
 	// Initialize compute
 	info, err := compute.Initialize()
 	if err != nil {
-		panic(fmt.Sprintf("Failed to init compute: %v", err))
+		// The benchmark compares against the GPU, so there's nothing to run
+		fmt.Fprintf(os.Stderr, "physics_stress: %v\n", err)
+		os.Exit(1)
+	}
+	if table {
+		fmt.Fprintf(out, "GPU: %s | %s | %s\n\n", info.Backend, info.Vendor, info.Name)
+	} else {
+		// Keep machine-readable output clean - device info goes to stderr
+		fmt.Fprintf(os.Stderr, "GPU: %s | %s | %s\n", info.Backend, info.Vendor, info.Name)
 	}
-	fmt.Printf("GPU: %s | %s | %s\n\n", info.Backend, info.Vendor, info.Name)
 
 	// Test various object counts
 	testCounts := []int{100, 500, 1000, 2000, 5000, 10000, 20000}
 
+	var results []result
 	for _, count := range testCounts {
-		testBroadPhase(count)
+		r, err := testBroadPhase(count, uint32(*workgroup))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%5d objects: GPU ERROR: %v\n", count, err)
+			continue
+		}
+		if table {
+			fmt.Fprintf(out, "%5d objects: GPU %8v (%4d pairs) | CPU %10v (%4d pairs) | %.1fx speedup\n",
+				r.Count, time.Duration(r.GPUMicro)*time.Microsecond, r.GPUPairs,
+				time.Duration(r.CPUMicro)*time.Microsecond, r.CPUPairs, r.Speedup)
+			fmt.Fprintf(out, "       narrow-phase: GPU %8v              | CPU %10v              | %.1fx speedup\n",
+				time.Duration(r.NarrowGPUMicro)*time.Microsecond,
+				time.Duration(r.NarrowCPUMicro)*time.Microsecond, r.NarrowSpeedup)
+		}
+		results = append(results, r)
+	}
+
+	switch {
+	case *csvOut:
+		err = writeCSV(out, results)
+	case *jsonOut:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(results)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "physics_stress: %v\n", err)
+		os.Exit(1)
 	}
 }
 
-func testBroadPhase(count int) {
+// writeCSV writes the results with a header row
+func writeCSV(out io.Writer, results []result) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"count", "gpu_us", "gpu_pairs", "cpu_us", "cpu_pairs", "speedup", "narrow_gpu_us", "narrow_cpu_us", "narrow_speedup"})
+	for _, r := range results {
+		w.Write([]string{
+			strconv.Itoa(r.Count),
+			strconv.FormatInt(r.GPUMicro, 10),
+			strconv.Itoa(r.GPUPairs),
+			strconv.FormatInt(r.CPUMicro, 10),
+			strconv.Itoa(r.CPUPairs),
+			strconv.FormatFloat(r.Speedup, 'f', 2, 64),
+			strconv.FormatInt(r.NarrowGPUMicro, 10),
+			strconv.FormatInt(r.NarrowCPUMicro, 10),
+			strconv.FormatFloat(r.NarrowSpeedup, 'f', 2, 64),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func testBroadPhase(count int, workgroupSize uint32) (result, error) {
 	// Generate random spheres in a bounded space
 	spheres := make([]compute.Sphere, count)
 	rand.Seed(42) // Consistent results
@@ -43,11 +150,10 @@ func testBroadPhase(count int) {
 	}
 
 	// GPU broad-phase
-	maxPairs := uint32(count * 20) // Generous pair buffer
-	bp, err := compute.NewBroadPhase(uint32(count), maxPairs)
+	maxPairs := uint32(count * 20) // Generous pair buffer (grows on overflow)
+	bp, err := compute.NewBroadPhase(uint32(count), maxPairs, workgroupSize)
 	if err != nil {
-		fmt.Printf("%5d objects: GPU ERROR: %v\n", count, err)
-		return
+		return result{}, err
 	}
 	defer bp.Release()
 
@@ -87,7 +193,37 @@ func testBroadPhase(count int) {
 	// Calculate speedup
 	speedup := float64(cpuTime) / float64(gpuTime)
 
-	fmt.Printf("%5d objects: GPU %8v (%4d pairs) | CPU %10v (%4d pairs) | %.1fx speedup\n",
-		count, gpuTime.Round(time.Microsecond), len(gpuPairs),
-		cpuTime.Round(time.Microsecond), cpuPairCount, speedup)
+	// Narrow-phase on the pairs the GPU found
+	bp.ResolveSpheres(spheres, gpuPairs) // warm up (compiles the shader)
+	narrowStart := time.Now()
+	for i := 0; i < gpuIterations; i++ {
+		bp.ResolveSpheres(spheres, gpuPairs)
+		if err := bp.NarrowPhaseError(); err != nil {
+			return result{}, err
+		}
+	}
+	narrowGPUTime := time.Since(narrowStart) / gpuIterations
+
+	narrowStart = time.Now()
+	for i := 0; i < cpuIterations; i++ {
+		compute.SphereContacts(spheres, gpuPairs)
+	}
+	narrowCPUTime := time.Since(narrowStart) / cpuIterations
+
+	narrowSpeedup := 0.0
+	if narrowGPUTime > 0 {
+		narrowSpeedup = float64(narrowCPUTime) / float64(narrowGPUTime)
+	}
+
+	return result{
+		Count:          count,
+		GPUMicro:       gpuTime.Microseconds(),
+		GPUPairs:       len(gpuPairs),
+		CPUMicro:       cpuTime.Microseconds(),
+		CPUPairs:       cpuPairCount,
+		Speedup:        speedup,
+		NarrowGPUMicro: narrowGPUTime.Microseconds(),
+		NarrowCPUMicro: narrowCPUTime.Microseconds(),
+		NarrowSpeedup:  narrowSpeedup,
+	}, nil
 }
@@ -9,32 +9,38 @@ import (
 
 // Material defines surface properties for rendering
 type Material struct {
-	Name       string
-	Color      rl.Color
-	Metallic   float32
-	Roughness  float32
-	Emissive   float32
-	Albedo     rl.Texture2D // diffuse/albedo texture (if ID > 0, use texture instead of color)
-	AlbedoPath string       // path to albedo texture (for saving)
+	Name        string
+	Color       rl.Color
+	Metallic    float32
+	Roughness   float32
+	Emissive    float32
+	AlphaCutoff float32      // alpha-test threshold: fragments with albedo alpha below it are discarded (0 = off)
+	DoubleSided bool         // draw back faces too (no backface culling), e.g. for single-plane foliage
+	Albedo      rl.Texture2D // diffuse/albedo texture (if ID > 0, use texture instead of color)
+	AlbedoPath  string       // path to albedo texture (for saving)
 }
 
 // materialDef is the JSON format for material files
 type materialDef struct {
-	Name      string  `json:"name"`
-	Color     string  `json:"color"`
-	Metallic  float32 `json:"metallic"`
-	Roughness float32 `json:"roughness"`
-	Emissive  float32 `json:"emissive"`
-	Albedo    string  `json:"albedo,omitempty"` // path to albedo texture
+	Name        string  `json:"name"`
+	Color       string  `json:"color"`
+	Metallic    float32 `json:"metallic"`
+	Roughness   float32 `json:"roughness"`
+	Emissive    float32 `json:"emissive"`
+	AlphaCutoff float32 `json:"alphaCutoff,omitempty"`
+	DoubleSided bool    `json:"doubleSided,omitempty"`
+	Albedo      string  `json:"albedo,omitempty"` // path to albedo texture
 }
 
 var manager *Manager
 
 type Manager struct {
-	models    map[string]rl.Model
-	textures  map[string]rl.Texture2D
-	materials map[string]*Material
-	meshes    map[string]rl.Mesh // unused, kept for compatibility
+	models     map[string]rl.Model
+	textures   map[string]rl.Texture2D
+	uiTextures map[string]rl.Texture2D // loaded by LoadUITexture, without mipmaps
+	materials  map[string]*Material
+	meshes     map[string]rl.Mesh // unused, kept for compatibility
+	retired    []rl.Model         // models replaced by ReloadModel, freed on Unload
 }
 
 // Color name mapping for materials
@@ -81,10 +87,11 @@ func LookupColorName(c rl.Color) string {
 
 func Init() {
 	manager = &Manager{
-		models:    make(map[string]rl.Model),
-		textures:  make(map[string]rl.Texture2D),
-		materials: make(map[string]*Material),
-		meshes:    make(map[string]rl.Mesh),
+		models:     make(map[string]rl.Model),
+		textures:   make(map[string]rl.Texture2D),
+		uiTextures: make(map[string]rl.Texture2D),
+		materials:  make(map[string]*Material),
+		meshes:     make(map[string]rl.Mesh),
 	}
 }
 
@@ -121,6 +128,25 @@ func GetCubeModel() rl.Model {
 	return model
 }
 
+// IsCachedModel returns true if the model is owned by the asset manager
+// and must not be unloaded by the caller.
+func IsCachedModel(model rl.Model) bool {
+	if manager == nil || model.Meshes == nil {
+		return false
+	}
+	for _, cached := range manager.models {
+		if cached.Meshes == model.Meshes {
+			return true
+		}
+	}
+	for _, old := range manager.retired {
+		if old.Meshes == model.Meshes {
+			return true
+		}
+	}
+	return false
+}
+
 func LoadModel(path string) rl.Model {
 	if manager == nil {
 		Init()
@@ -131,10 +157,26 @@ func LoadModel(path string) rl.Model {
 	}
 
 	model := rl.LoadModel(path)
+	applyImportSettings(model, LoadImportSettings(path))
 	manager.models[path] = model
 	return model
 }
 
+// ReloadModel reloads a model from disk, replacing the cached copy. Renderers
+// holding the old model should switch to the returned one. The old model stays
+// loaded until Unload, since undo history or deleted objects may still draw it.
+func ReloadModel(path string) rl.Model {
+	if manager == nil {
+		Init()
+	}
+
+	if model, exists := manager.models[path]; exists {
+		manager.retired = append(manager.retired, model)
+		delete(manager.models, path)
+	}
+	return LoadModel(path)
+}
+
 func LoadTexture(path string) rl.Texture2D {
 	if manager == nil {
 		Init()
@@ -144,11 +186,70 @@ func LoadTexture(path string) rl.Texture2D {
 		return texture
 	}
 
-	texture := rl.LoadTexture(path)
+	texture := importTexture(path)
 	manager.textures[path] = texture
 	return texture
 }
 
+// LoadUITexture loads a texture for UI images, caching it for reuse. UI is
+// drawn near its pixel size, so unlike LoadTexture the image keeps its full
+// resolution and gets plain bilinear filtering: mipmaps would only blur it.
+func LoadUITexture(path string) rl.Texture2D {
+	if manager == nil {
+		Init()
+	}
+
+	if texture, exists := manager.uiTextures[path]; exists {
+		return texture
+	}
+
+	texture := rl.LoadTexture(path)
+	if texture.ID > 0 {
+		rl.SetTextureFilter(texture, rl.FilterBilinear)
+	}
+	manager.uiTextures[path] = texture
+	return texture
+}
+
+// MaxTextureSize is the largest width or height LoadTexture keeps; bigger
+// images are scaled down, keeping their aspect ratio (<= 0 = no limit).
+// The game sets it from project.json.
+var MaxTextureSize = 2048
+
+// importTexture loads an image, downsizes it to MaxTextureSize, and uploads
+// it with a full mipmap chain and trilinear filtering so it doesn't shimmer
+// at a distance
+func importTexture(path string) rl.Texture2D {
+	img := rl.LoadImage(path)
+	if img == nil || img.Data == nil {
+		return rl.Texture2D{}
+	}
+	defer rl.UnloadImage(img)
+
+	if w, h := fitTextureSize(img.Width, img.Height, int32(MaxTextureSize)); w != img.Width || h != img.Height {
+		rl.ImageResize(img, w, h)
+	}
+
+	texture := rl.LoadTextureFromImage(img)
+	if texture.ID == 0 {
+		return texture
+	}
+	rl.GenTextureMipmaps(&texture)
+	rl.SetTextureFilter(texture, rl.FilterTrilinear)
+	return texture
+}
+
+// fitTextureSize scales w x h down so neither side exceeds limit, keeping the aspect ratio
+func fitTextureSize(w, h, limit int32) (int32, int32) {
+	if limit <= 0 || (w <= limit && h <= limit) {
+		return w, h
+	}
+	if w >= h {
+		return limit, max(int32(int64(h)*int64(limit)/int64(w)), 1)
+	}
+	return max(int32(int64(w)*int64(limit)/int64(h)), 1), limit
+}
+
 // LoadMaterial loads a material from a JSON file, caching it for reuse
 func LoadMaterial(path string) *Material {
 	if manager == nil {
@@ -179,11 +280,13 @@ func LoadMaterial(path string) *Material {
 	}
 
 	material := &Material{
-		Name:      def.Name,
-		Color:     LookupColor(def.Color),
-		Metallic:  def.Metallic,
-		Roughness: def.Roughness,
-		Emissive:  def.Emissive,
+		Name:        def.Name,
+		Color:       LookupColor(def.Color),
+		Metallic:    def.Metallic,
+		Roughness:   def.Roughness,
+		Emissive:    def.Emissive,
+		AlphaCutoff: def.AlphaCutoff,
+		DoubleSided: def.DoubleSided,
 	}
 
 	// Load albedo texture if specified
@@ -199,12 +302,14 @@ func LoadMaterial(path string) *Material {
 // SaveMaterial saves a material back to its JSON file
 func SaveMaterial(path string, mat *Material) error {
 	def := materialDef{
-		Name:      mat.Name,
-		Color:     LookupColorName(mat.Color),
-		Metallic:  mat.Metallic,
-		Roughness: mat.Roughness,
-		Emissive:  mat.Emissive,
-		Albedo:    mat.AlbedoPath,
+		Name:        mat.Name,
+		Color:       LookupColorName(mat.Color),
+		Metallic:    mat.Metallic,
+		Roughness:   mat.Roughness,
+		Emissive:    mat.Emissive,
+		AlphaCutoff: mat.AlphaCutoff,
+		DoubleSided: mat.DoubleSided,
+		Albedo:      mat.AlbedoPath,
 	}
 
 	data, err := json.MarshalIndent(def, "", "  ")
@@ -223,13 +328,21 @@ func Unload() {
 	for _, model := range manager.models {
 		rl.UnloadModel(model)
 	}
+	for _, model := range manager.retired {
+		rl.UnloadModel(model)
+	}
 
 	for _, texture := range manager.textures {
 		rl.UnloadTexture(texture)
 	}
+	for _, texture := range manager.uiTextures {
+		rl.UnloadTexture(texture)
+	}
 
 	manager.models = make(map[string]rl.Model)
 	manager.textures = make(map[string]rl.Texture2D)
+	manager.uiTextures = make(map[string]rl.Texture2D)
 	manager.materials = make(map[string]*Material)
 	manager.meshes = make(map[string]rl.Mesh)
+	manager.retired = nil
 }
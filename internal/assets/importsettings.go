@@ -0,0 +1,180 @@
+package assets
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ImportSettingsSuffix is appended to a model path to get its sidecar file,
+// e.g. assets/models/tree.glb.import.json
+const ImportSettingsSuffix = ".import.json"
+
+// ImportSettings correct a model for the tool it was exported from. They are
+// baked into the mesh data when the model loads, so colliders, picking and
+// bounds all see the corrected geometry.
+type ImportSettings struct {
+	Scale       float32    `json:"scale"`                 // uniform scale (e.g. 0.01 for centimeter exports)
+	Rotation    [3]float32 `json:"rotation"`              // Euler degrees, e.g. [-90, 0, 0] for Z-up exports
+	FlipNormals bool       `json:"flipNormals,omitempty"` // reverse normals and winding for inside-out meshes
+}
+
+// DefaultImportSettings leave the model as exported
+func DefaultImportSettings() ImportSettings {
+	return ImportSettings{Scale: 1}
+}
+
+// IsImportSettingsFile reports whether name is a model's import sidecar
+func IsImportSettingsFile(name string) bool {
+	return strings.HasSuffix(name, ImportSettingsSuffix)
+}
+
+// LoadImportSettings reads the sidecar next to a model, or returns the
+// defaults if there is none
+func LoadImportSettings(modelPath string) ImportSettings {
+	settings := DefaultImportSettings()
+	data, err := os.ReadFile(modelPath + ImportSettingsSuffix)
+	if err != nil {
+		return settings
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return DefaultImportSettings()
+	}
+	if settings.Scale == 0 {
+		settings.Scale = 1
+	}
+	return settings
+}
+
+// SaveImportSettings writes the sidecar next to a model. Default settings
+// remove it instead.
+func SaveImportSettings(modelPath string, settings ImportSettings) error {
+	path := modelPath + ImportSettingsSuffix
+	if settings == DefaultImportSettings() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// applyImportSettings bakes the settings into every mesh of a freshly loaded
+// model and re-uploads the changed buffers
+func applyImportSettings(model rl.Model, settings ImportSettings) {
+	if settings == DefaultImportSettings() || model.Meshes == nil {
+		return
+	}
+
+	rotX := rl.MatrixRotateX(settings.Rotation[0] * rl.Deg2rad)
+	rotY := rl.MatrixRotateY(settings.Rotation[1] * rl.Deg2rad)
+	rotZ := rl.MatrixRotateZ(settings.Rotation[2] * rl.Deg2rad)
+	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+	transform := rl.MatrixMultiply(rl.MatrixScale(settings.Scale, settings.Scale, settings.Scale), rotMatrix)
+
+	for _, mesh := range unsafe.Slice(model.Meshes, model.MeshCount) {
+		if mesh.Vertices == nil || mesh.VertexCount == 0 {
+			continue
+		}
+		count := int(mesh.VertexCount)
+
+		transformVec3s(unsafe.Slice(mesh.Vertices, count*3), 3, transform, false)
+		if mesh.Normals != nil {
+			transformVec3s(unsafe.Slice(mesh.Normals, count*3), 3, rotMatrix, settings.FlipNormals)
+		}
+		if mesh.Tangents != nil {
+			transformVec3s(unsafe.Slice(mesh.Tangents, count*4), 4, rotMatrix, false)
+		}
+		if settings.FlipNormals {
+			flipWinding(mesh)
+		}
+
+		// Buffer indices follow raylib's shader locations (0 = positions ... 6 = indices)
+		rl.UpdateMeshBuffer(mesh, 0, floatBytes(mesh.Vertices, count*3), 0)
+		if mesh.Normals != nil {
+			rl.UpdateMeshBuffer(mesh, 2, floatBytes(mesh.Normals, count*3), 0)
+		}
+		if mesh.Tangents != nil {
+			rl.UpdateMeshBuffer(mesh, 4, floatBytes(mesh.Tangents, count*4), 0)
+		}
+		if settings.FlipNormals {
+			if mesh.Texcoords != nil {
+				rl.UpdateMeshBuffer(mesh, 1, floatBytes(mesh.Texcoords, count*2), 0)
+			}
+			if mesh.Texcoords2 != nil {
+				rl.UpdateMeshBuffer(mesh, 5, floatBytes(mesh.Texcoords2, count*2), 0)
+			}
+			if mesh.Colors != nil {
+				rl.UpdateMeshBuffer(mesh, 3, unsafe.Slice(mesh.Colors, count*4), 0)
+			}
+			if mesh.Indices != nil {
+				data := unsafe.Slice((*byte)(unsafe.Pointer(mesh.Indices)), int(mesh.TriangleCount)*3*2)
+				rl.UpdateMeshBuffer(mesh, 6, data, 0)
+			}
+		}
+	}
+}
+
+// transformVec3s transforms the XYZ of each stride-sized element in place,
+// optionally negating the result
+func transformVec3s(values []float32, stride int, m rl.Matrix, negate bool) {
+	for i := 0; i+2 < len(values); i += stride {
+		v := rl.Vector3Transform(rl.Vector3{X: values[i], Y: values[i+1], Z: values[i+2]}, m)
+		if negate {
+			v = rl.Vector3Negate(v)
+		}
+		values[i], values[i+1], values[i+2] = v.X, v.Y, v.Z
+	}
+}
+
+// flipWinding reverses the vertex order of every triangle so the other side
+// faces out. Indexed meshes swap indices; others swap the vertex data itself.
+func flipWinding(mesh rl.Mesh) {
+	if mesh.Indices != nil {
+		indices := unsafe.Slice(mesh.Indices, mesh.TriangleCount*3)
+		for i := 0; i+2 < len(indices); i += 3 {
+			indices[i+1], indices[i+2] = indices[i+2], indices[i+1]
+		}
+		return
+	}
+
+	count := int(mesh.VertexCount)
+	swapFloats := func(p *float32, size int) {
+		if p == nil {
+			return
+		}
+		values := unsafe.Slice(p, count*size)
+		for tri := 0; (tri+3)*size <= len(values); tri += 3 {
+			a, b := (tri+1)*size, (tri+2)*size
+			for k := 0; k < size; k++ {
+				values[a+k], values[b+k] = values[b+k], values[a+k]
+			}
+		}
+	}
+	swapFloats(mesh.Vertices, 3)
+	swapFloats(mesh.Normals, 3)
+	swapFloats(mesh.Tangents, 4)
+	swapFloats(mesh.Texcoords, 2)
+	swapFloats(mesh.Texcoords2, 2)
+	if mesh.Colors != nil {
+		colors := unsafe.Slice(mesh.Colors, count*4)
+		for tri := 0; (tri+3)*4 <= len(colors); tri += 3 {
+			a, b := (tri+1)*4, (tri+2)*4
+			for k := 0; k < 4; k++ {
+				colors[a+k], colors[b+k] = colors[b+k], colors[a+k]
+			}
+		}
+	}
+}
+
+// floatBytes views n floats as raw bytes for uploading
+func floatBytes(p *float32, n int) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(p)), n*4)
+}
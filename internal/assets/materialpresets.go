@@ -0,0 +1,33 @@
+package assets
+
+// MaterialPreset is a starting point for a common kind of surface
+type MaterialPreset struct {
+	Name        string
+	Metallic    float32
+	Roughness   float32
+	Emissive    float32
+	AlphaCutoff float32
+	DoubleSided bool
+}
+
+// MaterialPresets are the quick-apply presets offered by the material editor.
+// Metals are fully metallic and fairly smooth; non-metals have no metallic at
+// all and get their look from roughness alone. Glass is as smooth as the
+// lighting model goes and double-sided so both faces of a pane show, but it
+// stays opaque: the renderer has no alpha blending.
+var MaterialPresets = []MaterialPreset{
+	{Name: "Metal", Metallic: 1, Roughness: 0.25},
+	{Name: "Plastic", Metallic: 0, Roughness: 0.4},
+	{Name: "Rubber", Metallic: 0, Roughness: 0.9},
+	{Name: "Glass", Metallic: 0, Roughness: 0.05, DoubleSided: true},
+	{Name: "Emissive", Metallic: 0, Roughness: 0.5, Emissive: 4},
+}
+
+// Apply sets the preset's surface values on m, keeping its name, color and texture
+func (p MaterialPreset) Apply(m *Material) {
+	m.Metallic = p.Metallic
+	m.Roughness = p.Roughness
+	m.Emissive = p.Emissive
+	m.AlphaCutoff = p.AlphaCutoff
+	m.DoubleSided = p.DoubleSided
+}
@@ -0,0 +1,121 @@
+package assets
+
+import (
+	"math"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// PrimitiveMeshTypes lists the generated mesh types, in the order the inspector shows them
+var PrimitiveMeshTypes = []string{"cube", "sphere", "plane", "capsule", "cone", "torus"}
+
+// primitiveDefaults holds the meshSize used when a value is missing from a scene file.
+//
+//	cube:    [width, height, length]
+//	sphere:  [radius]
+//	plane:   [width, length, subdivisionsX, subdivisionsZ]
+//	capsule: [radius, height]  (total height, including the caps)
+//	cone:    [radius, height]
+//	torus:   [radius, thickness]  (ring radius, tube radius)
+var primitiveDefaults = map[string][]float32{
+	"cube":    {1, 1, 1},
+	"sphere":  {0.5},
+	"plane":   {1, 1, 1, 1},
+	"capsule": {0.5, 2},
+	"cone":    {0.5, 1},
+	"torus":   {0.5, 0.15},
+}
+
+// DefaultMeshSize returns a copy of the default meshSize for a primitive type
+func DefaultMeshSize(meshType string) []float32 {
+	return append([]float32(nil), primitiveDefaults[meshType]...)
+}
+
+// meshParam returns size[i], falling back to the type's default
+func meshParam(meshType string, size []float32, i int) float32 {
+	if i < len(size) {
+		return size[i]
+	}
+	if defaults := primitiveDefaults[meshType]; i < len(defaults) {
+		return defaults[i]
+	}
+	return 1
+}
+
+// GenPrimitiveMesh generates the mesh for a primitive type from its meshSize.
+// Every mesh is centered on the origin. Returns false for unknown types.
+func GenPrimitiveMesh(meshType string, size []float32) (rl.Mesh, bool) {
+	p := func(i int) float32 { return meshParam(meshType, size, i) }
+
+	switch meshType {
+	case "cube":
+		return rl.GenMeshCube(p(0), p(1), p(2)), true
+	case "sphere":
+		return rl.GenMeshSphere(p(0), 16, 16), true
+	case "plane":
+		resX := max(int(p(2)), 1)
+		resZ := max(int(p(3)), 1)
+		return rl.GenMeshPlane(p(0), p(1), resX, resZ), true
+	case "capsule":
+		radius := p(0)
+		height := max(p(1), radius*2)
+		// Stretch a sphere apart at the equator. An odd ring count keeps
+		// every vertex off the equator so each one moves cleanly up or down.
+		mesh := rl.GenMeshSphere(radius, 15, 16)
+		offset := (height - radius*2) / 2
+		shiftVertices(mesh, func(y float32) float32 {
+			if y > 0 {
+				return y + offset
+			}
+			return y - offset
+		})
+		return mesh, true
+	case "cone":
+		// raylib builds the cone from y=0 up to the tip; center it
+		height := p(1)
+		mesh := rl.GenMeshCone(p(0), height, 16)
+		shiftVertices(mesh, func(y float32) float32 { return y - height/2 })
+		return mesh, true
+	case "torus":
+		// raylib takes the tube radius as a fraction of the ring (0.1-1)
+		// and the size as the ring diameter
+		radius := max(p(0), 0.01)
+		return rl.GenMeshTorus(p(1)/radius, radius*2, 24, 16), true
+	}
+	return rl.Mesh{}, false
+}
+
+// shiftVertices remaps the Y of every vertex and re-uploads the positions to the GPU
+func shiftVertices(mesh rl.Mesh, fn func(y float32) float32) {
+	if mesh.Vertices == nil || mesh.VertexCount == 0 {
+		return
+	}
+	vertices := unsafe.Slice(mesh.Vertices, mesh.VertexCount*3)
+	for i := 1; i < len(vertices); i += 3 {
+		vertices[i] = fn(vertices[i])
+	}
+	data := unsafe.Slice((*byte)(unsafe.Pointer(mesh.Vertices)), len(vertices)*4)
+	rl.UpdateMeshBuffer(mesh, 0, data, 0)
+}
+
+// PrimitiveRadius returns the bounding sphere radius of a generated mesh
+func PrimitiveRadius(meshType string, size []float32) float32 {
+	p := func(i int) float64 { return float64(meshParam(meshType, size, i)) }
+
+	switch meshType {
+	case "cube":
+		return float32(math.Sqrt(p(0)*p(0)+p(1)*p(1)+p(2)*p(2)) / 2)
+	case "sphere":
+		return float32(p(0))
+	case "plane":
+		return float32(math.Sqrt(p(0)*p(0)+p(1)*p(1)) / 2)
+	case "capsule":
+		return float32(math.Max(p(0), p(1)/2))
+	case "cone":
+		return float32(math.Sqrt(p(0)*p(0) + p(1)*p(1)/4))
+	case "torus":
+		return float32(p(0) + p(1))
+	}
+	return 2.0
+}
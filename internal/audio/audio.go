@@ -13,6 +13,44 @@ type Listener struct {
 	Up       rl.Vector3
 }
 
+// Channel groups sources under one volume control. SFX sources are
+// positioned in the world; UI and Music sources always play at full volume in
+// both ears, wherever the listener is.
+type Channel int
+
+const (
+	ChannelSFX Channel = iota
+	ChannelUI
+	ChannelMusic
+	channelCount
+)
+
+// channelNames are the serialized names, indexed by Channel
+var channelNames = []string{"sfx", "ui", "music"}
+
+func (c Channel) String() string {
+	if c >= 0 && c < channelCount {
+		return channelNames[c]
+	}
+	return channelNames[ChannelSFX]
+}
+
+// ParseChannel parses "sfx", "ui" or "music"
+func ParseChannel(s string) (Channel, bool) {
+	for i, name := range channelNames {
+		if name == s {
+			return Channel(i), true
+		}
+	}
+	return ChannelSFX, false
+}
+
+// Spatialized reports whether sources on the channel are attenuated and
+// panned by their position relative to the listener
+func (c Channel) Spatialized() bool {
+	return c == ChannelSFX
+}
+
 // Source represents an audio source in the world
 type Source struct {
 	ID          uint64
@@ -22,6 +60,7 @@ type Source struct {
 	MaxDistance float32
 	Loop        bool
 	Spatial     bool
+	Channel     Channel
 	playing     bool
 	wantsToPlay bool // True if Play() was called but playModeEnabled was false
 }
@@ -37,6 +76,53 @@ type Manager struct {
 var globalManager *Manager
 var playModeEnabled bool // Only play audio when in play mode
 
+// Volume controls: master scales everything, then each channel its own sources
+var (
+	volumeMu       sync.Mutex
+	masterVolume   float32 = 1
+	channelVolumes         = [channelCount]float32{1, 1, 1}
+)
+
+// SetMasterVolume sets the volume applied to every channel (0-1)
+func SetMasterVolume(volume float32) {
+	volumeMu.Lock()
+	defer volumeMu.Unlock()
+	masterVolume = min(max(volume, 0), 1)
+}
+
+// MasterVolume returns the volume applied to every channel
+func MasterVolume() float32 {
+	volumeMu.Lock()
+	defer volumeMu.Unlock()
+	return masterVolume
+}
+
+// SetChannelVolume sets the volume of one channel (0-1)
+func SetChannelVolume(c Channel, volume float32) {
+	if c < 0 || c >= channelCount {
+		return
+	}
+	volumeMu.Lock()
+	defer volumeMu.Unlock()
+	channelVolumes[c] = min(max(volume, 0), 1)
+}
+
+// ChannelVolume returns the volume of one channel, not counting the master volume
+func ChannelVolume(c Channel) float32 {
+	if c < 0 || c >= channelCount {
+		return 1
+	}
+	volumeMu.Lock()
+	defer volumeMu.Unlock()
+	return channelVolumes[c]
+}
+
+// ChannelGain returns the master volume times the channel's volume, the
+// factor every source on the channel is scaled by
+func ChannelGain(c Channel) float32 {
+	return MasterVolume() * ChannelVolume(c)
+}
+
 // Init initializes the audio system
 func Init() {
 	rl.InitAudioDevice()
@@ -225,6 +311,19 @@ func SetSourceSpatial(id uint64, spatial bool) {
 	}
 }
 
+// SetSourceChannel sets which volume channel a source plays on
+func SetSourceChannel(id uint64, c Channel) {
+	if globalManager == nil {
+		return
+	}
+	globalManager.mu.Lock()
+	defer globalManager.mu.Unlock()
+
+	if src, ok := globalManager.sources[id]; ok {
+		src.Channel = c
+	}
+}
+
 // UnloadSource removes a source
 func UnloadSource(id uint64) {
 	if globalManager == nil {
@@ -262,9 +361,10 @@ func Update() {
 			continue
 		}
 
-		if !src.Spatial {
-			// 2D audio - center pan, full volume
-			rl.SetSoundVolume(src.Sound, src.Volume)
+		gain := ChannelGain(src.Channel)
+		if !src.Spatial || !src.Channel.Spatialized() {
+			// 2D audio - center pan, no distance falloff
+			rl.SetSoundVolume(src.Sound, src.Volume*gain)
 			rl.SetSoundPan(src.Sound, 0.0)
 			continue
 		}
@@ -273,13 +373,8 @@ func Update() {
 		direction := rl.Vector3Subtract(src.Position, listener.Position)
 		distance := rl.Vector3Length(direction)
 
-		// Logarithmic distance attenuation
-		attenuation := 1.0 / (1.0 + (distance / src.MaxDistance))
-		if attenuation < 0 {
-			attenuation = 0
-		} else if attenuation > 1 {
-			attenuation = 1
-		}
+		// Distance attenuation: full volume at the listener, silent at MaxDistance
+		attenuation := Attenuation(distance, src.MaxDistance)
 
 		// Calculate normalized vectors for spatial positioning
 		normalizedDirection := rl.Vector3Normalize(direction)
@@ -296,11 +391,27 @@ func Update() {
 		pan := rl.Vector3DotProduct(normalizedDirection, right)
 
 		// Apply final sound properties
-		rl.SetSoundVolume(src.Sound, src.Volume*attenuation)
+		rl.SetSoundVolume(src.Sound, src.Volume*gain*attenuation)
 		rl.SetSoundPan(src.Sound, pan)
 	}
 }
 
+// Attenuation returns the volume factor for a sound at distance from the
+// listener. It falls off quadratically from 1 at the listener to 0 at maxDistance.
+func Attenuation(distance, maxDistance float32) float32 {
+	if maxDistance <= 0 {
+		return 1
+	}
+	t := 1 - distance/maxDistance
+	if t <= 0 {
+		return 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	return t * t
+}
+
 // IsPlaying returns whether a source is currently playing
 func IsPlaying(id uint64) bool {
 	if globalManager == nil {
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"math"
 	"test3d/internal/audio"
 	"test3d/internal/engine"
@@ -14,6 +15,9 @@ func init() {
 	})
 }
 
+// AudioListener is the "ears" of the scene, usually on the camera or player.
+// Spatial AudioSources are attenuated and panned relative to its position and
+// facing. Only one listener is used, see ActiveAudioListener.
 type AudioListener struct {
 	engine.BaseComponent
 }
@@ -38,7 +42,7 @@ func (a *AudioListener) Deserialize(data map[string]any) {
 
 func (a *AudioListener) Update(deltaTime float32) {
 	g := a.GetGameObject()
-	if g == nil {
+	if g == nil || ActiveAudioListener(g.Scene) != a {
 		return
 	}
 
@@ -65,3 +69,59 @@ func (a *AudioListener) Update(deltaTime float32) {
 
 	audio.SetListener(pos, forward, up)
 }
+
+// activeListener caches the listener ActiveAudioListener last picked, so the
+// scene is only searched again once it is removed or deactivated
+var activeListener *AudioListener
+
+// ActiveAudioListener returns the listener the audio system uses: the first
+// one on an active object in the scene, or nil if there is none. It stays
+// in use until its object is removed or deactivated.
+func ActiveAudioListener(scene *engine.Scene) *AudioListener {
+	if scene == nil {
+		return nil
+	}
+	if l := activeListener; l != nil {
+		if g := l.GetGameObject(); g != nil && g.Active && scene.FindByUID(g.UID) == g &&
+			engine.GetComponent[*AudioListener](g) == l {
+			return l
+		}
+	}
+	activeListener = nil
+	for _, g := range scene.GameObjects {
+		if !g.Active {
+			continue
+		}
+		if l := engine.GetComponent[*AudioListener](g); l != nil {
+			activeListener = l
+			return l
+		}
+	}
+	return nil
+}
+
+// AudioListenerWarning describes a listener setup problem: no listener while
+// the scene has audio sources, or more than one listener. Empty if all is well.
+func AudioListenerWarning(scene *engine.Scene) string {
+	listeners, sources := 0, 0
+	for _, g := range scene.GameObjects {
+		for _, c := range g.Components() {
+			switch c.(type) {
+			case *AudioListener:
+				listeners++
+			case *AudioSource, *HRTFAudioSource:
+				sources++
+			}
+		}
+	}
+	switch {
+	case listeners == 0 && sources > 0:
+		return "No AudioListener in the scene - spatial sounds are heard from the origin"
+	case listeners > 1:
+		if active := ActiveAudioListener(scene); active != nil {
+			return fmt.Sprintf("%d AudioListeners in the scene - only the one on %s is used", listeners, active.GetGameObject().Name)
+		}
+		return fmt.Sprintf("%d AudioListeners in the scene, all on inactive objects", listeners)
+	}
+	return ""
+}
@@ -20,7 +20,11 @@ type AudioSource struct {
 	MaxDistance float32 `json:"maxDistance"`
 	Loop        bool    `json:"loop"`
 	PlayOnStart bool    `json:"playOnStart"`
-	Spatial     bool    `json:"spatial"` // 3D spatialization
+	Spatial     bool    `json:"spatial"` // 3D spatialization (SFX channel only)
+
+	// Channel picks the volume control. UI and Music always play in 2D, so a
+	// menu click or the soundtrack doesn't fade with the listener's position.
+	Channel audio.Channel `json:"channel"`
 
 	// Runtime state
 	sourceID uint64
@@ -50,6 +54,7 @@ func (a *AudioSource) Serialize() map[string]any {
 		"loop":        a.Loop,
 		"playOnStart": a.PlayOnStart,
 		"spatial":     a.Spatial,
+		"channel":     a.Channel.String(),
 	}
 }
 
@@ -72,6 +77,11 @@ func (a *AudioSource) Deserialize(data map[string]any) {
 	if v, ok := data["spatial"].(bool); ok {
 		a.Spatial = v
 	}
+	if v, ok := data["channel"].(string); ok {
+		if c, ok := audio.ParseChannel(v); ok {
+			a.Channel = c
+		}
+	}
 }
 
 func (a *AudioSource) Start() {
@@ -114,6 +124,7 @@ func (a *AudioSource) Load(path string) bool {
 	audio.SetSourceMaxDistance(a.sourceID, a.MaxDistance)
 	audio.SetSourceLoop(a.sourceID, a.Loop)
 	audio.SetSourceSpatial(a.sourceID, a.Spatial)
+	audio.SetSourceChannel(a.sourceID, a.Channel)
 
 	return true
 }
@@ -148,6 +159,14 @@ func (a *AudioSource) IsPlaying() bool {
 	return audio.IsPlaying(a.sourceID)
 }
 
+// SetChannel moves the source to another volume channel
+func (a *AudioSource) SetChannel(c audio.Channel) {
+	a.Channel = c
+	if a.loaded {
+		audio.SetSourceChannel(a.sourceID, c)
+	}
+}
+
 // SetVolume updates the volume
 func (a *AudioSource) SetVolume(vol float32) {
 	a.Volume = vol
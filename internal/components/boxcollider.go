@@ -14,8 +14,9 @@ func init() {
 
 type BoxCollider struct {
 	engine.BaseComponent
-	Size   rl.Vector3
-	Offset rl.Vector3
+	Size      rl.Vector3
+	Offset    rl.Vector3
+	IsTrigger bool // detect overlaps (OnTriggerEnter/Exit) without pushing anything
 }
 
 func NewBoxCollider(size rl.Vector3) *BoxCollider {
@@ -57,9 +58,10 @@ func (b *BoxCollider) TypeName() string {
 // Serialize implements engine.Serializable
 func (b *BoxCollider) Serialize() map[string]any {
 	return map[string]any{
-		"type":   "BoxCollider",
-		"size":   [3]float32{b.Size.X, b.Size.Y, b.Size.Z},
-		"offset": [3]float32{b.Offset.X, b.Offset.Y, b.Offset.Z},
+		"type":      "BoxCollider",
+		"size":      [3]float32{b.Size.X, b.Size.Y, b.Size.Z},
+		"offset":    [3]float32{b.Offset.X, b.Offset.Y, b.Offset.Z},
+		"isTrigger": b.IsTrigger,
 	}
 }
 
@@ -75,4 +77,7 @@ func (b *BoxCollider) Deserialize(data map[string]any) {
 		b.Offset.Y = float32(offset[1].(float64))
 		b.Offset.Z = float32(offset[2].(float64))
 	}
+	if v, ok := data["isTrigger"].(bool); ok {
+		b.IsTrigger = v
+	}
 }
@@ -44,6 +44,7 @@ func (c *Camera) Serialize() map[string]any {
 		"fov":    c.FOV,
 		"near":   c.Near,
 		"far":    c.Far,
+		"ortho":  c.Projection == rl.CameraOrthographic,
 		"isMain": c.IsMain,
 	}
 }
@@ -59,6 +60,12 @@ func (c *Camera) Deserialize(data map[string]any) {
 	if f, ok := data["far"].(float64); ok {
 		c.Far = float32(f)
 	}
+	if o, ok := data["ortho"].(bool); ok {
+		c.Projection = rl.CameraPerspective
+		if o {
+			c.Projection = rl.CameraOrthographic
+		}
+	}
 	if m, ok := data["isMain"].(bool); ok {
 		c.IsMain = m
 	}
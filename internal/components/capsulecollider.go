@@ -0,0 +1,97 @@
+package components
+
+import (
+	"math"
+
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("CapsuleCollider", func() engine.Serializable {
+		return NewCapsuleCollider(0.5, 2)
+	})
+}
+
+// CapsuleCollider is a cylinder with rounded ends standing along the object's
+// local Y axis: every point within Radius of a line segment. It suits
+// characters, which slide over edges and small steps instead of catching on
+// them, and round props like tree trunks and barrels.
+type CapsuleCollider struct {
+	engine.BaseComponent
+	Radius float32
+	Height float32 // end to end, caps included
+	Offset rl.Vector3
+}
+
+func NewCapsuleCollider(radius, height float32) *CapsuleCollider {
+	return &CapsuleCollider{
+		Radius: radius,
+		Height: height,
+	}
+}
+
+// GetCenter returns the world-space center of this collider
+func (c *CapsuleCollider) GetCenter() rl.Vector3 {
+	g := c.GetGameObject()
+	scale := g.WorldScale()
+	scaledOffset := rl.Vector3{
+		X: c.Offset.X * scale.X,
+		Y: c.Offset.Y * scale.Y,
+		Z: c.Offset.Z * scale.Z,
+	}
+	return rl.Vector3Add(g.WorldPosition(), scaledOffset)
+}
+
+// GetWorldRadius returns the radius scaled by the larger of the object's X
+// and Z world scale, so a squashed capsule errs on the large side
+func (c *CapsuleCollider) GetWorldRadius() float32 {
+	scale := c.GetGameObject().WorldScale()
+	return c.Radius * float32(max(math.Abs(float64(scale.X)), math.Abs(float64(scale.Z))))
+}
+
+// GetWorldHeight returns the end-to-end height scaled by the object's Y world
+// scale, never less than the world diameter
+func (c *CapsuleCollider) GetWorldHeight() float32 {
+	scale := c.GetGameObject().WorldScale()
+	return max(c.Height*float32(math.Abs(float64(scale.Y))), 2*c.GetWorldRadius())
+}
+
+// Segment returns the world-space ends of the line segment at the capsule's
+// core, following the object's rotation. They meet at the center when the
+// capsule is no taller than it is wide, making it a sphere.
+func (c *CapsuleCollider) Segment() (rl.Vector3, rl.Vector3) {
+	center := c.GetCenter()
+	half := c.GetWorldHeight()/2 - c.GetWorldRadius()
+	up := rl.Vector3Transform(rl.Vector3{Y: half}, c.GetGameObject().WorldRotationMatrix())
+	return rl.Vector3Subtract(center, up), rl.Vector3Add(center, up)
+}
+
+// TypeName implements engine.Serializable
+func (c *CapsuleCollider) TypeName() string {
+	return "CapsuleCollider"
+}
+
+// Serialize implements engine.Serializable
+func (c *CapsuleCollider) Serialize() map[string]any {
+	return map[string]any{
+		"type":   "CapsuleCollider",
+		"radius": c.Radius,
+		"height": c.Height,
+		"offset": [3]float32{c.Offset.X, c.Offset.Y, c.Offset.Z},
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (c *CapsuleCollider) Deserialize(data map[string]any) {
+	if v, ok := data["radius"].(float64); ok {
+		c.Radius = float32(v)
+	}
+	if v, ok := data["height"].(float64); ok {
+		c.Height = float32(v)
+	}
+	if v, ok := data["offset"].([]any); ok && len(v) == 3 {
+		c.Offset = rl.Vector3{X: float32(v[0].(float64)), Y: float32(v[1].(float64)), Z: float32(v[2].(float64))}
+	}
+}
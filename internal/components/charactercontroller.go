@@ -1,6 +1,7 @@
 package components
 
 import (
+	"math"
 	"test3d/internal/engine"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -21,15 +22,16 @@ type CharacterController struct {
 	Height     float32 // Total height of the capsule/box
 	Radius     float32 // Radius (half-width) of the character
 	StepHeight float32 // Max height of steps to climb
-	SlopeLimit float32 // Max slope angle in degrees (not implemented yet)
+	SlopeLimit float32 // Max slope angle in degrees that still counts as ground
 
 	// Gravity
 	UseGravity bool
 	Gravity    float32 // Gravity strength (positive = down)
 
 	// Runtime state (not serialized)
-	velocity   rl.Vector3
-	isGrounded bool
+	velocity     rl.Vector3
+	isGrounded   bool
+	groundNormal rl.Vector3 // surface under the feet from the last ground check (zero when airborne)
 }
 
 // TypeName implements engine.Serializable
@@ -155,8 +157,9 @@ func (c *CharacterController) moveWithCollision(g *engine.GameObject, motion rl.
 			continue // Don't collide with other kinematic objects
 		}
 
+		// Triggers report overlaps through the physics world but never block
 		boxCol := engine.GetComponent[*BoxCollider](other)
-		if boxCol == nil {
+		if boxCol == nil || boxCol.IsTrigger {
 			continue
 		}
 
@@ -201,7 +204,6 @@ func (c *CharacterController) moveWithCollision(g *engine.GameObject, motion rl.
 				if !aabbOverlap(testMin, testMax, staticMin, staticMax) {
 					// Step up!
 					g.Transform.Position.Y = testY
-					c.isGrounded = true
 
 					// Update char bounds after stepping
 					charMin.Y = g.Transform.Position.Y - halfHeight
@@ -226,15 +228,16 @@ func (c *CharacterController) moveWithCollision(g *engine.GameObject, motion rl.
 			Z: g.Transform.Position.Z + halfWidth,
 		}
 
-		// Update grounded state
+		// Landing stops the fall; the physics step's ground check decides
+		// whether this counts as ground
 		if pushOut.Y > 0 {
-			c.isGrounded = true
 			c.velocity.Y = 0
 		}
 	}
 }
 
-// SimpleMove moves the character with gravity applied automatically
+// SimpleMove moves the character with gravity applied automatically. Whether
+// it is grounded comes from the ground check of the last physics step.
 func (c *CharacterController) SimpleMove(speed rl.Vector3, deltaTime float32) {
 	// Apply gravity (only if not grounded, or if we have upward velocity like a jump)
 	if c.UseGravity {
@@ -253,9 +256,6 @@ func (c *CharacterController) SimpleMove(speed rl.Vector3, deltaTime float32) {
 		Z: speed.Z * deltaTime,
 	}
 
-	// Reset grounded before move (will be set if we land)
-	c.isGrounded = false
-
 	c.Move(motion)
 }
 
@@ -269,6 +269,27 @@ func (c *CharacterController) SetGrounded(grounded bool) {
 	c.isGrounded = grounded
 }
 
+// GroundNormal returns the normal of the surface under the character from the
+// last ground check, or a zero vector while airborne. Use it to slide down or
+// align movement to slopes.
+func (c *CharacterController) GroundNormal() rl.Vector3 {
+	return c.groundNormal
+}
+
+// SetGround records the result of the physics world's ground check, run at
+// the end of every step. A hit counts as ground unless it is steeper than SlopeLimit or the
+// character is still moving up from a jump.
+func (c *CharacterController) SetGround(hit bool, normal rl.Vector3) {
+	if !hit {
+		c.isGrounded = false
+		c.groundNormal = rl.Vector3{}
+		return
+	}
+	c.groundNormal = normal
+	slope := float32(math.Acos(float64(max(min(normal.Y, 1), -1)))) * rl.Rad2deg
+	c.isGrounded = c.velocity.Y <= 0 && (c.SlopeLimit <= 0 || slope <= c.SlopeLimit)
+}
+
 // GetVelocity returns the current velocity
 func (c *CharacterController) GetVelocity() rl.Vector3 {
 	return c.velocity
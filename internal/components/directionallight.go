@@ -13,12 +13,13 @@ func init() {
 	})
 }
 
+// DirectionalLight lights the whole scene from one direction, like the sun.
+// Up to four are used at once; the world's main light also casts shadows.
 type DirectionalLight struct {
 	engine.BaseComponent
 	Direction      rl.Vector3
 	Color          rl.Color
 	Intensity      float32
-	AmbientColor   rl.Color
 	ShadowDistance float32
 }
 
@@ -27,7 +28,6 @@ func NewDirectionalLight() *DirectionalLight {
 		Direction:      rl.Vector3Normalize(rl.Vector3{X: 0.35, Y: -1.0, Z: -0.35}),
 		Color:          rl.White,
 		Intensity:      1.0,
-		AmbientColor:   rl.NewColor(25, 25, 25, 255),
 		ShadowDistance: 50.0,
 	}
 }
@@ -40,9 +40,11 @@ func (l *DirectionalLight) TypeName() string {
 // Serialize implements engine.Serializable
 func (l *DirectionalLight) Serialize() map[string]any {
 	return map[string]any{
-		"type":      "DirectionalLight",
-		"direction": [3]float32{l.Direction.X, l.Direction.Y, l.Direction.Z},
-		"intensity": l.Intensity,
+		"type":           "DirectionalLight",
+		"direction":      [3]float32{l.Direction.X, l.Direction.Y, l.Direction.Z},
+		"color":          [3]uint8{l.Color.R, l.Color.G, l.Color.B},
+		"intensity":      l.Intensity,
+		"shadowDistance": l.ShadowDistance,
 	}
 }
 
@@ -53,9 +55,18 @@ func (l *DirectionalLight) Deserialize(data map[string]any) {
 		l.Direction.Y = float32(dir[1].(float64))
 		l.Direction.Z = float32(dir[2].(float64))
 	}
+	if c, ok := data["color"].([]any); ok && len(c) == 3 {
+		l.Color.R = uint8(c[0].(float64))
+		l.Color.G = uint8(c[1].(float64))
+		l.Color.B = uint8(c[2].(float64))
+		l.Color.A = 255
+	}
 	if i, ok := data["intensity"].(float64); ok {
 		l.Intensity = float32(i)
 	}
+	if d, ok := data["shadowDistance"].(float64); ok {
+		l.ShadowDistance = float32(d)
+	}
 }
 
 func (l *DirectionalLight) GetLightCamera(orthoSize float32) rl.Camera3D {
@@ -84,15 +95,6 @@ func (l *DirectionalLight) GetColorFloat() []float32 {
 	}
 }
 
-func (l *DirectionalLight) GetAmbientFloat() []float32 {
-	return []float32{
-		float32(l.AmbientColor.R) / 255.0,
-		float32(l.AmbientColor.G) / 255.0,
-		float32(l.AmbientColor.B) / 255.0,
-		1.0,
-	}
-}
-
 func (l *DirectionalLight) lightCameraUp() rl.Vector3 {
 	if math.Abs(float64(l.Direction.Y)) > 0.9 {
 		return rl.Vector3{X: 0, Y: 0, Z: 1}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("FollowTarget", func() engine.Serializable {
+		return NewFollowTarget()
+	})
+}
+
+// FollowTarget keeps its object at another object's world position and/or
+// rotation plus an offset, without reparenting (e.g. cameras, held weapons).
+type FollowTarget struct {
+	engine.BaseComponent
+	Target         engine.GameObjectRef // object to follow
+	PositionOffset rl.Vector3           // added to the target's position
+	RotationOffset rl.Vector3           // degrees, added to the target's rotation
+	FollowPosition bool
+	FollowRotation bool
+	LocalOffset    bool // rotate PositionOffset with the target (stays "behind" it)
+}
+
+func NewFollowTarget() *FollowTarget {
+	return &FollowTarget{
+		FollowPosition: true,
+		FollowRotation: false,
+		LocalOffset:    true,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (f *FollowTarget) TypeName() string {
+	return "FollowTarget"
+}
+
+// Serialize implements engine.Serializable
+func (f *FollowTarget) Serialize() map[string]any {
+	return map[string]any{
+		"type":           "FollowTarget",
+		"target":         f.Target.UID,
+		"positionOffset": [3]float32{f.PositionOffset.X, f.PositionOffset.Y, f.PositionOffset.Z},
+		"rotationOffset": [3]float32{f.RotationOffset.X, f.RotationOffset.Y, f.RotationOffset.Z},
+		"followPosition": f.FollowPosition,
+		"followRotation": f.FollowRotation,
+		"localOffset":    f.LocalOffset,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (f *FollowTarget) Deserialize(data map[string]any) {
+	if uid, ok := data["target"].(float64); ok {
+		f.Target.UID = uint64(uid)
+	}
+	if o, ok := data["positionOffset"].([]any); ok && len(o) == 3 {
+		f.PositionOffset = rl.Vector3{X: float32(o[0].(float64)), Y: float32(o[1].(float64)), Z: float32(o[2].(float64))}
+	}
+	if o, ok := data["rotationOffset"].([]any); ok && len(o) == 3 {
+		f.RotationOffset = rl.Vector3{X: float32(o[0].(float64)), Y: float32(o[1].(float64)), Z: float32(o[2].(float64))}
+	}
+	if v, ok := data["followPosition"].(bool); ok {
+		f.FollowPosition = v
+	}
+	if v, ok := data["followRotation"].(bool); ok {
+		f.FollowRotation = v
+	}
+	if v, ok := data["localOffset"].(bool); ok {
+		f.LocalOffset = v
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (f *FollowTarget) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&f.Target}
+}
+
+// TargetObject resolves the followed object (nil if unset or missing)
+func (f *FollowTarget) TargetObject() *engine.GameObject {
+	g := f.GetGameObject()
+	if g == nil {
+		return nil
+	}
+	return f.Target.Get(g.Scene)
+}
+
+func (f *FollowTarget) Update(deltaTime float32) {
+	f.Apply()
+}
+
+// Apply snaps the object to the target now. Update calls it every frame;
+// call it directly after moving the target to avoid a frame of lag.
+func (f *FollowTarget) Apply() {
+	g := f.GetGameObject()
+	target := f.TargetObject()
+	if g == nil || target == nil || target == g || target.IsDescendantOf(g) {
+		return
+	}
+
+	if f.FollowPosition {
+		offset := f.PositionOffset
+		if f.LocalOffset {
+			offset = rl.Vector3Transform(offset, target.WorldRotationMatrix())
+		}
+		pos := rl.Vector3Add(target.WorldPosition(), offset)
+		if g.Parent != nil {
+			pos = WorldToLocalPoint(g.Parent, pos)
+		}
+		g.Transform.Position = pos
+	}
+
+	if f.FollowRotation {
+		g.SetWorldRotation(rl.Vector3Add(target.WorldRotation(), f.RotationOffset))
+	}
+}
@@ -0,0 +1,170 @@
+package components
+
+import (
+	"test3d/internal/engine"
+)
+
+func init() {
+	engine.RegisterComponent("Health", func() engine.Serializable {
+		return NewHealth()
+	})
+}
+
+// Health gives an object hit points that damage and healing move between 0
+// and Max. Scripts subscribe to OnDamaged, OnHealed and OnDeath instead of
+// polling. Hard collisions can deal damage too (see ImpactDamage), and Bar
+// keeps a UIProgressBar showing the current health.
+type Health struct {
+	engine.BaseComponent
+	Max        float32
+	Current    float32
+	Invincible bool // ignore all damage
+
+	// Collision damage: hits harder than MinImpulse deal ImpactDamage per unit
+	// of impulse above it (0 = collisions don't hurt)
+	ImpactDamage float32
+	MinImpulse   float32
+
+	Bar engine.GameObjectRef // object with a UIProgressBar to keep in sync (optional)
+
+	OnDamaged engine.EventWithArg[float32] // health actually lost
+	OnHealed  engine.EventWithArg[float32] // health actually gained
+	OnDeath   engine.Event                 // health reached zero
+
+	dead bool
+}
+
+func NewHealth() *Health {
+	return &Health{
+		Max:        100,
+		Current:    100,
+		MinImpulse: 5,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (h *Health) TypeName() string {
+	return "Health"
+}
+
+// Serialize implements engine.Serializable
+func (h *Health) Serialize() map[string]any {
+	return map[string]any{
+		"type":         "Health",
+		"max":          h.Max,
+		"current":      h.Current,
+		"invincible":   h.Invincible,
+		"impactDamage": h.ImpactDamage,
+		"minImpulse":   h.MinImpulse,
+		"bar":          h.Bar.UID,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (h *Health) Deserialize(data map[string]any) {
+	if v, ok := data["max"].(float64); ok {
+		h.Max = float32(v)
+	}
+	if v, ok := data["current"].(float64); ok {
+		h.Current = float32(v)
+	}
+	if v, ok := data["invincible"].(bool); ok {
+		h.Invincible = v
+	}
+	if v, ok := data["impactDamage"].(float64); ok {
+		h.ImpactDamage = float32(v)
+	}
+	if v, ok := data["minImpulse"].(float64); ok {
+		h.MinImpulse = float32(v)
+	}
+	if uid, ok := data["bar"].(float64); ok {
+		h.Bar.UID = uint64(uid)
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (h *Health) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&h.Bar}
+}
+
+func (h *Health) Start() {
+	h.Current = min(max(h.Current, 0), h.Max)
+	h.dead = h.Current <= 0
+	h.syncBar()
+}
+
+// TakeDamage lowers Current by amount, down to zero. Reaching zero fires
+// OnDeath once; a dead or Invincible object takes no damage.
+func (h *Health) TakeDamage(amount float32) {
+	if amount <= 0 || h.Invincible || h.dead {
+		return
+	}
+	before := h.Current
+	h.Current = max(h.Current-amount, 0)
+	h.syncBar()
+	h.OnDamaged.Invoke(before - h.Current)
+	if h.Current <= 0 {
+		h.dead = true
+		h.OnDeath.Invoke()
+	}
+}
+
+// Heal raises Current by amount, up to Max. It can't bring back the dead; use
+// Revive for that.
+func (h *Health) Heal(amount float32) {
+	if amount <= 0 || h.dead || h.Current >= h.Max {
+		return
+	}
+	before := h.Current
+	h.Current = min(h.Current+amount, h.Max)
+	h.syncBar()
+	h.OnHealed.Invoke(h.Current - before)
+}
+
+// Revive brings the object back at full health, so it can die again
+func (h *Health) Revive() {
+	h.dead = false
+	h.Current = h.Max
+	h.syncBar()
+}
+
+// IsDead reports whether health reached zero (and Revive wasn't called since)
+func (h *Health) IsDead() bool {
+	return h.dead
+}
+
+// Percent returns Current as a fraction of Max (0-1)
+func (h *Health) Percent() float32 {
+	if h.Max <= 0 {
+		return 0
+	}
+	return min(max(h.Current/h.Max, 0), 1)
+}
+
+// BarComponent resolves the health bar (nil if unset or missing)
+func (h *Health) BarComponent() *UIProgressBar {
+	g := h.GetGameObject()
+	if g == nil {
+		return nil
+	}
+	barObj := h.Bar.Get(g.Scene)
+	if barObj == nil {
+		return nil
+	}
+	return engine.GetComponent[*UIProgressBar](barObj)
+}
+
+// syncBar copies the health into the health bar
+func (h *Health) syncBar() {
+	if bar := h.BarComponent(); bar != nil {
+		bar.MaxValue = h.Max
+		bar.Value = h.Current
+	}
+}
+
+// OnCollisionImpact implements engine.ImpactHandler
+func (h *Health) OnCollisionImpact(other *engine.GameObject, impulse float32) {
+	if h.ImpactDamage > 0 && impulse > h.MinImpulse {
+		h.TakeDamage((impulse - h.MinImpulse) * h.ImpactDamage)
+	}
+}
@@ -309,8 +309,10 @@ func (r *hrtfReader) Read(buf []byte) (int, error) {
 		absPan = -absPan
 	}
 
-	leftGain := attenuation * r.source.Volume
-	rightGain := attenuation * r.source.Volume
+	// HRTF sources are always world sounds, so they follow the SFX volume
+	gain := attenuation * r.source.Volume * audio.ChannelGain(audio.ChannelSFX)
+	leftGain := gain
+	rightGain := gain
 
 	if pan > 0 {
 		// Sound to the right - reduce left ear
@@ -0,0 +1,132 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("Joint", func() engine.Serializable {
+		return NewJoint()
+	})
+}
+
+// Joint is a damped spring connecting this object to another one.
+// Anchors are in each object's local space, so they follow rotation and scale.
+type Joint struct {
+	engine.BaseComponent
+	Connected       engine.GameObjectRef // the other end of the spring
+	Anchor          rl.Vector3           // attach point on this object (local)
+	ConnectedAnchor rl.Vector3           // attach point on the connected object (local)
+	RestLength      float32              // spring length with no force
+	Stiffness       float32              // force per unit of stretch
+	Damping         float32              // force per unit of stretch velocity
+}
+
+func NewJoint() *Joint {
+	return &Joint{
+		RestLength: 1.0,
+		Stiffness:  50.0,
+		Damping:    2.0,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (j *Joint) TypeName() string {
+	return "Joint"
+}
+
+// Serialize implements engine.Serializable
+func (j *Joint) Serialize() map[string]any {
+	return map[string]any{
+		"type":            "Joint",
+		"connected":       j.Connected.UID,
+		"anchor":          [3]float32{j.Anchor.X, j.Anchor.Y, j.Anchor.Z},
+		"connectedAnchor": [3]float32{j.ConnectedAnchor.X, j.ConnectedAnchor.Y, j.ConnectedAnchor.Z},
+		"restLength":      j.RestLength,
+		"stiffness":       j.Stiffness,
+		"damping":         j.Damping,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (j *Joint) Deserialize(data map[string]any) {
+	if uid, ok := data["connected"].(float64); ok {
+		j.Connected.UID = uint64(uid)
+	}
+	if a, ok := data["anchor"].([]any); ok && len(a) == 3 {
+		j.Anchor = rl.Vector3{X: float32(a[0].(float64)), Y: float32(a[1].(float64)), Z: float32(a[2].(float64))}
+	}
+	if a, ok := data["connectedAnchor"].([]any); ok && len(a) == 3 {
+		j.ConnectedAnchor = rl.Vector3{X: float32(a[0].(float64)), Y: float32(a[1].(float64)), Z: float32(a[2].(float64))}
+	}
+	if r, ok := data["restLength"].(float64); ok {
+		j.RestLength = float32(r)
+	}
+	if s, ok := data["stiffness"].(float64); ok {
+		j.Stiffness = float32(s)
+	}
+	if d, ok := data["damping"].(float64); ok {
+		j.Damping = float32(d)
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (j *Joint) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&j.Connected}
+}
+
+// ConnectedObject resolves the other end of the joint (nil if missing)
+func (j *Joint) ConnectedObject() *engine.GameObject {
+	g := j.GetGameObject()
+	if g == nil {
+		return nil
+	}
+	return j.Connected.Get(g.Scene)
+}
+
+// WorldAnchor returns this end's attach point in world space
+func (j *Joint) WorldAnchor() rl.Vector3 {
+	return LocalToWorldPoint(j.GetGameObject(), j.Anchor)
+}
+
+// ConnectedWorldAnchor returns the other end's attach point in world space
+func (j *Joint) ConnectedWorldAnchor() rl.Vector3 {
+	return LocalToWorldPoint(j.ConnectedObject(), j.ConnectedAnchor)
+}
+
+// LocalToWorldPoint transforms a point from an object's local space to world space
+func LocalToWorldPoint(g *engine.GameObject, p rl.Vector3) rl.Vector3 {
+	if g == nil {
+		return p
+	}
+	scale := g.WorldScale()
+	p = rl.Vector3{X: p.X * scale.X, Y: p.Y * scale.Y, Z: p.Z * scale.Z}
+
+	p = rl.Vector3Transform(p, g.WorldRotationMatrix())
+
+	return rl.Vector3Add(g.WorldPosition(), p)
+}
+
+// WorldToLocalPoint transforms a world-space point into an object's local space
+func WorldToLocalPoint(g *engine.GameObject, p rl.Vector3) rl.Vector3 {
+	if g == nil {
+		return p
+	}
+	p = rl.Vector3Subtract(p, g.WorldPosition())
+
+	p = rl.Vector3Transform(p, rl.MatrixTranspose(g.WorldRotationMatrix())) // inverse of a pure rotation
+
+	scale := g.WorldScale()
+	if scale.X != 0 {
+		p.X /= scale.X
+	}
+	if scale.Y != 0 {
+		p.Y /= scale.Y
+	}
+	if scale.Z != 0 {
+		p.Z /= scale.Z
+	}
+	return p
+}
@@ -0,0 +1,129 @@
+package components
+
+import (
+	"os"
+	"test3d/internal/assets"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("LODGroup", func() engine.Serializable {
+		return NewLODGroup()
+	})
+}
+
+// LODCulled is the level reported while the object is beyond CullDistance
+const LODCulled = -1
+
+// LODLevel is a lower-detail model drawn once the camera is at least Distance away
+type LODLevel struct {
+	ModelPath string
+	Distance  float32
+
+	model      rl.Model // loaded from loadedPath, empty if the file was missing
+	loadedPath string
+}
+
+// LODGroup swaps the object's ModelRenderer model for cheaper ones as the
+// camera moves away. Level 0 is the ModelRenderer's own model; level i draws
+// Levels[i-1] with the same transform and material.
+type LODGroup struct {
+	engine.BaseComponent
+	Levels       []LODLevel
+	CullDistance float32 // nothing is drawn from this distance on (0 = never cull)
+
+	current int // level picked by the last Select
+}
+
+func NewLODGroup() *LODGroup {
+	return &LODGroup{}
+}
+
+// TypeName implements engine.Serializable
+func (l *LODGroup) TypeName() string {
+	return "LODGroup"
+}
+
+// Serialize implements engine.Serializable
+func (l *LODGroup) Serialize() map[string]any {
+	levels := make([]map[string]any, len(l.Levels))
+	for i, lv := range l.Levels {
+		levels[i] = map[string]any{
+			"model":    lv.ModelPath,
+			"distance": lv.Distance,
+		}
+	}
+	return map[string]any{
+		"type":         "LODGroup",
+		"levels":       levels,
+		"cullDistance": l.CullDistance,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (l *LODGroup) Deserialize(data map[string]any) {
+	if raw, ok := data["levels"].([]any); ok {
+		l.Levels = l.Levels[:0]
+		for _, r := range raw {
+			m, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			lv := LODLevel{}
+			if v, ok := m["model"].(string); ok {
+				lv.ModelPath = v
+			}
+			if v, ok := m["distance"].(float64); ok {
+				lv.Distance = float32(v)
+			}
+			l.Levels = append(l.Levels, lv)
+		}
+	}
+	if v, ok := data["cullDistance"].(float64); ok {
+		l.CullDistance = float32(v)
+	}
+}
+
+// Select picks the level for a camera at the given distance (the farthest
+// level already reached) and remembers it for Current. Returns LODCulled
+// beyond CullDistance.
+func (l *LODGroup) Select(distance float32) int {
+	l.current = 0
+	if l.CullDistance > 0 && distance >= l.CullDistance {
+		l.current = LODCulled
+		return l.current
+	}
+	var reached float32
+	for i, lv := range l.Levels {
+		if distance >= lv.Distance && lv.Distance >= reached {
+			l.current = i + 1
+			reached = lv.Distance
+		}
+	}
+	return l.current
+}
+
+// Current returns the level picked the last time the object was drawn
+func (l *LODGroup) Current() int {
+	return l.current
+}
+
+// Model returns the model for a level above 0. ok is false for level 0, for
+// culled and for levels whose model path is empty or missing, which draw the
+// base model. A level's file is loaded once when its path changes.
+func (l *LODGroup) Model(level int) (model rl.Model, ok bool) {
+	if level < 1 || level > len(l.Levels) {
+		return rl.Model{}, false
+	}
+	lv := &l.Levels[level-1]
+	if lv.loadedPath != lv.ModelPath {
+		lv.loadedPath = lv.ModelPath
+		lv.model = rl.Model{}
+		if _, err := os.Stat(lv.ModelPath); err == nil {
+			lv.model = assets.LoadModel(lv.ModelPath)
+		}
+	}
+	return lv.model, lv.model.MeshCount > 0
+}
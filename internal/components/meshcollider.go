@@ -2,6 +2,8 @@ package components
 
 import (
 	"math"
+	"os"
+	"test3d/internal/assets"
 	"test3d/internal/engine"
 	"unsafe"
 
@@ -25,6 +27,11 @@ type AABB struct {
 	Min, Max rl.Vector3
 }
 
+const (
+	bvhLeafSize = 4  // most triangles in a leaf
+	bvhMaxDepth = 32 // deeper nodes become leaves however many triangles they hold
+)
+
 // BVHNode is a node in the bounding volume hierarchy
 type BVHNode struct {
 	Bounds    AABB
@@ -37,8 +44,10 @@ type BVHNode struct {
 // This is for STATIC geometry only - moving the object won't update the collider.
 type MeshCollider struct {
 	engine.BaseComponent
+	ModelPath string // model file to collide against (empty = the object's ModelRenderer or Terrain)
 	Triangles []Triangle
 	Root      *BVHNode
+	nodes     int
 	built     bool
 }
 
@@ -47,6 +56,33 @@ func NewMeshCollider() *MeshCollider {
 	return &MeshCollider{}
 }
 
+// Rebuild builds the collider at the object's current transform from its
+// source: the ModelPath file if set, otherwise the object's ModelRenderer or
+// Terrain. It reports whether a source was found; a ModelPath that doesn't
+// exist leaves the collider as it was.
+func (m *MeshCollider) Rebuild() bool {
+	g := m.GetGameObject()
+	if g == nil {
+		return false
+	}
+	if m.ModelPath != "" {
+		if _, err := os.Stat(m.ModelPath); err != nil {
+			return false
+		}
+		m.BuildFromModel(assets.LoadModel(m.ModelPath))
+		return true
+	}
+	if renderer := engine.GetComponent[*ModelRenderer](g); renderer != nil {
+		m.BuildFromModel(renderer.Model)
+		return true
+	}
+	if terrain := engine.GetComponent[*Terrain](g); terrain != nil {
+		m.BuildFromModel(terrain.Model())
+		return true
+	}
+	return false
+}
+
 // BuildFromModel extracts triangles from a raylib Model and builds the BVH
 func (m *MeshCollider) BuildFromModel(model rl.Model) {
 	g := m.GetGameObject()
@@ -56,21 +92,25 @@ func (m *MeshCollider) BuildFromModel(model rl.Model) {
 
 	// Get world transform
 	worldPos := g.WorldPosition()
-	worldRot := g.WorldRotation()
 	worldScale := g.WorldScale()
 
 	// Build transform matrix
 	scaleMatrix := rl.MatrixScale(worldScale.X, worldScale.Y, worldScale.Z)
-	rotX := rl.MatrixRotateX(worldRot.X * rl.Deg2rad)
-	rotY := rl.MatrixRotateY(worldRot.Y * rl.Deg2rad)
-	rotZ := rl.MatrixRotateZ(worldRot.Z * rl.Deg2rad)
-	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+	rotMatrix := g.WorldRotationMatrix()
 	transMatrix := rl.MatrixTranslate(worldPos.X, worldPos.Y, worldPos.Z)
 	transform := rl.MatrixMultiply(rl.MatrixMultiply(scaleMatrix, rotMatrix), transMatrix)
 
 	// Extract triangles from all meshes
-	m.Triangles = nil
 	meshes := unsafe.Slice(model.Meshes, model.MeshCount)
+	total := 0
+	for _, mesh := range meshes {
+		if mesh.Indices != nil {
+			total += int(mesh.TriangleCount)
+		} else {
+			total += int(mesh.VertexCount / 3)
+		}
+	}
+	m.Triangles = make([]Triangle, 0, total)
 
 	for _, mesh := range meshes {
 		vertices := unsafe.Slice(mesh.Vertices, mesh.VertexCount*3)
@@ -129,29 +169,36 @@ func (m *MeshCollider) BuildFromModel(model rl.Model) {
 	m.built = true
 }
 
-// buildBVH constructs a bounding volume hierarchy for fast queries
+// buildBVH constructs a bounding volume hierarchy for fast queries. It is
+// built once per build, so queries only test the triangles near them.
 func (m *MeshCollider) buildBVH() {
+	m.Root = nil
+	m.nodes = 0
 	if len(m.Triangles) == 0 {
 		return
 	}
 
 	// Create indices for all triangles
 	indices := make([]int, len(m.Triangles))
+	centroids := make([]rl.Vector3, len(m.Triangles))
 	for i := range indices {
 		indices[i] = i
+		tri := &m.Triangles[i]
+		centroids[i] = rl.Vector3Scale(rl.Vector3Add(rl.Vector3Add(tri.V0, tri.V1), tri.V2), 1.0/3.0)
 	}
 
-	m.Root = m.buildBVHNode(indices, 0)
+	m.Root = m.buildBVHNode(indices, centroids, 0)
 }
 
-func (m *MeshCollider) buildBVHNode(indices []int, depth int) *BVHNode {
+func (m *MeshCollider) buildBVHNode(indices []int, centroids []rl.Vector3, depth int) *BVHNode {
 	node := &BVHNode{}
+	m.nodes++
 
 	// Compute bounds for all triangles in this node
 	node.Bounds = m.computeBounds(indices)
 
 	// If few triangles or max depth, make leaf
-	if len(indices) <= 4 || depth > 20 {
+	if len(indices) <= bvhLeafSize || depth >= bvhMaxDepth {
 		node.Triangles = indices
 		return node
 	}
@@ -167,7 +214,7 @@ func (m *MeshCollider) buildBVHNode(indices []int, depth int) *BVHNode {
 	}
 
 	// Sort by centroid on longest axis
-	mid := m.partitionTriangles(indices, axis)
+	mid := partitionTriangles(indices, centroids, axis)
 
 	if mid == 0 || mid == len(indices) {
 		// Couldn't split, make leaf
@@ -175,8 +222,8 @@ func (m *MeshCollider) buildBVHNode(indices []int, depth int) *BVHNode {
 		return node
 	}
 
-	node.Left = m.buildBVHNode(indices[:mid], depth+1)
-	node.Right = m.buildBVHNode(indices[mid:], depth+1)
+	node.Left = m.buildBVHNode(indices[:mid], centroids, depth+1)
+	node.Right = m.buildBVHNode(indices[mid:], centroids, depth+1)
 
 	return node
 }
@@ -200,13 +247,11 @@ func (m *MeshCollider) computeBounds(indices []int) AABB {
 	return bounds
 }
 
-func (m *MeshCollider) partitionTriangles(indices []int, axis int) int {
+func partitionTriangles(indices []int, centroids []rl.Vector3, axis int) int {
 	// Find median centroid
 	center := float32(0)
 	for _, idx := range indices {
-		tri := &m.Triangles[idx]
-		centroid := rl.Vector3Scale(rl.Vector3Add(rl.Vector3Add(tri.V0, tri.V1), tri.V2), 1.0/3.0)
-		center += getAxisValue(centroid, axis)
+		center += getAxisValue(centroids[idx], axis)
 	}
 	center /= float32(len(indices))
 
@@ -214,9 +259,7 @@ func (m *MeshCollider) partitionTriangles(indices []int, axis int) int {
 	left := 0
 	right := len(indices) - 1
 	for left <= right {
-		tri := &m.Triangles[indices[left]]
-		centroid := rl.Vector3Scale(rl.Vector3Add(rl.Vector3Add(tri.V0, tri.V1), tri.V2), 1.0/3.0)
-		if getAxisValue(centroid, axis) < center {
+		if getAxisValue(centroids[indices[left]], axis) < center {
 			left++
 		} else {
 			indices[left], indices[right] = indices[right], indices[left]
@@ -265,15 +308,11 @@ func (m *MeshCollider) SphereIntersect(center rl.Vector3, radius float32) (bool,
 		Max: rl.Vector3{X: center.X + radius, Y: center.Y + radius, Z: center.Z + radius},
 	}
 
-	// Find all potentially colliding triangles
-	candidates := m.queryBVH(m.Root, sphereAABB)
-
-	// Test each triangle
+	// Test each triangle in the leaves the sphere reaches
 	var totalPush rl.Vector3
 	hit := false
 
-	for _, idx := range candidates {
-		tri := &m.Triangles[idx]
+	m.eachTriangle(m.Root, sphereAABB, func(tri *Triangle) {
 		if collides, push := sphereTriangleIntersect(center, radius, tri); collides {
 			// Accumulate push vectors (take the largest in each direction)
 			if math.Abs(float64(push.X)) > math.Abs(float64(totalPush.X)) {
@@ -287,30 +326,136 @@ func (m *MeshCollider) SphereIntersect(center rl.Vector3, radius float32) (bool,
 			}
 			hit = true
 		}
-	}
+	})
 
 	return hit, totalPush
 }
 
-func (m *MeshCollider) queryBVH(node *BVHNode, query AABB) []int {
-	if node == nil {
-		return nil
+// Raycast returns the nearest triangle the ray hits within maxDistance: the
+// distance along the (normalized) direction and the triangle's normal, turned
+// to face the ray
+func (m *MeshCollider) Raycast(origin, direction rl.Vector3, maxDistance float32) (float32, rl.Vector3, bool) {
+	if !m.built || m.Root == nil {
+		return 0, rl.Vector3{}, false
+	}
+	inv := rl.Vector3{X: 1 / direction.X, Y: 1 / direction.Y, Z: 1 / direction.Z}
+	best := maxDistance
+	var normal rl.Vector3
+	hit := false
+
+	var visit func(node *BVHNode)
+	visit = func(node *BVHNode) {
+		if node == nil || !rayHitsAABB(origin, inv, node.Bounds, best) {
+			return
+		}
+		if node.Triangles == nil {
+			visit(node.Left)
+			visit(node.Right)
+			return
+		}
+		for _, idx := range node.Triangles {
+			tri := &m.Triangles[idx]
+			if t, ok := rayTriangleIntersect(origin, direction, tri); ok && t <= best {
+				best = t
+				normal = tri.Normal
+				hit = true
+			}
+		}
 	}
+	visit(m.Root)
 
-	// Check if query intersects this node's bounds
-	if !aabbIntersects(node.Bounds, query) {
+	if rl.Vector3DotProduct(normal, direction) > 0 {
+		normal = rl.Vector3Negate(normal)
+	}
+	return best, normal, hit
+}
+
+// rayHitsAABB is the slab test for a ray given by its origin and inverse
+// direction, limited to maxDistance
+func rayHitsAABB(origin, inv rl.Vector3, box AABB, maxDistance float32) bool {
+	tmin, tmax := float32(0), maxDistance
+	for axis := range 3 {
+		o, d := getAxisValue(origin, axis), getAxisValue(inv, axis)
+		t1 := (getAxisValue(box.Min, axis) - o) * d
+		t2 := (getAxisValue(box.Max, axis) - o) * d
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		// NaN (ray on the slab plane, parallel to it) keeps the current range
+		if t1 > tmin {
+			tmin = t1
+		}
+		if t2 < tmax {
+			tmax = t2
+		}
+		if tmin > tmax {
+			return false
+		}
+	}
+	return true
+}
+
+// rayTriangleIntersect is the Moller-Trumbore test, hitting either side
+func rayTriangleIntersect(origin, direction rl.Vector3, tri *Triangle) (float32, bool) {
+	const epsilon = 1e-7
+	edge1 := rl.Vector3Subtract(tri.V1, tri.V0)
+	edge2 := rl.Vector3Subtract(tri.V2, tri.V0)
+	h := rl.Vector3CrossProduct(direction, edge2)
+	a := rl.Vector3DotProduct(edge1, h)
+	if a > -epsilon && a < epsilon {
+		return 0, false // parallel to the triangle
+	}
+	f := 1 / a
+	s := rl.Vector3Subtract(origin, tri.V0)
+	u := f * rl.Vector3DotProduct(s, h)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := rl.Vector3CrossProduct(s, edge1)
+	v := f * rl.Vector3DotProduct(direction, q)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t := f * rl.Vector3DotProduct(edge2, q)
+	return t, t >= 0
+}
+
+// TrianglesInBounds returns the indices of the triangles whose BVH leaves
+// overlap query. It may include triangles just outside query.
+func (m *MeshCollider) TrianglesInBounds(query AABB) []int {
+	if !m.built {
 		return nil
 	}
+	return m.queryBVH(m.Root, query, nil)
+}
 
-	// If leaf, return triangles
+// queryBVH appends the triangles of the leaves under node that overlap query
+// to dst. Leaves share one index array, so they are copied, never appended to.
+func (m *MeshCollider) queryBVH(node *BVHNode, query AABB, dst []int) []int {
+	if node == nil || !aabbIntersects(node.Bounds, query) {
+		return dst
+	}
 	if node.Triangles != nil {
-		return node.Triangles
+		return append(dst, node.Triangles...)
 	}
+	dst = m.queryBVH(node.Left, query, dst)
+	return m.queryBVH(node.Right, query, dst)
+}
 
-	// Otherwise, recurse
-	left := m.queryBVH(node.Left, query)
-	right := m.queryBVH(node.Right, query)
-	return append(left, right...)
+// eachTriangle calls fn for the triangles of the leaves under node that
+// overlap query, without collecting them
+func (m *MeshCollider) eachTriangle(node *BVHNode, query AABB, fn func(tri *Triangle)) {
+	if node == nil || !aabbIntersects(node.Bounds, query) {
+		return
+	}
+	if node.Triangles == nil {
+		m.eachTriangle(node.Left, query, fn)
+		m.eachTriangle(node.Right, query, fn)
+		return
+	}
+	for _, idx := range node.Triangles {
+		fn(&m.Triangles[idx])
+	}
 }
 
 func aabbIntersects(a, b AABB) bool {
@@ -346,6 +491,11 @@ func sphereTriangleIntersect(center rl.Vector3, radius float32, tri *Triangle) (
 	return true, rl.Vector3Scale(pushDir, penetration)
 }
 
+// ClosestPoint returns the point on the triangle nearest p
+func (t *Triangle) ClosestPoint(p rl.Vector3) rl.Vector3 {
+	return closestPointOnTriangle(p, t.V0, t.V1, t.V2)
+}
+
 // closestPointOnTriangle finds the closest point on a triangle to point p
 func closestPointOnTriangle(p, a, b, c rl.Vector3) rl.Vector3 {
 	// Check if P in vertex region outside A
@@ -413,6 +563,11 @@ func (m *MeshCollider) TriangleCount() int {
 	return len(m.Triangles)
 }
 
+// NodeCount returns the number of nodes in the BVH, leaves included
+func (m *MeshCollider) NodeCount() int {
+	return m.nodes
+}
+
 // GetBounds returns the AABB of the entire mesh collider
 func (m *MeshCollider) GetBounds() AABB {
 	if m.Root == nil {
@@ -428,10 +583,17 @@ func (m *MeshCollider) TypeName() string {
 
 // Serialize implements engine.Serializable
 func (m *MeshCollider) Serialize() map[string]any {
-	return map[string]any{"type": "MeshCollider"}
+	data := map[string]any{"type": "MeshCollider"}
+	if m.ModelPath != "" {
+		data["model"] = m.ModelPath
+	}
+	return data
 }
 
-// Deserialize implements engine.Serializable
+// Deserialize implements engine.Serializable. The triangles aren't saved; the
+// scene loader calls Rebuild once the object's other components exist.
 func (m *MeshCollider) Deserialize(data map[string]any) {
-	// MeshCollider rebuilds from ModelRenderer, nothing to deserialize
+	if v, ok := data["model"].(string); ok {
+		m.ModelPath = v
+	}
 }
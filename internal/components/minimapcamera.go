@@ -0,0 +1,130 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("MinimapCamera", func() engine.Serializable {
+		return NewMinimapCamera()
+	})
+}
+
+// MinimapCamera renders the scene from a top-down orthographic view into a
+// render texture every frame. UI can bind the captured texture via Texture().
+type MinimapCamera struct {
+	engine.BaseComponent
+	Height     float32  // distance above the object the camera sits at
+	Zoom       float32  // orthographic view size in world units
+	Resolution int32    // render texture width and height in pixels
+	Layers     []string // only objects with one of these tags are drawn (empty = all)
+
+	target rl.RenderTexture2D
+}
+
+func NewMinimapCamera() *MinimapCamera {
+	return &MinimapCamera{
+		Height:     50.0,
+		Zoom:       40.0,
+		Resolution: 256,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (m *MinimapCamera) TypeName() string {
+	return "MinimapCamera"
+}
+
+// Serialize implements engine.Serializable
+func (m *MinimapCamera) Serialize() map[string]any {
+	return map[string]any{
+		"type":       "MinimapCamera",
+		"height":     m.Height,
+		"zoom":       m.Zoom,
+		"resolution": m.Resolution,
+		"layers":     m.Layers,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (m *MinimapCamera) Deserialize(data map[string]any) {
+	if h, ok := data["height"].(float64); ok {
+		m.Height = float32(h)
+	}
+	if z, ok := data["zoom"].(float64); ok {
+		m.Zoom = float32(z)
+	}
+	if r, ok := data["resolution"].(float64); ok {
+		m.Resolution = int32(r)
+	}
+	if layers, ok := data["layers"].([]any); ok {
+		m.Layers = nil
+		for _, l := range layers {
+			if s, ok := l.(string); ok {
+				m.Layers = append(m.Layers, s)
+			}
+		}
+	}
+}
+
+// GetRaylibCamera returns the top-down orthographic camera centered on the object.
+// Up is -Z so that "forward" in the world points to the top of the minimap.
+func (m *MinimapCamera) GetRaylibCamera() rl.Camera3D {
+	g := m.GetGameObject()
+	if g == nil {
+		return rl.Camera3D{}
+	}
+
+	center := g.WorldPosition()
+	return rl.Camera3D{
+		Position:   rl.Vector3{X: center.X, Y: center.Y + m.Height, Z: center.Z},
+		Target:     center,
+		Up:         rl.Vector3{X: 0, Y: 0, Z: -1},
+		Fovy:       m.Zoom,
+		Projection: rl.CameraOrthographic,
+	}
+}
+
+// Includes reports whether an object should be drawn into the minimap.
+func (m *MinimapCamera) Includes(g *engine.GameObject) bool {
+	if len(m.Layers) == 0 {
+		return true
+	}
+	for _, layer := range m.Layers {
+		if g.HasTag(layer) {
+			return true
+		}
+	}
+	return false
+}
+
+// Target returns the render texture, (re)creating it if the resolution changed.
+// Must be called with a valid OpenGL context.
+func (m *MinimapCamera) Target() rl.RenderTexture2D {
+	if m.Resolution <= 0 {
+		m.Resolution = 256
+	}
+	if m.target.ID > 0 && m.target.Texture.Width != m.Resolution {
+		m.Unload()
+	}
+	if m.target.ID == 0 {
+		m.target = rl.LoadRenderTexture(m.Resolution, m.Resolution)
+	}
+	return m.target
+}
+
+// Texture returns the captured minimap texture for UI display.
+// Render textures are stored upside-down, so flip V when drawing.
+func (m *MinimapCamera) Texture() rl.Texture2D {
+	return m.target.Texture
+}
+
+// Unload frees the render texture.
+func (m *MinimapCamera) Unload() {
+	if m.target.ID > 0 {
+		rl.UnloadRenderTexture(m.target)
+		m.target = rl.RenderTexture2D{}
+	}
+}
@@ -14,7 +14,7 @@ type ModelRenderer struct {
 	Color    rl.Color
 	shader   rl.Shader
 	FilePath string    // non-empty for file-loaded models
-	MeshType string    // "cube", "plane", "sphere" for generated meshes
+	MeshType string    // "cube", "sphere", "plane", "capsule", "cone", "torus" for generated meshes
 	MeshSize []float32 // mesh generation parameters
 
 	// Material properties (inline, used when Material is nil)
@@ -66,6 +66,12 @@ func (m *ModelRenderer) SetShader(shader rl.Shader) {
 }
 
 func (m *ModelRenderer) Draw() {
+	m.DrawModel(m.Model)
+}
+
+// DrawModel draws model in place of this renderer's own, with its transform and
+// material (used for LOD levels)
+func (m *ModelRenderer) DrawModel(model rl.Model) {
 	g := m.GetGameObject()
 	if g == nil || !g.Active {
 		return
@@ -75,27 +81,24 @@ func (m *ModelRenderer) Draw() {
 	scale := g.WorldScale()
 	scaleMatrix := rl.MatrixScale(scale.X, scale.Y, scale.Z)
 
-	// Build rotation matrix from world Euler angles
-	rot := g.WorldRotation()
-	rotX := rl.MatrixRotateX(rot.X * rl.Deg2rad)
-	rotY := rl.MatrixRotateY(rot.Y * rl.Deg2rad)
-	rotZ := rl.MatrixRotateZ(rot.Z * rl.Deg2rad)
-	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+	// Build rotation matrix (world space)
+	rotMatrix := g.WorldRotationMatrix()
 
 	// Build translation matrix (world space)
 	pos := g.WorldPosition()
 	transMatrix := rl.MatrixTranslate(pos.X, pos.Y, pos.Z)
 
 	// Combine: scale -> rotate -> translate
-	m.Model.Transform = rl.MatrixMultiply(rl.MatrixMultiply(scaleMatrix, rotMatrix), transMatrix)
+	model.Transform = rl.MatrixMultiply(rl.MatrixMultiply(scaleMatrix, rotMatrix), transMatrix)
 
 	// Set material uniforms - use Material if set, otherwise use inline properties
-	var metallic, roughness, emissive float32
+	var metallic, roughness, emissive, alphaCutoff float32
 	var color rl.Color
 	if m.Material != nil {
 		metallic = m.Material.Metallic
 		roughness = m.Material.Roughness
 		emissive = m.Material.Emissive
+		alphaCutoff = m.Material.AlphaCutoff
 		color = m.Material.Color
 	} else {
 		metallic = m.Metallic
@@ -105,7 +108,13 @@ func (m *ModelRenderer) Draw() {
 	}
 
 	// Apply material texture/color to ALL materials (GLTF models can have multiple)
-	materials := unsafe.Slice(m.Model.Materials, m.Model.MaterialCount)
+	materials := unsafe.Slice(model.Materials, model.MaterialCount)
+	if m.shader.ID > 0 && model.Materials != m.Model.Materials {
+		// Other models (LOD levels) haven't been through SetShader
+		for i := range materials {
+			materials[i].Shader = m.shader
+		}
+	}
 	if m.Material != nil && m.Material.Albedo.ID > 0 {
 		// Material has texture - apply to all models (overrides GLTF textures)
 		for i := range materials {
@@ -124,13 +133,25 @@ func (m *ModelRenderer) Draw() {
 		metallicLoc := rl.GetShaderLocation(m.shader, "metallic")
 		roughnessLoc := rl.GetShaderLocation(m.shader, "roughness")
 		emissiveLoc := rl.GetShaderLocation(m.shader, "emissive")
+		alphaCutoffLoc := rl.GetShaderLocation(m.shader, "alphaCutoff")
 
 		rl.SetShaderValue(m.shader, metallicLoc, []float32{metallic}, rl.ShaderUniformFloat)
 		rl.SetShaderValue(m.shader, roughnessLoc, []float32{roughness}, rl.ShaderUniformFloat)
 		rl.SetShaderValue(m.shader, emissiveLoc, []float32{emissive}, rl.ShaderUniformFloat)
+		rl.SetShaderValue(m.shader, alphaCutoffLoc, []float32{alphaCutoff}, rl.ShaderUniformFloat)
 	}
 
-	rl.DrawModel(m.Model, rl.Vector3Zero(), 1.0, rl.White)
+	rl.DrawModel(model, rl.Vector3Zero(), 1.0, rl.White)
+}
+
+// DoubleSided reports whether the material asks for back faces to be drawn
+func (m *ModelRenderer) DoubleSided() bool {
+	return m.Material != nil && m.Material.DoubleSided
+}
+
+// AlphaTested reports whether the material discards fragments below an alpha cutoff
+func (m *ModelRenderer) AlphaTested() bool {
+	return m.Material != nil && m.Material.AlphaCutoff > 0
 }
 
 func (m *ModelRenderer) Unload() {
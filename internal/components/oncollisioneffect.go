@@ -0,0 +1,80 @@
+package components
+
+import (
+	"test3d/internal/engine"
+)
+
+func init() {
+	engine.RegisterComponent("OnCollisionEffect", func() engine.Serializable {
+		return NewOnCollisionEffect()
+	})
+}
+
+// OnCollisionEffect plays the object's AudioSource when a collision starts,
+// for the common "clang when this hits something" without a script. Hits can
+// be filtered by the other object's tag and by how hard they were.
+type OnCollisionEffect struct {
+	engine.BaseComponent
+	PlaySound  bool    // play the AudioSource on this object
+	Tag        string  // only react to objects with this tag ("" = any)
+	MinImpulse float32 // ignore softer hits (0 = every hit)
+
+	impulse float32 // impulse of the collision being entered
+}
+
+func NewOnCollisionEffect() *OnCollisionEffect {
+	return &OnCollisionEffect{PlaySound: true}
+}
+
+// TypeName implements engine.Serializable
+func (c *OnCollisionEffect) TypeName() string {
+	return "OnCollisionEffect"
+}
+
+// Serialize implements engine.Serializable
+func (c *OnCollisionEffect) Serialize() map[string]any {
+	return map[string]any{
+		"type":       "OnCollisionEffect",
+		"playSound":  c.PlaySound,
+		"tag":        c.Tag,
+		"minImpulse": c.MinImpulse,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (c *OnCollisionEffect) Deserialize(data map[string]any) {
+	if v, ok := data["playSound"].(bool); ok {
+		c.PlaySound = v
+	}
+	if v, ok := data["tag"].(string); ok {
+		c.Tag = v
+	}
+	if v, ok := data["minImpulse"].(float64); ok {
+		c.MinImpulse = float32(v)
+	}
+}
+
+// OnCollisionImpact implements engine.ImpactHandler
+func (c *OnCollisionEffect) OnCollisionImpact(other *engine.GameObject, impulse float32) {
+	c.impulse = impulse
+}
+
+// OnCollisionEnter implements engine.CollisionHandler
+func (c *OnCollisionEffect) OnCollisionEnter(other *engine.GameObject) {
+	impulse := c.impulse
+	c.impulse = 0
+	if c.Tag != "" && !other.HasTag(c.Tag) {
+		return
+	}
+	if impulse < c.MinImpulse {
+		return
+	}
+	if c.PlaySound {
+		if src := engine.GetComponent[*AudioSource](c.GetGameObject()); src != nil {
+			src.Play()
+		}
+	}
+}
+
+// OnCollisionExit implements engine.CollisionHandler
+func (c *OnCollisionEffect) OnCollisionExit(other *engine.GameObject) {}
@@ -0,0 +1,86 @@
+package components
+
+import (
+	"math"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("Oscillator", func() engine.Serializable {
+		return NewOscillator()
+	})
+}
+
+// Oscillator moves its object back and forth along an axis on a sine wave,
+// centered on the position it had when play started (e.g. a bobbing pickup).
+type Oscillator struct {
+	engine.BaseComponent
+	Axis      rl.Vector3 // direction of movement in parent space (normalized when used)
+	Amplitude float32    // distance from the center to either end
+	Frequency float32    // full cycles per second
+	Phase     float32    // offset into the cycle, 0-1 (to desync copies)
+
+	origin  rl.Vector3
+	elapsed float32
+}
+
+func NewOscillator() *Oscillator {
+	return &Oscillator{
+		Axis:      rl.Vector3{Y: 1},
+		Amplitude: 0.25,
+		Frequency: 0.5,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (o *Oscillator) TypeName() string {
+	return "Oscillator"
+}
+
+// Serialize implements engine.Serializable
+func (o *Oscillator) Serialize() map[string]any {
+	return map[string]any{
+		"type":      "Oscillator",
+		"axis":      [3]float32{o.Axis.X, o.Axis.Y, o.Axis.Z},
+		"amplitude": o.Amplitude,
+		"frequency": o.Frequency,
+		"phase":     o.Phase,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (o *Oscillator) Deserialize(data map[string]any) {
+	if a, ok := data["axis"].([]any); ok && len(a) == 3 {
+		o.Axis = rl.Vector3{X: float32(a[0].(float64)), Y: float32(a[1].(float64)), Z: float32(a[2].(float64))}
+	}
+	if a, ok := data["amplitude"].(float64); ok {
+		o.Amplitude = float32(a)
+	}
+	if f, ok := data["frequency"].(float64); ok {
+		o.Frequency = float32(f)
+	}
+	if p, ok := data["phase"].(float64); ok {
+		o.Phase = float32(p)
+	}
+}
+
+func (o *Oscillator) Start() {
+	if g := o.GetGameObject(); g != nil {
+		o.origin = g.Transform.Position
+	}
+	o.elapsed = 0
+}
+
+func (o *Oscillator) Update(deltaTime float32) {
+	g := o.GetGameObject()
+	if g == nil || rl.Vector3Length(o.Axis) == 0 {
+		return
+	}
+	o.elapsed += deltaTime
+
+	t := float64(o.elapsed*o.Frequency + o.Phase)
+	offset := o.Amplitude * float32(math.Sin(2*math.Pi*t))
+	g.Transform.Position = rl.Vector3Add(o.origin, rl.Vector3Scale(rl.Vector3Normalize(o.Axis), offset))
+}
@@ -0,0 +1,195 @@
+package components
+
+import (
+	"math"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("PatrolMover", func() engine.Serializable {
+		return NewPatrolMover()
+	})
+}
+
+// PatrolMode says what a PatrolMover does at the end of its path
+type PatrolMode int
+
+const (
+	PatrolLoop     PatrolMode = iota // head back to the first point
+	PatrolPingPong                   // walk the path backwards, then forwards again
+	PatrolOnce                       // stop at the last point
+)
+
+// patrolModeNames are the serialized names, indexed by PatrolMode
+var patrolModeNames = []string{"loop", "pingpong", "once"}
+
+func (m PatrolMode) String() string {
+	if int(m) < len(patrolModeNames) {
+		return patrolModeNames[m]
+	}
+	return patrolModeNames[PatrolLoop]
+}
+
+// PatrolMover walks its object along a PatrolPath at a constant speed,
+// starting from wherever the object is towards the first waypoint.
+type PatrolMover struct {
+	engine.BaseComponent
+	Path         engine.GameObjectRef // object with the PatrolPath
+	Speed        float32              // units per second
+	Mode         PatrolMode
+	FaceMovement bool // turn (around Y) to face the direction of travel
+
+	target    int // index of the waypoint being walked to
+	direction int // +1 forwards, -1 backwards (ping-pong)
+	finished  bool
+}
+
+func NewPatrolMover() *PatrolMover {
+	return &PatrolMover{
+		Speed:        2,
+		Mode:         PatrolLoop,
+		FaceMovement: true,
+		direction:    1,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (m *PatrolMover) TypeName() string {
+	return "PatrolMover"
+}
+
+// Serialize implements engine.Serializable
+func (m *PatrolMover) Serialize() map[string]any {
+	return map[string]any{
+		"type":         "PatrolMover",
+		"path":         m.Path.UID,
+		"speed":        m.Speed,
+		"mode":         m.Mode.String(),
+		"faceMovement": m.FaceMovement,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (m *PatrolMover) Deserialize(data map[string]any) {
+	if uid, ok := data["path"].(float64); ok {
+		m.Path.UID = uint64(uid)
+	}
+	if s, ok := data["speed"].(float64); ok {
+		m.Speed = float32(s)
+	}
+	if s, ok := data["mode"].(string); ok {
+		for i, name := range patrolModeNames {
+			if name == s {
+				m.Mode = PatrolMode(i)
+			}
+		}
+	}
+	if v, ok := data["faceMovement"].(bool); ok {
+		m.FaceMovement = v
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (m *PatrolMover) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&m.Path}
+}
+
+// PathComponent resolves the followed path (nil if unset or missing)
+func (m *PatrolMover) PathComponent() *PatrolPath {
+	g := m.GetGameObject()
+	if g == nil {
+		return nil
+	}
+	pathObj := m.Path.Get(g.Scene)
+	if pathObj == nil {
+		return nil
+	}
+	return engine.GetComponent[*PatrolPath](pathObj)
+}
+
+// Finished reports whether a PatrolOnce mover reached the last waypoint
+func (m *PatrolMover) Finished() bool {
+	return m.finished
+}
+
+func (m *PatrolMover) Start() {
+	m.target = 0
+	m.direction = 1
+	m.finished = false
+}
+
+func (m *PatrolMover) Update(deltaTime float32) {
+	g := m.GetGameObject()
+	path := m.PathComponent()
+	if g == nil || path == nil || len(path.Points) == 0 || m.finished || m.Speed <= 0 {
+		return
+	}
+	if m.target >= len(path.Points) {
+		m.target = 0
+	}
+
+	// Walk the distance for this frame, carrying what's left past each
+	// waypoint. The step limit stops paths whose points all coincide.
+	pos := g.WorldPosition()
+	remaining := m.Speed * deltaTime
+	var heading rl.Vector3
+	for step := 0; remaining > 0 && step <= 2*len(path.Points); step++ {
+		goal := path.WorldPoint(m.target)
+		toGoal := rl.Vector3Subtract(goal, pos)
+		dist := rl.Vector3Length(toGoal)
+		if dist > remaining {
+			heading = toGoal
+			pos = rl.Vector3Add(pos, rl.Vector3Scale(toGoal, remaining/dist))
+			break
+		}
+		if dist > 0 {
+			heading = toGoal
+		}
+		pos = goal
+		remaining -= dist
+		if !m.advance(len(path.Points)) {
+			break
+		}
+	}
+
+	if g.Parent != nil {
+		pos = WorldToLocalPoint(g.Parent, pos)
+	}
+	g.Transform.Position = pos
+
+	if m.FaceMovement && (heading.X != 0 || heading.Z != 0) {
+		yaw := float32(math.Atan2(float64(heading.X), float64(heading.Z))) * rl.Rad2deg
+		if g.Parent != nil {
+			yaw -= g.Parent.WorldRotation().Y
+		}
+		g.Transform.Rotation.Y = yaw
+	}
+}
+
+// advance picks the next waypoint once the current one is reached. Returns
+// false when the mover stops (PatrolOnce at the end).
+func (m *PatrolMover) advance(count int) bool {
+	if count == 1 {
+		return false
+	}
+	switch m.Mode {
+	case PatrolPingPong:
+		next := m.target + m.direction
+		if next < 0 || next >= count {
+			m.direction = -m.direction
+			next = m.target + m.direction
+		}
+		m.target = next
+	case PatrolOnce:
+		if m.target == count-1 {
+			m.finished = true
+			return false
+		}
+		m.target++
+	default:
+		m.target = (m.target + 1) % count
+	}
+	return true
+}
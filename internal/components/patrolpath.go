@@ -0,0 +1,71 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("PatrolPath", func() engine.Serializable {
+		return NewPatrolPath()
+	})
+}
+
+// PatrolPath is a polyline of waypoints for PatrolMovers to follow. Points are
+// in the path object's local space, so moving the object moves the route.
+type PatrolPath struct {
+	engine.BaseComponent
+	Points []rl.Vector3
+}
+
+func NewPatrolPath() *PatrolPath {
+	return &PatrolPath{
+		Points: []rl.Vector3{{X: -2}, {X: 2}},
+	}
+}
+
+// TypeName implements engine.Serializable
+func (p *PatrolPath) TypeName() string {
+	return "PatrolPath"
+}
+
+// Serialize implements engine.Serializable
+func (p *PatrolPath) Serialize() map[string]any {
+	points := make([][3]float32, len(p.Points))
+	for i, pt := range p.Points {
+		points[i] = [3]float32{pt.X, pt.Y, pt.Z}
+	}
+	return map[string]any{
+		"type":   "PatrolPath",
+		"points": points,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (p *PatrolPath) Deserialize(data map[string]any) {
+	raw, ok := data["points"].([]any)
+	if !ok {
+		return
+	}
+	p.Points = p.Points[:0]
+	for _, r := range raw {
+		if v, ok := r.([]any); ok && len(v) == 3 {
+			p.Points = append(p.Points, rl.Vector3{X: float32(v[0].(float64)), Y: float32(v[1].(float64)), Z: float32(v[2].(float64))})
+		}
+	}
+}
+
+// WorldPoint returns waypoint i in world space
+func (p *PatrolPath) WorldPoint(i int) rl.Vector3 {
+	return LocalToWorldPoint(p.GetGameObject(), p.Points[i])
+}
+
+// WorldPoints returns every waypoint in world space
+func (p *PatrolPath) WorldPoints() []rl.Vector3 {
+	out := make([]rl.Vector3, len(p.Points))
+	for i := range p.Points {
+		out[i] = p.WorldPoint(i)
+	}
+	return out
+}
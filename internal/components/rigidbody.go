@@ -22,18 +22,24 @@ const (
 type Rigidbody struct {
 	engine.BaseComponent
 	Velocity        rl.Vector3
-	AngularVelocity rl.Vector3 // degrees per second on each axis
+	AngularVelocity rl.Vector3 // world-space spin axis, length in degrees per second
 	Mass            float32
 	Bounciness      float32 // 0 = no bounce, 1 = perfect bounce
 	Friction        float32 // 0 = ice, 1 = stops immediately
 	AngularDamping  float32 // how fast rotation slows down
 	UseGravity      bool
 	IsKinematic     bool // moves but doesn't get pushed by physics
+	UseCCD          bool // sweep fast moves against statics so the body can't pass through thin walls
 
 	// Sleep state - sleeping objects skip physics simulation
 	IsSleeping bool
 	sleepTimer float32 // time spent below velocity threshold
 	CanSleep   bool    // whether this object can sleep (default true)
+
+	// Forces added by scripts this frame, and impulses since the last physics step
+	force   rl.Vector3
+	torque  rl.Vector3
+	impulse rl.Vector3
 }
 
 func NewRigidbody() *Rigidbody {
@@ -50,14 +56,94 @@ func NewRigidbody() *Rigidbody {
 	}
 }
 
+// InverseMass returns 1/Mass. A Mass of zero or less is treated as infinite
+// mass: the body is immovable and the inverse mass is 0.
+func (r *Rigidbody) InverseMass() float32 {
+	if r.Mass <= 0 {
+		return 0
+	}
+	return 1 / r.Mass
+}
+
+// IsImmovable returns true if the rigidbody has infinite (zero or negative) mass
+func (r *Rigidbody) IsImmovable() bool {
+	return r.Mass <= 0
+}
+
+// AddForce pushes the body with force f (mass * units/sec²) through every
+// physics step of this frame. Call it every frame for a steady push like a
+// thruster.
+func (r *Rigidbody) AddForce(f rl.Vector3) {
+	if r.IsKinematic {
+		return
+	}
+	r.force = rl.Vector3Add(r.force, f)
+}
+
+// AddImpulse changes the body's momentum by i (mass * units/sec) at the next
+// physics step, for one-off kicks like a jump or an explosion
+func (r *Rigidbody) AddImpulse(i rl.Vector3) {
+	if r.IsKinematic {
+		return
+	}
+	r.impulse = rl.Vector3Add(r.impulse, i)
+}
+
+// AddTorque spins the body with torque t through every physics step of this
+// frame. The body's mass doubles as its rotational inertia, so a torque of 1
+// turns a body of mass 1 one radian/sec faster each second about that axis.
+func (r *Rigidbody) AddTorque(t rl.Vector3) {
+	if r.IsKinematic {
+		return
+	}
+	r.torque = rl.Vector3Add(r.torque, t)
+}
+
+// AddForceAtPoint applies force f at a world-space point through every physics
+// step of this frame. Off-center forces also spin the body, like a push on a
+// crate's corner.
+func (r *Rigidbody) AddForceAtPoint(f, worldPoint rl.Vector3) {
+	r.AddForce(f)
+	if g := r.GetGameObject(); g != nil {
+		arm := rl.Vector3Subtract(worldPoint, g.WorldPosition())
+		r.AddTorque(rl.Vector3CrossProduct(arm, f))
+	}
+}
+
+// ApplyForces turns the frame's forces and torques into velocity for one step
+// of deltaTime, and the impulses added since the last step, which it clears.
+// Forces and torques stay until ClearForces, so every step of a frame applies
+// them. It reports whether any were pending, so the physics world can wake
+// the body. Immovable bodies ignore them.
+func (r *Rigidbody) ApplyForces(deltaTime float32) bool {
+	var zero rl.Vector3
+	if r.force == zero && r.torque == zero && r.impulse == zero {
+		return false
+	}
+	invMass := r.InverseMass()
+	dv := rl.Vector3Add(rl.Vector3Scale(r.force, deltaTime), r.impulse)
+	r.Velocity = rl.Vector3Add(r.Velocity, rl.Vector3Scale(dv, invMass))
+	// AngularVelocity is in degrees
+	r.AngularVelocity = rl.Vector3Add(r.AngularVelocity, rl.Vector3Scale(r.torque, deltaTime*invMass*rl.Rad2deg))
+	r.impulse = zero
+	return invMass > 0
+}
+
+// ClearForces drops the forces and torques added this frame, once the frame's
+// physics steps have run
+func (r *Rigidbody) ClearForces() {
+	r.force, r.torque = rl.Vector3{}, rl.Vector3{}
+}
+
 // Wake forces the rigidbody out of sleep state
 func (r *Rigidbody) Wake() {
 	r.IsSleeping = false
 	r.sleepTimer = 0
 }
 
-// TrySleep checks if the rigidbody should go to sleep based on velocity
-func (r *Rigidbody) TrySleep(deltaTime float32) {
+// UpdateSleepTimer tracks how long the rigidbody has been nearly at rest.
+// The physics world puts bodies to sleep by island once every touching body is ready.
+func (r *Rigidbody) UpdateSleepTimer(deltaTime float32) {
 	if !r.CanSleep || r.IsSleeping {
 		return
 	}
@@ -73,17 +159,23 @@ func (r *Rigidbody) TrySleep(deltaTime float32) {
 		dampFactor := float32(0.9)
 		r.Velocity = rl.Vector3Scale(r.Velocity, dampFactor)
 		r.AngularVelocity = rl.Vector3Scale(r.AngularVelocity, dampFactor)
-
-		if r.sleepTimer >= SleepTimeThreshold {
-			r.IsSleeping = true
-			r.Velocity = rl.Vector3{}
-			r.AngularVelocity = rl.Vector3{}
-		}
 	} else {
 		r.sleepTimer = 0
 	}
 }
 
+// ReadyToSleep returns true once the rigidbody has been at rest for SleepTimeThreshold
+func (r *Rigidbody) ReadyToSleep() bool {
+	return r.CanSleep && r.sleepTimer >= SleepTimeThreshold
+}
+
+// Sleep puts the rigidbody to sleep and zeroes its velocity
+func (r *Rigidbody) Sleep() {
+	r.IsSleeping = true
+	r.Velocity = rl.Vector3{}
+	r.AngularVelocity = rl.Vector3{}
+}
+
 // TypeName implements engine.Serializable
 func (r *Rigidbody) TypeName() string {
 	return "Rigidbody"
@@ -92,12 +184,15 @@ func (r *Rigidbody) TypeName() string {
 // Serialize implements engine.Serializable
 func (r *Rigidbody) Serialize() map[string]any {
 	return map[string]any{
-		"type":        "Rigidbody",
-		"mass":        r.Mass,
-		"bounciness":  r.Bounciness,
-		"friction":    r.Friction,
-		"useGravity":  r.UseGravity,
-		"isKinematic": r.IsKinematic,
+		"type":           "Rigidbody",
+		"mass":           r.Mass,
+		"bounciness":     r.Bounciness,
+		"friction":       r.Friction,
+		"angularDamping": r.AngularDamping,
+		"useGravity":     r.UseGravity,
+		"isKinematic":    r.IsKinematic,
+		"useCCD":         r.UseCCD,
+		"canSleep":       r.CanSleep,
 	}
 }
 
@@ -112,10 +207,19 @@ func (r *Rigidbody) Deserialize(data map[string]any) {
 	if f, ok := data["friction"].(float64); ok {
 		r.Friction = float32(f)
 	}
+	if d, ok := data["angularDamping"].(float64); ok {
+		r.AngularDamping = float32(d)
+	}
 	if g, ok := data["useGravity"].(bool); ok {
 		r.UseGravity = g
 	}
 	if k, ok := data["isKinematic"].(bool); ok {
 		r.IsKinematic = k
 	}
+	if c, ok := data["useCCD"].(bool); ok {
+		r.UseCCD = c
+	}
+	if s, ok := data["canSleep"].(bool); ok {
+		r.CanSleep = s
+	}
 }
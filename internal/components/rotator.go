@@ -0,0 +1,65 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("Rotator", func() engine.Serializable {
+		return NewRotator()
+	})
+}
+
+// Rotator spins its object at a constant rate, e.g. for coins and pickups.
+type Rotator struct {
+	engine.BaseComponent
+	Speed rl.Vector3 // degrees per second around each local axis
+}
+
+func NewRotator() *Rotator {
+	return &Rotator{Speed: rl.Vector3{Y: 90}}
+}
+
+// TypeName implements engine.Serializable
+func (r *Rotator) TypeName() string {
+	return "Rotator"
+}
+
+// Serialize implements engine.Serializable
+func (r *Rotator) Serialize() map[string]any {
+	return map[string]any{
+		"type":  "Rotator",
+		"speed": [3]float32{r.Speed.X, r.Speed.Y, r.Speed.Z},
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (r *Rotator) Deserialize(data map[string]any) {
+	if s, ok := data["speed"].([]any); ok && len(s) == 3 {
+		r.Speed = rl.Vector3{X: float32(s[0].(float64)), Y: float32(s[1].(float64)), Z: float32(s[2].(float64))}
+	}
+}
+
+func (r *Rotator) Update(deltaTime float32) {
+	g := r.GetGameObject()
+	if g == nil {
+		return
+	}
+	rot := &g.Transform.Rotation
+	rot.X = wrapDegrees(rot.X + r.Speed.X*deltaTime)
+	rot.Y = wrapDegrees(rot.Y + r.Speed.Y*deltaTime)
+	rot.Z = wrapDegrees(rot.Z + r.Speed.Z*deltaTime)
+}
+
+// wrapDegrees keeps an angle within (-360, 360) so it doesn't grow forever
+func wrapDegrees(a float32) float32 {
+	for a >= 360 {
+		a -= 360
+	}
+	for a <= -360 {
+		a += 360
+	}
+	return a
+}
@@ -1,6 +1,8 @@
 package components
 
 import (
+	"math"
+
 	"test3d/internal/engine"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -14,8 +16,9 @@ func init() {
 
 type SphereCollider struct {
 	engine.BaseComponent
-	Radius float32
-	Offset rl.Vector3
+	Radius    float32
+	Offset    rl.Vector3
+	IsTrigger bool // detect overlaps (OnTriggerEnter/Exit) without pushing anything
 }
 
 func NewSphereCollider(radius float32) *SphereCollider {
@@ -28,7 +31,23 @@ func NewSphereCollider(radius float32) *SphereCollider {
 // GetCenter returns the world-space center of this collider
 func (s *SphereCollider) GetCenter() rl.Vector3 {
 	g := s.GetGameObject()
-	return rl.Vector3Add(g.WorldPosition(), s.Offset)
+	scale := g.WorldScale()
+	// Scale the offset by the object's scale
+	scaledOffset := rl.Vector3{
+		X: s.Offset.X * scale.X,
+		Y: s.Offset.Y * scale.Y,
+		Z: s.Offset.Z * scale.Z,
+	}
+	return rl.Vector3Add(g.WorldPosition(), scaledOffset)
+}
+
+// GetWorldRadius returns the radius scaled by the object's largest world scale
+// axis. A non-uniformly scaled sphere collides as the sphere around the
+// stretched shape rather than as an ellipsoid, so it errs on the large side.
+func (s *SphereCollider) GetWorldRadius() float32 {
+	scale := s.GetGameObject().WorldScale()
+	largest := max(math.Abs(float64(scale.X)), math.Abs(float64(scale.Y)), math.Abs(float64(scale.Z)))
+	return s.Radius * float32(largest)
 }
 
 // TypeName implements engine.Serializable
@@ -39,8 +58,10 @@ func (s *SphereCollider) TypeName() string {
 // Serialize implements engine.Serializable
 func (s *SphereCollider) Serialize() map[string]any {
 	return map[string]any{
-		"type":   "SphereCollider",
-		"radius": s.Radius,
+		"type":      "SphereCollider",
+		"radius":    s.Radius,
+		"offset":    [3]float32{s.Offset.X, s.Offset.Y, s.Offset.Z},
+		"isTrigger": s.IsTrigger,
 	}
 }
 
@@ -49,4 +70,12 @@ func (s *SphereCollider) Deserialize(data map[string]any) {
 	if r, ok := data["radius"].(float64); ok {
 		s.Radius = float32(r)
 	}
+	if offset, ok := data["offset"].([]any); ok && len(offset) == 3 {
+		s.Offset.X = float32(offset[0].(float64))
+		s.Offset.Y = float32(offset[1].(float64))
+		s.Offset.Z = float32(offset[2].(float64))
+	}
+	if v, ok := data["isTrigger"].(bool); ok {
+		s.IsTrigger = v
+	}
 }
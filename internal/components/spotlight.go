@@ -0,0 +1,111 @@
+package components
+
+import (
+	"math"
+
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("SpotLight", func() engine.Serializable {
+		return NewSpotLight()
+	})
+}
+
+// SpotLight shines a cone of light along the object's forward axis (-Z, the
+// way a Camera looks), for flashlights, headlights and stage lights. Inside
+// InnerAngle the light is at full strength; it fades out toward OuterAngle
+// and with distance up to Range.
+type SpotLight struct {
+	engine.BaseComponent
+	Color      rl.Color
+	Intensity  float32
+	Range      float32 // falloff distance
+	InnerAngle float32 // degrees from the axis lit at full strength
+	OuterAngle float32 // degrees from the axis where the light ends
+}
+
+func NewSpotLight() *SpotLight {
+	return &SpotLight{
+		Color:      rl.White,
+		Intensity:  1.0,
+		Range:      15.0,
+		InnerAngle: 20,
+		OuterAngle: 30,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (s *SpotLight) TypeName() string {
+	return "SpotLight"
+}
+
+// Serialize implements engine.Serializable
+func (s *SpotLight) Serialize() map[string]any {
+	return map[string]any{
+		"type":       "SpotLight",
+		"color":      [3]uint8{s.Color.R, s.Color.G, s.Color.B},
+		"intensity":  s.Intensity,
+		"range":      s.Range,
+		"innerAngle": s.InnerAngle,
+		"outerAngle": s.OuterAngle,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (s *SpotLight) Deserialize(data map[string]any) {
+	if c, ok := data["color"].([]any); ok && len(c) == 3 {
+		s.Color.R = uint8(c[0].(float64))
+		s.Color.G = uint8(c[1].(float64))
+		s.Color.B = uint8(c[2].(float64))
+		s.Color.A = 255
+	}
+	if i, ok := data["intensity"].(float64); ok {
+		s.Intensity = float32(i)
+	}
+	if r, ok := data["range"].(float64); ok {
+		s.Range = float32(r)
+	}
+	if a, ok := data["innerAngle"].(float64); ok {
+		s.InnerAngle = float32(a)
+	}
+	if a, ok := data["outerAngle"].(float64); ok {
+		s.OuterAngle = float32(a)
+	}
+}
+
+func (s *SpotLight) GetPosition() rl.Vector3 {
+	if g := s.GetGameObject(); g != nil {
+		return g.WorldPosition()
+	}
+	return rl.Vector3Zero()
+}
+
+// GetDirection returns the world-space direction the light points in
+func (s *SpotLight) GetDirection() rl.Vector3 {
+	forward := rl.Vector3{Z: -1}
+	g := s.GetGameObject()
+	if g == nil {
+		return forward
+	}
+	return rl.Vector3Transform(forward, g.WorldRotationMatrix())
+}
+
+// ConeCos returns the cosines of the inner and outer cone angles, which the
+// lighting shader compares against. The outer angle is kept below 90 degrees
+// and the inner one just inside it, so the cone always has a soft edge.
+func (s *SpotLight) ConeCos() (inner, outer float32) {
+	outerDeg := min(max(s.OuterAngle, 0.1), 89)
+	innerDeg := min(max(s.InnerAngle, 0), outerDeg-0.1)
+	return float32(math.Cos(float64(innerDeg * rl.Deg2rad))), float32(math.Cos(float64(outerDeg * rl.Deg2rad)))
+}
+
+func (s *SpotLight) GetColorFloat() []float32 {
+	return []float32{
+		float32(s.Color.R) / 255.0 * s.Intensity,
+		float32(s.Color.G) / 255.0 * s.Intensity,
+		float32(s.Color.B) / 255.0 * s.Intensity,
+	}
+}
@@ -0,0 +1,149 @@
+package components
+
+import (
+	"test3d/internal/assets"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("Terrain", func() engine.Serializable {
+		return NewTerrain()
+	})
+}
+
+// Terrain generates a mesh from a grayscale heightmap image. The mesh starts at
+// the object's position and spans Size.X by Size.Z; white pixels reach Size.Y.
+// Add a MeshCollider to the same object to make the terrain walkable.
+type Terrain struct {
+	engine.BaseComponent
+	Heightmap    string     // path to a grayscale PNG (empty = flat)
+	Size         rl.Vector3 // width, max height, length in world units
+	MaterialPath string     // optional material file, overrides Color
+	Color        rl.Color
+
+	renderer       *ModelRenderer
+	shader         rl.Shader
+	builtHeightmap string     // heightmap the current mesh was generated from
+	builtSize      rl.Vector3 // size the current mesh was generated with
+}
+
+func NewTerrain() *Terrain {
+	return &Terrain{
+		Size:  rl.Vector3{X: 64, Y: 8, Z: 64},
+		Color: rl.NewColor(110, 140, 90, 255),
+	}
+}
+
+// TypeName implements engine.Serializable
+func (t *Terrain) TypeName() string {
+	return "Terrain"
+}
+
+// Serialize implements engine.Serializable
+func (t *Terrain) Serialize() map[string]any {
+	data := map[string]any{
+		"type":      "Terrain",
+		"heightmap": t.Heightmap,
+		"size":      [3]float32{t.Size.X, t.Size.Y, t.Size.Z},
+		"color":     [3]uint8{t.Color.R, t.Color.G, t.Color.B},
+	}
+	if t.MaterialPath != "" {
+		data["material"] = t.MaterialPath
+	}
+	return data
+}
+
+// Deserialize implements engine.Serializable
+func (t *Terrain) Deserialize(data map[string]any) {
+	if h, ok := data["heightmap"].(string); ok {
+		t.Heightmap = h
+	}
+	if s, ok := data["size"].([]any); ok && len(s) == 3 {
+		t.Size.X = float32(s[0].(float64))
+		t.Size.Y = float32(s[1].(float64))
+		t.Size.Z = float32(s[2].(float64))
+	}
+	if c, ok := data["color"].([]any); ok && len(c) == 3 {
+		t.Color.R = uint8(c[0].(float64))
+		t.Color.G = uint8(c[1].(float64))
+		t.Color.B = uint8(c[2].(float64))
+		t.Color.A = 255
+	}
+	if m, ok := data["material"].(string); ok {
+		t.MaterialPath = m
+	}
+}
+
+// SetShader sets the lighting shader the terrain is drawn with
+func (t *Terrain) SetShader(shader rl.Shader) {
+	t.shader = shader
+	if t.renderer != nil {
+		t.renderer.SetShader(shader)
+	}
+}
+
+// Model returns the terrain model, regenerating it if the heightmap or size changed.
+// Must be called with a valid OpenGL context.
+func (t *Terrain) Model() rl.Model {
+	if t.renderer == nil || t.Heightmap != t.builtHeightmap || t.Size != t.builtSize {
+		t.generate()
+		t.builtHeightmap, t.builtSize = t.Heightmap, t.Size
+	}
+	return t.renderer.Model
+}
+
+// generate builds the heightmap mesh and the renderer that draws it
+func (t *Terrain) generate() {
+	t.Unload()
+
+	var image *rl.Image
+	if t.Heightmap != "" {
+		image = rl.LoadImage(t.Heightmap)
+	}
+	if image == nil || !rl.IsImageValid(image) {
+		image = rl.GenImageColor(2, 2, rl.Black) // flat
+	}
+	mesh := rl.GenMeshHeightmap(*image, t.Size)
+	rl.UnloadImage(image)
+
+	t.renderer = NewModelRenderer(rl.LoadModelFromMesh(mesh), t.Color)
+	t.renderer.SetGameObject(t.GetGameObject())
+	if t.shader.ID > 0 {
+		t.renderer.SetShader(t.shader)
+	}
+}
+
+// Draw renders the terrain with its material (called by renderer)
+func (t *Terrain) Draw() {
+	t.Model()
+
+	t.renderer.SetGameObject(t.GetGameObject())
+	t.renderer.Color = t.Color
+	if t.MaterialPath != "" {
+		t.renderer.Material = assets.LoadMaterial(t.MaterialPath)
+	} else {
+		t.renderer.Material = nil
+	}
+	t.renderer.Draw()
+}
+
+// BuildCollider rebuilds a MeshCollider on the same object from the terrain mesh
+func (t *Terrain) BuildCollider() {
+	g := t.GetGameObject()
+	if g == nil {
+		return
+	}
+	if mc := engine.GetComponent[*MeshCollider](g); mc != nil && mc.ModelPath == "" {
+		mc.BuildFromModel(t.Model())
+	}
+}
+
+// Unload frees the generated model
+func (t *Terrain) Unload() {
+	if t.renderer != nil {
+		rl.UnloadModel(t.renderer.Model)
+		t.renderer = nil
+	}
+}
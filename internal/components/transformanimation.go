@@ -0,0 +1,315 @@
+package components
+
+import (
+	"math"
+	"slices"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func init() {
+	engine.RegisterComponent("TransformAnimation", func() engine.Serializable {
+		return NewTransformAnimation()
+	})
+}
+
+// keyframeTimeEpsilon is how close two key times must be to count as the same key
+const keyframeTimeEpsilon = 0.001
+
+// Easing shapes the motion between two keyframes
+type Easing int
+
+const (
+	EaseLinear Easing = iota // constant speed
+	EaseIn                   // start slow, end fast
+	EaseOut                  // start fast, end slow
+	EaseInOut                // slow at both keys
+)
+
+// easingNames are the serialized names, indexed by Easing
+var easingNames = []string{"linear", "in", "out", "inout"}
+
+func (e Easing) String() string {
+	if int(e) < len(easingNames) {
+		return easingNames[e]
+	}
+	return easingNames[EaseLinear]
+}
+
+// Apply maps t in 0-1 through the easing curve
+func (e Easing) Apply(t float32) float32 {
+	switch e {
+	case EaseIn:
+		return t * t
+	case EaseOut:
+		return t * (2 - t)
+	case EaseInOut:
+		return t * t * (3 - 2*t)
+	}
+	return t
+}
+
+// AnimationMode says what a TransformAnimation does after its last keyframe
+type AnimationMode int
+
+const (
+	AnimationOnce     AnimationMode = iota // hold the last key
+	AnimationLoop                          // jump back to the first key
+	AnimationPingPong                      // play backwards, then forwards again
+)
+
+// animationModeNames are the serialized names, indexed by AnimationMode
+var animationModeNames = []string{"once", "loop", "pingpong"}
+
+func (m AnimationMode) String() string {
+	if int(m) < len(animationModeNames) {
+		return animationModeNames[m]
+	}
+	return animationModeNames[AnimationOnce]
+}
+
+// Keyframe is the object's transform at a point on the timeline. Values are
+// local, like Transform, so an animated child moves along with its parent.
+type Keyframe struct {
+	Time     float32 // seconds from the start of the animation
+	Position rl.Vector3
+	Rotation rl.Vector3 // Euler degrees, interpolated per axis
+	Scale    rl.Vector3
+}
+
+// TransformAnimation moves, rotates and scales its object through a list of
+// keyframes (moving platforms, doors, camera moves). Rotation interpolates
+// the Euler angles directly, so a key at 0 followed by one at 360 spins a
+// full turn rather than standing still.
+type TransformAnimation struct {
+	engine.BaseComponent
+	Keyframes   []Keyframe // sorted by Time
+	Easing      Easing
+	Mode        AnimationMode
+	PlayOnStart bool
+
+	elapsed float32
+	playing bool
+}
+
+func NewTransformAnimation() *TransformAnimation {
+	return &TransformAnimation{
+		Easing:      EaseInOut,
+		Mode:        AnimationLoop,
+		PlayOnStart: true,
+	}
+}
+
+// TypeName implements engine.Serializable
+func (a *TransformAnimation) TypeName() string {
+	return "TransformAnimation"
+}
+
+// Serialize implements engine.Serializable
+func (a *TransformAnimation) Serialize() map[string]any {
+	keys := make([]map[string]any, len(a.Keyframes))
+	for i, k := range a.Keyframes {
+		keys[i] = map[string]any{
+			"time":     k.Time,
+			"position": [3]float32{k.Position.X, k.Position.Y, k.Position.Z},
+			"rotation": [3]float32{k.Rotation.X, k.Rotation.Y, k.Rotation.Z},
+			"scale":    [3]float32{k.Scale.X, k.Scale.Y, k.Scale.Z},
+		}
+	}
+	return map[string]any{
+		"type":        "TransformAnimation",
+		"keyframes":   keys,
+		"easing":      a.Easing.String(),
+		"mode":        a.Mode.String(),
+		"playOnStart": a.PlayOnStart,
+	}
+}
+
+// Deserialize implements engine.Serializable
+func (a *TransformAnimation) Deserialize(data map[string]any) {
+	if raw, ok := data["keyframes"].([]any); ok {
+		a.Keyframes = a.Keyframes[:0]
+		for _, r := range raw {
+			m, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			k := Keyframe{Scale: rl.Vector3{X: 1, Y: 1, Z: 1}}
+			if t, ok := m["time"].(float64); ok {
+				k.Time = float32(t)
+			}
+			if v, ok := m["position"].([]any); ok && len(v) == 3 {
+				k.Position = rl.Vector3{X: float32(v[0].(float64)), Y: float32(v[1].(float64)), Z: float32(v[2].(float64))}
+			}
+			if v, ok := m["rotation"].([]any); ok && len(v) == 3 {
+				k.Rotation = rl.Vector3{X: float32(v[0].(float64)), Y: float32(v[1].(float64)), Z: float32(v[2].(float64))}
+			}
+			if v, ok := m["scale"].([]any); ok && len(v) == 3 {
+				k.Scale = rl.Vector3{X: float32(v[0].(float64)), Y: float32(v[1].(float64)), Z: float32(v[2].(float64))}
+			}
+			a.Keyframes = append(a.Keyframes, k)
+		}
+		a.SortKeyframes()
+	}
+	if s, ok := data["easing"].(string); ok {
+		for i, name := range easingNames {
+			if name == s {
+				a.Easing = Easing(i)
+			}
+		}
+	}
+	if s, ok := data["mode"].(string); ok {
+		for i, name := range animationModeNames {
+			if name == s {
+				a.Mode = AnimationMode(i)
+			}
+		}
+	}
+	if v, ok := data["playOnStart"].(bool); ok {
+		a.PlayOnStart = v
+	}
+}
+
+// SortKeyframes puts the keyframes back in time order after their times were edited
+func (a *TransformAnimation) SortKeyframes() {
+	slices.SortStableFunc(a.Keyframes, func(x, y Keyframe) int {
+		switch {
+		case x.Time < y.Time:
+			return -1
+		case x.Time > y.Time:
+			return 1
+		}
+		return 0
+	})
+}
+
+// SetKey records t as a keyframe at time, replacing a key already at that
+// time, and returns its index
+func (a *TransformAnimation) SetKey(time float32, t *engine.Transform) int {
+	time = max(time, 0)
+	k := Keyframe{Time: time, Position: t.Position, Rotation: t.Rotation, Scale: t.Scale}
+	for i := range a.Keyframes {
+		if math.Abs(float64(a.Keyframes[i].Time-time)) < keyframeTimeEpsilon {
+			a.Keyframes[i] = k
+			return i
+		}
+	}
+	i, _ := slices.BinarySearchFunc(a.Keyframes, time, func(k Keyframe, t float32) int {
+		if k.Time < t {
+			return -1
+		}
+		return 1
+	})
+	a.Keyframes = slices.Insert(a.Keyframes, i, k)
+	return i
+}
+
+// MoveKey changes the time of key i, keeping the keys sorted, and returns its new index
+func (a *TransformAnimation) MoveKey(i int, time float32) int {
+	a.Keyframes[i].Time = max(time, 0)
+	for i > 0 && a.Keyframes[i-1].Time > a.Keyframes[i].Time {
+		a.Keyframes[i-1], a.Keyframes[i] = a.Keyframes[i], a.Keyframes[i-1]
+		i--
+	}
+	for i < len(a.Keyframes)-1 && a.Keyframes[i+1].Time < a.Keyframes[i].Time {
+		a.Keyframes[i+1], a.Keyframes[i] = a.Keyframes[i], a.Keyframes[i+1]
+		i++
+	}
+	return i
+}
+
+// Duration is the time of the last keyframe
+func (a *TransformAnimation) Duration() float32 {
+	if len(a.Keyframes) == 0 {
+		return 0
+	}
+	return a.Keyframes[len(a.Keyframes)-1].Time
+}
+
+// Sample returns the interpolated transform at time t, holding the first key
+// before it and the last key after it. ok is false when there are no keys.
+func (a *TransformAnimation) Sample(t float32) (k Keyframe, ok bool) {
+	n := len(a.Keyframes)
+	if n == 0 {
+		return Keyframe{}, false
+	}
+	if t <= a.Keyframes[0].Time {
+		return a.Keyframes[0], true
+	}
+	if t >= a.Keyframes[n-1].Time {
+		return a.Keyframes[n-1], true
+	}
+
+	i := 1
+	for a.Keyframes[i].Time < t {
+		i++
+	}
+	from, to := a.Keyframes[i-1], a.Keyframes[i]
+	f := a.Easing.Apply((t - from.Time) / (to.Time - from.Time))
+	return Keyframe{
+		Time:     t,
+		Position: rl.Vector3Lerp(from.Position, to.Position, f),
+		Rotation: rl.Vector3Lerp(from.Rotation, to.Rotation, f),
+		Scale:    rl.Vector3Lerp(from.Scale, to.Scale, f),
+	}, true
+}
+
+// Play starts the animation from the beginning
+func (a *TransformAnimation) Play() {
+	a.elapsed = 0
+	a.playing = true
+}
+
+// Stop freezes the object where it is
+func (a *TransformAnimation) Stop() {
+	a.playing = false
+}
+
+// IsPlaying reports whether the animation is running. An AnimationOnce
+// animation stops by itself at its last key.
+func (a *TransformAnimation) IsPlaying() bool {
+	return a.playing
+}
+
+// Time is the current position on the timeline, after looping
+func (a *TransformAnimation) Time() float32 {
+	d := a.Duration()
+	if d <= 0 {
+		return 0
+	}
+	switch a.Mode {
+	case AnimationLoop:
+		return float32(math.Mod(float64(a.elapsed), float64(d)))
+	case AnimationPingPong:
+		t := float32(math.Mod(float64(a.elapsed), float64(2*d)))
+		if t > d {
+			t = 2*d - t
+		}
+		return t
+	}
+	return min(a.elapsed, d)
+}
+
+func (a *TransformAnimation) Start() {
+	a.elapsed = 0
+	a.playing = a.PlayOnStart
+}
+
+func (a *TransformAnimation) Update(deltaTime float32) {
+	g := a.GetGameObject()
+	if g == nil || !a.playing {
+		return
+	}
+	a.elapsed += deltaTime
+	if a.Mode == AnimationOnce && a.elapsed >= a.Duration() {
+		a.playing = false
+	}
+
+	if k, ok := a.Sample(a.Time()); ok {
+		g.Transform.Position = k.Position
+		g.Transform.Rotation = k.Rotation
+		g.Transform.Scale = k.Scale
+		g.Transform.MarkRotationDirty()
+	}
+}
@@ -33,6 +33,13 @@ type UIButton struct {
 	// Current state
 	State    ButtonState
 	Disabled bool
+	Focused  bool // set by UINavigation for keyboard/gamepad focus
+
+	// Explicit navigation targets (override spatial navigation when set)
+	NavigateUp    engine.GameObjectRef
+	NavigateDown  engine.GameObjectRef
+	NavigateLeft  engine.GameObjectRef
+	NavigateRight engine.GameObjectRef
 
 	// Unity-style event - supports multiple listeners
 	OnClick engine.Event
@@ -67,18 +74,52 @@ func (b *UIButton) Draw(rect rl.Rectangle) {
 			color = b.PressedColor
 		default:
 			color = b.NormalColor
+			if b.Focused {
+				color = b.HoverColor
+			}
 		}
 	}
 
 	// Draw background
 	rl.DrawRectangleRec(rect, color)
 
-	// Draw border
-	if b.BorderWidth > 0 {
+	// Draw border (thicker when focused so keyboard/gamepad users can see it)
+	if b.Focused && !b.Disabled {
+		rl.DrawRectangleLinesEx(rect, float32(b.BorderWidth+2), b.HoverColor)
+	} else if b.BorderWidth > 0 {
 		rl.DrawRectangleLinesEx(rect, float32(b.BorderWidth), b.BorderColor)
 	}
 }
 
+// navOverride resolves the explicit navigation target for a direction, if any
+func (b *UIButton) navOverride(dir NavDirection) *UIButton {
+	var ref engine.GameObjectRef
+	switch dir {
+	case NavUp:
+		ref = b.NavigateUp
+	case NavDown:
+		ref = b.NavigateDown
+	case NavLeft:
+		ref = b.NavigateLeft
+	case NavRight:
+		ref = b.NavigateRight
+	}
+
+	g := b.GetGameObject()
+	if g == nil {
+		return nil
+	}
+	target := ref.Get(g.Scene)
+	if target == nil {
+		return nil
+	}
+	next := engine.GetComponent[*UIButton](target)
+	if next == nil || next.Disabled {
+		return nil
+	}
+	return next
+}
+
 // HandleInput processes mouse input for the button
 func (b *UIButton) HandleInput(rect rl.Rectangle, mousePos rl.Vector2, pressed, down, released bool) {
 	if b.Disabled {
@@ -121,6 +162,10 @@ func (b *UIButton) Serialize() map[string]any {
 		"borderColor":   []uint8{b.BorderColor.R, b.BorderColor.G, b.BorderColor.B, b.BorderColor.A},
 		"borderWidth":   b.BorderWidth,
 		"disabled":      b.Disabled,
+		"navigateUp":    float64(b.NavigateUp.UID),
+		"navigateDown":  float64(b.NavigateDown.UID),
+		"navigateLeft":  float64(b.NavigateLeft.UID),
+		"navigateRight": float64(b.NavigateRight.UID),
 	}
 }
 
@@ -146,6 +191,23 @@ func (b *UIButton) Deserialize(data map[string]any) {
 	if v, ok := data["disabled"].(bool); ok {
 		b.Disabled = v
 	}
+	if v, ok := data["navigateUp"].(float64); ok {
+		b.NavigateUp.UID = uint64(v)
+	}
+	if v, ok := data["navigateDown"].(float64); ok {
+		b.NavigateDown.UID = uint64(v)
+	}
+	if v, ok := data["navigateLeft"].(float64); ok {
+		b.NavigateLeft.UID = uint64(v)
+	}
+	if v, ok := data["navigateRight"].(float64); ok {
+		b.NavigateRight.UID = uint64(v)
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (b *UIButton) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&b.NavigateUp, &b.NavigateDown, &b.NavigateLeft, &b.NavigateRight}
 }
 
 func init() {
@@ -87,10 +87,10 @@ func (c *UICanvas) Update(deltaTime float32) {
 		return
 	}
 
-	mousePos := rl.GetMousePosition()
-	mousePressed := rl.IsMouseButtonPressed(rl.MouseLeftButton)
-	mouseDown := rl.IsMouseButtonDown(rl.MouseLeftButton)
-	mouseReleased := rl.IsMouseButtonReleased(rl.MouseLeftButton)
+	mousePos := engine.Input.GetMousePosition()
+	mousePressed := engine.Input.IsMouseButtonPressed(rl.MouseLeftButton)
+	mouseDown := engine.Input.IsMouseButtonDown(rl.MouseLeftButton)
+	mouseReleased := engine.Input.IsMouseButtonReleased(rl.MouseLeftButton)
 
 	screenRect := rl.Rectangle{
 		X:      0,
@@ -1,16 +1,18 @@
 package components
 
 import (
+	"test3d/internal/assets"
 	"test3d/internal/engine"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-// UIImage displays a texture or solid color rectangle
+// UIImage displays a texture or solid color rectangle. It can also show a live
+// render texture, either from a MinimapCamera (Source) or set by a script.
 type UIImage struct {
 	engine.BaseComponent
 
-	// Texture path (loaded on Start)
+	// Texture path (loaded on Start, owned by the asset manager)
 	TexturePath string
 	texture     rl.Texture2D
 
@@ -22,6 +24,12 @@ type UIImage struct {
 
 	// Whether to preserve aspect ratio
 	PreserveAspect bool
+
+	// Object with a MinimapCamera whose view is displayed (overrides TexturePath)
+	Source engine.GameObjectRef
+
+	// Render texture set by a script with SetRenderTexture
+	renderTexture rl.RenderTexture2D
 }
 
 func NewUIImage() *UIImage {
@@ -34,19 +42,24 @@ func NewUIImage() *UIImage {
 
 func (i *UIImage) Start() {
 	if i.TexturePath != "" {
-		i.texture = rl.LoadTexture(i.TexturePath)
+		i.texture = assets.LoadUITexture(i.TexturePath)
 	}
 }
 
 // Draw renders the image within the given rect
 func (i *UIImage) Draw(rect rl.Rectangle) {
-	if i.texture.ID > 0 {
+	texture, live := i.liveTexture()
+	if !live {
+		texture = i.texture
+	}
+
+	if texture.ID > 0 {
 		// Draw texture
 		var destRect rl.Rectangle
 
 		if i.PreserveAspect {
 			// Calculate aspect-preserving rect
-			texAspect := float32(i.texture.Width) / float32(i.texture.Height)
+			texAspect := float32(texture.Width) / float32(texture.Height)
 			rectAspect := rect.Width / rect.Height
 
 			if texAspect > rectAspect {
@@ -69,11 +82,15 @@ func (i *UIImage) Draw(rect rl.Rectangle) {
 		sourceRect := rl.Rectangle{
 			X:      0,
 			Y:      0,
-			Width:  float32(i.texture.Width),
-			Height: float32(i.texture.Height),
+			Width:  float32(texture.Width),
+			Height: float32(texture.Height),
+		}
+		if live {
+			// Render textures are stored upside-down
+			sourceRect.Height = -sourceRect.Height
 		}
 
-		rl.DrawTexturePro(i.texture, sourceRect, destRect, rl.Vector2{}, 0, i.Tint)
+		rl.DrawTexturePro(texture, sourceRect, destRect, rl.Vector2{}, 0, i.Tint)
 	} else {
 		// Draw solid color rectangle
 		rl.DrawRectangleRec(rect, i.Color)
@@ -82,25 +99,49 @@ func (i *UIImage) Draw(rect rl.Rectangle) {
 
 // SetTexture loads a texture from path
 func (i *UIImage) SetTexture(path string) {
-	if i.texture.ID > 0 {
-		rl.UnloadTexture(i.texture)
-	}
 	i.TexturePath = path
+	i.texture = rl.Texture2D{}
 	if path != "" {
-		i.texture = rl.LoadTexture(path)
+		i.texture = assets.LoadUITexture(path)
+	}
+}
+
+// SetRenderTexture displays a live render texture (e.g. a script's own camera
+// feed) instead of the static texture. Pass an empty RenderTexture2D to clear it.
+func (i *UIImage) SetRenderTexture(target rl.RenderTexture2D) {
+	i.renderTexture = target
+}
+
+// liveTexture returns the render texture to display, if any. A MinimapCamera
+// source takes priority over a texture set with SetRenderTexture.
+func (i *UIImage) liveTexture() (rl.Texture2D, bool) {
+	if g := i.GetGameObject(); g != nil {
+		if src := i.Source.Get(g.Scene); src != nil {
+			if mc := engine.GetComponent[*MinimapCamera](src); mc != nil && mc.Texture().ID > 0 {
+				return mc.Texture(), true
+			}
+		}
+	}
+	if i.renderTexture.ID > 0 {
+		return i.renderTexture.Texture, true
 	}
+	return rl.Texture2D{}, false
 }
 
 // Serialization
 func (i *UIImage) TypeName() string { return "UIImage" }
 
 func (i *UIImage) Serialize() map[string]any {
-	return map[string]any{
+	data := map[string]any{
 		"texturePath":    i.TexturePath,
 		"color":          []uint8{i.Color.R, i.Color.G, i.Color.B, i.Color.A},
 		"tint":           []uint8{i.Tint.R, i.Tint.G, i.Tint.B, i.Tint.A},
 		"preserveAspect": i.PreserveAspect,
 	}
+	if i.Source.UID != 0 {
+		data["source"] = i.Source.UID
+	}
+	return data
 }
 
 func (i *UIImage) Deserialize(data map[string]any) {
@@ -126,6 +167,16 @@ func (i *UIImage) Deserialize(data map[string]any) {
 	if v, ok := data["preserveAspect"].(bool); ok {
 		i.PreserveAspect = v
 	}
+	// An unset source isn't saved, so a missing key clears it
+	i.Source.UID = 0
+	if v, ok := data["source"].(float64); ok {
+		i.Source.UID = uint64(v)
+	}
+}
+
+// ObjectRefs implements engine.ObjectRefHolder
+func (i *UIImage) ObjectRefs() []*engine.GameObjectRef {
+	return []*engine.GameObjectRef{&i.Source}
 }
 
 func init() {
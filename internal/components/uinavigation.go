@@ -0,0 +1,231 @@
+package components
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// NavDirection is a direction for keyboard/gamepad UI navigation
+type NavDirection int
+
+const (
+	NavUp NavDirection = iota
+	NavDown
+	NavLeft
+	NavRight
+)
+
+// UINavigation moves focus between a canvas's buttons with the arrow keys or
+// gamepad d-pad, and activates the focused button with Enter or the A button.
+// Attach to the same GameObject as the UICanvas.
+type UINavigation struct {
+	engine.BaseComponent
+
+	Gamepad int32 // Gamepad index to read (default 0)
+
+	focused *UIButton
+}
+
+func NewUINavigation() *UINavigation {
+	return &UINavigation{}
+}
+
+// Focused returns the currently focused button, or nil.
+func (n *UINavigation) Focused() *UIButton {
+	return n.focused
+}
+
+// SetFocus moves focus to the given button (nil clears focus).
+func (n *UINavigation) SetFocus(b *UIButton) {
+	if n.focused != nil {
+		n.focused.Focused = false
+	}
+	n.focused = b
+	if b != nil {
+		b.Focused = true
+	}
+}
+
+// Update reads navigation input and moves/activates focus
+func (n *UINavigation) Update(deltaTime float32) {
+	g := n.GetGameObject()
+	if g == nil {
+		return
+	}
+
+	buttons := n.navigableButtons(g)
+	if len(buttons) == 0 {
+		n.SetFocus(nil)
+		return
+	}
+
+	// Drop focus if the button went away or was disabled
+	if n.focused != nil && !containsButton(buttons, n.focused) {
+		n.SetFocus(nil)
+	}
+
+	dir, moved := n.readDirection()
+	if moved {
+		if n.focused == nil {
+			n.SetFocus(topLeftButton(buttons))
+		} else if next := n.findNext(n.focused, dir, buttons); next != nil {
+			n.SetFocus(next)
+		}
+	}
+
+	if n.focused != nil && n.readSubmit() {
+		n.focused.OnClick.Invoke()
+	}
+}
+
+// readDirection returns the navigation direction pressed this frame, if any
+func (n *UINavigation) readDirection() (NavDirection, bool) {
+	pad := n.Gamepad
+	padOK := engine.Input.IsGamepadAvailable(pad)
+
+	switch {
+	case engine.Input.IsKeyPressed(rl.KeyUp) || (padOK && engine.Input.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceUp)):
+		return NavUp, true
+	case engine.Input.IsKeyPressed(rl.KeyDown) || (padOK && engine.Input.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceDown)):
+		return NavDown, true
+	case engine.Input.IsKeyPressed(rl.KeyLeft) || (padOK && engine.Input.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceLeft)):
+		return NavLeft, true
+	case engine.Input.IsKeyPressed(rl.KeyRight) || (padOK && engine.Input.IsGamepadButtonPressed(pad, rl.GamepadButtonLeftFaceRight)):
+		return NavRight, true
+	}
+	return NavUp, false
+}
+
+// readSubmit reports whether the activate button was pressed this frame
+func (n *UINavigation) readSubmit() bool {
+	if engine.Input.IsKeyPressed(rl.KeyEnter) || engine.Input.IsKeyPressed(rl.KeyKpEnter) {
+		return true
+	}
+	return engine.Input.IsGamepadAvailable(n.Gamepad) && engine.Input.IsGamepadButtonPressed(n.Gamepad, rl.GamepadButtonRightFaceDown)
+}
+
+// navigableButtons collects active, enabled buttons under the canvas
+func (n *UINavigation) navigableButtons(g *engine.GameObject) []*UIButton {
+	var result []*UIButton
+	for _, b := range engine.GetComponentsInChildren[*UIButton](g) {
+		owner := b.GetGameObject()
+		if b.Disabled || owner == nil || !activeInHierarchy(owner) {
+			continue
+		}
+		if engine.GetComponent[*RectTransform](owner) == nil {
+			continue
+		}
+		result = append(result, b)
+	}
+	return result
+}
+
+// findNext returns the button to move to from the current one in the given direction.
+// Explicit overrides on the button win; otherwise the nearest button in that
+// direction is chosen, preferring ones that are aligned with the current button.
+func (n *UINavigation) findNext(from *UIButton, dir NavDirection, buttons []*UIButton) *UIButton {
+	if override := from.navOverride(dir); override != nil {
+		return override
+	}
+
+	origin := buttonCenter(from)
+	var best *UIButton
+	bestScore := float32(0)
+
+	for _, b := range buttons {
+		if b == from {
+			continue
+		}
+		c := buttonCenter(b)
+		dx := c.X - origin.X
+		dy := c.Y - origin.Y
+
+		// Distance along the navigation axis and across it
+		var along, across float32
+		switch dir {
+		case NavUp:
+			along, across = -dy, dx
+		case NavDown:
+			along, across = dy, dx
+		case NavLeft:
+			along, across = -dx, dy
+		case NavRight:
+			along, across = dx, dy
+		}
+		if along <= 0 {
+			continue
+		}
+		if across < 0 {
+			across = -across
+		}
+
+		// Misalignment is penalized so a button straight ahead beats a closer diagonal one
+		score := along + across*2
+		if best == nil || score < bestScore {
+			best = b
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func buttonCenter(b *UIButton) rl.Vector2 {
+	rt := engine.GetComponent[*RectTransform](b.GetGameObject())
+	r := rt.GetScreenRect()
+	return rl.Vector2{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
+
+// topLeftButton picks the initial focus target (top-most, then left-most)
+func topLeftButton(buttons []*UIButton) *UIButton {
+	best := buttons[0]
+	bestC := buttonCenter(best)
+	for _, b := range buttons[1:] {
+		c := buttonCenter(b)
+		if c.Y < bestC.Y || (c.Y == bestC.Y && c.X < bestC.X) {
+			best = b
+			bestC = c
+		}
+	}
+	return best
+}
+
+func containsButton(buttons []*UIButton, b *UIButton) bool {
+	for _, other := range buttons {
+		if other == b {
+			return true
+		}
+	}
+	return false
+}
+
+func activeInHierarchy(g *engine.GameObject) bool {
+	for obj := g; obj != nil; obj = obj.Parent {
+		if !obj.Active {
+			return false
+		}
+	}
+	return true
+}
+
+// Serialization
+func (n *UINavigation) TypeName() string { return "UINavigation" }
+
+func (n *UINavigation) Serialize() map[string]any {
+	return map[string]any{
+		"gamepad": n.Gamepad,
+	}
+}
+
+func (n *UINavigation) Deserialize(data map[string]any) {
+	if v, ok := data["gamepad"].(float64); ok {
+		n.Gamepad = int32(v)
+	}
+}
+
+func init() {
+	engine.RegisterComponent("UINavigation", func() engine.Serializable {
+		return NewUINavigation()
+	})
+}
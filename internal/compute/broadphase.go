@@ -2,9 +2,29 @@
 package compute
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cogentcore/webgpu/wgpu"
 )
 
+// DefaultWorkgroupSize is used when NewBroadPhase is given a workgroup size of 0
+const DefaultWorkgroupSize = 256
+
+// maxWorkgroupSize is WebGPU's default maxComputeInvocationsPerWorkgroup limit
+const maxWorkgroupSize = 256
+
+// PairOverflowError reports that the shader found more pairs than the pair buffer holds.
+// DetectPairs still returns the pairs that fit.
+type PairOverflowError struct {
+	Found    uint32 // pairs the shader detected
+	Capacity uint32 // pairs the buffer holds
+}
+
+func (e *PairOverflowError) Error() string {
+	return fmt.Sprintf("broad-phase pair buffer overflow: found %d pairs, capacity %d", e.Found, e.Capacity)
+}
+
 // BroadPhase handles GPU-accelerated collision pair detection.
 // Uses sphere bounding volumes for fast culling.
 type BroadPhase struct {
@@ -16,8 +36,20 @@ type BroadPhase struct {
 	pairBuffer   *Buffer // Output: collision pairs
 	countBuffer  *Buffer // Output: number of pairs found
 
-	maxObjects uint32
-	maxPairs   uint32
+	maxObjects    uint32
+	maxPairs      uint32
+	workgroupSize uint32
+
+	// AutoGrow grows the pair buffer and re-runs detection when it overflows
+	// (default true). When false, overflow is returned as a *PairOverflowError.
+	AutoGrow bool
+
+	// Narrow-phase (see ResolveSpheres), set up on first use
+	narrowPipeline    *Pipeline
+	contactPairBuffer *Buffer // Input: pairs to resolve
+	contactBuffer     *Buffer // Output: one contact per pair
+	maxContacts       uint32
+	narrowErr         error
 
 	// Cached pipeline objects (reused every frame)
 	cachedBindGroupLayout *wgpu.BindGroupLayout
@@ -58,7 +90,7 @@ struct Pair {
 @group(0) @binding(2) var<storage, read_write> pairCount: atomic<u32>;
 @group(0) @binding(3) var<uniform> objectCount: u32;
 
-@compute @workgroup_size(256)
+@compute @workgroup_size(WORKGROUP_SIZE)
 fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
     let i = global_id.x;
     if (i >= objectCount) {
@@ -91,14 +123,19 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
 
 // NewBroadPhase creates a GPU broad-phase system.
 // maxObjects: maximum number of objects to track
-// maxPairs: maximum collision pairs to output (should be generous, e.g., maxObjects * 10)
-func NewBroadPhase(maxObjects, maxPairs uint32) (*BroadPhase, error) {
-	sys := Get()
-	if sys == nil {
-		return nil, nil // Compute not available
+// maxPairs: initial pair buffer capacity (grows on overflow, see AutoGrow)
+// workgroupSize: threads per workgroup hint, 0 = DefaultWorkgroupSize (clamped to 1-256)
+// Returns an error matching ErrUnavailable if there is no GPU compute.
+func NewBroadPhase(maxObjects, maxPairs, workgroupSize uint32) (*BroadPhase, error) {
+	sys, err := Available()
+	if err != nil {
+		return nil, err
 	}
 
-	pipeline, err := sys.CreatePipeline("broadphase", broadPhaseShader, "main")
+	workgroupSize = clampWorkgroupSize(workgroupSize)
+	shaderSource := broadPhaseShaderSource(workgroupSize)
+
+	pipeline, err := sys.CreatePipeline("broadphase", shaderSource, "main")
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +207,7 @@ func NewBroadPhase(maxObjects, maxPairs uint32) (*BroadPhase, error) {
 	// Create shader module
 	shaderModule, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
 		Label:          "broadphase_shader",
-		WGSLDescriptor: &wgpu.ShaderModuleWGSLDescriptor{Code: broadPhaseShader},
+		WGSLDescriptor: &wgpu.ShaderModuleWGSLDescriptor{Code: shaderSource},
 	})
 	if err != nil {
 		pipelineLayout.Release()
@@ -208,6 +245,8 @@ func NewBroadPhase(maxObjects, maxPairs uint32) (*BroadPhase, error) {
 		countBuffer:           countBuffer,
 		maxObjects:            maxObjects,
 		maxPairs:              maxPairs,
+		workgroupSize:         workgroupSize,
+		AutoGrow:              true,
 		cachedBindGroupLayout: bindGroupLayout,
 		cachedPipelineLayout:  pipelineLayout,
 		cachedShaderModule:    shaderModule,
@@ -215,8 +254,33 @@ func NewBroadPhase(maxObjects, maxPairs uint32) (*BroadPhase, error) {
 	}, nil
 }
 
+// clampWorkgroupSize applies the default and keeps the size within WebGPU limits
+func clampWorkgroupSize(size uint32) uint32 {
+	if size == 0 {
+		return DefaultWorkgroupSize
+	}
+	return min(size, maxWorkgroupSize)
+}
+
+// broadPhaseShaderSource returns the broad-phase shader for a workgroup size
+func broadPhaseShaderSource(workgroupSize uint32) string {
+	return strings.Replace(broadPhaseShader, "WORKGROUP_SIZE", fmt.Sprint(workgroupSize), 1)
+}
+
+// WorkgroupSize returns the threads per workgroup the shader was built with
+func (bp *BroadPhase) WorkgroupSize() uint32 {
+	return bp.workgroupSize
+}
+
+// PairCapacity returns how many pairs the pair buffer currently holds
+func (bp *BroadPhase) PairCapacity() uint32 {
+	return bp.maxPairs
+}
+
 // DetectPairs finds all potentially colliding pairs.
 // Returns slice of (indexA, indexB) pairs where indices correspond to input sphere order.
+// If more pairs are found than the buffer holds and AutoGrow is off (or growing fails),
+// the pairs that fit are returned with a *PairOverflowError.
 func (bp *BroadPhase) DetectPairs(spheres []Sphere) ([]CollisionPair, error) {
 	if len(spheres) == 0 {
 		return nil, nil
@@ -225,6 +289,34 @@ func (bp *BroadPhase) DetectPairs(spheres []Sphere) ([]CollisionPair, error) {
 		spheres = spheres[:bp.maxObjects]
 	}
 
+	pairCount, err := bp.run(spheres)
+	if err != nil {
+		return nil, err
+	}
+
+	if pairCount > bp.maxPairs && bp.AutoGrow {
+		// Grow with headroom so a slowly densifying scene doesn't regrow every frame
+		if err := bp.growPairBuffer(pairCount + pairCount/2); err == nil {
+			if pairCount, err = bp.run(spheres); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if pairCount == 0 {
+		return nil, nil
+	}
+
+	// Read back pairs
+	pairData, err := bp.system.ReadBuffer(bp.pairBuffer)
+	if err != nil {
+		return nil, err
+	}
+	return readPairs(pairData, pairCount, bp.maxPairs)
+}
+
+// run uploads the spheres, dispatches the shader and returns how many pairs it found
+func (bp *BroadPhase) run(spheres []Sphere) (uint32, error) {
 	// Upload sphere data
 	bp.system.WriteBuffer(bp.sphereBuffer, 0, ToBytes(spheres))
 
@@ -237,43 +329,49 @@ func (bp *BroadPhase) DetectPairs(spheres []Sphere) ([]CollisionPair, error) {
 		ToBytes([]uint32{objectCount}),
 		wgpu.BufferUsageUniform|wgpu.BufferUsageCopyDst)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	defer uniformBuffer.Release()
 
 	// We need a custom dispatch since we have 4 buffers including uniform
-	err = bp.dispatchWithUniform(objectCount, uniformBuffer)
-	if err != nil {
-		return nil, err
+	if err := bp.dispatchWithUniform(objectCount, uniformBuffer); err != nil {
+		return 0, err
 	}
 
 	// Read back pair count
 	countData, err := bp.system.ReadBuffer(bp.countBuffer)
 	if err != nil {
-		return nil, err
-	}
-	pairCount := toSlice[uint32](countData)[0]
-
-	if pairCount == 0 {
-		return nil, nil
-	}
-
-	// Clamp to max pairs
-	if pairCount > bp.maxPairs {
-		pairCount = bp.maxPairs
+		return 0, err
 	}
+	return toSlice[uint32](countData)[0], nil
+}
 
-	// Read back pairs
-	pairData, err := bp.system.ReadBuffer(bp.pairBuffer)
+// growPairBuffer replaces the pair buffer with one holding at least capacity pairs
+func (bp *BroadPhase) growPairBuffer(capacity uint32) error {
+	buf, err := bp.system.CreateBuffer("pairs", uint64(capacity)*8,
+		wgpu.BufferUsageStorage|wgpu.BufferUsageCopySrc)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	bp.pairBuffer.Release()
+	bp.pairBuffer = buf
+	bp.maxPairs = capacity
+	return nil
+}
 
-	// Convert to pairs slice
-	pairs := make([]CollisionPair, pairCount)
+// readPairs converts the pair buffer contents, reporting overflow if the shader
+// found more pairs than the buffer could hold.
+func readPairs(pairData []byte, found, capacity uint32) ([]CollisionPair, error) {
+	count := min(found, capacity)
 	rawPairs := toSlice[CollisionPair](pairData)
-	copy(pairs, rawPairs[:pairCount])
+	count = min(count, uint32(len(rawPairs)))
+
+	pairs := make([]CollisionPair, count)
+	copy(pairs, rawPairs[:count])
 
+	if found > capacity {
+		return pairs, &PairOverflowError{Found: found, Capacity: capacity}
+	}
 	return pairs, nil
 }
 
@@ -308,7 +406,7 @@ func (bp *BroadPhase) dispatchWithUniform(objectCount uint32, uniformBuffer *Buf
 	pass := encoder.BeginComputePass(nil)
 	pass.SetPipeline(bp.cachedPipeline)
 	pass.SetBindGroup(0, bindGroup, nil)
-	workgroups := (objectCount + 255) / 256
+	workgroups := (objectCount + bp.workgroupSize - 1) / bp.workgroupSize
 	pass.DispatchWorkgroups(workgroups, 1, 1)
 	pass.End()
 	pass.Release()
@@ -334,6 +432,7 @@ func (bp *BroadPhase) Release() {
 	if bp.countBuffer != nil {
 		bp.countBuffer.Release()
 	}
+	bp.releaseContactBuffers()
 }
 
 // Helper to get unsafe pointer
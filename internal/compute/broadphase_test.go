@@ -0,0 +1,50 @@
+package compute
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadPairsReportsOverflow(t *testing.T) {
+	buffer := ToBytes([]CollisionPair{{0, 1}, {0, 2}, {1, 2}})
+
+	// Shader found 5 pairs but the buffer only holds 3
+	pairs, err := readPairs(buffer, 5, 3)
+
+	var overflow *PairOverflowError
+	if !errors.As(err, &overflow) {
+		t.Fatalf("expected *PairOverflowError, got %v", err)
+	}
+	if overflow.Found != 5 || overflow.Capacity != 3 {
+		t.Errorf("overflow = found %d, capacity %d; want 5, 3", overflow.Found, overflow.Capacity)
+	}
+	if len(pairs) != 3 {
+		t.Errorf("pairs that fit should still be returned, got %d", len(pairs))
+	}
+}
+
+func TestReadPairsWithinCapacity(t *testing.T) {
+	buffer := ToBytes([]CollisionPair{{0, 1}, {2, 3}, {0, 0}, {0, 0}})
+
+	pairs, err := readPairs(buffer, 2, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pairs) != 2 || pairs[1] != (CollisionPair{2, 3}) {
+		t.Errorf("pairs = %v; want [{0 1} {2 3}]", pairs)
+	}
+}
+
+func TestWorkgroupSize(t *testing.T) {
+	cases := map[uint32]uint32{0: DefaultWorkgroupSize, 64: 64, 1024: maxWorkgroupSize}
+	for in, want := range cases {
+		if got := clampWorkgroupSize(in); got != want {
+			t.Errorf("clampWorkgroupSize(%d) = %d; want %d", in, got, want)
+		}
+	}
+
+	if src := broadPhaseShaderSource(64); !strings.Contains(src, "@workgroup_size(64)") {
+		t.Error("shader source should use the requested workgroup size")
+	}
+}
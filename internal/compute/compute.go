@@ -3,6 +3,7 @@
 package compute
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"unsafe"
@@ -43,6 +44,32 @@ var (
 	initErr      error
 )
 
+// ErrUnavailable matches (with errors.Is) every error that means GPU compute
+// can't be used on this machine, including an *InitError.
+var ErrUnavailable = errors.New("GPU compute unavailable")
+
+// InitError reports why Initialize couldn't set up GPU compute. It matches
+// ErrUnavailable, and unwraps to the WebGPU error.
+type InitError struct {
+	Stage string // what failed: "instance", "adapter" or "device"
+	Err   error
+}
+
+func (e *InitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("GPU compute unavailable: failed to get GPU %s", e.Stage)
+	}
+	return fmt.Sprintf("GPU compute unavailable: failed to get GPU %s: %v", e.Stage, e.Err)
+}
+
+func (e *InitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InitError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
 // AdapterInfo contains GPU information.
 type AdapterInfo struct {
 	Name       string
@@ -52,8 +79,9 @@ type AdapterInfo struct {
 	Driver     string
 }
 
-// Initialize sets up the compute system. Safe to call multiple times.
-// Returns detailed GPU info on success.
+// Initialize sets up the compute system. Safe to call multiple times; a failure
+// is remembered and returned again. Returns detailed GPU info on success, or
+// an *InitError when there is no usable GPU.
 func Initialize() (info AdapterInfo, err error) {
 	initOnce.Do(func() {
 		globalSystem, initErr = newSystem()
@@ -76,22 +104,37 @@ func Get() *System {
 	return globalSystem
 }
 
+// Available returns the global compute system, or an error matching
+// ErrUnavailable that says why there isn't one
+func Available() (*System, error) {
+	if globalSystem != nil {
+		return globalSystem, nil
+	}
+	if initErr != nil {
+		return nil, initErr
+	}
+	return nil, fmt.Errorf("%w: compute.Initialize was not called", ErrUnavailable)
+}
+
 func newSystem() (*System, error) {
 	instance := wgpu.CreateInstance(nil)
+	if instance == nil {
+		return nil, &InitError{Stage: "instance"}
+	}
 
 	adapter, err := instance.RequestAdapter(&wgpu.RequestAdapterOptions{
 		PowerPreference: wgpu.PowerPreferenceHighPerformance,
 	})
-	if err != nil {
+	if err != nil || adapter == nil {
 		instance.Release()
-		return nil, fmt.Errorf("failed to get GPU adapter: %w", err)
+		return nil, &InitError{Stage: "adapter", Err: err}
 	}
 
 	device, err := adapter.RequestDevice(nil)
-	if err != nil {
+	if err != nil || device == nil {
 		adapter.Release()
 		instance.Release()
-		return nil, fmt.Errorf("failed to get GPU device: %w", err)
+		return nil, &InitError{Stage: "device", Err: err}
 	}
 
 	queue := device.GetQueue()
@@ -0,0 +1,29 @@
+package compute
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInitErrorMatchesUnavailable(t *testing.T) {
+	cause := errors.New("no adapter found")
+	var err error = &InitError{Stage: "adapter", Err: cause}
+
+	if !errors.Is(err, ErrUnavailable) {
+		t.Error("InitError should match ErrUnavailable")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("InitError should unwrap to the WebGPU error")
+	}
+	if got, want := err.Error(), "GPU compute unavailable: failed to get GPU adapter: no adapter found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestNewBroadPhaseWithoutCompute(t *testing.T) {
+	// Initialize is never called in tests, so there is no GPU
+	bp, err := NewBroadPhase(16, 64, 0)
+	if bp != nil || !errors.Is(err, ErrUnavailable) {
+		t.Errorf("NewBroadPhase = %v, %v; want nil and an error matching ErrUnavailable", bp, err)
+	}
+}
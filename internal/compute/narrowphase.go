@@ -0,0 +1,226 @@
+// GPU-accelerated narrow-phase for sphere pairs
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// Contact is the narrow-phase result for one sphere pair.
+// Packed as vec4: xyz = normal pointing from sphere B to sphere A,
+// w = penetration depth (<= 0 when the spheres don't touch)
+type Contact struct {
+	NX, NY, NZ float32
+	Depth      float32
+}
+
+const narrowPhaseShader = `
+// Narrow-phase for sphere pairs
+// Each thread computes the contact normal and depth of one candidate pair
+
+struct Sphere {
+    pos: vec3<f32>,
+    radius: f32,
+}
+
+struct Pair {
+    a: u32,
+    b: u32,
+}
+
+struct Contact {
+    normal: vec3<f32>,
+    depth: f32,
+}
+
+@group(0) @binding(0) var<storage, read> spheres: array<Sphere>;
+@group(0) @binding(1) var<storage, read> pairs: array<Pair>;
+@group(0) @binding(2) var<storage, read_write> contacts: array<Contact>;
+@group(0) @binding(3) var<uniform> counts: vec4<u32>; // x = pairs, y = spheres
+
+@compute @workgroup_size(WORKGROUP_SIZE)
+fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
+    let i = global_id.x;
+    if (i >= counts.x) {
+        return;
+    }
+
+    let pair = pairs[i];
+    if (pair.a >= counts.y || pair.b >= counts.y) {
+        contacts[i] = Contact(vec3<f32>(0.0, 0.0, 0.0), 0.0);
+        return;
+    }
+
+    let sphereA = spheres[pair.a];
+    let sphereB = spheres[pair.b];
+    let diff = sphereA.pos - sphereB.pos;
+    let dist = length(diff);
+
+    // Coincident centers get pushed apart vertically
+    var normal = vec3<f32>(0.0, 1.0, 0.0);
+    if (dist > 0.0001) {
+        normal = diff / dist;
+    }
+    contacts[i] = Contact(normal, sphereA.radius + sphereB.radius - dist);
+}
+`
+
+// narrowPhaseShaderSource returns the narrow-phase shader for a workgroup size
+func narrowPhaseShaderSource(workgroupSize uint32) string {
+	return strings.Replace(narrowPhaseShader, "WORKGROUP_SIZE", fmt.Sprint(workgroupSize), 1)
+}
+
+// SphereContact returns the contact between spheres a and b, computed the
+// same way as the narrow-phase shader
+func SphereContact(a, b Sphere) Contact {
+	dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+	dist := float32(math.Sqrt(float64(dx*dx + dy*dy + dz*dz)))
+	c := Contact{NY: 1, Depth: a.Radius + b.Radius - dist}
+	if dist > 0.0001 {
+		c.NX, c.NY, c.NZ = dx/dist, dy/dist, dz/dist
+	}
+	return c
+}
+
+// SphereContacts is the CPU fallback for ResolveSpheres. Pairs naming a sphere
+// outside spheres get a zero contact.
+func SphereContacts(spheres []Sphere, pairs []CollisionPair) []Contact {
+	contacts := make([]Contact, len(pairs))
+	for i, pair := range pairs {
+		if int(pair.A) < len(spheres) && int(pair.B) < len(spheres) {
+			contacts[i] = SphereContact(spheres[pair.A], spheres[pair.B])
+		}
+	}
+	return contacts
+}
+
+// ResolveSpheres computes the contact of each candidate pair on the GPU;
+// contacts[i] belongs to pairs[i]. When the GPU can't run it (or bp is nil)
+// the contacts are computed on the CPU with SphereContacts instead, and
+// NarrowPhaseError reports why.
+func (bp *BroadPhase) ResolveSpheres(spheres []Sphere, pairs []CollisionPair) []Contact {
+	if bp == nil {
+		return SphereContacts(spheres, pairs)
+	}
+	bp.narrowErr = nil
+	if len(pairs) == 0 {
+		return nil
+	}
+	contacts, err := bp.resolveSpheresGPU(spheres, pairs)
+	if err != nil {
+		bp.narrowErr = err
+		return SphereContacts(spheres, pairs)
+	}
+	return contacts
+}
+
+// NarrowPhaseError returns the error that sent the last ResolveSpheres to the
+// CPU, or nil if it ran on the GPU
+func (bp *BroadPhase) NarrowPhaseError() error {
+	if bp == nil {
+		return nil
+	}
+	return bp.narrowErr
+}
+
+// resolveSpheresGPU uploads the spheres and pairs, dispatches the
+// narrow-phase shader and reads back one contact per pair
+func (bp *BroadPhase) resolveSpheresGPU(spheres []Sphere, pairs []CollisionPair) ([]Contact, error) {
+	if len(spheres) == 0 {
+		return nil, errors.New("narrow-phase: no spheres")
+	}
+	if uint32(len(spheres)) > bp.maxObjects {
+		return nil, fmt.Errorf("narrow-phase: %d spheres, capacity %d", len(spheres), bp.maxObjects)
+	}
+	if bp.narrowPipeline == nil {
+		name := fmt.Sprintf("narrowphase_%d", bp.workgroupSize)
+		pipeline, err := bp.system.CreatePipeline(name, narrowPhaseShaderSource(bp.workgroupSize), "main")
+		if err != nil {
+			return nil, err
+		}
+		bp.narrowPipeline = pipeline
+	}
+	if err := bp.growContactBuffers(uint32(len(pairs))); err != nil {
+		return nil, err
+	}
+
+	bp.system.WriteBuffer(bp.sphereBuffer, 0, ToBytes(spheres))
+	bp.system.WriteBuffer(bp.contactPairBuffer, 0, ToBytes(pairs))
+
+	pairCount := uint32(len(pairs))
+	countBuffer, err := bp.system.CreateBufferWithData("narrowphaseCounts",
+		ToBytes([]uint32{pairCount, uint32(len(spheres)), 0, 0}),
+		wgpu.BufferUsageUniform|wgpu.BufferUsageCopyDst)
+	if err != nil {
+		return nil, err
+	}
+	defer countBuffer.Release()
+
+	err = bp.system.Dispatch(DispatchParams{
+		Pipeline:    bp.narrowPipeline,
+		Buffers:     []*Buffer{bp.sphereBuffer, bp.contactPairBuffer, bp.contactBuffer, countBuffer},
+		WorkgroupsX: (pairCount + bp.workgroupSize - 1) / bp.workgroupSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bp.system.ReadBuffer(bp.contactBuffer)
+	if err != nil {
+		return nil, err
+	}
+	return readContacts(data, pairCount)
+}
+
+// growContactBuffers makes the narrow-phase pair and contact buffers hold at
+// least count pairs, with headroom so they don't regrow every frame
+func (bp *BroadPhase) growContactBuffers(count uint32) error {
+	if count <= bp.maxContacts {
+		return nil
+	}
+	capacity := count + count/2
+	pairBuffer, err := bp.system.CreateBuffer("contactPairs", uint64(capacity)*8,
+		wgpu.BufferUsageStorage|wgpu.BufferUsageCopyDst)
+	if err != nil {
+		return err
+	}
+	contactBuffer, err := bp.system.CreateBuffer("contacts", uint64(capacity)*16,
+		wgpu.BufferUsageStorage|wgpu.BufferUsageCopySrc)
+	if err != nil {
+		pairBuffer.Release()
+		return err
+	}
+	bp.releaseContactBuffers()
+	bp.contactPairBuffer = pairBuffer
+	bp.contactBuffer = contactBuffer
+	bp.maxContacts = capacity
+	return nil
+}
+
+// releaseContactBuffers frees the narrow-phase buffers
+func (bp *BroadPhase) releaseContactBuffers() {
+	if bp.contactPairBuffer != nil {
+		bp.contactPairBuffer.Release()
+		bp.contactPairBuffer = nil
+	}
+	if bp.contactBuffer != nil {
+		bp.contactBuffer.Release()
+		bp.contactBuffer = nil
+	}
+	bp.maxContacts = 0
+}
+
+// readContacts converts the first count contacts of the contact buffer
+func readContacts(data []byte, count uint32) ([]Contact, error) {
+	raw := toSlice[Contact](data)
+	if uint32(len(raw)) < count {
+		return nil, fmt.Errorf("narrow-phase: read %d contacts, want %d", len(raw), count)
+	}
+	contacts := make([]Contact, count)
+	copy(contacts, raw[:count])
+	return contacts, nil
+}
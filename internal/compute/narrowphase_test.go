@@ -0,0 +1,57 @@
+package compute
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSphereContacts(t *testing.T) {
+	spheres := []Sphere{
+		{X: 0, Y: 0, Z: 0, Radius: 1},
+		{X: 1.5, Y: 0, Z: 0, Radius: 1},
+		{X: 0, Y: 0, Z: 0, Radius: 0.5},
+		{X: 0, Y: 5, Z: 0, Radius: 1},
+	}
+	pairs := []CollisionPair{{0, 1}, {0, 2}, {0, 3}, {0, 9}}
+
+	contacts := SphereContacts(spheres, pairs)
+	if len(contacts) != len(pairs) {
+		t.Fatalf("expected one contact per pair, got %d", len(contacts))
+	}
+	// Normal points from B to A
+	if c := contacts[0]; c.NX != -1 || c.Depth != 0.5 {
+		t.Errorf("overlapping spheres: got %+v; want normal -X, depth 0.5", c)
+	}
+	if c := contacts[1]; c.NY != 1 || c.Depth != 1.5 {
+		t.Errorf("coincident centers: got %+v; want normal +Y, depth 1.5", c)
+	}
+	if c := contacts[2]; c.Depth >= 0 {
+		t.Errorf("separated spheres should have negative depth, got %+v", c)
+	}
+	if c := contacts[3]; c != (Contact{}) {
+		t.Errorf("out of range pair should get a zero contact, got %+v", c)
+	}
+}
+
+func TestResolveSpheresFallsBackToCPU(t *testing.T) {
+	var bp *BroadPhase
+	spheres := []Sphere{{Radius: 1}, {X: 1, Radius: 1}}
+	contacts := bp.ResolveSpheres(spheres, []CollisionPair{{0, 1}})
+	if len(contacts) != 1 || contacts[0].Depth != 1 {
+		t.Errorf("nil BroadPhase should resolve on the CPU, got %+v", contacts)
+	}
+}
+
+func TestReadContacts(t *testing.T) {
+	data := ToBytes([]Contact{{NY: 1, Depth: 0.25}, {NX: 1, Depth: 2}, {}})
+	contacts, err := readContacts(data, 2)
+	if err != nil || len(contacts) != 2 || contacts[1] != (Contact{NX: 1, Depth: 2}) {
+		t.Errorf("readContacts = %+v, %v", contacts, err)
+	}
+	if _, err := readContacts(data, 4); err == nil {
+		t.Error("reading more contacts than the buffer holds should fail")
+	}
+	if src := narrowPhaseShaderSource(64); !strings.Contains(src, "@workgroup_size(64)") {
+		t.Error("shader source should use the requested workgroup size")
+	}
+}
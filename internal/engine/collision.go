@@ -0,0 +1,47 @@
+package engine
+
+import "slices"
+
+// tagCollisionCallback is a collision callback registered for one tag
+type tagCollisionCallback struct {
+	tag     string
+	fn      func(other *GameObject)
+	removed bool // unsubscribed, possibly while callbacks were running
+}
+
+// OnCollisionWithTag registers fn to be called when self starts colliding with
+// an object carrying tag. Saves scripts from tag-matching in OnCollisionEnter:
+//
+//	engine.OnCollisionWithTag(s.GetGameObject(), "Enemy", func(enemy *engine.GameObject) {
+//		s.Health -= 10
+//	})
+//
+// The returned func unregisters fn; it is safe to call more than once, and
+// from inside fn.
+func OnCollisionWithTag(self *GameObject, tag string, fn func(other *GameObject)) (unsubscribe func()) {
+	if self == nil || fn == nil {
+		return func() {}
+	}
+	cb := &tagCollisionCallback{tag: tag, fn: fn}
+	self.tagCallbacks = append(self.tagCallbacks, cb)
+	return func() {
+		if cb.removed {
+			return
+		}
+		cb.removed = true
+		// Copy so a NotifyTagCollision in progress keeps its own list
+		self.tagCallbacks = slices.DeleteFunc(slices.Clone(self.tagCallbacks), func(c *tagCollisionCallback) bool {
+			return c == cb
+		})
+	}
+}
+
+// NotifyTagCollision runs the tag callbacks of g that match other's tags.
+// Called by the physics world when a collision starts.
+func (g *GameObject) NotifyTagCollision(other *GameObject) {
+	for _, cb := range g.tagCallbacks {
+		if !cb.removed && other.HasTag(cb.tag) {
+			cb.fn(other)
+		}
+	}
+}
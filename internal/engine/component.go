@@ -31,6 +31,21 @@ type CollisionHandler interface {
 	OnCollisionExit(other *GameObject)
 }
 
+// TriggerHandler is implemented by components that want to know when objects
+// start and stop overlapping a trigger collider. Both the trigger's object and
+// the one passing through it receive the callbacks.
+type TriggerHandler interface {
+	OnTriggerEnter(other *GameObject)
+	OnTriggerExit(other *GameObject)
+}
+
+// ImpactHandler is implemented by components that want to know how hard a new
+// collision was. OnCollisionImpact runs right before OnCollisionEnter with the
+// impulse of the hit (relative speed times the pair's effective mass).
+type ImpactHandler interface {
+	OnCollisionImpact(other *GameObject, impulse float32)
+}
+
 // Serializable is implemented by components that can save/load themselves.
 // This reduces boilerplate in scenefile.go - just implement these methods
 // on your component instead of adding switch cases.
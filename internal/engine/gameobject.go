@@ -2,6 +2,7 @@ package engine
 
 import (
 	"math"
+	"slices"
 	"sync/atomic"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -15,7 +16,10 @@ type Transform struct {
 	Rotation rl.Vector3 // Euler angles in degrees (XYZ order)
 	Scale    rl.Vector3
 
+	// quaternion caches Rotation. quatEuler is the Rotation it was built from,
+	// so edits made straight to Rotation are picked up on the next read.
 	quaternion rl.Quaternion
+	quatEuler  rl.Vector3
 	quatDirty  bool
 }
 
@@ -28,13 +32,16 @@ type GameObject struct {
 	Scene      *Scene
 	Parent     *GameObject
 	Children   []*GameObject
+	PrefabPath string // source prefab file if this object was instantiated from one
 	components []Component
 	started    bool
+
+	tagCallbacks []*tagCollisionCallback // registered by OnCollisionWithTag
 }
 
 func NewGameObject(name string) *GameObject {
 	return &GameObject{
-		UID:    atomic.AddUint64(&uidCounter, 1),
+		UID:    NewUID(),
 		Name:   name,
 		Active: true,
 		Transform: Transform{
@@ -49,18 +56,30 @@ func NewGameObject(name string) *GameObject {
 	}
 }
 
-// NewGameObjectWithUID creates a GameObject with a specific UID (for loading from files)
-func NewGameObjectWithUID(name string, uid uint64) *GameObject {
-	// Update counter if loaded UID is higher to avoid collisions
+// NewUID returns a UID no object has been given yet, for objects loaded from
+// files whose own UIDs have to be replaced
+func NewUID() uint64 {
+	return atomic.AddUint64(&uidCounter, 1)
+}
+
+// ReserveUID makes sure NewUID and NewGameObject never hand out uid, so a UID
+// read from a file can be kept
+func ReserveUID(uid uint64) {
 	for {
 		current := atomic.LoadUint64(&uidCounter)
 		if uid <= current {
-			break
+			return
 		}
 		if atomic.CompareAndSwapUint64(&uidCounter, current, uid) {
-			break
+			return
 		}
 	}
+}
+
+// NewGameObjectWithUID creates a GameObject with a specific UID (for loading from files)
+func NewGameObjectWithUID(name string, uid uint64) *GameObject {
+	// Update counter if loaded UID is higher to avoid collisions
+	ReserveUID(uid)
 	return &GameObject{
 		UID:    uid,
 		Name:   name,
@@ -169,6 +188,18 @@ func (g *GameObject) RemoveComponentByIndex(index int) bool {
 	return true
 }
 
+// InsertComponent adds c at position index among g's components, e.g. to put
+// back a removed component where it was. A negative or out-of-range index
+// appends.
+func (g *GameObject) InsertComponent(c Component, index int) {
+	c.SetGameObject(g)
+	if index < 0 || index >= len(g.components) {
+		g.components = append(g.components, c)
+		return
+	}
+	g.components = slices.Insert(g.components, index, c)
+}
+
 func (g *GameObject) HasTag(tag string) bool {
 	for _, t := range g.Tags {
 		if t == tag {
@@ -183,6 +214,32 @@ func (g *GameObject) AddChild(child *GameObject) {
 	g.Children = append(g.Children, child)
 }
 
+// InsertChild makes child a child of g at position index in Children.
+// A negative or out-of-range index appends.
+func (g *GameObject) InsertChild(child *GameObject, index int) {
+	child.Parent = g
+	if index < 0 || index >= len(g.Children) {
+		g.Children = append(g.Children, child)
+		return
+	}
+	g.Children = slices.Insert(g.Children, index, child)
+}
+
+// ChildIndex returns the position of child in Children, or -1
+func (g *GameObject) ChildIndex(child *GameObject) int {
+	return slices.Index(g.Children, child)
+}
+
+// IsDescendantOf reports whether ancestor is above g in the hierarchy
+func (g *GameObject) IsDescendantOf(ancestor *GameObject) bool {
+	for p := g.Parent; p != nil; p = p.Parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *GameObject) RemoveChild(child *GameObject) {
 	for i, c := range g.Children {
 		if c == child {
@@ -198,7 +255,7 @@ func (g *GameObject) WorldPosition() rl.Vector3 {
 		return g.Transform.Position
 	}
 	parentPos := g.Parent.WorldPosition()
-	parentRot := g.Parent.WorldRotation()
+	parentRot := g.Parent.WorldQuaternion()
 	parentScale := g.Parent.WorldScale()
 
 	// Scale local position by parent's world scale
@@ -208,24 +265,77 @@ func (g *GameObject) WorldPosition() rl.Vector3 {
 		Z: g.Transform.Position.Z * parentScale.Z,
 	}
 
-	// Rotate by parent rotation (same convention as ModelRenderer: X then Y then Z)
-	rx := float64(parentRot.X) * math.Pi / 180
-	ry := float64(parentRot.Y) * math.Pi / 180
-	rz := float64(parentRot.Z) * math.Pi / 180
-	rotX := rl.MatrixRotateX(float32(rx))
-	rotY := rl.MatrixRotateY(float32(ry))
-	rotZ := rl.MatrixRotateZ(float32(rz))
-	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
-
-	rotated := rl.Vector3Transform(scaled, rotMatrix)
+	rotated := rl.Vector3RotateByQuaternion(scaled, parentRot)
 	return rl.Vector3Add(parentPos, rotated)
 }
 
+// SetWorldPosition moves the object to a world position by undoing the parent's
+// world transform, the inverse of WorldPosition. An axis the parent scales to
+// zero can't be solved and keeps its local value.
+func (g *GameObject) SetWorldPosition(pos rl.Vector3) {
+	if g.Parent == nil {
+		g.Transform.Position = pos
+		return
+	}
+	parentRot := g.Parent.WorldQuaternion()
+	parentScale := g.Parent.WorldScale()
+
+	offset := rl.Vector3Subtract(pos, g.Parent.WorldPosition())
+	local := rl.Vector3RotateByQuaternion(offset, rl.QuaternionInvert(parentRot))
+
+	if parentScale.X != 0 {
+		g.Transform.Position.X = local.X / parentScale.X
+	}
+	if parentScale.Y != 0 {
+		g.Transform.Position.Y = local.Y / parentScale.Y
+	}
+	if parentScale.Z != 0 {
+		g.Transform.Position.Z = local.Z / parentScale.Z
+	}
+}
+
+// WorldRotation returns the world rotation as Euler angles in degrees. A child's
+// rotation is applied before its parent's, so the angles are only the sum of
+// the two when the rotations share an axis.
 func (g *GameObject) WorldRotation() rl.Vector3 {
 	if g.Parent == nil {
 		return g.Transform.Rotation
 	}
-	return rl.Vector3Add(g.Parent.WorldRotation(), g.Transform.Rotation)
+	return quaternionToEulerDegrees(g.WorldQuaternion())
+}
+
+// SetWorldRotation sets the local rotation so WorldRotation returns rot
+func (g *GameObject) SetWorldRotation(rot rl.Vector3) {
+	if g.Parent == nil {
+		g.Transform.Rotation = rot
+		g.Transform.MarkRotationDirty()
+		return
+	}
+	g.SetWorldQuaternion(eulerDegreesToQuaternion(rot))
+}
+
+// WorldQuaternion returns the world rotation as a quaternion, the form
+// physics and rendering build their matrices from
+func (g *GameObject) WorldQuaternion() rl.Quaternion {
+	q := g.Transform.GetQuaternion()
+	if g.Parent == nil {
+		return q
+	}
+	return rl.QuaternionMultiply(g.Parent.WorldQuaternion(), q)
+}
+
+// SetWorldQuaternion sets the local rotation so WorldQuaternion returns q
+func (g *GameObject) SetWorldQuaternion(q rl.Quaternion) {
+	if g.Parent != nil {
+		q = rl.QuaternionMultiply(rl.QuaternionInvert(g.Parent.WorldQuaternion()), q)
+	}
+	g.Transform.SetQuaternion(q)
+}
+
+// WorldRotationMatrix returns the world rotation as a matrix, for transforming
+// local directions and building model matrices
+func (g *GameObject) WorldRotationMatrix() rl.Matrix {
+	return rl.QuaternionToMatrix(g.WorldQuaternion())
 }
 
 func (g *GameObject) WorldScale() rl.Vector3 {
@@ -241,29 +351,53 @@ func (g *GameObject) WorldScale() rl.Vector3 {
 }
 
 // GetQuaternion returns the quaternion representation of the rotation.
-// Converts from Euler angles if needed (lazy evaluation).
+// Converts from Euler angles if needed (lazy evaluation), including after
+// Rotation was assigned directly.
 func (t *Transform) GetQuaternion() rl.Quaternion {
-	if t.quatDirty {
-		radX := t.Rotation.X * rl.Deg2rad
-		radY := t.Rotation.Y * rl.Deg2rad
-		radZ := t.Rotation.Z * rl.Deg2rad
-
-		t.quaternion = rl.QuaternionFromEuler(radX, radY, radZ)
+	if t.quatDirty || t.Rotation != t.quatEuler {
+		t.quaternion = eulerDegreesToQuaternion(t.Rotation)
+		t.quatEuler = t.Rotation
 		t.quatDirty = false
 	}
 	return t.quaternion
 }
 
 // SetQuaternion sets the rotation from a quaternion and updates Euler angles.
+// The quaternion is kept as given, so repeated small rotations (a tumbling
+// rigidbody) don't pick up rounding from the Euler conversion.
 func (t *Transform) SetQuaternion(q rl.Quaternion) {
-	t.quaternion = q
+	t.quaternion = rl.QuaternionNormalize(q)
+	t.Rotation = quaternionToEulerDegrees(t.quaternion)
+	t.quatEuler = t.Rotation
 	t.quatDirty = false
+}
 
-	euler := rl.QuaternionToEuler(q)
-	t.Rotation = rl.Vector3{
-		X: euler.X * rl.Rad2deg,
-		Y: euler.Y * rl.Rad2deg,
-		Z: euler.Z * rl.Rad2deg,
+// eulerDegreesToQuaternion converts Euler angles in degrees, applied X then Y
+// then Z like every rotation matrix in the engine, to a quaternion
+func eulerDegreesToQuaternion(e rl.Vector3) rl.Quaternion {
+	return rl.QuaternionFromEuler(e.X*rl.Deg2rad, e.Y*rl.Deg2rad, e.Z*rl.Deg2rad)
+}
+
+// quaternionToEulerDegrees is the inverse of eulerDegreesToQuaternion. The
+// angles are read off the rotation matrix Rz*Ry*Rx; when Y is at ±90 degrees X
+// and Z turn about the same axis, so Z is taken as 0 and X gets the rest.
+func quaternionToEulerDegrees(q rl.Quaternion) rl.Vector3 {
+	m := rl.QuaternionToMatrix(q)
+	cosY := math.Hypot(float64(m.M0), float64(m.M1))
+	y := math.Atan2(float64(-m.M2), cosY)
+	var x, z float64
+	if cosY > 1e-6 {
+		x = math.Atan2(float64(m.M6), float64(m.M10))
+		z = math.Atan2(float64(m.M1), float64(m.M0))
+	} else if y > 0 {
+		x = math.Atan2(float64(m.M4), float64(m.M8))
+	} else {
+		x = math.Atan2(float64(-m.M4), float64(-m.M8))
+	}
+	return rl.Vector3{
+		X: float32(x * 180 / math.Pi),
+		Y: float32(y * 180 / math.Pi),
+		Z: float32(z * 180 / math.Pi),
 	}
 }
 
@@ -48,3 +48,10 @@ func (r *GameObjectRef) Set(g *GameObject) {
 func (r *GameObjectRef) Clear() {
 	r.UID = 0
 }
+
+// ObjectRefHolder is implemented by components with GameObjectRef fields, so
+// their references can be repointed when the objects they point at are loaded
+// under new UIDs (duplicate UIDs in a scene, pasted copies, prefab instances).
+type ObjectRefHolder interface {
+	ObjectRefs() []*GameObjectRef
+}
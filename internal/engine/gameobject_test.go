@@ -1,6 +1,10 @@
 package engine
 
-import "testing"
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
 
 func TestNewGameObject(t *testing.T) {
 	obj := NewGameObject("TestObject")
@@ -114,6 +118,27 @@ func TestGameObjectAddComponent(t *testing.T) {
 	}
 }
 
+func TestGameObjectInsertComponent(t *testing.T) {
+	obj := NewGameObject("Test")
+	a := &BaseComponent{}
+	b := &BaseComponent{}
+	c := &BaseComponent{}
+
+	obj.InsertComponent(a, -1)
+	obj.InsertComponent(b, 5)
+	obj.InsertComponent(c, 1)
+
+	if c.gameObject != obj {
+		t.Error("InsertComponent should set the component's gameObject")
+	}
+	want := []Component{a, c, b}
+	for i, comp := range want {
+		if obj.components[i] != comp {
+			t.Fatalf("components[%d] is in the wrong place", i)
+		}
+	}
+}
+
 func TestGameObjectGetComponent(t *testing.T) {
 	obj := NewGameObject("Test")
 	comp := &BaseComponent{}
@@ -138,3 +163,141 @@ func TestGameObjectStartCalledOnce(t *testing.T) {
 	// Second call should be a no-op (no panic, no re-initialization)
 	obj.Start() // Should not panic or cause issues
 }
+
+func TestGameObjectInsertChild(t *testing.T) {
+	parent := NewGameObject("Parent")
+	a := NewGameObject("A")
+	b := NewGameObject("B")
+	c := NewGameObject("C")
+
+	parent.InsertChild(a, -1)
+	parent.InsertChild(b, -1)
+	parent.InsertChild(c, 1)
+
+	if c.Parent != parent {
+		t.Error("InsertChild should set Parent")
+	}
+	want := []*GameObject{a, c, b}
+	for i, child := range want {
+		if parent.Children[i] != child {
+			t.Fatalf("Children[%d] = %s, want %s", i, parent.Children[i].Name, child.Name)
+		}
+	}
+	if idx := parent.ChildIndex(b); idx != 2 {
+		t.Errorf("ChildIndex(B) = %d, want 2", idx)
+	}
+	if idx := parent.ChildIndex(parent); idx != -1 {
+		t.Errorf("ChildIndex of a non-child = %d, want -1", idx)
+	}
+	if !c.IsDescendantOf(parent) || parent.IsDescendantOf(c) {
+		t.Error("IsDescendantOf gave the wrong answer")
+	}
+}
+
+func TestSetWorldPositionInvertsParentTransform(t *testing.T) {
+	parent := NewGameObject("Parent")
+	parent.Transform.Position = rl.Vector3{X: 5, Y: 2, Z: -3}
+	parent.Transform.Rotation = rl.Vector3{X: 0, Y: 90, Z: 30}
+	parent.Transform.Scale = rl.Vector3{X: 2, Y: 1, Z: 0.5}
+	child := NewGameObject("Child")
+	child.Transform.Scale = rl.Vector3{X: 1, Y: 1, Z: 1}
+	parent.AddChild(child)
+
+	want := rl.Vector3{X: 1, Y: 4, Z: 7}
+	child.SetWorldPosition(want)
+	if got := child.WorldPosition(); rl.Vector3Distance(got, want) > 1e-4 {
+		t.Errorf("WorldPosition after SetWorldPosition = %v, want %v", got, want)
+	}
+
+	wantRot := rl.Vector3{X: 10, Y: 100, Z: 40}
+	child.SetWorldRotation(wantRot)
+	forward := rl.Vector3{X: 0.3, Y: -0.2, Z: 1}
+	gotDir := rl.Vector3Transform(forward, child.WorldRotationMatrix())
+	wantDir := rl.Vector3Transform(forward, eulerMatrix(wantRot))
+	if rl.Vector3Distance(gotDir, wantDir) > 1e-4 {
+		t.Errorf("direction after SetWorldRotation = %v, want %v", gotDir, wantDir)
+	}
+}
+
+// eulerMatrix builds a rotation matrix the way renderers do from Euler angles
+func eulerMatrix(rot rl.Vector3) rl.Matrix {
+	return rl.MatrixMultiply(rl.MatrixMultiply(
+		rl.MatrixRotateX(rot.X*rl.Deg2rad),
+		rl.MatrixRotateY(rot.Y*rl.Deg2rad)),
+		rl.MatrixRotateZ(rot.Z*rl.Deg2rad))
+}
+
+func TestWorldRotationAppliesChildBeforeParent(t *testing.T) {
+	parent := NewGameObject("Parent")
+	parent.Transform.Rotation = rl.Vector3{Y: 90}
+	child := NewGameObject("Child")
+	child.Transform.Position = rl.Vector3{Z: 1}
+	child.Transform.Rotation = rl.Vector3{X: 90}
+	parent.AddChild(child)
+
+	// The child tips its up axis onto +Z, then the parent turns +Z onto +X
+	up := rl.Vector3Transform(rl.Vector3{Y: 1}, child.WorldRotationMatrix())
+	if rl.Vector3Distance(up, rl.Vector3{X: 1}) > 1e-4 {
+		t.Errorf("child up = %v, want (1, 0, 0)", up)
+	}
+	if pos := child.WorldPosition(); rl.Vector3Distance(pos, rl.Vector3{X: 1}) > 1e-4 {
+		t.Errorf("child WorldPosition = %v, want (1, 0, 0)", pos)
+	}
+
+	// The Euler angles describe the same rotation as the quaternion
+	fromEuler := rl.Vector3Transform(rl.Vector3{Y: 1}, eulerMatrix(child.WorldRotation()))
+	if rl.Vector3Distance(fromEuler, up) > 1e-4 {
+		t.Errorf("WorldRotation() gives up = %v, want %v", fromEuler, up)
+	}
+}
+
+func TestTransformQuaternionFollowsRotationEdits(t *testing.T) {
+	g := NewGameObject("Box")
+	g.Transform.GetQuaternion()
+
+	// Assigned directly, without MarkRotationDirty, as the inspector does
+	g.Transform.Rotation = rl.Vector3{Y: 90}
+	got := rl.Vector3RotateByQuaternion(rl.Vector3{X: 1}, g.Transform.GetQuaternion())
+	if rl.Vector3Distance(got, rl.Vector3{Z: -1}) > 1e-4 {
+		t.Errorf("rotated +X = %v, want (0, 0, -1)", got)
+	}
+
+	g.Transform.SetQuaternion(rl.QuaternionFromAxisAngle(rl.Vector3{X: 1}, rl.Pi/2))
+	if rl.Vector3Distance(g.Transform.Rotation, rl.Vector3{X: 90}) > 1e-3 {
+		t.Errorf("Rotation after SetQuaternion = %v, want (90, 0, 0)", g.Transform.Rotation)
+	}
+}
+
+func TestOnCollisionWithTag(t *testing.T) {
+	player := NewGameObject("Player")
+	enemy := NewGameObject("Enemy")
+	enemy.Tags = []string{"Enemy"}
+	wall := NewGameObject("Wall")
+
+	var hits []*GameObject
+	OnCollisionWithTag(player, "Enemy", func(other *GameObject) {
+		hits = append(hits, other)
+	})
+
+	player.NotifyTagCollision(wall)
+	player.NotifyTagCollision(enemy)
+
+	if len(hits) != 1 || hits[0] != enemy {
+		t.Errorf("Expected one callback for the Enemy, got %v", hits)
+	}
+
+	// Unsubscribing from inside the callback stops later collisions
+	var once int
+	var unsubscribe func()
+	unsubscribe = OnCollisionWithTag(player, "Enemy", func(other *GameObject) {
+		once++
+		unsubscribe()
+	})
+	player.NotifyTagCollision(enemy)
+	player.NotifyTagCollision(enemy)
+	unsubscribe()
+
+	if len(hits) != 3 || once != 1 {
+		t.Errorf("Expected 3 hits and 1 unsubscribed hit, got %d and %d", len(hits), once)
+	}
+}
@@ -0,0 +1,109 @@
+package engine
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// InputBackend is where runtime input comes from. The default reads the real
+// keyboard, mouse and gamepads through raylib; tests and replays swap in a
+// RecordedInput with SetInputBackend.
+type InputBackend interface {
+	IsKeyDown(key int32) bool
+	IsKeyPressed(key int32) bool
+	IsKeyReleased(key int32) bool
+	IsMouseButtonDown(button rl.MouseButton) bool
+	IsMouseButtonPressed(button rl.MouseButton) bool
+	IsMouseButtonReleased(button rl.MouseButton) bool
+	GetMousePosition() rl.Vector2
+	GetMouseDelta() rl.Vector2
+	GetMouseWheelMove() float32
+	IsGamepadAvailable(gamepad int32) bool
+	IsGamepadButtonDown(gamepad, button int32) bool
+	IsGamepadButtonPressed(gamepad, button int32) bool
+	GetGamepadAxisMovement(gamepad, axis int32) float32
+}
+
+// InputLayer forwards to the current backend. Scripts and runtime components
+// read input through Input instead of calling raylib directly:
+//
+//	if engine.Input.IsKeyPressed(rl.KeySpace) {
+//	    jump()
+//	}
+type InputLayer struct {
+	backend InputBackend
+}
+
+// Input is the input layer used by scripts and runtime components
+var Input = &InputLayer{backend: raylibInput{}}
+
+// SetInputBackend replaces where input comes from. Pass nil to go back to
+// the real devices.
+func SetInputBackend(b InputBackend) {
+	if b == nil {
+		b = raylibInput{}
+	}
+	Input.backend = b
+}
+
+// AdvanceInput moves frame-based backends (like RecordedInput) to the next
+// frame. The game loop calls it once per frame.
+func AdvanceInput() {
+	if a, ok := Input.backend.(interface{ Advance() }); ok {
+		a.Advance()
+	}
+}
+
+func (in *InputLayer) IsKeyDown(key int32) bool     { return in.backend.IsKeyDown(key) }
+func (in *InputLayer) IsKeyPressed(key int32) bool  { return in.backend.IsKeyPressed(key) }
+func (in *InputLayer) IsKeyReleased(key int32) bool { return in.backend.IsKeyReleased(key) }
+func (in *InputLayer) IsMouseButtonDown(button rl.MouseButton) bool {
+	return in.backend.IsMouseButtonDown(button)
+}
+func (in *InputLayer) IsMouseButtonPressed(button rl.MouseButton) bool {
+	return in.backend.IsMouseButtonPressed(button)
+}
+func (in *InputLayer) IsMouseButtonReleased(button rl.MouseButton) bool {
+	return in.backend.IsMouseButtonReleased(button)
+}
+func (in *InputLayer) GetMousePosition() rl.Vector2 { return in.backend.GetMousePosition() }
+func (in *InputLayer) GetMouseDelta() rl.Vector2    { return in.backend.GetMouseDelta() }
+func (in *InputLayer) GetMouseWheelMove() float32   { return in.backend.GetMouseWheelMove() }
+func (in *InputLayer) IsGamepadAvailable(gamepad int32) bool {
+	return in.backend.IsGamepadAvailable(gamepad)
+}
+func (in *InputLayer) IsGamepadButtonDown(gamepad, button int32) bool {
+	return in.backend.IsGamepadButtonDown(gamepad, button)
+}
+func (in *InputLayer) IsGamepadButtonPressed(gamepad, button int32) bool {
+	return in.backend.IsGamepadButtonPressed(gamepad, button)
+}
+func (in *InputLayer) GetGamepadAxisMovement(gamepad, axis int32) float32 {
+	return in.backend.GetGamepadAxisMovement(gamepad, axis)
+}
+
+// raylibInput reads the real devices
+type raylibInput struct{}
+
+func (raylibInput) IsKeyDown(key int32) bool     { return rl.IsKeyDown(key) }
+func (raylibInput) IsKeyPressed(key int32) bool  { return rl.IsKeyPressed(key) }
+func (raylibInput) IsKeyReleased(key int32) bool { return rl.IsKeyReleased(key) }
+func (raylibInput) IsMouseButtonDown(button rl.MouseButton) bool {
+	return rl.IsMouseButtonDown(button)
+}
+func (raylibInput) IsMouseButtonPressed(button rl.MouseButton) bool {
+	return rl.IsMouseButtonPressed(button)
+}
+func (raylibInput) IsMouseButtonReleased(button rl.MouseButton) bool {
+	return rl.IsMouseButtonReleased(button)
+}
+func (raylibInput) GetMousePosition() rl.Vector2          { return rl.GetMousePosition() }
+func (raylibInput) GetMouseDelta() rl.Vector2             { return rl.GetMouseDelta() }
+func (raylibInput) GetMouseWheelMove() float32            { return rl.GetMouseWheelMove() }
+func (raylibInput) IsGamepadAvailable(gamepad int32) bool { return rl.IsGamepadAvailable(gamepad) }
+func (raylibInput) IsGamepadButtonDown(gamepad, button int32) bool {
+	return rl.IsGamepadButtonDown(gamepad, button)
+}
+func (raylibInput) IsGamepadButtonPressed(gamepad, button int32) bool {
+	return rl.IsGamepadButtonPressed(gamepad, button)
+}
+func (raylibInput) GetGamepadAxisMovement(gamepad, axis int32) float32 {
+	return rl.GetGamepadAxisMovement(gamepad, axis)
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// InputFrame is the state of every input device during one frame
+type InputFrame struct {
+	Keys           []int32          `json:"keys,omitempty"`         // keys held down
+	MouseButtons   []rl.MouseButton `json:"mouseButtons,omitempty"` // mouse buttons held down
+	MousePosition  rl.Vector2       `json:"mousePosition"`
+	MouseDelta     rl.Vector2       `json:"mouseDelta"`
+	MouseWheel     float32          `json:"mouseWheel,omitempty"`
+	GamepadButtons []int32          `json:"gamepadButtons,omitempty"` // gamepad 0 buttons held down
+	GamepadAxes    []float32        `json:"gamepadAxes,omitempty"`    // gamepad 0 axes, by axis index
+}
+
+// RecordedInput plays back a fixed list of frames, one per AdvanceInput call,
+// for deterministic tests and replays. Pressed/released are derived from the
+// previous frame; after the last frame everything reads as released.
+type RecordedInput struct {
+	Gamepad bool         `json:"gamepad,omitempty"` // report gamepad 0 as connected
+	Frames  []InputFrame `json:"frames"`
+
+	frame int
+}
+
+// NewRecordedInput creates a playback backend starting at the first frame
+func NewRecordedInput(frames ...InputFrame) *RecordedInput {
+	return &RecordedInput{Frames: frames}
+}
+
+// LoadRecordedInput reads a replay file ({"gamepad": bool, "frames": [...]})
+func LoadRecordedInput(path string) (*RecordedInput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r RecordedInput
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Advance moves playback to the next frame
+func (r *RecordedInput) Advance() {
+	r.frame++
+}
+
+// Frame returns the index of the frame being played
+func (r *RecordedInput) Frame() int {
+	return r.frame
+}
+
+// Done reports whether every recorded frame has been played
+func (r *RecordedInput) Done() bool {
+	return r.frame >= len(r.Frames)
+}
+
+func (r *RecordedInput) at(i int) InputFrame {
+	if i < 0 || i >= len(r.Frames) {
+		return InputFrame{}
+	}
+	return r.Frames[i]
+}
+
+func (r *RecordedInput) cur() InputFrame  { return r.at(r.frame) }
+func (r *RecordedInput) prev() InputFrame { return r.at(r.frame - 1) }
+
+func (r *RecordedInput) IsKeyDown(key int32) bool {
+	return slices.Contains(r.cur().Keys, key)
+}
+
+func (r *RecordedInput) IsKeyPressed(key int32) bool {
+	return slices.Contains(r.cur().Keys, key) && !slices.Contains(r.prev().Keys, key)
+}
+
+func (r *RecordedInput) IsKeyReleased(key int32) bool {
+	return !slices.Contains(r.cur().Keys, key) && slices.Contains(r.prev().Keys, key)
+}
+
+func (r *RecordedInput) IsMouseButtonDown(button rl.MouseButton) bool {
+	return slices.Contains(r.cur().MouseButtons, button)
+}
+
+func (r *RecordedInput) IsMouseButtonPressed(button rl.MouseButton) bool {
+	return slices.Contains(r.cur().MouseButtons, button) && !slices.Contains(r.prev().MouseButtons, button)
+}
+
+func (r *RecordedInput) IsMouseButtonReleased(button rl.MouseButton) bool {
+	return !slices.Contains(r.cur().MouseButtons, button) && slices.Contains(r.prev().MouseButtons, button)
+}
+
+func (r *RecordedInput) GetMousePosition() rl.Vector2 {
+	if r.Done() && len(r.Frames) > 0 {
+		return r.Frames[len(r.Frames)-1].MousePosition // the mouse stays where it was
+	}
+	return r.cur().MousePosition
+}
+
+func (r *RecordedInput) GetMouseDelta() rl.Vector2  { return r.cur().MouseDelta }
+func (r *RecordedInput) GetMouseWheelMove() float32 { return r.cur().MouseWheel }
+
+func (r *RecordedInput) IsGamepadAvailable(gamepad int32) bool {
+	return gamepad == 0 && r.Gamepad
+}
+
+func (r *RecordedInput) IsGamepadButtonDown(gamepad, button int32) bool {
+	return gamepad == 0 && slices.Contains(r.cur().GamepadButtons, button)
+}
+
+func (r *RecordedInput) IsGamepadButtonPressed(gamepad, button int32) bool {
+	return gamepad == 0 && slices.Contains(r.cur().GamepadButtons, button) &&
+		!slices.Contains(r.prev().GamepadButtons, button)
+}
+
+func (r *RecordedInput) GetGamepadAxisMovement(gamepad, axis int32) float32 {
+	axes := r.cur().GamepadAxes
+	if gamepad != 0 || axis < 0 || int(axis) >= len(axes) {
+		return 0
+	}
+	return axes[axis]
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestRecordedInputPressedAndReleased(t *testing.T) {
+	r := NewRecordedInput(
+		InputFrame{Keys: []int32{rl.KeySpace}},
+		InputFrame{Keys: []int32{rl.KeySpace}},
+		InputFrame{},
+	)
+	SetInputBackend(r)
+	defer SetInputBackend(nil)
+
+	if !Input.IsKeyPressed(rl.KeySpace) || !Input.IsKeyDown(rl.KeySpace) {
+		t.Error("Space should be pressed and down on the first frame")
+	}
+
+	AdvanceInput()
+	if Input.IsKeyPressed(rl.KeySpace) || !Input.IsKeyDown(rl.KeySpace) {
+		t.Error("Space should be held, not pressed again, on the second frame")
+	}
+
+	AdvanceInput()
+	if Input.IsKeyDown(rl.KeySpace) || !Input.IsKeyReleased(rl.KeySpace) {
+		t.Error("Space should be released on the third frame")
+	}
+
+	AdvanceInput()
+	if !r.Done() || Input.IsKeyReleased(rl.KeySpace) {
+		t.Error("Playback should be done with nothing held")
+	}
+}
+
+func TestRecordedInputMouseAndGamepad(t *testing.T) {
+	r := NewRecordedInput(
+		InputFrame{
+			MouseButtons:   []rl.MouseButton{rl.MouseLeftButton},
+			MousePosition:  rl.Vector2{X: 10, Y: 20},
+			GamepadButtons: []int32{rl.GamepadButtonRightFaceDown},
+			GamepadAxes:    []float32{0.5},
+		},
+	)
+	r.Gamepad = true
+	SetInputBackend(r)
+	defer SetInputBackend(nil)
+
+	if !Input.IsMouseButtonPressed(rl.MouseLeftButton) {
+		t.Error("Left mouse should be pressed")
+	}
+	if pos := Input.GetMousePosition(); pos.X != 10 || pos.Y != 20 {
+		t.Errorf("Expected mouse at (10, 20), got %v", pos)
+	}
+	if !Input.IsGamepadAvailable(0) || Input.IsGamepadAvailable(1) {
+		t.Error("Only gamepad 0 should be available")
+	}
+	if !Input.IsGamepadButtonPressed(0, rl.GamepadButtonRightFaceDown) {
+		t.Error("Gamepad A should be pressed")
+	}
+	if got := Input.GetGamepadAxisMovement(0, 0); got != 0.5 {
+		t.Errorf("Expected axis 0 at 0.5, got %v", got)
+	}
+
+	AdvanceInput()
+	if pos := Input.GetMousePosition(); pos.X != 10 {
+		t.Errorf("Mouse should stay at its last position after playback, got %v", pos)
+	}
+}
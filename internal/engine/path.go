@@ -0,0 +1,284 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Property paths name a value on a GameObject for tooling and tests. Segments
+// are separated by dots, with indexes in brackets, or JSON-pointer style with
+// slashes:
+//
+//	Transform.Position.Y          /Transform/Position/Y
+//	components[0].Speed           /components/0/Speed
+//	components[Rotator].Speed     Rotator.Speed
+//	Tags[1]                       Name, Active, ...
+//
+// A component is picked by index or by type name (the scene file's "type" or
+// the script name). Script properties go through the script's generated
+// applier, so "Shooter.fire_rate" sets what the inspector would. Field names
+// match case-insensitively.
+
+// GetByPath returns the value at path on g
+func GetByPath(g *GameObject, path string) (any, error) {
+	segs, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if c, rest, ok, err := pathComponent(g, segs); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) == 0 {
+			return c, nil
+		}
+		if v, ok := scriptProperty(c, rest); ok {
+			return v, nil
+		}
+		v, err := walkPath(reflect.ValueOf(c), rest, path)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	v, err := walkPath(reflect.ValueOf(g), segs, path)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// SetByPath sets the value at path on g, converting numbers between kinds
+func SetByPath(g *GameObject, path string, value any) error {
+	segs, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if c, rest, ok, err := pathComponent(g, segs); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		if len(rest) == 0 {
+			return fmt.Errorf("%s: can't replace a component", path)
+		}
+		if _, ok := scriptProperty(c, rest); ok {
+			key := scriptPropertyKey(c, rest[0])
+			if !ApplyScriptProperty(c, key, scriptValue(value)) {
+				return fmt.Errorf("%s: script rejected %v", path, value)
+			}
+			return nil
+		}
+		return setValue(reflect.ValueOf(c), rest, path, value)
+	}
+	if err := setValue(reflect.ValueOf(g), segs, path, value); err != nil {
+		return err
+	}
+	if strings.EqualFold(segs[0], "Transform") {
+		g.Transform.MarkRotationDirty()
+	}
+	return nil
+}
+
+// Get returns the value at a property path (see GetByPath)
+func (g *GameObject) Get(path string) (any, error) {
+	return GetByPath(g, path)
+}
+
+// Set sets the value at a property path (see SetByPath)
+func (g *GameObject) Set(path string, value any) error {
+	return SetByPath(g, path, value)
+}
+
+// splitPath breaks a dotted or slash path into segments, with bracketed
+// indexes as segments of their own
+func splitPath(path string) ([]string, error) {
+	var segs []string
+	if strings.HasPrefix(path, "/") {
+		for _, s := range strings.Split(path[1:], "/") {
+			// JSON pointer escapes
+			s = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+			segs = append(segs, s)
+		}
+	} else {
+		for _, part := range strings.Split(path, ".") {
+			for {
+				open := strings.IndexByte(part, '[')
+				if open < 0 {
+					segs = append(segs, part)
+					break
+				}
+				end := strings.IndexByte(part, ']')
+				if end < open {
+					return nil, fmt.Errorf("%s: unbalanced brackets", path)
+				}
+				if open > 0 {
+					segs = append(segs, part[:open])
+				}
+				segs = append(segs, part[open+1:end])
+				part = part[end+1:]
+				if part == "" {
+					break
+				}
+			}
+		}
+	}
+	for _, s := range segs {
+		if s == "" {
+			return nil, fmt.Errorf("%q: empty path segment", path)
+		}
+	}
+	return segs, nil
+}
+
+// pathComponent resolves a leading "components[i]" or component type name.
+// ok is false when the path doesn't start at a component.
+func pathComponent(g *GameObject, segs []string) (c Component, rest []string, ok bool, err error) {
+	if strings.EqualFold(segs[0], "components") {
+		if len(segs) < 2 {
+			return nil, nil, false, fmt.Errorf("components: missing index or type")
+		}
+		c, err := findPathComponent(g, segs[1])
+		return c, segs[2:], true, err
+	}
+	// A GameObject field wins over a component type of the same name
+	if _, found := reflect.TypeOf(GameObject{}).FieldByNameFunc(func(n string) bool {
+		return strings.EqualFold(n, segs[0])
+	}); found {
+		return nil, nil, false, nil
+	}
+	if c, err := findPathComponent(g, segs[0]); err == nil {
+		return c, segs[1:], true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// findPathComponent finds a component of g by index or type name
+func findPathComponent(g *GameObject, sel string) (Component, error) {
+	if i, err := strconv.Atoi(sel); err == nil {
+		if i < 0 || i >= len(g.components) {
+			return nil, fmt.Errorf("components[%d]: %s has %d components", i, g.Name, len(g.components))
+		}
+		return g.components[i], nil
+	}
+	for _, c := range g.components {
+		if strings.EqualFold(componentTypeName(c), sel) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("components[%s]: %s has no such component", sel, g.Name)
+}
+
+// componentTypeName returns the name a component is saved under
+func componentTypeName(c Component) string {
+	if s, ok := c.(Serializable); ok {
+		return s.TypeName()
+	}
+	if name, _, ok := SerializeScript(c); ok {
+		return name
+	}
+	return reflect.TypeOf(c).Elem().Name()
+}
+
+// scriptProperty returns a script's serialized property when rest names one
+func scriptProperty(c Component, rest []string) (any, bool) {
+	if len(rest) != 1 {
+		return nil, false
+	}
+	_, props, ok := SerializeScript(c)
+	if !ok {
+		return nil, false
+	}
+	v, ok := props[scriptPropertyKey(c, rest[0])]
+	return v, ok
+}
+
+// scriptPropertyKey returns the script's own spelling of a property name
+func scriptPropertyKey(c Component, name string) string {
+	_, props, _ := SerializeScript(c)
+	if _, ok := props[name]; ok {
+		return name
+	}
+	for k := range props {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
+
+// scriptValue converts numbers to float64, the way script appliers receive
+// them from JSON
+func scriptValue(value any) any {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return value
+}
+
+// walkPath follows segs through struct fields and slice/array indexes
+func walkPath(v reflect.Value, segs []string, path string) (reflect.Value, error) {
+	for _, seg := range segs {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("%s: nil before %q", path, seg)
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			f := v.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, seg) })
+			sf, _ := v.Type().FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, seg) })
+			if !f.IsValid() || !sf.IsExported() {
+				return reflect.Value{}, fmt.Errorf("%s: %s has no field %q", path, v.Type().Name(), seg)
+			}
+			v = f
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= v.Len() {
+				return reflect.Value{}, fmt.Errorf("%s: bad index %q (length %d)", path, seg, v.Len())
+			}
+			v = v.Index(i)
+		default:
+			return reflect.Value{}, fmt.Errorf("%s: can't index %s with %q", path, v.Type(), seg)
+		}
+	}
+	return v, nil
+}
+
+// setValue assigns value to the field at segs under root
+func setValue(root reflect.Value, segs []string, path string, value any) error {
+	field, err := walkPath(root, segs, path)
+	if err != nil {
+		return err
+	}
+	if !field.CanSet() {
+		return fmt.Errorf("%s: not settable", path)
+	}
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+	case isNumber(v.Kind()) && isNumber(field.Kind()):
+		field.Set(v.Convert(field.Type()))
+	default:
+		return fmt.Errorf("%s: can't set %s to %T", path, field.Type(), value)
+	}
+	return nil
+}
+
+// isNumber reports whether k is an integer or float kind
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// pathSpinner is a Serializable component for path tests
+type pathSpinner struct {
+	BaseComponent
+	Speed rl.Vector3
+	Laps  int
+}
+
+func (s *pathSpinner) TypeName() string                { return "Spinner" }
+func (s *pathSpinner) Serialize() map[string]any       { return map[string]any{"type": "Spinner"} }
+func (s *pathSpinner) Deserialize(data map[string]any) {}
+
+func newPathObject() (*GameObject, *pathSpinner, *MockScript) {
+	scriptRegistry = map[string]scriptEntry{}
+	RegisterScriptWithApplier("MockScript", mockFactory, mockSerializer, mockApplier)
+
+	g := NewGameObject("Target")
+	g.Tags = []string{"a", "b"}
+	spinner := &pathSpinner{}
+	script := &MockScript{Speed: 1}
+	g.AddComponent(spinner)
+	g.AddComponent(script)
+	return g, spinner, script
+}
+
+func TestSetByPathTransform(t *testing.T) {
+	g, _, _ := newPathObject()
+
+	for _, path := range []string{"Transform.Position.Y", "/Transform/Position/Y", "transform.position.y"} {
+		g.Transform.Position.Y = 0
+		if err := g.Set(path, 5); err != nil {
+			t.Fatalf("Set(%q): %v", path, err)
+		}
+		if g.Transform.Position.Y != 5 {
+			t.Errorf("Set(%q): Y = %v, want 5", path, g.Transform.Position.Y)
+		}
+	}
+
+	if err := g.Set("Transform.Scale", rl.Vector3{X: 2, Y: 2, Z: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := g.Get("Transform.Scale.Z"); err != nil || v != float32(2) {
+		t.Errorf("Get(Transform.Scale.Z) = %v, %v; want 2", v, err)
+	}
+}
+
+func TestSetByPathComponents(t *testing.T) {
+	g, spinner, script := newPathObject()
+
+	if err := g.Set("components[0].Speed.Y", 90.0); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Set("Spinner.laps", 3); err != nil {
+		t.Fatal(err)
+	}
+	if spinner.Speed.Y != 90 || spinner.Laps != 3 {
+		t.Errorf("spinner = %+v, want Speed.Y 90 and Laps 3", spinner)
+	}
+
+	// Script properties go through the applier under their serialized names
+	if err := g.Set("components[MockScript].speed", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Set("/components/1/health", 7); err != nil {
+		t.Fatal(err)
+	}
+	if script.Speed != 2 || script.Health != 7 {
+		t.Errorf("script = %+v, want Speed 2 and Health 7", script)
+	}
+	if v, err := g.Get("MockScript.health"); err != nil || v != 7 {
+		t.Errorf("Get(MockScript.health) = %v, %v; want 7", v, err)
+	}
+}
+
+func TestGetByPathFieldsAndErrors(t *testing.T) {
+	g, _, _ := newPathObject()
+
+	if v, err := g.Get("Tags[1]"); err != nil || v != "b" {
+		t.Errorf("Get(Tags[1]) = %v, %v; want b", v, err)
+	}
+	if v, err := g.Get("Name"); err != nil || v != "Target" {
+		t.Errorf("Get(Name) = %v, %v; want Target", v, err)
+	}
+
+	for _, path := range []string{
+		"Transform.Position.W",
+		"components[5].Speed",
+		"components[Missing].Speed",
+		"Tags[9]",
+		"Transform..X",
+		"started",
+	} {
+		if _, err := g.Get(path); err == nil {
+			t.Errorf("Get(%q) succeeded, want an error", path)
+		}
+	}
+	if err := g.Set("Name", 3); err == nil {
+		t.Error("Set(Name, 3) succeeded, want a type error")
+	}
+}
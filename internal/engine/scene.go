@@ -1,5 +1,7 @@
 package engine
 
+import "slices"
+
 type Scene struct {
 	Name        string
 	World       WorldAccess
@@ -43,7 +45,11 @@ func (s *Scene) RemoveGameObject(g *GameObject) {
 		}
 	}
 
-	// Recursively remove children from flat list and UID map
+	// Recursively remove descendants from flat list and UID map
+	s.removeDescendants(g)
+}
+
+func (s *Scene) removeDescendants(g *GameObject) {
 	for _, child := range g.Children {
 		delete(s.uidMap, child.UID)
 		for i, obj := range s.GameObjects {
@@ -52,7 +58,39 @@ func (s *Scene) RemoveGameObject(g *GameObject) {
 				break
 			}
 		}
+		s.removeDescendants(child)
+	}
+}
+
+// IndexOf returns the position of g in GameObjects, or -1
+func (s *Scene) IndexOf(g *GameObject) int {
+	return slices.Index(s.GameObjects, g)
+}
+
+// MoveGameObject moves g and its descendants, as one block with g first, so
+// that g lands at index in GameObjects. The index counts only the objects
+// outside the block; a negative or out-of-range index moves it to the end.
+func (s *Scene) MoveGameObject(g *GameObject, index int) {
+	block := []*GameObject{g}
+	rest := make([]*GameObject, 0, len(s.GameObjects))
+	found := false
+	for _, obj := range s.GameObjects {
+		switch {
+		case obj == g:
+			found = true
+		case obj.IsDescendantOf(g):
+			block = append(block, obj)
+		default:
+			rest = append(rest, obj)
+		}
+	}
+	if !found {
+		return
+	}
+	if index < 0 || index > len(rest) {
+		index = len(rest)
 	}
+	s.GameObjects = slices.Concat(rest[:index], block, rest[index:])
 }
 
 func (s *Scene) FindByName(name string) *GameObject {
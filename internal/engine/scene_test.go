@@ -156,3 +156,30 @@ func TestSceneUIDMapInitialization(t *testing.T) {
 		t.Error("uidMap should be initialized on first AddGameObject")
 	}
 }
+
+func TestSceneMoveGameObjectKeepsChildrenTogether(t *testing.T) {
+	scene := NewScene("Test")
+	a := NewGameObject("A")
+	b := NewGameObject("B")
+	child := NewGameObject("BChild")
+	c := NewGameObject("C")
+	b.AddChild(child)
+	for _, g := range []*GameObject{a, b, c, child} {
+		scene.AddGameObject(g)
+	}
+
+	// Move B (and its child) to the front
+	scene.MoveGameObject(b, 0)
+	want := []string{"B", "BChild", "A", "C"}
+	for i, name := range want {
+		if scene.GameObjects[i].Name != name {
+			t.Fatalf("GameObjects[%d] = %s, want %s", i, scene.GameObjects[i].Name, name)
+		}
+	}
+
+	// Out-of-range index moves to the end
+	scene.MoveGameObject(a, 99)
+	if scene.IndexOf(a) != 3 {
+		t.Errorf("IndexOf(A) = %d, want 3", scene.IndexOf(a))
+	}
+}
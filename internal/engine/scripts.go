@@ -1,6 +1,10 @@
 package engine
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // ScriptFactory creates a Component from JSON props.
 type ScriptFactory func(props map[string]any) Component
@@ -16,7 +20,7 @@ type scriptEntry struct {
 	factory    ScriptFactory
 	serializer ScriptSerializer
 	applier    ScriptApplier
-	fieldTypes map[string]string // Map of field name -> type (e.g., "target_button" -> "GameObjectRef")
+	fieldTypes map[string]string // Map of field name -> type (e.g., "target_button" -> "GameObjectRef", "speed" -> "range:0,10", "state" -> "options:idle,chase")
 }
 
 var scriptRegistry = map[string]scriptEntry{}
@@ -131,3 +135,32 @@ func GetScriptFieldType(c Component, fieldName string) string {
 	}
 	return ""
 }
+
+// GetScriptFieldRange returns the inspector bounds of a script field tagged
+// with `mirgo:"range:min,max"`. ok is false if the field has no range.
+func GetScriptFieldRange(c Component, fieldName string) (minVal, maxVal float32, ok bool) {
+	spec, found := strings.CutPrefix(GetScriptFieldType(c, fieldName), "range:")
+	if !found {
+		return 0, 0, false
+	}
+	lo, hi, found := strings.Cut(spec, ",")
+	if !found {
+		return 0, 0, false
+	}
+	minF, err1 := strconv.ParseFloat(lo, 32)
+	maxF, err2 := strconv.ParseFloat(hi, 32)
+	if err1 != nil || err2 != nil || minF >= maxF {
+		return 0, 0, false
+	}
+	return float32(minF), float32(maxF), true
+}
+
+// GetScriptFieldOptions returns the allowed values of a string script field
+// marked with a `// @options: a,b,c` comment, or nil if any string is allowed
+func GetScriptFieldOptions(c Component, fieldName string) []string {
+	spec, found := strings.CutPrefix(GetScriptFieldType(c, fieldName), "options:")
+	if !found || spec == "" {
+		return nil
+	}
+	return strings.Split(spec, ",")
+}
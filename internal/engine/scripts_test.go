@@ -250,3 +250,133 @@ func TestGetScriptFieldType(t *testing.T) {
 		t.Errorf("Expected empty string for non-existent field, got '%s'", emptyType)
 	}
 }
+
+func TestGetScriptFieldRange(t *testing.T) {
+	// Clear registry for clean test
+	scriptRegistry = map[string]scriptEntry{}
+
+	fieldTypes := map[string]string{
+		"target_ref": "GameObjectRef",
+		"speed":      "range:0,12.5",
+		"broken":     "range:5,1",
+	}
+
+	RegisterScriptWithMetadata("MockScript", mockFactory, mockSerializer, mockApplier, fieldTypes)
+
+	script := &MockScript{}
+
+	minVal, maxVal, ok := GetScriptFieldRange(script, "speed")
+	if !ok || minVal != 0 || maxVal != 12.5 {
+		t.Errorf("Expected range 0..12.5, got %v..%v (ok=%v)", minVal, maxVal, ok)
+	}
+
+	for _, field := range []string{"target_ref", "broken", "nonexistent"} {
+		if _, _, ok := GetScriptFieldRange(script, field); ok {
+			t.Errorf("Expected no range for %q", field)
+		}
+	}
+}
+
+func TestGetScriptFieldOptions(t *testing.T) {
+	// Clear registry for clean test
+	scriptRegistry = map[string]scriptEntry{}
+
+	fieldTypes := map[string]string{
+		"state": "options:idle,patrol,chase",
+		"speed": "range:0,10",
+	}
+
+	RegisterScriptWithMetadata("MockScript", mockFactory, mockSerializer, mockApplier, fieldTypes)
+
+	script := &MockScript{}
+
+	options := GetScriptFieldOptions(script, "state")
+	if len(options) != 3 || options[0] != "idle" || options[2] != "chase" {
+		t.Errorf("Expected [idle patrol chase], got %v", options)
+	}
+	for _, field := range []string{"speed", "nonexistent"} {
+		if options := GetScriptFieldOptions(script, field); options != nil {
+			t.Errorf("Expected no options for %q, got %v", field, options)
+		}
+	}
+}
+
+// testMover moves its object along X and can panic on a given frame
+type testMover struct {
+	BaseComponent
+	Speed   float32
+	PanicAt int
+	frames  int
+}
+
+func (m *testMover) Update(deltaTime float32) {
+	m.frames++
+	if m.frames == m.PanicAt {
+		panic("boom")
+	}
+	m.GetGameObject().Transform.Position.X += m.Speed * deltaTime
+}
+
+func registerTestMover() {
+	scriptRegistry = map[string]scriptEntry{}
+	RegisterScript("TestMover", func(props map[string]any) Component {
+		m := &testMover{}
+		if v, ok := props["speed"].(float64); ok {
+			m.Speed = float32(v)
+		}
+		if v, ok := props["panicAt"].(float64); ok {
+			m.PanicAt = int(v)
+		}
+		return m
+	}, func(c Component) map[string]any {
+		m, ok := c.(*testMover)
+		if !ok {
+			return nil
+		}
+		return map[string]any{"speed": m.Speed, "panicAt": m.PanicAt}
+	})
+}
+
+func TestTestScriptReportsAndRestoresTransform(t *testing.T) {
+	registerTestMover()
+
+	g := NewGameObject("Mover")
+	script := &testMover{Speed: 2}
+	g.AddComponent(script)
+
+	result, err := TestScript(script, 10, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Panic != nil || result.Frames != 10 {
+		t.Fatalf("frames = %d, panic = %v; want 10 frames without panic", result.Frames, result.Panic)
+	}
+	if result.After.Position.X != 10 {
+		t.Errorf("after X = %v, want 10", result.After.Position.X)
+	}
+	if g.Transform.Position.X != 0 {
+		t.Errorf("object X = %v after the test, want it restored to 0", g.Transform.Position.X)
+	}
+	if script.frames != 0 {
+		t.Errorf("the attached script ran %d frames, want the test to use a copy", script.frames)
+	}
+}
+
+func TestTestScriptRecoversPanic(t *testing.T) {
+	registerTestMover()
+
+	g := NewGameObject("Mover")
+	script := &testMover{Speed: 1, PanicAt: 4}
+	g.AddComponent(script)
+
+	result, err := TestScript(script, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Panic != "boom" || result.Frames != 3 {
+		t.Errorf("frames = %d, panic = %v; want a boom after 3 frames", result.Frames, result.Panic)
+	}
+	if result.After.Position.X != 3 || g.Transform.Position.X != 0 {
+		t.Errorf("after X = %v, object X = %v; want 3 and 0", result.After.Position.X, g.Transform.Position.X)
+	}
+}
@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+)
+
+// ScriptTestResult reports what TestScript saw
+type ScriptTestResult struct {
+	Name   string    // script name
+	Frames int       // Update calls that completed
+	Before Transform // object transform before the run
+	After  Transform // object transform after the last completed Update
+	Panic  any       // recovered panic value (nil if the run finished)
+	Stack  string    // stack trace of the panic
+}
+
+// TestScript runs a fresh copy of script c against c's GameObject: Start once,
+// then Update frames times with dt. The copy is never added to the object, and
+// the object's transform and tag callbacks are put back afterwards, so the
+// scene is left as it was apart from side effects on other objects.
+func TestScript(c Component, frames int, dt float32) (ScriptTestResult, error) {
+	g := c.GetGameObject()
+	if g == nil {
+		return ScriptTestResult{}, fmt.Errorf("script is not attached to an object")
+	}
+	name, props, ok := SerializeScript(c)
+	if !ok {
+		return ScriptTestResult{}, fmt.Errorf("not a registered script")
+	}
+
+	// Round-trip the props through JSON so the factory sees them the way it
+	// does when loading a scene
+	data, err := json.Marshal(props)
+	if err != nil {
+		return ScriptTestResult{}, fmt.Errorf("serialize %s: %w", name, err)
+	}
+	var loaded map[string]any
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return ScriptTestResult{}, fmt.Errorf("serialize %s: %w", name, err)
+	}
+	script := CreateScript(name, loaded)
+	if script == nil {
+		return ScriptTestResult{}, fmt.Errorf("script %q is not registered", name)
+	}
+	script.SetGameObject(g)
+
+	result := ScriptTestResult{Name: name, Before: g.Transform}
+	callbacks := len(g.tagCallbacks)
+	runScriptFrames(script, frames, dt, &result)
+
+	result.After = g.Transform
+	g.Transform = result.Before
+	g.tagCallbacks = g.tagCallbacks[:callbacks]
+	return result, nil
+}
+
+// runScriptFrames calls Start and then Update on script, recording a panic
+// instead of letting it take down the editor
+func runScriptFrames(script Component, frames int, dt float32, result *ScriptTestResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result.Panic = r
+			result.Stack = string(debug.Stack())
+		}
+	}()
+	script.Start()
+	for result.Frames < frames {
+		script.Update(dt)
+		result.Frames++
+	}
+}
@@ -0,0 +1,35 @@
+package engine
+
+// TimeState is the game clock. The game loop advances it once per play-mode
+// frame, before physics and scripts run, so scripts can read it anywhere:
+//
+//	if engine.Time.ElapsedTime-lastShot > cooldown {
+//	    shoot()
+//	}
+type TimeState struct {
+	DeltaTime         float32 // seconds since the last frame, scaled by Scale (what Update receives)
+	UnscaledDeltaTime float32 // real seconds since the last frame
+	ElapsedTime       float64 // scaled seconds since play started
+	FrameCount        uint64  // frames since play started
+	Scale             float32 // 1 = normal speed, 0.5 = slow motion, 0 = frozen
+}
+
+// Time is the game clock read by scripts
+var Time = &TimeState{Scale: 1}
+
+// AdvanceTime moves the clock forward by one frame of unscaled seconds and
+// returns the scaled delta to pass to Update
+func AdvanceTime(unscaledDelta float32) float32 {
+	t := Time
+	t.UnscaledDeltaTime = unscaledDelta
+	t.DeltaTime = unscaledDelta * max(t.Scale, 0)
+	t.ElapsedTime += float64(t.DeltaTime)
+	t.FrameCount++
+	return t.DeltaTime
+}
+
+// ResetTime restarts the clock at zero, keeping the time scale. Called when
+// play mode starts.
+func ResetTime() {
+	*Time = TimeState{Scale: Time.Scale}
+}
@@ -0,0 +1,33 @@
+package engine
+
+import "testing"
+
+func TestAdvanceTime(t *testing.T) {
+	ResetTime()
+	defer func() {
+		Time.Scale = 1
+		ResetTime()
+	}()
+
+	for i := 0; i < 4; i++ {
+		if dt := AdvanceTime(0.25); dt != 0.25 {
+			t.Fatalf("frame %d: expected delta 0.25, got %v", i, dt)
+		}
+	}
+	if Time.ElapsedTime != 1 || Time.FrameCount != 4 {
+		t.Errorf("expected 1s over 4 frames, got %vs over %d", Time.ElapsedTime, Time.FrameCount)
+	}
+
+	Time.Scale = 0.5
+	if dt := AdvanceTime(0.2); dt != 0.1 {
+		t.Errorf("expected scaled delta 0.1, got %v", dt)
+	}
+	if Time.UnscaledDeltaTime != 0.2 {
+		t.Errorf("expected unscaled delta 0.2, got %v", Time.UnscaledDeltaTime)
+	}
+
+	ResetTime()
+	if Time.ElapsedTime != 0 || Time.FrameCount != 0 || Time.Scale != 0.5 {
+		t.Errorf("reset should zero the clock and keep the scale, got %+v", *Time)
+	}
+}
@@ -16,7 +16,13 @@ type RaycastResult struct {
 type WorldAccess interface {
 	GetCollidableObjects() []*GameObject
 	SpawnObject(g *GameObject)
+	InstantiatePrefab(path string) *GameObject
 	Destroy(g *GameObject)
 	Raycast(origin, direction rl.Vector3, maxDistance float32) (RaycastResult, bool)
+	RaycastAll(origin, direction rl.Vector3, maxDistance float32) []RaycastResult
+	SphereCast(origin, direction rl.Vector3, radius, maxDistance float32) (RaycastResult, bool)
+	BoxCast(origin, direction, halfExtents rl.Vector3, maxDistance float32) (RaycastResult, bool)
+	OverlapSphere(center rl.Vector3, radius float32) []*GameObject
+	OverlapBox(center, halfExtents, rotation rl.Vector3) []*GameObject
 	GetShader() rl.Shader
 }
@@ -22,12 +22,28 @@ var editorComponentTypes = []ComponentType{
 	{"ModelRenderer", createModelRenderer},
 	{"BoxCollider", createBoxCollider},
 	{"SphereCollider", createSphereCollider},
+	{"CapsuleCollider", createCapsuleCollider},
 	{"MeshCollider", createMeshCollider},
+	{"Terrain", createTerrain},
 	{"Rigidbody", createRigidbody},
+	{"Joint", createJoint},
+	{"Rotator", createRotator},
+	{"Oscillator", createOscillator},
+	{"FollowTarget", createFollowTarget},
+	{"PatrolPath", createPatrolPath},
+	{"PatrolMover", createPatrolMover},
+	{"TransformAnimation", createTransformAnimation},
+	{"Health", createHealth},
+	{"LODGroup", createLODGroup},
 	{"CharacterController", createCharacterController},
 	{"DirectionalLight", createDirectionalLight},
 	{"PointLight", createPointLight},
+	{"SpotLight", createSpotLight},
 	{"Camera", createCamera},
+	{"MinimapCamera", createMinimapCamera},
+	{"AudioSource", createAudioSource},
+	{"AudioListener", createAudioListener},
+	{"OnCollisionEffect", createOnCollisionEffect},
 }
 
 func createModelRenderer(w *world.World, g *engine.GameObject) engine.Component {
@@ -48,28 +64,83 @@ func createSphereCollider(w *world.World, g *engine.GameObject) engine.Component
 	return components.NewSphereCollider(0.5)
 }
 
+func createCapsuleCollider(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewCapsuleCollider(0.5, 2)
+}
+
 func createMeshCollider(w *world.World, g *engine.GameObject) engine.Component {
 	meshCol := components.NewMeshCollider()
-	// If object already has a ModelRenderer, build the collider from it
-	if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
-		// Need to add component first so it has access to GameObject
-		g.AddComponent(meshCol)
-		meshCol.BuildFromModel(renderer.Model)
-		// Return nil since we already added it
-		return nil
+	// Add the component first so it has access to the GameObject, then build
+	// the collider from the object's ModelRenderer or Terrain if it has one
+	g.AddComponent(meshCol)
+	meshCol.Rebuild()
+	// Return nil since we already added it
+	return nil
+}
+
+func createTerrain(w *world.World, g *engine.GameObject) engine.Component {
+	terrain := components.NewTerrain()
+	terrain.SetShader(w.Renderer.Shader)
+	g.AddComponent(terrain)
+
+	// Terrain is walkable out of the box
+	if engine.GetComponent[*components.MeshCollider](g) == nil {
+		g.AddComponent(components.NewMeshCollider())
 	}
-	return meshCol
+	terrain.BuildCollider()
+	return nil
 }
 
 func createRigidbody(w *world.World, g *engine.GameObject) engine.Component {
 	return components.NewRigidbody()
 }
 
+func createJoint(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewJoint()
+}
+
+func createRotator(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewRotator()
+}
+
+func createOscillator(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewOscillator()
+}
+
+func createFollowTarget(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewFollowTarget()
+}
+
+func createPatrolPath(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewPatrolPath()
+}
+
+func createPatrolMover(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewPatrolMover()
+}
+
+func createTransformAnimation(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewTransformAnimation()
+}
+
+func createHealth(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewHealth()
+}
+
+func createLODGroup(w *world.World, g *engine.GameObject) engine.Component {
+	// Start with one empty level so the distance row is there to fill in
+	lod := components.NewLODGroup()
+	lod.Levels = []components.LODLevel{{Distance: 20}}
+	return lod
+}
+
 func createDirectionalLight(w *world.World, g *engine.GameObject) engine.Component {
 	light := components.NewDirectionalLight()
-	// Wire to renderer (only one directional light is supported)
-	w.Light = g
-	w.Renderer.SetLight(light)
+	// The first directional light casts shadows; later ones only add light
+	if w.Light == nil {
+		w.Light = g
+		w.Renderer.SetLight(light)
+	}
 	return light
 }
 
@@ -77,10 +148,30 @@ func createPointLight(w *world.World, g *engine.GameObject) engine.Component {
 	return components.NewPointLight()
 }
 
+func createSpotLight(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewSpotLight()
+}
+
 func createCamera(w *world.World, g *engine.GameObject) engine.Component {
 	return components.NewCamera()
 }
 
+func createMinimapCamera(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewMinimapCamera()
+}
+
 func createCharacterController(w *world.World, g *engine.GameObject) engine.Component {
 	return components.NewCharacterController()
 }
+
+func createAudioSource(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewAudioSource()
+}
+
+func createAudioListener(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewAudioListener()
+}
+
+func createOnCollisionEffect(w *world.World, g *engine.GameObject) engine.Component {
+	return components.NewOnCollisionEffect()
+}
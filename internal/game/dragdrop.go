@@ -85,12 +85,12 @@ func (e *Editor) importModel(srcPath string) {
 
 	// Create a new GameObject
 	obj := engine.NewGameObject(name)
-	obj.Transform.Position = e.camera.Position
 	obj.Transform.Scale = rl.NewVector3(10, 10, 10)
 
 	// Add ModelRenderer component (safe path)
 	modelRenderer := components.NewModelRendererFromFile(dstModelPath, rl.White)
 	obj.AddComponent(modelRenderer)
+	obj.Transform.Position = e.spawnPosition(obj)
 
 	// Add to scene
 	e.world.Scene.AddGameObject(obj)
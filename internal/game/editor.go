@@ -3,15 +3,20 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"test3d/internal/assets"
 	"test3d/internal/audio"
 	"test3d/internal/components"
 	"test3d/internal/engine"
+	"test3d/internal/physics"
+	"test3d/internal/project"
 	"test3d/internal/world"
 
+	gui "github.com/gen2brain/raylib-go/raygui"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
@@ -29,26 +34,114 @@ type Editor struct {
 	Selected *engine.GameObject
 	world    *world.World
 
+	// Multi-selection (see editor_selection.go): every selected object,
+	// Selected included. selectAnchor is where a Shift+click range starts.
+	SelectedSet  map[*engine.GameObject]bool
+	selectAnchor *engine.GameObject
+
+	// Selection history (see editor_selhistory.go)
+	selectionHistory []uint64           // UIDs of previous selections, most recent last
+	lastSelected     *engine.GameObject // Selected as of the last trackSelection
+
 	// Gizmo state
 	gizmoMode        GizmoMode
 	dragging         bool
-	dragAxisIdx      int
+	dragAxisIdx      int // handle being dragged (see hoveredAxis)
 	dragAxis         rl.Vector3
 	dragPlaneNormal  rl.Vector3
 	dragStart        float32
+	dragStartPoint   rl.Vector3 // Plane hit at drag start (planar/center handles)
 	dragInitPos      rl.Vector3 // Local position
 	dragInitWorldPos rl.Vector3 // World position (for drag plane math)
 	dragInitRot      rl.Vector3
 	dragInitScale    rl.Vector3
-	hoveredAxis      int // -1 = none, 0=X, 1=Y, 2=Z
+	dragGroup        []groupDragStart // rest of a multi-selection, following the drag
+	hoveredAxis      int              // -1 = none, 0=X, 1=Y, 2=Z, 3-5 = planes, 6 = center
+	scaleLocked      bool             // Inspector: editing one scale field keeps X/Y/Z proportional
+	vertexSnap       vertexSnap       // V held during a move drag
+	snap             gizmoSnap        // increments for Ctrl+drag (see editor_snap.go)
+	showSnapSettings bool
+	placeOnSurface   bool // spawned objects rest on the surface under the cursor
+	showGrid         bool // ground grid in the viewport
+
+	// PatrolPath waypoint being dragged in the viewport (nil when idle)
+	patrolPath      *components.PatrolPath
+	patrolPoint     int
+	patrolDragPoint rl.Vector3
+
+	// Solo (hierarchy "S" button): in play mode only this object and its
+	// children run scripts and physics. Not saved with the scene.
+	soloObject *engine.GameObject
+
+	// Debug draw
+	showPhysicsGrid  bool // F2: draw occupied broad-phase grid cells
+	showShortcutHelp bool // ?: keyboard shortcut overlay
+	// F5: velocity arrows on dynamic bodies, in play mode and while paused
+	showVelocityArrows bool
+
+	// Scene changes panel (Ctrl+Shift+S): unsaved differences from the file on disk
+	showSceneChanges   bool
+	sceneChanges       []world.SceneChange
+	sceneChangesScroll int32
+
+	// Scene validation panel (Ctrl+Shift+V): intersecting static colliders
+	showValidation     bool
+	validationOverlaps []physics.StaticOverlap
+	validationScroll   int32
+
+	// Keyframe timeline (K) for the selected object's TransformAnimation.
+	// timelineKey is the selected key and timelineDragKey the one being
+	// dragged (-1 for none); timelineObject notices selection changes.
+	showTimeline      bool
+	timelineTime      float32
+	timelineLength    float32
+	timelineKey       int
+	timelineDragKey   int
+	timelineScrubbing bool
+	timelinePlaying   bool
+	timelineObject    *engine.GameObject
+
+	// Click picking: pickIndex is which of the objects under pickPos was
+	// selected last, so clicking there again moves on to the next one
+	pickPos   rl.Vector2
+	pickIndex int
+
+	// Quick-spawn palette (Shift+A), opened at quickSpawnAt
+	showQuickSpawn bool
+	quickSpawnAt   rl.Vector2
+
+	// Export Selection as Scene panel (Ctrl+Shift+E)
+	showExportScene bool
+	exportScenePath string
+	exportKeepUIDs  bool
+
+	// Joint authoring (J): drag from the selected rigidbody to another to connect them
+	jointMode         bool
+	jointDragging     bool
+	jointDragPoint    rl.Vector3        // world point the connection or handle drag started at
+	jointHandle       *components.Joint // joint whose anchor handle is being dragged
+	jointHandleEnd    int               // 0 = own anchor, 1 = connected anchor
+	jointHandleBefore objectState       // selected object as the handle drag started, for undo
 
 	// Hierarchy panel
-	hierarchyScroll int32
+	hierarchyScroll     int32
+	hierarchyFilter     string // name/tag filter text
+	hierarchyCompFilter string // only show objects with this component type ("" = all)
+	showCompFilterMenu  bool
 
 	// Inspector panel
 	inspectorScroll      int32
 	showAddComponentMenu bool
-	addComponentScroll   int32 // Scroll offset for add component menu
+	addComponentScroll   int32                 // Scroll offset for add component menu
+	normalsAngle         float32               // Smoothing angle for Recalc Normals (degrees)
+	importSettingsPath   string                // model whose import settings are being edited
+	importSettings       assets.ImportSettings // unapplied edits for importSettingsPath
+	importSettingsSaved  assets.ImportSettings // what the sidecar holds
+	focusedComponent     engine.Component      // component whose header was clicked last, copied by Ctrl+C
+	prefabOverrides      []string              // overrides of prefabOverridesOf, see refreshPrefabOverrides
+	prefabOverridesErr   error                 // why prefabOverrides couldn't be computed
+	prefabOverridesOf    *engine.GameObject    // instance prefabOverrides belongs to
+	lastOverridesCheck   float64               // when prefabOverrides was last computed
 
 	// Float field editing state
 	activeInputID     string  // e.g., "pos.x", "rot.y", "mass"
@@ -63,8 +156,22 @@ type Editor struct {
 	saveMsg     string
 	saveMsgTime float64
 
-	// Undo stack
-	undoStack []UndoState
+	// Clipboard (Ctrl+C / Ctrl+V, see editor_clipboard.go): holds either a
+	// copied object tree or a single copied component
+	clipboardObject    *world.ObjectDef
+	clipboardComponent json.RawMessage
+
+	// In-editor simulation (Simulate button) and the scene it started from
+	simulating  bool
+	simSnapshot []byte
+
+	// Undo history: undone steps move to redoStack until something new is done
+	undoStack        []undoCommand
+	redoStack        []undoCommand
+	maxUndo          int              // undo depth, oldest entries are dropped beyond it
+	undoBytes        int              // approximate memory held by both stacks
+	pendingTransform []transformState // selection transforms as the gizmo drag started
+	inspectorEdit    []objectState    // selection as the inspector edit in progress started
 
 	// Asset browser
 	showAssetBrowser     bool
@@ -77,11 +184,25 @@ type Editor struct {
 	lastClickedAsset     string             // Path of last clicked asset
 	lastHierarchyClick   float64            // For hierarchy double-click detection
 	lastClickedObject    *engine.GameObject // Last clicked object in hierarchy
-
-	// Script hot-reload
-	scriptModTimes  map[string]int64 // path -> mod time (unix nano)
-	scriptsChanged  bool
-	lastScriptCheck float64
+	assetMenu            *AssetEntry        // Asset whose right-click menu is open (nil = closed)
+	assetMenuPos         rl.Vector2         // Where the asset menu was opened
+	favoriteAssets       []string           // Pinned asset paths, shown above the grid in any folder
+	recentScenes         []string           // Recently opened scene paths, newest first
+	showRecentScenes     bool
+
+	// Script and editor theme hot-reload
+	scriptModTimes    map[string]int64 // path -> mod time (unix nano)
+	scriptsChanged    bool
+	lastScriptCheck   float64
+	autoReloadScripts bool    // rebuild automatically instead of waiting for Ctrl+R
+	autoReloadPending bool    // a change was seen that hasn't been auto-rebuilt yet
+	lastScriptChange  float64 // when the newest change was seen (debounce start)
+	newestScriptMod   int64   // newest script mod time seen so far
+	lastThemeCheck    float64 // last poll of editor_theme.json (see checkThemeChanges)
+
+	// Script test runs from the inspector (see editor_scripttest.go)
+	scriptTestFrames int32   // Update calls per run
+	scriptTestDt     float32 // deltaTime passed to each Update
 
 	// Rebuild progress tracking
 	rebuildInProgress  bool
@@ -92,12 +213,11 @@ type Editor struct {
 	rebuildMutex       sync.Mutex
 
 	// Drag-and-drop state
-	draggingAsset       bool               // True if dragging an asset from the browser
-	draggedAsset        *AssetEntry        // The asset being dragged
-	draggingHierarchy   bool               // True if dragging an object in hierarchy
-	draggedObject       *engine.GameObject // The object being dragged for reparenting
-	hierarchyDropTarget *engine.GameObject // Target for hierarchy drop (parent candidate)
-	hierarchyDropIndex  int                // Index where to drop (-1 = as child, >= 0 = at position)
+	draggingAsset     bool               // True if dragging an asset from the browser
+	draggedAsset      *AssetEntry        // The asset being dragged
+	draggingHierarchy bool               // True if dragging an object in hierarchy
+	draggedObject     *engine.GameObject // The object being dragged for reparenting
+	hierarchyDrop     *hierarchyDrop     // Where the dragged object would land (nil = nowhere)
 
 	// Hierarchy click/drag detection (Unity-style)
 	hierarchyMouseDownObj  *engine.GameObject // Object that was mouse-downed on (not yet confirmed as drag)
@@ -123,8 +243,15 @@ type Editor struct {
 	zoomingToTarget bool
 	zoomTargetPos   rl.Vector3
 	zoomStartPos    rl.Vector3
+	zoomStartYaw    float32
+	zoomStartPitch  float32
+	zoomTargetYaw   float32
+	zoomTargetPitch float32
 	zoomProgress    float32
 
+	// Camera bookmarks per scene path (Shift+1..9 to jump, Ctrl+Shift+1..9 to save)
+	bookmarks map[string]map[int]CameraBookmark
+
 	// UI Edit Mode
 	uiEditState *UIEditState
 }
@@ -136,9 +263,18 @@ func NewEditor(w *world.World) *Editor {
 			MoveSpeed: 10.0,
 		},
 		hoveredAxis:    -1,
-		undoStack:      make([]UndoState, 0, maxUndoStack),
+		undoStack:      make([]undoCommand, 0, defaultMaxUndo),
+		SelectedSet:    map[*engine.GameObject]bool{},
+		snap:           defaultGizmoSnap,
+		placeOnSurface: true,
+		showGrid:       true,
+		maxUndo:        defaultMaxUndo,
 		hierarchyWidth: 210,
 		inspectorWidth: 310,
+		normalsAngle:   60,
+
+		scriptTestFrames: 60,
+		scriptTestDt:     1.0 / 60,
 	}
 }
 
@@ -148,9 +284,12 @@ func (e *Editor) Enter(currentCam rl.Camera3D) {
 	rl.EnableCursor()
 	audio.SetPlayMode(false)
 
-	// Reload scene from disk to undo all play mode changes
+	// Reload scene from disk to undo all play mode changes. The solo only
+	// lasts for the play session it was set for.
 	e.world.ResetScene()
+	e.world.Solo = nil
 	e.Selected = nil
+	e.soloObject = nil
 
 	// Initialize script hot-reload watcher
 	e.scanScriptModTimes()
@@ -191,12 +330,26 @@ func (e *Editor) Pause(currentCam rl.Camera3D) {
 }
 
 func (e *Editor) Exit() {
+	// Play mode starts from the edited scene, not the simulated one
+	e.stopSimulation()
+
 	// Only save scene if we're in pure editor mode (not resuming from pause)
 	if !e.Paused {
-		if err := e.world.SaveScene(world.ScenePath); err != nil {
+		if err := e.world.SaveScene(project.Current.CurrentScene); err != nil {
 			fmt.Printf("Warning: Failed to save scene before play mode: %v\n", err)
 		}
+		// A fresh run starts the game clock at zero; resuming keeps counting
+		engine.ResetTime()
+	}
+	if warning := components.AudioListenerWarning(e.world.Scene); warning != "" {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	// Solo the object only if it is still in the scene
+	if e.soloObject != nil && e.world.Scene.FindByUID(e.soloObject.UID) != e.soloObject {
+		e.soloObject = nil
 	}
+	e.world.Solo = e.soloObject
 
 	e.Active = false
 	e.Paused = false
@@ -211,8 +364,14 @@ func (e *Editor) Update(deltaTime float32) {
 	// Check if rebuild is ready to relaunch (must be on main thread)
 	e.checkRebuildExit()
 
+	// Remember the previous selection for the ` shortcut
+	e.trackSelection()
+
+	// Step physics and scripts while simulating, whatever mode the editor is in
+	e.updateSimulation(deltaTime)
+
 	// U key: toggle UI edit mode (only when not editing text)
-	isEditingText := e.editingName || e.editingTags || e.activeInputID != ""
+	isEditingText := e.editingText()
 	if rl.IsKeyPressed(rl.KeyU) && !isEditingText && !rl.IsMouseButtonDown(rl.MouseRightButton) {
 		e.ToggleUIEditMode()
 	}
@@ -225,58 +384,22 @@ func (e *Editor) Update(deltaTime float32) {
 
 	// Update camera zoom animation
 	e.updateCameraZoom(deltaTime)
+	e.updateTimeline(deltaTime)
 
 	// Check for script file changes
 	e.checkScriptChanges()
+	e.updateAutoReload()
+	e.checkThemeChanges()
 
 	// Handle file drops (GLTF models, etc.)
 	e.handleFileDrop()
 
-	// Ctrl+Z or Cmd+Z: undo
-	if (rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper)) && rl.IsKeyPressed(rl.KeyZ) {
-		e.undo()
-	}
-
-	// Ctrl+S: save scene - ONLY in pure editor mode (not paused)
-	if (rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper)) && rl.IsKeyPressed(rl.KeyS) && !e.Paused {
-		if err := e.world.SaveScene(world.ScenePath); err != nil {
-			e.saveMsg = fmt.Sprintf("Save failed: %v", err)
-		} else {
-			e.saveMsg = "Scene saved!"
-		}
-		e.saveMsgTime = rl.GetTime()
-	}
-
-	// Ctrl+B: build game
-	if (rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper)) && rl.IsKeyPressed(rl.KeyB) {
-		e.buildGame()
-	}
-
-	// Ctrl+R: rebuild and relaunch (for script hot-reload)
-	if (rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper)) && rl.IsKeyPressed(rl.KeyR) {
-		e.rebuildAndRelaunch()
-	}
-
-	// Tab: toggle asset browser
-	if rl.IsKeyPressed(rl.KeyTab) {
-		e.showAssetBrowser = !e.showAssetBrowser
-		if e.showAssetBrowser {
-			if e.currentAssetPath == "" {
-				e.currentAssetPath = "assets"
-			}
-			e.scanAssets()
-		}
-	}
-
-	// Ctrl+D: duplicate selected object
-	if e.Selected != nil && (rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper)) && rl.IsKeyPressed(rl.KeyD) {
-		newObj := e.world.DuplicateObject(e.Selected)
-		e.Selected = newObj
-	}
+	// Keyboard shortcuts (see editorShortcuts)
+	e.handleShortcuts()
 
-	// Cmd+Delete (Mac) or Ctrl+Delete: delete selected object
-	if e.Selected != nil && (rl.IsKeyDown(rl.KeyLeftSuper) || rl.IsKeyDown(rl.KeyLeftControl)) && rl.IsKeyPressed(rl.KeyBackspace) {
-		e.deleteSelectedObject()
+	// Modal panels take all mouse input until closed
+	if e.showShortcutHelp || e.showSceneChanges || e.showExportScene || e.showValidation || e.showQuickSpawn {
+		return
 	}
 
 	// Camera: right-click + drag to look, right-click + WASD to fly
@@ -329,29 +452,27 @@ func (e *Editor) Update(deltaTime float32) {
 		}
 	}
 
-	// Gizmo mode hotkeys (only when not holding RMB for camera and not editing text)
-	isEditingText = e.editingName || e.editingTags || e.activeInputID != ""
-	if !rl.IsMouseButtonDown(rl.MouseRightButton) && !isEditingText {
-		if rl.IsKeyPressed(rl.KeyW) {
-			e.gizmoMode = GizmoMove
-		}
-		if rl.IsKeyPressed(rl.KeyE) {
-			e.gizmoMode = GizmoRotate
-		}
-		if rl.IsKeyPressed(rl.KeyR) {
-			e.gizmoMode = GizmoScale
-		}
-	}
-
 	cam := e.GetRaylibCamera()
 	ray := rl.GetScreenToWorldRay(rl.GetMousePosition(), cam)
 
+	// Joint mode: connect rigidbodies and move joint anchors
+	if e.jointMode && !e.dragging && e.updateJointMode(ray) {
+		return
+	}
+
+	// Waypoints of the selected PatrolPath can be dragged directly
+	if !e.dragging && e.updatePatrolHandles(ray) {
+		return
+	}
+
 	// Handle active drag
 	if e.dragging {
 		if !rl.IsMouseButtonDown(rl.MouseLeftButton) {
 			e.dragging = false
+			e.commitTransformUndo()
 		} else {
 			e.updateDrag(ray)
+			e.applyGroupDrag()
 		}
 		return
 	}
@@ -372,12 +493,43 @@ func (e *Editor) Update(deltaTime float32) {
 			}
 		}
 
-		hit, ok := e.world.EditorRaycast(ray.Position, ray.Direction, 1000)
-		if ok {
-			e.Selected = hit.GameObject
-		} else {
-			e.Selected = nil
-		}
+		e.pickObject(ray)
+	}
+}
+
+// pickSlop is how far (pixels) a click can be from the last one and still
+// count as clicking the same spot again
+const pickSlop = 4
+
+// pickObject selects the nearest object under the mouse. Clicking the same
+// spot again selects the next one further along the ray, wrapping around, so
+// objects behind walls or inside others can be picked. Alt+click does the
+// same from anywhere over the selected object.
+func (e *Editor) pickObject(ray rl.Ray) {
+	mousePos := rl.GetMousePosition()
+	hits := e.world.EditorRaycastAll(ray.Position, ray.Direction, 1000)
+	if len(hits) == 0 {
+		e.selectOnly(nil)
+		e.pickIndex = 0
+		e.pickPos = mousePos
+		return
+	}
+
+	sameSpot := rl.Vector2Distance(mousePos, e.pickPos) <= pickSlop
+	selected := slices.IndexFunc(hits, func(h engine.RaycastResult) bool { return h.GameObject == e.Selected })
+	selectThrough := rl.IsKeyDown(rl.KeyLeftAlt) || rl.IsKeyDown(rl.KeyRightAlt)
+	if sameSpot && e.pickIndex < len(hits) && hits[e.pickIndex].GameObject == e.Selected {
+		e.pickIndex = (e.pickIndex + 1) % len(hits)
+	} else if selectThrough && selected >= 0 {
+		// Alt+click picks whatever is behind the selection, wherever on it you click
+		e.pickIndex = (selected + 1) % len(hits)
+	} else {
+		e.pickIndex = 0
+	}
+	e.pickPos = mousePos
+	e.selectOnly(hits[e.pickIndex].GameObject)
+	if len(hits) > 1 {
+		e.setMsg("%s (%d of %d under the cursor - click again for the next)", e.Selected.Name, e.pickIndex+1, len(hits))
 	}
 }
 
@@ -459,13 +611,23 @@ func (e *Editor) focusOnObject(obj *engine.GameObject) {
 	offsetZ := float32(-math.Sin(yawRad)*math.Cos(pitchRad)) * distance
 
 	// Start zoom animation
-	e.zoomingToTarget = true
-	e.zoomStartPos = e.camera.Position
-	e.zoomTargetPos = rl.Vector3{
+	e.startCameraMove(rl.Vector3{
 		X: targetPos.X + offsetX,
 		Y: targetPos.Y + offsetY,
 		Z: targetPos.Z + offsetZ,
-	}
+	}, e.camera.Yaw, e.camera.Pitch)
+}
+
+// startCameraMove starts a smooth camera move to a position and look direction.
+func (e *Editor) startCameraMove(pos rl.Vector3, yaw, pitch float32) {
+	e.zoomingToTarget = true
+	e.zoomStartPos = e.camera.Position
+	e.zoomStartYaw = e.camera.Yaw
+	e.zoomStartPitch = e.camera.Pitch
+	e.zoomTargetPos = pos
+	// Turn the short way around
+	e.zoomTargetYaw = e.camera.Yaw + float32(math.Remainder(float64(yaw-e.camera.Yaw), 360))
+	e.zoomTargetPitch = pitch
 	e.zoomProgress = 0
 }
 
@@ -483,6 +645,8 @@ func (e *Editor) updateCameraZoom(deltaTime float32) {
 		e.zoomProgress = 1.0
 		e.zoomingToTarget = false
 		e.camera.Position = e.zoomTargetPos
+		e.camera.Yaw = e.zoomTargetYaw
+		e.camera.Pitch = e.zoomTargetPitch
 		return
 	}
 
@@ -496,6 +660,8 @@ func (e *Editor) updateCameraZoom(deltaTime float32) {
 		Y: e.zoomStartPos.Y + (e.zoomTargetPos.Y-e.zoomStartPos.Y)*ease,
 		Z: e.zoomStartPos.Z + (e.zoomTargetPos.Z-e.zoomStartPos.Z)*ease,
 	}
+	e.camera.Yaw = e.zoomStartYaw + (e.zoomTargetYaw-e.zoomStartYaw)*ease
+	e.camera.Pitch = e.zoomStartPitch + (e.zoomTargetPitch-e.zoomStartPitch)*ease
 }
 
 // DrawUI draws the editor overlay: top bar, hierarchy panel (left), inspector panel (right).
@@ -521,16 +687,25 @@ func (e *Editor) DrawUI() {
 		}
 		drawTextEx(editorFont, name, x, 9, 18, color)
 	}
-	helpText := "Ctrl+S: Save  |  Ctrl+B: Build  |  Ctrl+Z: Undo"
+	e.drawSnapButton()
+	e.drawSceneButton()
+	e.drawSimulateButton()
+	helpText := "Ctrl+S: Save  |  Ctrl+B: Build  |  Ctrl+Z: Undo  |  ?: Shortcuts"
 	if e.Paused {
-		helpText = "P: Resume  |  Ctrl+S: Save"
+		helpText = "P: Resume  |  ?: Shortcuts"
 	}
-	drawTextEx(editorFont, helpText, 430, 9, 18, colorTextMuted)
+	drawTextEx(editorFont, helpText, 751, 9, 18, colorTextMuted)
 	drawTextEx(editorFontMono, fmt.Sprintf("Speed: %.0f", e.camera.MoveSpeed), int32(rl.GetScreenWidth())-130, 9, 18, colorTextMuted)
+	// Label is drawn to the right of the box, so keep it clear of the Assets button
+	autoBounds := rl.Rectangle{X: float32(rl.GetScreenWidth() - 355), Y: 10, Width: 16, Height: 16}
+	e.autoReloadScripts = gui.CheckBox(autoBounds, "Auto-reload", e.autoReloadScripts)
 
 	// Scripts changed banner (below top bar) - indigo themed
 	if e.scriptsChanged {
 		bannerText := "Scripts changed - Press Ctrl+R to rebuild"
+		if e.autoReloadScripts {
+			bannerText = "Scripts changed - Rebuilding automatically"
+		}
 		textWidth := rl.MeasureText(bannerText, 14)
 		bannerX := (int32(rl.GetScreenWidth()) - textWidth) / 2
 		rl.DrawRectangle(bannerX-12, 42, textWidth+24, 26, rl.NewColor(108, 99, 255, 40))
@@ -547,6 +722,17 @@ func (e *Editor) DrawUI() {
 		drawTextEx(editorFontBold, e.saveMsg, int32(rl.GetScreenWidth()/2)-50, 47, 16, color)
 	}
 
+	// Physics grid legend (top-left of viewport)
+	if e.showPhysicsGrid {
+		legend := fmt.Sprintf("Physics Grid [F2]  cell %.0f  |  %d dynamic", physics.CellSize, e.world.PhysicsWorld.DynamicObjectCount())
+		drawTextEx(editorFont, legend, e.hierarchyWidth+10, 44, 14, colorTextSecondary)
+	}
+
+	// Joint mode hint (below the grid legend)
+	if e.jointMode {
+		drawTextEx(editorFont, "Joint Mode [J]  drag from the selected rigidbody to another to connect, drag handles to move anchors", e.hierarchyWidth+10, 62, 14, colorAccentLight)
+	}
+
 	// Rebuild progress bar
 	e.rebuildMutex.Lock()
 	if e.rebuildInProgress {
@@ -624,6 +810,7 @@ func (e *Editor) DrawUI() {
 	if e.showAssetBrowser {
 		e.drawAssetBrowser()
 	}
+	e.drawTimeline()
 
 	// Draw material drag indicator - indigo themed
 	if e.draggingAsset && e.draggedAsset != nil {
@@ -652,6 +839,16 @@ func (e *Editor) DrawUI() {
 	} else {
 		rl.SetMouseCursor(rl.MouseCursorDefault)
 	}
+
+	e.drawRecentScenesMenu()
+	e.drawSnapSettings()
+	e.drawQuickSpawn()
+
+	// Modal panels on top of everything
+	e.drawSceneChanges()
+	e.drawValidation()
+	e.drawExportScene()
+	e.drawShortcutHelp()
 }
 
 // isOverPanelEdge checks if mouse is over a resizable panel edge
@@ -748,9 +945,24 @@ func (e *Editor) mouseInPanel() bool {
 	if m.Y <= 36 {
 		return true
 	}
+	// Popups hanging below the top bar
+	if e.showSnapSettings && rl.CheckCollisionPointRec(m, snapSettingsRect()) {
+		return true
+	}
+	if e.showRecentScenes && rl.CheckCollisionPointRec(m, e.recentScenesMenuRect()) {
+		return true
+	}
 	// Asset browser: bottom 150px (when visible)
 	if e.showAssetBrowser && m.Y >= screenH-150 && m.X > hierW && m.X < screenW-inspW {
 		return true
 	}
+	// Material editor, which reaches above the asset browser
+	if e.showAssetBrowser && e.selectedMaterial != nil && rl.CheckCollisionPointRec(m, e.materialEditorRect()) {
+		return true
+	}
+	// Keyframe timeline, above the asset browser
+	if e.showTimeline && rl.CheckCollisionPointRec(m, e.timelineRect()) {
+		return true
+	}
 	return false
 }
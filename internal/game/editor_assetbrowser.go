@@ -3,15 +3,20 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"test3d/internal/assets"
 	"test3d/internal/components"
 	"test3d/internal/engine"
+	"test3d/internal/project"
 	"test3d/internal/world"
 
+	gui "github.com/gen2brain/raylib-go/raygui"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
@@ -33,7 +38,7 @@ func (e *Editor) drawAssetBrowser() {
 	// Reserve space for material editor on the right when a material is selected
 	contentW := panelW
 	if e.selectedMaterial != nil {
-		contentW = panelW - 180
+		contentW = panelW - materialEditorW
 	}
 
 	// Background with border
@@ -99,11 +104,18 @@ func (e *Editor) drawAssetBrowser() {
 		e.scanAssets()
 	}
 
+	// Favorites strip: pinned assets from any folder, above the grid
+	gridTop := panelY + 24
+	if len(e.favoriteAssets) > 0 {
+		e.drawFavoritesStrip(panelX+10, gridTop+2, contentW-20, mousePos)
+		gridTop += favoritesStripH
+	}
+
 	// Asset grid - larger items for better icons
 	itemW := int32(80)
 	itemH := int32(85)
 	startX := panelX + 10
-	startY := panelY + 30
+	startY := gridTop + 6
 	cols := (contentW - 20) / (itemW + 8)
 	if cols < 1 {
 		cols = 1
@@ -122,7 +134,7 @@ func (e *Editor) drawAssetBrowser() {
 	}
 
 	// Clip content
-	rl.BeginScissorMode(panelX, panelY+24, contentW, panelH-24)
+	rl.BeginScissorMode(panelX, gridTop, contentW, panelH-(gridTop-panelY))
 
 	for i, asset := range e.assetFiles {
 		col := int32(i) % cols
@@ -132,13 +144,13 @@ func (e *Editor) drawAssetBrowser() {
 		y := startY + row*(itemH+8) - e.assetBrowserScroll
 
 		// Skip if off screen
-		if y+itemH < panelY+24 || y > panelY+panelH {
+		if y+itemH < gridTop || y > panelY+panelH {
 			continue
 		}
 
 		// Item background - rounded
 		itemHovered := mousePos.X >= float32(x) && mousePos.X <= float32(x+itemW) &&
-			mousePos.Y >= float32(y) && mousePos.Y <= float32(y+itemH)
+			mousePos.Y >= float32(y) && mousePos.Y <= float32(y+itemH) && mousePos.Y >= float32(gridTop)
 
 		isSelected := asset.Path == e.selectedMaterialPath
 
@@ -162,46 +174,25 @@ func (e *Editor) drawAssetBrowser() {
 		drawTextEx(editorFont, name, x+(itemW-textW)/2, y+itemH-18, 13, colorTextSecondary)
 
 		// Handle clicks
-		if itemHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !e.draggingAsset {
-			now := rl.GetTime()
-			isDoubleClick := (now-e.lastClickTime < 0.3) && (e.lastClickedAsset == asset.Path)
-
-			if asset.IsFolder {
-				if isDoubleClick {
-					// Double-click folder: navigate into it
-					e.currentAssetPath = asset.Path
-					e.assetBrowserScroll = 0
-					e.selectedMaterial = nil
-					e.selectedMaterialPath = ""
-					e.scanAssets()
-				}
-			} else if asset.Type == "material" {
-				// Start dragging material
-				e.draggingAsset = true
-				assetCopy := asset // Make a copy to avoid referencing loop variable
-				e.draggedAsset = &assetCopy
-				e.selectedMaterialPath = asset.Path
-				e.selectedMaterial = assets.LoadMaterial(asset.Path)
-			} else if asset.Type == "model" {
-				// Click model: spawn into scene
-				e.spawnModelFromAsset(asset)
-			} else if asset.Type == "scene" {
-				if isDoubleClick {
-					// Double-click scene: open it
-					e.openScene(asset.Path)
-				}
-			}
+		if itemHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !e.draggingAsset && e.assetMenu == nil {
+			e.clickAsset(asset)
+		}
 
-			e.lastClickTime = now
-			e.lastClickedAsset = asset.Path
+		// Right-click: open the asset menu (favorites, create material from texture)
+		if itemHovered && rl.IsMouseButtonPressed(rl.MouseRightButton) && !asset.IsFolder {
+			e.openAssetMenu(asset, mousePos)
 		}
 	}
 
 	rl.EndScissorMode()
 
+	if e.assetMenu != nil {
+		e.drawAssetMenu()
+	}
+
 	// Clamp scroll
 	rows := (int32(len(e.assetFiles)) + cols - 1) / cols
-	maxScroll := rows*(itemH+8) - (panelH - 30)
+	maxScroll := rows*(itemH+8) - (panelH - (startY - panelY))
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -211,12 +202,32 @@ func (e *Editor) drawAssetBrowser() {
 
 	// Empty state
 	if len(e.assetFiles) == 0 {
-		drawTextEx(editorFont, "Empty folder", panelX+20, panelY+60, 16, colorTextMuted)
+		drawTextEx(editorFont, "Empty folder", panelX+20, gridTop+36, 16, colorTextMuted)
 	}
 
 	// Draw material editor panel on the right
 	if e.selectedMaterial != nil {
-		e.drawMaterialEditor(panelX+contentW, panelY, panelW-contentW, panelH)
+		r := e.materialEditorRect()
+		e.drawMaterialEditor(int32(r.X), int32(r.Y), int32(r.Width), int32(r.Height))
+	}
+}
+
+const (
+	materialEditorW = 180
+	// Header plus ten 22px rows (presets take two). Taller than the asset
+	// browser, so the editor sticks up above it into the viewport.
+	materialEditorH = 26 + 10*22 + 6
+)
+
+// materialEditorRect returns the bounds of the material editor, at the right
+// end of the asset browser and bottom-aligned with it
+func (e *Editor) materialEditorRect() rl.Rectangle {
+	screenW := float32(rl.GetScreenWidth())
+	return rl.Rectangle{
+		X:      screenW - float32(e.inspectorWidth) - materialEditorW,
+		Y:      float32(rl.GetScreenHeight()) - materialEditorH,
+		Width:  materialEditorW,
+		Height: materialEditorH,
 	}
 }
 
@@ -274,6 +285,14 @@ func (e *Editor) drawAssetIcon(x, y, itemW int32, assetType string) {
 		rl.DrawRectangleRounded(rl.Rectangle{X: float32(iconX + half + 2), Y: float32(iconY + 4), Width: float32(half - 6), Height: float32(half - 6)}, 0.2, 2, rl.NewColor(120, 120, 130, 255))
 		rl.DrawRectangleRounded(rl.Rectangle{X: float32(iconX + 4), Y: float32(iconY + half + 2), Width: float32(half - 6), Height: float32(half - 6)}, 0.2, 2, rl.NewColor(120, 120, 130, 255))
 
+	case "prefab":
+		// Prefab icon - a cube stacked on a shadow copy of itself
+		prefabColor := rl.NewColor(90, 200, 220, 255)
+		prefabDark := rl.NewColor(50, 140, 170, 255)
+		rl.DrawRectangleRounded(rl.Rectangle{X: float32(iconX + 12), Y: float32(iconY + 2), Width: float32(iconSize - 16), Height: float32(iconSize - 16)}, 0.15, 4, prefabDark)
+		rl.DrawRectangleRounded(rl.Rectangle{X: float32(iconX + 4), Y: float32(iconY + 12), Width: float32(iconSize - 16), Height: float32(iconSize - 16)}, 0.15, 4, prefabColor)
+		drawTextEx(editorFontBold, "P", iconX+15, iconY+17, 18, rl.White)
+
 	case "scene":
 		// Scene icon - clapperboard style
 		sceneColor := rl.NewColor(100, 180, 255, 255) // Light blue
@@ -317,6 +336,228 @@ func (e *Editor) drawAssetIcon(x, y, itemW int32, assetType string) {
 	}
 }
 
+// clickAsset handles a left click on an asset in the grid or the favorites
+// strip: folders and scenes open on double-click, materials start a drag,
+// models and prefabs spawn into the scene
+func (e *Editor) clickAsset(asset AssetEntry) {
+	now := rl.GetTime()
+	isDoubleClick := (now-e.lastClickTime < 0.3) && (e.lastClickedAsset == asset.Path)
+
+	if asset.IsFolder {
+		if isDoubleClick {
+			// Double-click folder: navigate into it
+			e.currentAssetPath = asset.Path
+			e.assetBrowserScroll = 0
+			e.selectedMaterial = nil
+			e.selectedMaterialPath = ""
+			e.scanAssets()
+		}
+	} else if asset.Type == "material" {
+		// Start dragging material
+		e.draggingAsset = true
+		assetCopy := asset // Make a copy to avoid referencing loop variable
+		e.draggedAsset = &assetCopy
+		e.selectedMaterialPath = asset.Path
+		e.selectedMaterial = assets.LoadMaterial(asset.Path)
+	} else if asset.Type == "model" {
+		// Click model: spawn into scene
+		e.spawnModelFromAsset(asset)
+	} else if asset.Type == "prefab" {
+		// Click prefab: spawn an instance into scene
+		e.spawnPrefabFromAsset(asset)
+	} else if asset.Type == "scene" {
+		if isDoubleClick {
+			// Double-click scene: open it
+			e.openScene(asset.Path)
+		}
+	}
+
+	e.lastClickTime = now
+	e.lastClickedAsset = asset.Path
+}
+
+// openAssetMenu opens the right-click menu for an asset at pos
+func (e *Editor) openAssetMenu(asset AssetEntry, pos rl.Vector2) {
+	assetCopy := asset
+	e.assetMenu = &assetCopy
+	e.assetMenuPos = pos
+}
+
+// drawAssetMenu draws the right-click menu for an asset
+func (e *Editor) drawAssetMenu() {
+	asset := *e.assetMenu
+	var items []string
+	if asset.Type == "texture" {
+		items = append(items, "Create Material")
+	}
+	if e.isFavoriteAsset(asset.Path) {
+		items = append(items, "Remove from Favorites")
+	} else {
+		items = append(items, "Add to Favorites")
+	}
+
+	menuW := int32(160)
+	itemH := int32(26)
+	menuH := itemH * int32(len(items))
+	menuX := int32(e.assetMenuPos.X)
+	menuY := int32(e.assetMenuPos.Y) - menuH // open upward, the browser is at the bottom
+
+	mousePos := rl.GetMousePosition()
+	menuRect := rl.Rectangle{X: float32(menuX), Y: float32(menuY), Width: float32(menuW), Height: float32(menuH)}
+	rl.DrawRectangleRounded(menuRect, 0.2, 4, colorBgElement)
+
+	clicked := ""
+	for i, item := range items {
+		itemY := menuY + int32(i)*itemH
+		hovered := mousePos.X >= float32(menuX) && mousePos.X <= float32(menuX+menuW) &&
+			mousePos.Y >= float32(itemY) && mousePos.Y < float32(itemY+itemH)
+		if hovered {
+			rl.DrawRectangleRounded(rl.Rectangle{X: float32(menuX), Y: float32(itemY), Width: float32(menuW), Height: float32(itemH)}, 0.3, 4, colorAccent)
+			if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				clicked = item
+			}
+		}
+		drawTextEx(editorFont, item, menuX+10, itemY+5, 15, colorTextPrimary)
+	}
+	rl.DrawRectangleRoundedLinesEx(menuRect, 0.2, 4, 1, colorBorder)
+
+	switch clicked {
+	case "Create Material":
+		e.createMaterialFromTexture(asset.Path)
+	case "Add to Favorites":
+		e.favoriteAssets = append(e.favoriteAssets, filepath.ToSlash(asset.Path))
+		e.setMsg("Pinned %s", asset.Name)
+	case "Remove from Favorites":
+		e.removeFavoriteAsset(asset.Path)
+	}
+
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) || rl.IsKeyPressed(rl.KeyEscape) {
+		e.assetMenu = nil
+	}
+}
+
+// favoritesStripH is the height of the favorites strip, including its gap
+const favoritesStripH = int32(26)
+
+// drawFavoritesStrip draws the pinned assets as a row of chips that behave
+// like grid items. Favorites whose file is gone are skipped.
+func (e *Editor) drawFavoritesStrip(x, y, w int32, mousePos rl.Vector2) {
+	const chipH = int32(20)
+	drawTextEx(editorFontBold, "Pinned", x, y+2, 14, colorTextMuted)
+	chipX := x + measureTextEx(editorFontBold, "Pinned", 14) + 8
+
+	rl.BeginScissorMode(x, y, w, chipH)
+	for _, path := range e.favoriteAssets {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		asset := assetEntryFor(filepath.Dir(path), filepath.Base(path), info.IsDir())
+
+		textW := measureTextEx(editorFont, asset.Name, 14)
+		chipW := textW + 24
+		chip := rl.Rectangle{X: float32(chipX), Y: float32(y), Width: float32(chipW), Height: float32(chipH)}
+		hovered := rl.CheckCollisionPointRec(mousePos, chip) && mousePos.X <= float32(x+w)
+
+		bgColor := colorBgElement
+		if asset.Path == e.selectedMaterialPath {
+			bgColor = colorAccent
+		} else if hovered {
+			bgColor = colorBgHover
+		}
+		rl.DrawRectangleRounded(chip, 0.4, 4, bgColor)
+		rl.DrawCircle(chipX+10, y+chipH/2, 4, assetTypeColor(asset.Type))
+		drawTextEx(editorFont, asset.Name, chipX+18, y+3, 14, colorTextSecondary)
+
+		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !e.draggingAsset && e.assetMenu == nil {
+			e.clickAsset(asset)
+		}
+		if hovered && rl.IsMouseButtonPressed(rl.MouseRightButton) {
+			e.openAssetMenu(asset, mousePos)
+		}
+		chipX += chipW + 6
+	}
+	rl.EndScissorMode()
+}
+
+// isFavoriteAsset reports whether path is pinned in the favorites strip
+func (e *Editor) isFavoriteAsset(path string) bool {
+	return slices.Contains(e.favoriteAssets, filepath.ToSlash(path))
+}
+
+// removeFavoriteAsset unpins path from the favorites strip
+func (e *Editor) removeFavoriteAsset(path string) {
+	e.favoriteAssets = slices.DeleteFunc(e.favoriteAssets, func(p string) bool {
+		return p == filepath.ToSlash(path)
+	})
+}
+
+// assetTypeColor is the accent color of an asset type's icon
+func assetTypeColor(assetType string) rl.Color {
+	switch assetType {
+	case "folder":
+		return rl.NewColor(220, 180, 80, 255)
+	case "material":
+		return colorAccent
+	case "model":
+		return rl.NewColor(120, 200, 140, 255)
+	case "texture":
+		return rl.NewColor(220, 220, 220, 255)
+	case "scene":
+		return rl.NewColor(100, 180, 255, 255)
+	case "prefab":
+		return rl.NewColor(90, 200, 220, 255)
+	default:
+		return rl.NewColor(140, 140, 160, 255)
+	}
+}
+
+// createMaterialFromTexture writes a new material in assets/materials using the texture
+// as its albedo, then opens it in the material editor.
+func (e *Editor) createMaterialFromTexture(texturePath string) {
+	dir := filepath.Join("assets", "materials")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		e.setMsg("Create material failed: %v", err)
+		return
+	}
+
+	// Name after the texture, adding a suffix if a material already has that name
+	base := strings.TrimSuffix(filepath.Base(texturePath), filepath.Ext(texturePath))
+	path := filepath.Join(dir, base+".json")
+	for i := 1; ; i++ {
+		_, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			e.setMsg("Create material failed: %v", err)
+			return
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s_%d.json", base, i))
+	}
+
+	mat := &assets.Material{
+		Name:       base,
+		Color:      rl.White,
+		Metallic:   0,
+		Roughness:  0.5,
+		AlbedoPath: texturePath,
+	}
+	if err := assets.SaveMaterial(path, mat); err != nil {
+		e.setMsg("Create material failed: %v", err)
+		return
+	}
+
+	// Select it for editing
+	e.selectedMaterialPath = path
+	e.selectedMaterial = assets.LoadMaterial(path)
+	if e.currentAssetPath == dir {
+		e.scanAssets()
+	}
+
+	e.setMsg("Created material %s", filepath.Base(path))
+}
+
 // drawMaterialEditor draws the material properties editor in the asset browser
 func (e *Editor) drawMaterialEditor(x, y, w, h int32) {
 	// Background with border
@@ -359,6 +600,8 @@ func (e *Editor) drawMaterialEditor(x, y, w, h int32) {
 	oldMet := mat.Metallic
 	oldRough := mat.Roughness
 	oldEmit := mat.Emissive
+	oldCutoff := mat.AlphaCutoff
+	oldDoubleSided := mat.DoubleSided
 
 	// Material name (read-only for now)
 	drawTextEx(editorFont, "Name:", indent, propY+2, 13, colorTextMuted)
@@ -372,6 +615,29 @@ func (e *Editor) drawMaterialEditor(x, y, w, h int32) {
 	drawTextEx(editorFont, colorNameStr, indent+labelW+fieldH+6, propY+2, 13, colorTextSecondary)
 	propY += fieldH + 4
 
+	// Presets, wrapping onto a second row; applying one saves below like any edit
+	presetX := indent
+	for _, preset := range assets.MaterialPresets {
+		btnW := measureTextEx(editorFont, preset.Name, 12) + 10
+		if presetX+btnW > x+w-8 {
+			presetX = indent
+			propY += fieldH + 4
+		}
+		btn := rl.Rectangle{X: float32(presetX), Y: float32(propY), Width: float32(btnW), Height: float32(fieldH)}
+		hovered := rl.CheckCollisionPointRec(mousePos, btn)
+		btnColor := colorBgElement
+		if hovered {
+			btnColor = colorBgHover
+		}
+		rl.DrawRectangleRounded(btn, 0.3, 4, btnColor)
+		drawTextEx(editorFont, preset.Name, presetX+5, propY+3, 12, colorTextSecondary)
+		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			preset.Apply(mat)
+		}
+		presetX += btnW + 4
+	}
+	propY += fieldH + 4
+
 	// Metallic
 	drawTextEx(editorFont, "Metallic:", indent, propY+3, 13, colorTextMuted)
 	mat.Metallic = e.drawFloatField(indent+labelW, propY, fieldW, fieldH, "mated.met", mat.Metallic)
@@ -387,6 +653,17 @@ func (e *Editor) drawMaterialEditor(x, y, w, h int32) {
 	mat.Emissive = e.drawFloatField(indent+labelW, propY, fieldW, fieldH, "mated.emit", mat.Emissive)
 	propY += fieldH + 4
 
+	// Alpha cutout threshold (0 = opaque)
+	drawTextEx(editorFont, "Cutoff:", indent, propY+3, 13, colorTextMuted)
+	cutoffBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(propY), Width: float32(fieldW), Height: float32(fieldH)}
+	mat.AlphaCutoff = gui.Slider(cutoffBounds, "", fmt.Sprintf("%.2f", mat.AlphaCutoff), mat.AlphaCutoff, 0, 1)
+	propY += fieldH + 4
+
+	// Double-sided (no backface culling)
+	doubleSidedBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(propY), Width: float32(fieldH), Height: float32(fieldH)}
+	mat.DoubleSided = gui.CheckBox(doubleSidedBounds, "Double-sided", mat.DoubleSided)
+	propY += fieldH + 4
+
 	// Albedo texture path (editable)
 	drawTextEx(editorFont, "Albedo:", indent, propY+3, 13, colorTextMuted)
 	oldAlbedo := mat.AlbedoPath
@@ -401,7 +678,7 @@ func (e *Editor) drawMaterialEditor(x, y, w, h int32) {
 	}
 
 	// Auto-save if changed
-	if mat.Metallic != oldMet || mat.Roughness != oldRough || mat.Emissive != oldEmit || albedoChanged {
+	if mat.Metallic != oldMet || mat.Roughness != oldRough || mat.Emissive != oldEmit || mat.AlphaCutoff != oldCutoff || mat.DoubleSided != oldDoubleSided || albedoChanged {
 		assets.SaveMaterial(e.selectedMaterialPath, mat)
 	}
 }
@@ -470,16 +747,15 @@ func (e *Editor) applyMaterialToObject(obj *engine.GameObject, materialPath stri
 // spawnModelFromAsset creates a new GameObject with the given model
 func (e *Editor) spawnModelFromAsset(asset AssetEntry) {
 	obj := engine.NewGameObject(asset.Name)
-
-	// Position in front of camera
-	forward, _ := e.getDirections()
-	obj.Transform.Position = rl.Vector3Add(e.camera.Position, rl.Vector3Scale(forward, 5))
 	obj.Transform.Scale = rl.NewVector3(1, 1, 1)
 
 	// Add ModelRenderer
 	modelRenderer := components.NewModelRendererFromFile(asset.Path, rl.White)
 	obj.AddComponent(modelRenderer)
 
+	// On the surface under the cursor, or in front of the camera
+	obj.Transform.Position = e.spawnPosition(obj)
+
 	// Add to scene
 	e.world.Scene.AddGameObject(obj)
 	e.world.PhysicsWorld.AddObject(obj)
@@ -489,6 +765,20 @@ func (e *Editor) spawnModelFromAsset(asset AssetEntry) {
 	e.saveMsgTime = rl.GetTime()
 }
 
+// spawnPrefabFromAsset creates an instance of a prefab where spawnPosition puts it
+func (e *Editor) spawnPrefabFromAsset(asset AssetEntry) {
+	obj := e.world.InstantiatePrefab(asset.Path)
+	if obj == nil {
+		e.setMsg("Failed to load prefab %s", asset.Name)
+		return
+	}
+
+	obj.Transform.Position = e.spawnPosition(obj)
+	e.Selected = obj
+
+	e.setMsg("Spawned %s", obj.Name)
+}
+
 // openScene saves the current scene and loads a new one
 func (e *Editor) openScene(scenePath string) {
 	// Don't allow scene switching while paused (scene has runtime modifications)
@@ -498,15 +788,18 @@ func (e *Editor) openScene(scenePath string) {
 		return
 	}
 
+	// Save the edited scene, not the simulated one
+	e.stopSimulation()
+
 	// Don't reload if it's the same scene
-	if scenePath == world.ScenePath {
+	if scenePath == project.Current.CurrentScene {
 		e.saveMsg = "Already editing this scene"
 		e.saveMsgTime = rl.GetTime()
 		return
 	}
 
 	// Save current scene first
-	if err := e.world.SaveScene(world.ScenePath); err != nil {
+	if err := e.world.SaveScene(project.Current.CurrentScene); err != nil {
 		e.saveMsg = fmt.Sprintf("Save failed: %v", err)
 		e.saveMsgTime = rl.GetTime()
 		return
@@ -525,8 +818,10 @@ func (e *Editor) openScene(scenePath string) {
 	e.world.PhysicsWorld.Statics = e.world.PhysicsWorld.Statics[:0]
 	e.world.PhysicsWorld.Kinematics = e.world.PhysicsWorld.Kinematics[:0]
 
-	// Update the scene path
-	world.ScenePath = scenePath
+	// Update the scene path, keeping both scenes in the Recent Scenes menu
+	e.addRecentScene(project.Current.CurrentScene)
+	project.Current.CurrentScene = scenePath
+	e.addRecentScene(scenePath)
 
 	// Load the new scene
 	if err := e.world.LoadScene(scenePath); err != nil {
@@ -540,7 +835,8 @@ func (e *Editor) openScene(scenePath string) {
 
 	// Clear selection and undo stack
 	e.Selected = nil
-	e.undoStack = e.undoStack[:0]
+	e.clearUndo()
+	e.clearSelectionHistory()
 
 	e.saveMsg = fmt.Sprintf("Opened %s", filepath.Base(scenePath))
 	e.saveMsgTime = rl.GetTime()
@@ -558,54 +854,52 @@ func (e *Editor) scanAssets() {
 	// Sort: folders first, then files
 	for _, entry := range entries {
 		if entry.IsDir() {
-			e.assetFiles = append(e.assetFiles, AssetEntry{
-				Name:     entry.Name(),
-				Path:     filepath.Join(e.currentAssetPath, entry.Name()),
-				IsFolder: true,
-				Type:     "folder",
-			})
+			e.assetFiles = append(e.assetFiles, assetEntryFor(e.currentAssetPath, entry.Name(), true))
 		}
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// Skip hidden/system files and model import sidecars
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || assets.IsImportSettingsFile(entry.Name()) {
 			continue
 		}
+		e.assetFiles = append(e.assetFiles, assetEntryFor(e.currentAssetPath, entry.Name(), false))
+	}
+}
 
-		name := entry.Name()
-
-		// Skip hidden/system files
-		if strings.HasPrefix(name, ".") {
-			continue
-		}
+// assetEntryFor describes the file or folder name inside dir, picking the
+// asset type from its extension and folder
+func assetEntryFor(dir, name string, isFolder bool) AssetEntry {
+	fullPath := filepath.Join(dir, name)
+	if isFolder {
+		return AssetEntry{Name: name, Path: fullPath, IsFolder: true, Type: "folder"}
+	}
 
-		ext := strings.ToLower(filepath.Ext(name))
-		fullPath := filepath.Join(e.currentAssetPath, name)
-
-		var assetType string
-		switch ext {
-		case ".json":
-			// Check if in materials folder
-			if strings.Contains(e.currentAssetPath, "materials") {
-				assetType = "material"
-			} else if strings.Contains(e.currentAssetPath, "scenes") {
-				assetType = "scene"
-			} else {
-				assetType = "json"
-			}
-		case ".gltf", ".glb":
-			assetType = "model"
-		case ".png", ".jpg", ".jpeg":
-			assetType = "texture"
-		default:
-			assetType = "file"
+	var assetType string
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		// Prefabs anywhere, otherwise check if in materials folder
+		if strings.HasSuffix(strings.ToLower(name), world.PrefabExt) {
+			assetType = "prefab"
+		} else if strings.Contains(dir, "materials") {
+			assetType = "material"
+		} else if strings.Contains(dir, "scenes") {
+			assetType = "scene"
+		} else {
+			assetType = "json"
 		}
+	case ".gltf", ".glb":
+		assetType = "model"
+	case ".png", ".jpg", ".jpeg":
+		assetType = "texture"
+	default:
+		assetType = "file"
+	}
 
-		e.assetFiles = append(e.assetFiles, AssetEntry{
-			Name:     name,
-			Path:     fullPath,
-			IsFolder: false,
-			Type:     assetType,
-		})
+	return AssetEntry{
+		Name:     name,
+		Path:     fullPath,
+		IsFolder: false,
+		Type:     assetType,
 	}
 }
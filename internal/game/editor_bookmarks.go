@@ -0,0 +1,63 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/project"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// CameraBookmark is a saved editor viewpoint
+type CameraBookmark struct {
+	Position rl.Vector3 `json:"position"`
+	Yaw      float32    `json:"yaw"`
+	Pitch    float32    `json:"pitch"`
+}
+
+// Bookmark keys are the digit row, numbered 1-9
+const maxCameraBookmarks = 9
+
+func init() {
+	// One hidden entry per digit; the help overlay lists them as a single line
+	for i := 1; i <= maxCameraBookmarks; i++ {
+		slot := i
+		key := int32(rl.KeyOne + i - 1)
+		editorShortcuts = append(editorShortcuts,
+			shortcut{key: key, shift: true, action: func(e *Editor) { e.jumpToBookmark(slot) }},
+			shortcut{key: key, ctrl: true, shift: true, action: func(e *Editor) { e.saveBookmark(slot) }},
+		)
+	}
+}
+
+// sceneBookmarks returns the bookmarks of the open scene, creating the map if needed
+func (e *Editor) sceneBookmarks() map[int]CameraBookmark {
+	if e.bookmarks == nil {
+		e.bookmarks = make(map[string]map[int]CameraBookmark)
+	}
+	scene := project.Current.CurrentScene
+	if e.bookmarks[scene] == nil {
+		e.bookmarks[scene] = make(map[int]CameraBookmark)
+	}
+	return e.bookmarks[scene]
+}
+
+// saveBookmark stores the current camera in a bookmark slot
+func (e *Editor) saveBookmark(slot int) {
+	e.sceneBookmarks()[slot] = CameraBookmark{
+		Position: e.camera.Position,
+		Yaw:      e.camera.Yaw,
+		Pitch:    e.camera.Pitch,
+	}
+	e.setMsg("Saved camera bookmark %d", slot)
+}
+
+// jumpToBookmark smoothly moves the camera to a bookmark slot
+func (e *Editor) jumpToBookmark(slot int) {
+	b, ok := e.bookmarks[project.Current.CurrentScene][slot]
+	if !ok {
+		e.setMsg("No camera bookmark %d (Ctrl+Shift+%d to save)", slot, slot)
+		return
+	}
+	e.startCameraMove(b.Position, b.Yaw, b.Pitch)
+}
@@ -0,0 +1,78 @@
+//go:build !game
+
+package game
+
+import (
+	"reflect"
+
+	"test3d/internal/world"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// editingText reports whether a text field has the keyboard, in which case
+// Ctrl+C and Ctrl+V are left alone
+func (e *Editor) editingText() bool {
+	return e.editingName || e.editingTags || e.activeInputID != ""
+}
+
+// copySelection puts the focused component, or the selected object with its
+// children when no component header is focused, on the editor clipboard
+func (e *Editor) copySelection() {
+	if e.Selected == nil || e.editingText() {
+		return
+	}
+
+	if c := e.focusedComponent; c != nil && c.GetGameObject() == e.Selected {
+		typeName := reflect.TypeOf(c).Elem().Name()
+		raw := world.CopyComponent(c)
+		if raw == nil {
+			e.setMsg("%s can't be copied", typeName)
+			return
+		}
+		e.clipboardComponent = raw
+		e.clipboardObject = nil
+		e.setMsg("Copied %s", typeName)
+		return
+	}
+
+	def := world.CopyObject(e.Selected)
+	e.clipboardObject = &def
+	e.clipboardComponent = nil
+	e.setMsg("Copied %s", e.Selected.Name)
+}
+
+// pasteClipboard adds the clipboard contents to the scene: a copied object in
+// front of the camera, a copied component to the selected object
+func (e *Editor) pasteClipboard() {
+	if e.editingText() {
+		return
+	}
+
+	switch {
+	case e.clipboardObject != nil:
+		obj := e.world.PasteObject(*e.clipboardObject)
+		forward, _ := e.getDirections()
+		obj.Transform.Position = rl.Vector3Add(e.camera.Position, rl.Vector3Scale(forward, 5))
+		e.Selected = obj
+		e.setMsg("Pasted %s", obj.Name)
+
+	case e.clipboardComponent != nil:
+		if e.Selected == nil {
+			e.setMsg("Select an object to paste %s onto", world.CopiedComponentName(e.clipboardComponent))
+			return
+		}
+		comp, err := e.world.PasteComponent(e.Selected, e.clipboardComponent)
+		if err != nil {
+			e.setMsg("Paste failed: %v", err)
+			return
+		}
+
+		// Re-add it as an undoable step, which also attaches the PlayerCollision
+		// a PlayerController script needs, as addScript does
+		e.Selected.RemoveComponent(comp)
+		e.runUndoable(e.addComponentCommand(comp))
+		e.focusedComponent = comp
+		e.setMsg("Pasted %s onto %s", reflect.TypeOf(comp).Elem().Name(), e.Selected.Name)
+	}
+}
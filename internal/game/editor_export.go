@@ -0,0 +1,106 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gui "github.com/gen2brain/raylib-go/raygui"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const exportScenePathID = "exportScene.path"
+
+// openExportScene shows the Export Selection as Scene panel (Ctrl+Shift+E),
+// suggesting a file named after the first selected object
+func (e *Editor) openExportScene() {
+	sel := e.selection()
+	if len(sel) == 0 {
+		e.setMsg("Nothing selected to export")
+		return
+	}
+	name := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(sel[0].Name), " ", "_"))
+	if name == "" {
+		name = "selection"
+	}
+	e.exportScenePath = filepath.ToSlash(filepath.Join("assets", "scenes", name+".json"))
+	e.exportKeepUIDs = true
+	e.showExportScene = true
+}
+
+// exportSelection writes the selection to the chosen scene file
+func (e *Editor) exportSelection() {
+	path := strings.TrimSpace(e.exportScenePath)
+	if path == "" {
+		return
+	}
+	if !strings.HasSuffix(path, ".json") {
+		path += ".json"
+	}
+	if err := e.world.ExportScene(e.selection(), path, e.exportKeepUIDs); err != nil {
+		e.setMsg("Export failed: %v", err)
+		return
+	}
+	e.setMsg("Exported to %s", path)
+	e.showExportScene = false
+}
+
+// drawExportScene draws the export panel: the target path, whether to keep
+// UIDs, and Export/Cancel buttons. The current scene is left untouched.
+func (e *Editor) drawExportScene() {
+	if !e.showExportScene {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) && e.activeInputID != exportScenePathID {
+		e.showExportScene = false
+		return
+	}
+
+	const (
+		panelW = int32(460)
+		panelH = int32(200)
+		pad    = int32(16)
+		fieldH = int32(24)
+		btnW   = int32(80)
+		btnH   = int32(24)
+	)
+	screenW := int32(rl.GetScreenWidth())
+	screenH := int32(rl.GetScreenHeight())
+	panelX := (screenW - panelW) / 2
+	panelY := (screenH - panelH) / 2
+
+	rl.DrawRectangle(0, 0, screenW, screenH, rl.NewColor(0, 0, 0, 140))
+	bounds := rl.Rectangle{X: float32(panelX), Y: float32(panelY), Width: float32(panelW), Height: float32(panelH)}
+	rl.DrawRectangleRounded(bounds, 0.05, 8, colorBgPanel)
+	rl.DrawRectangleRoundedLines(bounds, 0.05, 8, colorAccent)
+
+	drawTextEx(editorFontBold, "Export Selection as Scene", panelX+pad, panelY+pad-4, 20, colorTextPrimary)
+	sel := e.selection()
+	summary := fmt.Sprintf("%d object(s) with their children, kept in this scene", len(sel))
+	drawTextEx(editorFont, summary, panelX+pad, panelY+pad+20, 14, colorTextMuted)
+
+	y := panelY + pad + 46
+	e.exportScenePath = e.drawTextField(panelX+pad, y, panelW-2*pad, fieldH, exportScenePathID, e.exportScenePath)
+	y += fieldH + 8
+
+	keepBounds := rl.Rectangle{X: float32(panelX + pad), Y: float32(y), Width: 18, Height: 18}
+	e.exportKeepUIDs = gui.CheckBox(keepBounds, "Keep UIDs (otherwise new ones are assigned)", e.exportKeepUIDs)
+	y += 26
+
+	if _, err := os.Stat(e.exportScenePath); err == nil {
+		drawTextEx(editorFont, "File exists and will be overwritten", panelX+pad, y, 14, rl.Orange)
+	}
+
+	btnY := panelY + panelH - pad - btnH
+	cancelX := panelX + panelW - pad - btnW
+	exportX := cancelX - btnW - 8
+	if drawDialogButton(exportX, btnY, btnW, btnH, "Export", true) || (rl.IsKeyPressed(rl.KeyEnter) && e.activeInputID == "") {
+		e.exportSelection()
+	}
+	if drawDialogButton(cancelX, btnY, btnW, btnH, "Cancel", false) {
+		e.showExportScene = false
+	}
+}
@@ -0,0 +1,114 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// generatedScriptsDir is where cmd/gen-scripts writes the script wrappers
+const generatedScriptsDir = "internal/scripts"
+
+// scriptRegistration matches the engine.RegisterScript* call in a generated file
+var scriptRegistration = regexp.MustCompile(`engine\.RegisterScript\w*\("([^"]+)"`)
+
+// regenerateScripts runs gen-scripts and type-checks the result without
+// rebuilding the editor, then reports which scripts the running binary doesn't
+// have yet. They become available after the next Ctrl+R.
+func (e *Editor) regenerateScripts() {
+	e.rebuildMutex.Lock()
+	if e.rebuildInProgress {
+		e.rebuildMutex.Unlock()
+		return
+	}
+	e.rebuildInProgress = true
+	e.rebuildProgress = 0.0
+	e.rebuildStage = "Generating scripts..."
+	e.rebuildMutex.Unlock()
+
+	go func() {
+		finish := func(msg string) {
+			e.rebuildMutex.Lock()
+			e.rebuildInProgress = false
+			e.saveMsg = msg
+			e.saveMsgTime = rl.GetTime()
+			e.rebuildMutex.Unlock()
+		}
+
+		fmt.Println("Generating scripts...")
+		output, err := exec.Command("go", "run", "./cmd/gen-scripts").CombinedOutput()
+		fmt.Print(string(output))
+		if err != nil {
+			finish("Script generation failed!")
+			return
+		}
+
+		e.rebuildMutex.Lock()
+		e.rebuildProgress = 0.5
+		e.rebuildStage = "Checking scripts..."
+		e.rebuildMutex.Unlock()
+
+		if output, err := exec.Command("go", "build", "./"+generatedScriptsDir).CombinedOutput(); err != nil {
+			fmt.Printf("Script compile error:\n%s\n", string(output))
+			finish("Generated scripts don't compile (see console)")
+			return
+		}
+
+		generated, err := generatedScriptNames(generatedScriptsDir)
+		if err != nil {
+			finish(fmt.Sprintf("Failed to read %s: %v", generatedScriptsDir, err))
+			return
+		}
+		added := newScripts(generated, engine.GetRegisteredScripts())
+		if len(added) == 0 {
+			fmt.Println("No new scripts")
+			finish("Scripts regenerated, no new scripts")
+			return
+		}
+		for _, name := range added {
+			fmt.Printf("  + %s\n", name)
+		}
+		finish(fmt.Sprintf("New scripts ready: %s - press Ctrl+R to load them", strings.Join(added, ", ")))
+	}()
+}
+
+// generatedScriptNames returns the names registered by the script files in dir
+func generatedScriptNames(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range scriptRegistration.FindAllSubmatch(data, -1) {
+			names = append(names, string(m[1]))
+		}
+	}
+	return names, nil
+}
+
+// newScripts returns the sorted names in generated that aren't in registered
+func newScripts(generated, registered []string) []string {
+	var added []string
+	for _, name := range generated {
+		if !slices.Contains(registered, name) && !slices.Contains(added, name) {
+			added = append(added, name)
+		}
+	}
+	slices.Sort(added)
+	return added
+}
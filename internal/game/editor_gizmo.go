@@ -7,6 +7,8 @@ import (
 
 	"test3d/internal/components"
 	"test3d/internal/engine"
+	"test3d/internal/physics"
+	"test3d/internal/world"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -34,7 +36,28 @@ var gizmoAxes = [3]rl.Vector3{
 
 var gizmoColors = [3]rl.Color{rl.Red, rl.Green, rl.Blue}
 
-// pickGizmoAxis returns the index of the gizmo axis closest to the mouse ray, or -1.
+// Gizmo handle indices after the three axes (0=X, 1=Y, 2=Z)
+const (
+	gizmoPlaneYZ = 3 // planar handles - the plane's normal is gizmoAxes[idx-gizmoPlaneYZ]
+	gizmoPlaneXZ = 4
+	gizmoPlaneXY = 5
+	gizmoCenter  = 6 // screen-space handle, drags in the camera-facing plane
+)
+
+const (
+	gizmoPlaneOffset float32 = 0.5  // planar quads start this far from the center
+	gizmoPlaneSize   float32 = 0.45 // and are this wide
+	gizmoCenterSize  float32 = 0.18
+)
+
+// planeHandleAxes returns the two in-plane axes of a planar handle
+func planeHandleAxes(idx int) (u, v rl.Vector3) {
+	normal := idx - gizmoPlaneYZ
+	return gizmoAxes[(normal+1)%3], gizmoAxes[(normal+2)%3]
+}
+
+// pickGizmoAxis returns the index of the gizmo handle under the mouse ray, or -1.
+// Axes are 0-2; the move gizmo also has planar quads and a center handle.
 func (e *Editor) pickGizmoAxis(ray rl.Ray) int {
 	if e.Selected == nil {
 		return -1
@@ -44,6 +67,38 @@ func (e *Editor) pickGizmoAxis(ray rl.Ray) int {
 	bestDist := float32(999.0)
 	bestAxis := -1
 
+	if e.gizmoMode == GizmoMove {
+		// Center handle first, then the planar quads - both sit on top of the axes
+		if rl.GetRayCollisionSphere(ray, center, gizmoCenterSize*1.5).Hit {
+			return gizmoCenter
+		}
+		for idx := gizmoPlaneYZ; idx <= gizmoPlaneXY; idx++ {
+			pt, ok := rayPlaneIntersect(ray.Position, ray.Direction, center, gizmoAxes[idx-gizmoPlaneYZ])
+			if !ok {
+				continue
+			}
+			u, v := planeHandleAxes(idx)
+			rel := rl.Vector3Subtract(pt, center)
+			du, dv := rl.Vector3DotProduct(rel, u), rl.Vector3DotProduct(rel, v)
+			if du >= gizmoPlaneOffset && du <= gizmoPlaneOffset+gizmoPlaneSize &&
+				dv >= gizmoPlaneOffset && dv <= gizmoPlaneOffset+gizmoPlaneSize {
+				return idx
+			}
+		}
+	}
+
+	if e.gizmoMode == GizmoScale {
+		// Center cube scales uniformly
+		half := gizmoCenterSize * 1.5
+		box := rl.BoundingBox{
+			Min: rl.Vector3Subtract(center, rl.Vector3{X: half, Y: half, Z: half}),
+			Max: rl.Vector3Add(center, rl.Vector3{X: half, Y: half, Z: half}),
+		}
+		if rl.GetRayCollisionBox(ray, box).Hit {
+			return gizmoCenter
+		}
+	}
+
 	if e.gizmoMode == GizmoRotate {
 		// For rotation gizmo, check distance to each ring
 		radius := gizmoLength * 0.8
@@ -89,16 +144,43 @@ func (e *Editor) pickGizmoAxis(ray rl.Ray) int {
 }
 
 func (e *Editor) startDrag(axisIdx int, ray rl.Ray) {
-	// Save undo state before modifying
-	e.pushUndo()
+	// Remember where the selection was, for undo once the drag ends
+	e.beginTransformUndo()
 
 	e.dragging = true
 	e.dragAxisIdx = axisIdx
-	e.dragAxis = gizmoAxes[axisIdx]
+	e.vertexSnap = vertexSnap{}
 	e.dragInitPos = e.Selected.Transform.Position
 	e.dragInitWorldPos = e.Selected.WorldPosition()
 	e.dragInitRot = e.Selected.Transform.Rotation
 	e.dragInitScale = e.Selected.Transform.Scale
+	e.startGroupDrag()
+
+	// Planar and center handles drag freely within a plane
+	if axisIdx >= gizmoPlaneYZ {
+		e.dragAxis = rl.Vector3{}
+		if axisIdx == gizmoCenter {
+			e.dragPlaneNormal = rl.Vector3Normalize(rl.Vector3Subtract(e.dragInitWorldPos, e.camera.Position))
+		} else {
+			e.dragPlaneNormal = gizmoAxes[axisIdx-gizmoPlaneYZ]
+		}
+		if pt, ok := rayPlaneIntersect(ray.Position, ray.Direction, e.dragInitWorldPos, e.dragPlaneNormal); ok {
+			e.dragStartPoint = pt
+		} else {
+			e.dragStartPoint = e.dragInitWorldPos
+		}
+		if e.gizmoMode == GizmoScale {
+			// Dragging up or right on screen grows the object
+			_, right := e.getDirections()
+			up := rl.Vector3CrossProduct(right, e.dragPlaneNormal)
+			if up.Y < 0 {
+				up = rl.Vector3Negate(up)
+			}
+			e.dragAxis = rl.Vector3Normalize(rl.Vector3Add(rl.Vector3Negate(right), up))
+		}
+		return
+	}
+	e.dragAxis = gizmoAxes[axisIdx]
 
 	// Build a drag plane using world position for correct 3D picking
 	viewDir := rl.Vector3Normalize(rl.Vector3Subtract(e.dragInitWorldPos, e.camera.Position))
@@ -116,46 +198,40 @@ func (e *Editor) updateDrag(ray rl.Ray) {
 		return
 	}
 
+	// Holding V pins a vertex of the selection to one under the cursor
+	if e.updateVertexSnap(ray) {
+		return
+	}
+
+	// Holding Ctrl rounds whatever the handle writes below to the snap grid
+	if snapping() {
+		defer e.snapDrag()
+	}
+
 	// Use the stored initial world position for drag plane intersection
 	pt, ok := rayPlaneIntersect(ray.Position, ray.Direction, e.dragInitWorldPos, e.dragPlaneNormal)
 	if !ok {
 		return
 	}
 
+	// Planar and center handles follow the mouse across their plane
+	if e.dragAxisIdx >= gizmoPlaneYZ {
+		switch e.gizmoMode {
+		case GizmoMove:
+			e.applyWorldMove(rl.Vector3Subtract(pt, e.dragStartPoint))
+		case GizmoScale:
+			delta := rl.Vector3DotProduct(rl.Vector3Subtract(pt, e.dragStartPoint), e.dragAxis)
+			e.Selected.Transform.Scale = rl.Vector3Scale(e.dragInitScale, scaleDragFactor(delta))
+		}
+		return
+	}
+
 	currentT := rl.Vector3DotProduct(rl.Vector3Subtract(pt, e.dragInitWorldPos), e.dragAxis)
 	delta := currentT - e.dragStart
 
 	switch e.gizmoMode {
 	case GizmoMove:
-		// Calculate world-space delta
-		worldDelta := rl.Vector3Scale(e.dragAxis, delta)
-
-		// Convert to local space if object has a parent
-		if e.Selected.Parent != nil {
-			// Get inverse parent rotation
-			parentRot := e.Selected.Parent.WorldRotation()
-			rx := float64(-parentRot.X) * math.Pi / 180
-			ry := float64(-parentRot.Y) * math.Pi / 180
-			rz := float64(-parentRot.Z) * math.Pi / 180
-			// Inverse rotation order: Z, Y, X (reverse of forward)
-			rotZ := rl.MatrixRotateZ(float32(rz))
-			rotY := rl.MatrixRotateY(float32(ry))
-			rotX := rl.MatrixRotateX(float32(rx))
-			invRotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotZ, rotY), rotX)
-
-			// Rotate delta into parent's local space
-			localDelta := rl.Vector3Transform(worldDelta, invRotMatrix)
-
-			// Account for parent scale
-			parentScale := e.Selected.Parent.WorldScale()
-			localDelta.X /= parentScale.X
-			localDelta.Y /= parentScale.Y
-			localDelta.Z /= parentScale.Z
-
-			e.Selected.Transform.Position = rl.Vector3Add(e.dragInitPos, localDelta)
-		} else {
-			e.Selected.Transform.Position = rl.Vector3Add(e.dragInitPos, worldDelta)
-		}
+		e.applyWorldMove(rl.Vector3Scale(e.dragAxis, delta))
 
 	case GizmoRotate:
 		// Map drag distance to degrees (1 unit = 45 degrees)
@@ -172,10 +248,11 @@ func (e *Editor) updateDrag(ray rl.Ray) {
 		e.Selected.Transform.Rotation = rot
 
 	case GizmoScale:
-		// Map drag distance to scale factor (drag right = bigger)
-		factor := float32(1.0) + delta*0.5
-		if factor < 0.1 {
-			factor = 0.1
+		factor := scaleDragFactor(delta)
+		// Shift scales all three axes by the same factor
+		if rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift) {
+			e.Selected.Transform.Scale = rl.Vector3Scale(e.dragInitScale, factor)
+			return
 		}
 		s := e.dragInitScale
 		switch e.dragAxisIdx {
@@ -190,27 +267,115 @@ func (e *Editor) updateDrag(ray rl.Ray) {
 	}
 }
 
+// snapDrag rounds the values the current drag changed to the snap increments
+func (e *Editor) snapDrag() {
+	t := &e.Selected.Transform
+	switch e.gizmoMode {
+	case GizmoMove:
+		t.Position = snapVector(t.Position, e.dragInitPos, e.snap.Move)
+	case GizmoRotate:
+		t.Rotation = snapVector(t.Rotation, e.dragInitRot, e.snap.Rotate)
+	case GizmoScale:
+		t.Scale = snapScaleVector(t.Scale, e.dragInitScale, e.snap.Scale)
+	}
+}
+
+// scaleDragFactor maps drag distance to a scale factor (drag outward = bigger)
+func scaleDragFactor(delta float32) float32 {
+	return max(1.0+delta*0.5, 0.1)
+}
+
+// applyWorldMove offsets the selected object from its drag-start position by a
+// world-space delta, converting into the parent's local space if it has one.
+func (e *Editor) applyWorldMove(worldDelta rl.Vector3) {
+	if e.Selected.Parent != nil {
+		// Inverse parent rotation (the transpose of a pure rotation)
+		invRotMatrix := rl.MatrixTranspose(e.Selected.Parent.WorldRotationMatrix())
+
+		// Rotate delta into parent's local space
+		localDelta := rl.Vector3Transform(worldDelta, invRotMatrix)
+
+		// Account for parent scale
+		parentScale := e.Selected.Parent.WorldScale()
+		localDelta.X /= parentScale.X
+		localDelta.Y /= parentScale.Y
+		localDelta.Z /= parentScale.Z
+
+		e.Selected.Transform.Position = rl.Vector3Add(e.dragInitPos, localDelta)
+	} else {
+		e.Selected.Transform.Position = rl.Vector3Add(e.dragInitPos, worldDelta)
+	}
+}
+
 // Draw3D draws selection wireframes and gizmo. Call inside BeginMode3D/EndMode3D.
 func (e *Editor) Draw3D() {
 	// Ensure depth testing is enabled for component gizmos (colliders, lights, cameras)
 	// so they render correctly in 3D space
 	rl.EnableDepthTest()
 
+	if e.showGrid {
+		e.drawGroundGrid()
+	}
+
 	// Draw component gizmos for all objects (depth-tested)
 	for _, g := range e.world.Scene.GameObjects {
 		e.drawAlwaysOnGizmos(g)
 	}
 
+	if e.showPhysicsGrid {
+		e.drawPhysicsGrid()
+	}
+
+	e.drawJoints()
+	e.drawPatrolPaths()
+
 	// Flush the depth-tested gizmos before switching modes
 	rl.DrawRenderBatchActive()
 
 	// Draw transform gizmo for selected object (always on top)
 	e.drawSelectionGizmo()
+	e.drawVertexSnap()
+}
+
+// drawPhysicsGrid draws the occupied broad-phase grid cells, colored by how many
+// dynamic objects they hold, and the cells queried for the selected object.
+func (e *Editor) drawPhysicsGrid() {
+	pw := e.world.PhysicsWorld
+	for key, count := range pw.GridOccupancy() {
+		b := physics.GridCellBounds(key)
+		center := rl.Vector3Scale(rl.Vector3Add(b.Min, b.Max), 0.5)
+		rl.DrawCubeWiresV(center, rl.Vector3Subtract(b.Max, b.Min), gridOccupancyColor(count))
+	}
+
+	if e.Selected == nil {
+		return
+	}
+	for _, key := range pw.NeighborCells(e.Selected) {
+		b := physics.GridCellBounds(key)
+		center := rl.Vector3Scale(rl.Vector3Add(b.Min, b.Max), 0.5)
+		// Shrink slightly so the queried cells don't z-fight with occupied cells
+		size := rl.Vector3Scale(rl.Vector3Subtract(b.Max, b.Min), 0.98)
+		rl.DrawCubeWiresV(center, size, rl.Fade(rl.SkyBlue, 0.6))
+	}
+}
+
+// gridOccupancyColor maps a cell's object count to a green-to-red scale
+func gridOccupancyColor(count int) rl.Color {
+	switch {
+	case count >= 16:
+		return rl.Red
+	case count >= 8:
+		return rl.Orange
+	case count >= 3:
+		return rl.Yellow
+	default:
+		return rl.Fade(rl.Green, 0.7)
+	}
 }
 
 // drawAlwaysOnGizmos draws gizmos that are always visible (not just when selected)
 func (e *Editor) drawAlwaysOnGizmos(g *engine.GameObject) {
-	isSelected := g == e.Selected
+	isSelected := e.isSelected(g)
 
 	// Point lights - always show
 	if pl := engine.GetComponent[*components.PointLight](g); pl != nil {
@@ -219,25 +384,53 @@ func (e *Editor) drawAlwaysOnGizmos(g *engine.GameObject) {
 		rl.DrawSphereWires(pos, pl.Radius, 8, 8, rl.Fade(pl.Color, 0.3))
 	}
 
-	// Box colliders - always show (green, yellow if selected)
+	// Spot lights - always show, with the cone while selected to help aim them
+	if sl := engine.GetComponent[*components.SpotLight](g); sl != nil {
+		pos := sl.GetPosition()
+		rl.DrawSphere(pos, 0.15, sl.Color)
+		if isSelected {
+			_, cosOuter := sl.ConeCos()
+			end := rl.Vector3Add(pos, rl.Vector3Scale(sl.GetDirection(), sl.Range))
+			radius := sl.Range * float32(math.Sqrt(float64(1-cosOuter*cosOuter))) / cosOuter // Range * tan(outer)
+			rl.DrawCylinderWiresEx(pos, end, 0, radius, 12, rl.Fade(sl.Color, 0.5))
+		}
+	}
+
+	// Box colliders - always show (green, sky blue for triggers, yellow if selected)
 	if box := engine.GetComponent[*components.BoxCollider](g); box != nil {
 		center := box.GetCenter()
 		rot := g.WorldRotation()
 		color := rl.Fade(rl.Green, 0.5)
+		if box.IsTrigger {
+			color = rl.Fade(rl.SkyBlue, 0.5)
+		}
 		if isSelected {
 			color = rl.Yellow
 		}
-		drawRotatedBoxWires(center, box.GetWorldSize(), rot, color)
+		world.DrawRotatedBoxWires(center, box.GetWorldSize(), rot, color)
 	}
 
 	// Sphere colliders - always show
 	if sphere := engine.GetComponent[*components.SphereCollider](g); sphere != nil {
 		center := sphere.GetCenter()
 		color := rl.Fade(rl.Green, 0.5)
+		if sphere.IsTrigger {
+			color = rl.Fade(rl.SkyBlue, 0.5)
+		}
 		if isSelected {
 			color = rl.Yellow
 		}
-		rl.DrawSphereWires(center, sphere.Radius, 8, 8, color)
+		rl.DrawSphereWires(center, sphere.GetWorldRadius(), 8, 8, color)
+	}
+
+	// Capsule colliders - always show
+	if capsule := engine.GetComponent[*components.CapsuleCollider](g); capsule != nil {
+		a, b := capsule.Segment()
+		color := rl.Fade(rl.Green, 0.5)
+		if isSelected {
+			color = rl.Yellow
+		}
+		rl.DrawCapsuleWires(a, b, capsule.GetWorldRadius(), 8, 4, color)
 	}
 
 	// Character controllers - always show (green wireframe)
@@ -260,12 +453,38 @@ func (e *Editor) drawAlwaysOnGizmos(g *engine.GameObject) {
 		e.drawCameraGizmo(g, cam, isSelected)
 	}
 
+	// Empty objects - marker so they can be seen and clicked
+	if physics.IsEmptyObject(g) {
+		color := rl.Fade(rl.White, 0.5)
+		if isSelected {
+			color = rl.Yellow
+		}
+		drawOctahedronWires(g.WorldPosition(), physics.EditorMarkerRadius, color)
+	}
+
 	// Recurse into children
 	for _, child := range g.Children {
 		e.drawAlwaysOnGizmos(child)
 	}
 }
 
+// drawOctahedronWires draws a wireframe octahedron with its tips radius away from center
+func drawOctahedronWires(center rl.Vector3, radius float32, color rl.Color) {
+	tips := [6]rl.Vector3{
+		{X: center.X + radius, Y: center.Y, Z: center.Z},
+		{X: center.X, Y: center.Y, Z: center.Z + radius},
+		{X: center.X - radius, Y: center.Y, Z: center.Z},
+		{X: center.X, Y: center.Y, Z: center.Z - radius},
+		{X: center.X, Y: center.Y + radius, Z: center.Z},
+		{X: center.X, Y: center.Y - radius, Z: center.Z},
+	}
+	for i := 0; i < 4; i++ {
+		rl.DrawLine3D(tips[i], tips[(i+1)%4], color) // equator
+		rl.DrawLine3D(tips[i], tips[4], color)
+		rl.DrawLine3D(tips[i], tips[5], color)
+	}
+}
+
 // drawCameraGizmo draws the camera frustum wireframe
 func (e *Editor) drawCameraGizmo(g *engine.GameObject, cam *components.Camera, isSelected bool) {
 	pos := g.WorldPosition()
@@ -399,11 +618,52 @@ func (e *Editor) drawSelectionGizmo() {
 		}
 	}
 
+	switch e.gizmoMode {
+	case GizmoMove:
+		e.drawPlaneHandles(center)
+	case GizmoScale:
+		size := rl.Vector3{X: gizmoCenterSize * 2, Y: gizmoCenterSize * 2, Z: gizmoCenterSize * 2}
+		rl.DrawCubeV(center, size, e.handleColor(gizmoCenter, rl.White))
+		rl.DrawCubeWiresV(center, size, rl.DarkGray)
+	}
+
 	// Re-enable depth testing
 	rl.DrawRenderBatchActive() // Force flush of gizmo draw calls
 	rl.EnableDepthTest()
 }
 
+// handleColor returns a gizmo handle's color, yellow while hovered or dragged
+func (e *Editor) handleColor(idx int, base rl.Color) rl.Color {
+	if (e.dragging && e.dragAxisIdx == idx) || (!e.dragging && e.hoveredAxis == idx) {
+		return rl.Yellow
+	}
+	return base
+}
+
+// drawPlaneHandles draws the move gizmo's planar quads and center handle
+func (e *Editor) drawPlaneHandles(center rl.Vector3) {
+	for idx := gizmoPlaneYZ; idx <= gizmoPlaneXY; idx++ {
+		u, v := planeHandleAxes(idx)
+		color := e.handleColor(idx, rl.Fade(gizmoColors[idx-gizmoPlaneYZ], 0.45))
+
+		origin := rl.Vector3Add(center, rl.Vector3Scale(rl.Vector3Add(u, v), gizmoPlaneOffset))
+		du := rl.Vector3Scale(u, gizmoPlaneSize)
+		dv := rl.Vector3Scale(v, gizmoPlaneSize)
+		c0 := origin
+		c1 := rl.Vector3Add(origin, du)
+		c2 := rl.Vector3Add(c1, dv)
+		c3 := rl.Vector3Add(origin, dv)
+
+		// Both windings so the quad shows from either side
+		rl.DrawTriangle3D(c0, c1, c2, color)
+		rl.DrawTriangle3D(c0, c2, c3, color)
+		rl.DrawTriangle3D(c0, c2, c1, color)
+		rl.DrawTriangle3D(c0, c3, c2, color)
+	}
+
+	rl.DrawSphere(center, gizmoCenterSize*0.6, e.handleColor(gizmoCenter, rl.White))
+}
+
 // --- math helpers ---
 
 // closestPointBetweenRays finds the closest approach between two rays.
@@ -443,56 +703,6 @@ func rayPlaneIntersect(rayOrigin, rayDir, planePoint, planeNormal rl.Vector3) (r
 	return rl.Vector3Add(rayOrigin, rl.Vector3Scale(rayDir, t)), true
 }
 
-// drawRotatedBoxWires draws a wireframe box with rotation applied
-func drawRotatedBoxWires(center, size, rotation rl.Vector3, color rl.Color) {
-	// Build rotation matrix
-	rx := float64(rotation.X) * math.Pi / 180
-	ry := float64(rotation.Y) * math.Pi / 180
-	rz := float64(rotation.Z) * math.Pi / 180
-	rotX := rl.MatrixRotateX(float32(rx))
-	rotY := rl.MatrixRotateY(float32(ry))
-	rotZ := rl.MatrixRotateZ(float32(rz))
-	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
-
-	// Half extents
-	hx, hy, hz := size.X/2, size.Y/2, size.Z/2
-
-	// 8 corners in local space
-	corners := [8]rl.Vector3{
-		{X: -hx, Y: -hy, Z: -hz},
-		{X: hx, Y: -hy, Z: -hz},
-		{X: hx, Y: hy, Z: -hz},
-		{X: -hx, Y: hy, Z: -hz},
-		{X: -hx, Y: -hy, Z: hz},
-		{X: hx, Y: -hy, Z: hz},
-		{X: hx, Y: hy, Z: hz},
-		{X: -hx, Y: hy, Z: hz},
-	}
-
-	// Transform corners to world space
-	for i := range corners {
-		corners[i] = rl.Vector3Transform(corners[i], rotMatrix)
-		corners[i] = rl.Vector3Add(corners[i], center)
-	}
-
-	// Draw 12 edges
-	// Bottom face
-	rl.DrawLine3D(corners[0], corners[1], color)
-	rl.DrawLine3D(corners[1], corners[2], color)
-	rl.DrawLine3D(corners[2], corners[3], color)
-	rl.DrawLine3D(corners[3], corners[0], color)
-	// Top face
-	rl.DrawLine3D(corners[4], corners[5], color)
-	rl.DrawLine3D(corners[5], corners[6], color)
-	rl.DrawLine3D(corners[6], corners[7], color)
-	rl.DrawLine3D(corners[7], corners[4], color)
-	// Vertical edges
-	rl.DrawLine3D(corners[0], corners[4], color)
-	rl.DrawLine3D(corners[1], corners[5], color)
-	rl.DrawLine3D(corners[2], corners[6], color)
-	rl.DrawLine3D(corners[3], corners[7], color)
-}
-
 func absF(x float32) float32 {
 	if x < 0 {
 		return -x
@@ -5,6 +5,9 @@ package game
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"strings"
+	"test3d/internal/components"
 	"test3d/internal/engine"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -24,6 +27,7 @@ func (e *Editor) drawHierarchy() {
 
 	// Header
 	drawTextEx(editorFontBold, "Hierarchy", panelX+12, panelY+8, 18, colorTextSecondary)
+	headerW := measureTextEx(editorFontBold, "Hierarchy", 18)
 
 	// "New Object" button - rounded pill
 	btnX := panelX + panelW - 62
@@ -50,11 +54,30 @@ func (e *Editor) drawHierarchy() {
 		clickedNewButton = true
 	}
 
-	y := panelY + 28
+	// Filter row: name/tag text and component type
+	filterY := panelY + 32
+	compBtnW := min(110, panelW/2)
+	e.hierarchyFilter = e.drawTextField(panelX+8, filterY, panelW-compBtnW-20, 22, hierarchyFilterID, e.hierarchyFilter)
+	filterText := e.hierarchyFilter
+	if e.activeInputID == hierarchyFilterID {
+		filterText = e.inputTextValue // filter live while typing
+	}
+	compBtn := rl.Rectangle{X: float32(panelX + panelW - compBtnW - 8), Y: float32(filterY), Width: float32(compBtnW), Height: 22}
+	clickedFilterButton := e.drawCompFilterButton(compBtn, mousePos)
+
+	listTop := panelY + 60
+	y := listTop + 4
+
+	// The open filter menu covers the list below it
+	var menuRect rl.Rectangle
+	if e.showCompFilterMenu {
+		menuRect = compFilterMenuRect(compBtn)
+	}
 
 	// Scroll with mouse wheel when hovering hierarchy
 	mouseInPanel := mousePos.X >= float32(panelX) && mousePos.X <= float32(panelX+panelW) &&
-		mousePos.Y >= float32(panelY) && mousePos.Y <= float32(panelY+panelH)
+		mousePos.Y >= float32(panelY) && mousePos.Y <= float32(panelY+panelH) &&
+		!(e.showCompFilterMenu && rl.CheckCollisionPointRec(mousePos, menuRect))
 
 	if mouseInPanel && !rl.IsMouseButtonDown(rl.MouseRightButton) {
 		scroll := rl.GetMouseWheelMove()
@@ -65,7 +88,15 @@ func (e *Editor) drawHierarchy() {
 	}
 
 	itemH := int32(22)
-	objects := e.world.Scene.GameObjects
+	objects := e.filteredHierarchy(filterText)
+
+	// Object count next to the header ("shown / total" while filtering)
+	total := len(e.world.Scene.GameObjects)
+	count := fmt.Sprintf("%d", total)
+	if len(objects) != total {
+		count = fmt.Sprintf("%d / %d", len(objects), total)
+	}
+	drawTextEx(editorFont, count, panelX+18+headerW, panelY+11, 14, colorTextMuted)
 	maxScroll := int32(len(objects))*itemH - panelH + 30
 	if maxScroll < 0 {
 		maxScroll = 0
@@ -75,28 +106,48 @@ func (e *Editor) drawHierarchy() {
 	}
 
 	// Reset drop target each frame
-	e.hierarchyDropTarget = nil
+	e.hierarchyDrop = nil
 
 	// Clip to panel area
-	rl.BeginScissorMode(panelX, panelY+24, panelW, panelH-24)
+	rl.BeginScissorMode(panelX, listTop, panelW, panelH-(listTop-panelY))
 
 	for i, g := range objects {
 		itemY := y + int32(i)*itemH - e.hierarchyScroll
 
 		// Skip if off screen
-		if itemY+itemH < panelY+24 || itemY > panelY+panelH {
+		if itemY+itemH < listTop || itemY > panelY+panelH {
 			continue
 		}
 
 		// Hover highlight
 		hovered := mouseInPanel && mousePos.Y >= float32(itemY) && mousePos.Y < float32(itemY+itemH)
-		selected := e.Selected == g
-		isDragTarget := e.draggingHierarchy && hovered && e.draggedObject != g && !e.isDescendantOf(g, e.draggedObject)
+		selected := e.isSelected(g)
+		isDragTarget := e.draggingHierarchy && hovered && e.draggedObject != g && !g.IsDescendantOf(e.draggedObject)
+
+		// Compute depth for indentation
+		depth := int32(0)
+		p := g.Parent
+		for p != nil {
+			depth++
+			p = p.Parent
+		}
+		indent := int32(12) + depth*16
 
 		if isDragTarget {
-			// Highlight as drop target - indigo
-			rl.DrawRectangle(panelX, itemY, panelW, itemH, rl.NewColor(108, 99, 255, 60))
-			e.hierarchyDropTarget = g
+			// Top/bottom edge: insert as a sibling above/below, middle: make a child
+			rel := mousePos.Y - float32(itemY)
+			switch {
+			case rel < float32(itemH)/4:
+				e.hierarchyDrop = e.siblingDrop(g, false)
+				rl.DrawRectangle(panelX+indent, itemY, panelW-indent, 2, colorAccent)
+			case rel > float32(itemH)*3/4:
+				e.hierarchyDrop = e.siblingDrop(g, true)
+				rl.DrawRectangle(panelX+indent, itemY+itemH-2, panelW-indent, 2, colorAccent)
+			default:
+				// Highlight as drop target - indigo
+				rl.DrawRectangle(panelX, itemY, panelW, itemH, rl.NewColor(108, 99, 255, 60))
+				e.hierarchyDrop = e.childDrop(g)
+			}
 		} else if selected {
 			// Selected - indigo tint
 			rl.DrawRectangle(panelX, itemY, panelW, itemH, colorSelection)
@@ -105,14 +156,44 @@ func (e *Editor) drawHierarchy() {
 			rl.DrawRectangle(panelX, itemY, panelW, itemH, colorBgHover)
 		}
 
+		// Solo toggle at the right edge, shown on hover or while soloed
+		soloRect := rl.Rectangle{X: float32(panelX + panelW - 24), Y: float32(itemY + 2), Width: 18, Height: float32(itemH - 4)}
+		onSolo := hovered && rl.CheckCollisionPointRec(mousePos, soloRect)
+		if hovered || e.soloObject == g {
+			soloColor := colorTextMuted
+			if e.soloObject == g {
+				rl.DrawRectangleRounded(soloRect, 0.3, 4, colorAccent)
+				soloColor = colorTextPrimary
+			} else if onSolo {
+				rl.DrawRectangleRounded(soloRect, 0.3, 4, colorBgElement)
+			}
+			drawTextEx(editorFontBold, "S", int32(soloRect.X)+5, itemY+3, 15, soloColor)
+		}
+		if onSolo && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !clickedNewButton && !clickedFilterButton {
+			e.toggleSolo(g)
+		}
+
 		// Track mouse down (potential drag start)
-		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !clickedNewButton {
+		if hovered && !onSolo && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !clickedNewButton && !clickedFilterButton {
 			now := rl.GetTime()
 			isDoubleClick := (now-e.lastHierarchyClick < 0.3) && (e.lastClickedObject == g)
 
-			if isDoubleClick {
+			ctrl := rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper) ||
+				rl.IsKeyDown(rl.KeyRightControl) || rl.IsKeyDown(rl.KeyRightSuper)
+			shift := rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift)
+
+			if ctrl || shift {
+				// Ctrl+click toggles, Shift+click selects a range; neither drags
+				if ctrl {
+					e.toggleSelected(g)
+				} else {
+					e.selectRange(objects, g)
+				}
+				e.hierarchyMouseDownObj = nil
+				now = 0 // a modified click never starts a double-click
+			} else if isDoubleClick {
 				// Double-click: select and focus camera on object
-				e.Selected = g
+				e.selectOnly(g)
 				if e.IsUIEditModeActive() {
 					e.uiEditState.SelectedElement = g
 				}
@@ -129,27 +210,32 @@ func (e *Editor) drawHierarchy() {
 			e.lastClickedObject = g
 		}
 
-		// Compute depth for indentation
-		depth := int32(0)
-		p := g.Parent
-		for p != nil {
-			depth++
-			p = p.Parent
-		}
-		indent := int32(12) + depth*16
-
 		txtColor := colorTextSecondary
 		if selected {
 			txtColor = colorAccentLight
 		}
+		if e.soloObject != nil && !e.isSoloed(g) {
+			txtColor = colorTextMuted // Frozen while another object is soloed
+		}
 		if e.draggingHierarchy && e.draggedObject == g {
 			txtColor = colorAccent // Indicate dragged item
 		}
 		drawTextEx(editorFont, g.Name, panelX+indent, itemY+3, 16, txtColor)
+
+		// Component badges, right-aligned before the solo button
+		badgeX := panelX + panelW - 40
+		for _, b := range objectBadges(g) {
+			drawHierarchyBadge(b, badgeX, itemY+itemH/2)
+			badgeX -= 16
+		}
 	}
 
 	rl.EndScissorMode()
 
+	if e.showCompFilterMenu {
+		e.drawCompFilterMenu(menuRect, mousePos, clickedFilterButton)
+	}
+
 	// Handle mouse down -> drag or click detection (Unity-style)
 	if e.hierarchyMouseDownObj != nil {
 		if rl.IsMouseButtonDown(rl.MouseLeftButton) {
@@ -169,7 +255,8 @@ func (e *Editor) drawHierarchy() {
 		} else if rl.IsMouseButtonReleased(rl.MouseLeftButton) {
 			// Released without dragging - this is a click, select the object
 			if !e.draggingHierarchy {
-				e.Selected = e.hierarchyMouseDownObj
+				e.selectOnly(e.hierarchyMouseDownObj)
+				e.selectAnchor = e.hierarchyMouseDownObj
 				if e.IsUIEditModeActive() {
 					e.uiEditState.SelectedElement = e.hierarchyMouseDownObj
 				}
@@ -186,8 +273,8 @@ func (e *Editor) drawHierarchy() {
 		bgColor := rl.NewColor(80, 50, 50, 180)
 		if unparentHovered {
 			bgColor = rl.NewColor(180, 80, 80, 200)
-			e.hierarchyDropTarget = nil // nil means unparent
-			e.hierarchyDropIndex = -2   // special value for unparent
+			// Root level, keeping its place in the list
+			e.hierarchyDrop = &hierarchyDrop{ChildIndex: -1, SceneIndex: -1}
 		}
 		rl.DrawRectangle(panelX, unparentY, panelW, itemH, bgColor)
 		drawTextEx(editorFont, "— Unparent —", panelX+55, unparentY+3, 16, colorTextSecondary)
@@ -195,14 +282,8 @@ func (e *Editor) drawHierarchy() {
 
 	// Handle reparenting on mouse release (but don't clear drag state yet - inspector needs it)
 	if e.draggingHierarchy && rl.IsMouseButtonReleased(rl.MouseLeftButton) {
-		if e.draggedObject != nil {
-			if e.hierarchyDropIndex == -2 {
-				// Unparent
-				e.reparentObject(e.draggedObject, nil)
-			} else if e.hierarchyDropTarget != nil && e.hierarchyDropTarget != e.draggedObject {
-				// Reparent to drop target
-				e.reparentObject(e.draggedObject, e.hierarchyDropTarget)
-			}
+		if e.draggedObject != nil && e.hierarchyDrop != nil {
+			e.reparentObject(e.draggedObject, *e.hierarchyDrop)
 		}
 		// Note: Drag state is cleared after all panels are drawn (see cleanupDragState)
 	}
@@ -213,40 +294,293 @@ func (e *Editor) drawHierarchy() {
 	}
 }
 
+const hierarchyFilterID = "hierarchy.filter"
+
+// hierarchyBadge is a small icon on a hierarchy row summarizing its components
+type hierarchyBadge int
+
+const (
+	badgeModel   hierarchyBadge = iota // ModelRenderer
+	badgeLight                         // DirectionalLight, PointLight or SpotLight
+	badgeScript                        // any script
+	badgePhysics                       // Rigidbody
+)
+
+// objectBadges lists the badges for an object's components, in display order
+// from right to left
+func objectBadges(g *engine.GameObject) []hierarchyBadge {
+	var hasModel, hasLight, hasScript, hasPhysics bool
+	for _, c := range g.Components() {
+		switch c.(type) {
+		case *components.ModelRenderer:
+			hasModel = true
+		case *components.DirectionalLight, *components.PointLight, *components.SpotLight:
+			hasLight = true
+		case *components.Rigidbody:
+			hasPhysics = true
+		default:
+			if _, _, ok := engine.SerializeScript(c); ok {
+				hasScript = true
+			}
+		}
+	}
+	var badges []hierarchyBadge
+	if hasModel {
+		badges = append(badges, badgeModel)
+	}
+	if hasLight {
+		badges = append(badges, badgeLight)
+	}
+	if hasPhysics {
+		badges = append(badges, badgePhysics)
+	}
+	if hasScript {
+		badges = append(badges, badgeScript)
+	}
+	return badges
+}
+
+// drawHierarchyBadge draws a badge icon centered on (x, cy)
+func drawHierarchyBadge(b hierarchyBadge, x, cy int32) {
+	center := rl.Vector2{X: float32(x), Y: float32(cy)}
+	switch b {
+	case badgeModel:
+		// Cube: front face plus the top and side edges
+		c := rl.NewColor(120, 170, 255, 255)
+		rl.DrawRectangleLines(x-5, cy-3, 8, 8, c)
+		rl.DrawLine(x-5, cy-3, x-2, cy-6, c)
+		rl.DrawLine(x+3, cy-3, x+6, cy-6, c)
+		rl.DrawLine(x-2, cy-6, x+6, cy-6, c)
+		rl.DrawLine(x+6, cy-6, x+6, cy+2, c)
+		rl.DrawLine(x+3, cy+5, x+6, cy+2, c)
+	case badgeLight:
+		// Bulb: a circle over a small base
+		c := rl.NewColor(255, 210, 90, 255)
+		rl.DrawCircleV(rl.Vector2{X: float32(x), Y: float32(cy - 2)}, 4, c)
+		rl.DrawRectangle(x-2, cy+3, 5, 3, c)
+	case badgeScript:
+		// Gear: an octagon outline around a hub
+		c := rl.NewColor(170, 220, 140, 255)
+		rl.DrawPolyLinesEx(center, 8, 6, 22.5, 2, c)
+		rl.DrawCircleV(center, 2, c)
+	case badgePhysics:
+		drawTextEx(editorFontBold, "P", x-4, cy-8, 15, rl.NewColor(255, 140, 110, 255))
+	}
+}
+
+// filteredHierarchy returns the scene objects matching the hierarchy filters
+func (e *Editor) filteredHierarchy(text string) []*engine.GameObject {
+	if text == "" && e.hierarchyCompFilter == "" {
+		return e.world.Scene.GameObjects
+	}
+	text = strings.ToLower(text)
+	var result []*engine.GameObject
+	for _, g := range e.world.Scene.GameObjects {
+		if matchesText(g, text) && hasComponentType(g, e.hierarchyCompFilter) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// matchesText reports whether g's name or one of its tags contains text (lowercase)
+func matchesText(g *engine.GameObject, text string) bool {
+	if text == "" || strings.Contains(strings.ToLower(g.Name), text) {
+		return true
+	}
+	for _, tag := range g.Tags {
+		if strings.Contains(strings.ToLower(tag), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasComponentType reports whether g has a component of the named type ("" = any)
+func hasComponentType(g *engine.GameObject, typeName string) bool {
+	if typeName == "" {
+		return true
+	}
+	for _, c := range g.Components() {
+		if reflect.TypeOf(c).Elem().Name() == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// compFilterOptions lists the component types the hierarchy can be filtered by
+func compFilterOptions() []string {
+	options := []string{""}
+	for _, compType := range editorComponentTypes {
+		options = append(options, compType.Name)
+	}
+	return append(options, engine.GetRegisteredScripts()...)
+}
+
+// drawCompFilterButton draws the component filter dropdown button.
+// Returns true if it was clicked this frame.
+func (e *Editor) drawCompFilterButton(bounds rl.Rectangle, mousePos rl.Vector2) bool {
+	hovered := rl.CheckCollisionPointRec(mousePos, bounds)
+	bgColor := colorBgElement
+	if hovered || e.showCompFilterMenu {
+		bgColor = colorBgHover
+	}
+	rl.DrawRectangleRounded(bounds, 0.2, 4, bgColor)
+
+	label := "All types"
+	color := colorTextMuted
+	if e.hierarchyCompFilter != "" {
+		label = e.hierarchyCompFilter
+		color = colorAccentLight
+	}
+	for len(label) > 1 && measureTextEx(editorFont, label+" v", 14) > int32(bounds.Width)-10 {
+		label = label[:len(label)-1]
+	}
+	drawTextEx(editorFont, label+" v", int32(bounds.X)+6, int32(bounds.Y)+4, 14, color)
+
+	if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.showCompFilterMenu = !e.showCompFilterMenu
+		return true
+	}
+	return false
+}
+
+const compFilterItemH = 20
+
+// compFilterMenuRect returns the bounds of the component filter menu below its button
+func compFilterMenuRect(btn rl.Rectangle) rl.Rectangle {
+	w := float32(160)
+	return rl.Rectangle{
+		X:      max(btn.X+btn.Width-w, 0),
+		Y:      btn.Y + btn.Height + 2,
+		Width:  w,
+		Height: float32(len(compFilterOptions()) * compFilterItemH),
+	}
+}
+
+// drawCompFilterMenu draws the component type list and applies the picked filter
+func (e *Editor) drawCompFilterMenu(bounds rl.Rectangle, mousePos rl.Vector2, justOpened bool) {
+	rl.DrawRectangleRounded(bounds, 0.05, 4, colorBgPanel)
+	rl.DrawRectangleRoundedLinesEx(bounds, 0.05, 4, 1, colorBorder)
+
+	for i, name := range compFilterOptions() {
+		item := rl.Rectangle{X: bounds.X, Y: bounds.Y + float32(i*compFilterItemH), Width: bounds.Width, Height: compFilterItemH}
+		hovered := rl.CheckCollisionPointRec(mousePos, item)
+		if hovered {
+			rl.DrawRectangleRec(item, colorAccent)
+		}
+
+		label, color := name, colorTextSecondary
+		if name == "" {
+			label = "All types"
+		} else if i > len(editorComponentTypes) {
+			color = colorAccentLight // scripts in accent color, like the Add Component menu
+		}
+		if hovered || name == e.hierarchyCompFilter {
+			color = colorTextPrimary
+		}
+		drawTextEx(editorFont, label, int32(item.X)+10, int32(item.Y)+2, 15, color)
+
+		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			e.hierarchyCompFilter = name
+			e.hierarchyScroll = 0
+			e.showCompFilterMenu = false
+		}
+	}
+
+	if !justOpened && rl.IsMouseButtonPressed(rl.MouseLeftButton) && !rl.CheckCollisionPointRec(mousePos, bounds) {
+		e.showCompFilterMenu = false
+	}
+}
+
 // cleanupDragState clears the hierarchy drag state after all panels have processed the drop.
 // This is called after drawHierarchy and drawInspector to ensure inspector can handle drops.
 func (e *Editor) cleanupDragState() {
 	if e.draggingHierarchy && rl.IsMouseButtonReleased(rl.MouseLeftButton) {
 		e.draggingHierarchy = false
 		e.draggedObject = nil
-		e.hierarchyDropTarget = nil
-		e.hierarchyDropIndex = 0
+		e.hierarchyDrop = nil
 	}
 }
 
-// isDescendantOf checks if 'potential' is a descendant of 'ancestor'
-func (e *Editor) isDescendantOf(potential, ancestor *engine.GameObject) bool {
-	p := potential.Parent
-	for p != nil {
-		if p == ancestor {
-			return true
+// hierarchyDrop describes where a dragged hierarchy item lands
+type hierarchyDrop struct {
+	Parent     *engine.GameObject // new parent (nil = root)
+	ChildIndex int                // position in Parent.Children (-1 = last)
+	SceneIndex int                // position in the scene's object list, not counting the moved block (-1 = keep)
+}
+
+// siblingDrop returns a drop that places the dragged object next to target
+func (e *Editor) siblingDrop(target *engine.GameObject, after bool) *hierarchyDrop {
+	drop := &hierarchyDrop{Parent: target.Parent, ChildIndex: -1}
+	if target.Parent != nil {
+		drop.ChildIndex = target.Parent.ChildIndex(target)
+		if after {
+			drop.ChildIndex++
+		}
+		// Moving down within the same parent: our own slot disappears first
+		if own := target.Parent.ChildIndex(e.draggedObject); own >= 0 && own < drop.ChildIndex {
+			drop.ChildIndex--
 		}
-		p = p.Parent
 	}
-	return false
+	pos := e.world.Scene.IndexOf(target)
+	if after {
+		pos = e.subtreeEnd(target)
+	}
+	drop.SceneIndex = e.indexOutsideBlock(e.draggedObject, pos)
+	return drop
 }
 
-// reparentObject changes the parent of an object, preserving world position
-func (e *Editor) reparentObject(child, newParent *engine.GameObject) {
-	if child == nil || child == newParent {
+// childDrop returns a drop that makes the dragged object target's last child
+func (e *Editor) childDrop(target *engine.GameObject) *hierarchyDrop {
+	return &hierarchyDrop{
+		Parent:     target,
+		ChildIndex: -1,
+		SceneIndex: e.indexOutsideBlock(e.draggedObject, e.subtreeEnd(target)),
+	}
+}
+
+// subtreeEnd returns the list position just past g and all its descendants
+func (e *Editor) subtreeEnd(g *engine.GameObject) int {
+	end := e.world.Scene.IndexOf(g) + 1
+	for i, obj := range e.world.Scene.GameObjects {
+		if i >= end && obj.IsDescendantOf(g) {
+			end = i + 1
+		}
+	}
+	return end
+}
+
+// indexOutsideBlock converts a position in the scene's object list into one
+// that skips g and its descendants (see engine.Scene.MoveGameObject)
+func (e *Editor) indexOutsideBlock(g *engine.GameObject, pos int) int {
+	idx := 0
+	for i, obj := range e.world.Scene.GameObjects {
+		if i >= pos {
+			break
+		}
+		if obj != g && !obj.IsDescendantOf(g) {
+			idx++
+		}
+	}
+	return idx
+}
+
+// reparentObject moves an object to a new parent and sibling position,
+// preserving its world position. Undoable.
+func (e *Editor) reparentObject(child *engine.GameObject, drop hierarchyDrop) {
+	if child == nil || child == drop.Parent {
 		return
 	}
 
 	// Don't allow parenting to a descendant
-	if newParent != nil && e.isDescendantOf(newParent, child) {
+	if drop.Parent != nil && drop.Parent.IsDescendantOf(child) {
 		return
 	}
 
+	before := e.capturePlacement(child)
+
 	// Store world position before reparenting
 	worldPos := child.WorldPosition()
 
@@ -256,31 +590,39 @@ func (e *Editor) reparentObject(child, newParent *engine.GameObject) {
 	}
 
 	// Add to new parent
-	if newParent != nil {
-		newParent.AddChild(child)
+	if drop.Parent != nil {
+		drop.Parent.InsertChild(child, drop.ChildIndex)
 		// Convert world position to new local position
-		parentWorldPos := newParent.WorldPosition()
+		parentWorldPos := drop.Parent.WorldPosition()
 		child.Transform.Position = rl.Vector3Subtract(worldPos, parentWorldPos)
 	} else {
 		child.Parent = nil
 		child.Transform.Position = worldPos
 	}
 
+	if drop.SceneIndex >= 0 {
+		e.world.Scene.MoveGameObject(child, drop.SceneIndex)
+	}
+	e.pushReparentUndo(before)
+
 	e.saveMsg = fmt.Sprintf("Reparented %s", child.Name)
 	e.saveMsgTime = rl.GetTime()
 }
 
-// createNewGameObject creates a new empty GameObject and adds it to the scene.
-func (e *Editor) createNewGameObject() {
-	// Generate unique name
-	baseName := "GameObject"
+// uniqueObjectName returns baseName, numbered if the scene already has an object by that name
+func (e *Editor) uniqueObjectName(baseName string) string {
 	name := baseName
 	count := 1
 	for e.world.Scene.FindByName(name) != nil {
 		name = fmt.Sprintf("%s (%d)", baseName, count)
 		count++
 	}
+	return name
+}
 
+// createNewGameObject creates a new empty GameObject and adds it to the scene.
+func (e *Editor) createNewGameObject() {
+	name := e.uniqueObjectName("GameObject")
 	obj := engine.NewGameObject(name)
 
 	// Position in front of camera
@@ -295,22 +637,50 @@ func (e *Editor) createNewGameObject() {
 	e.saveMsgTime = rl.GetTime()
 }
 
-// deleteSelectedObject removes the currently selected object from the scene.
+// deleteSelectedObject removes every selected object from the scene, as one undo step.
 func (e *Editor) deleteSelectedObject() {
-	if e.Selected == nil {
+	e.deleteObjects(e.selection())
+}
+
+// deleteObjects removes objects from the scene as a single undo step that
+// restores all of them. Objects under another deleted object go with it.
+func (e *Editor) deleteObjects(objs []*engine.GameObject) {
+	roots := selectionRoots(objs)
+	if len(roots) == 0 {
 		return
 	}
 
-	// Push undo state before deleting (keeps the object reference alive)
-	e.pushDeleteUndo(e.Selected)
-
-	name := e.Selected.Name
+	// Capture placements before deleting (keeps the object references alive).
+	// Each is taken after the previous object is gone, so undoing in reverse
+	// order finds the positions it expects.
+	cmd := &deleteCommand{}
+	for _, obj := range roots {
+		cmd.objs = append(cmd.objs, e.capturePlacement(obj))
+		e.world.EditorDestroy(obj)
+	}
+	e.pushUndo(cmd)
+	if len(roots) == 1 {
+		e.setMsg("Deleted %s", roots[0].Name)
+	} else {
+		e.setMsg("Deleted %d objects", len(roots))
+	}
 
-	// Remove from scene and physics, but keep model loaded (for undo)
-	e.world.EditorDestroy(e.Selected)
+	e.selectOnly(nil)
+}
 
-	e.Selected = nil
+// toggleSolo solos g (only it and its children simulate in play mode), or
+// clears the solo if g is already soloed
+func (e *Editor) toggleSolo(g *engine.GameObject) {
+	if e.soloObject == g {
+		e.soloObject = nil
+		e.setMsg("Solo off")
+		return
+	}
+	e.soloObject = g
+	e.setMsg("Solo: only %s simulates in play mode", g.Name)
+}
 
-	e.saveMsg = fmt.Sprintf("Deleted %s", name)
-	e.saveMsgTime = rl.GetTime()
+// isSoloed reports whether g simulates under the current solo
+func (e *Editor) isSoloed(g *engine.GameObject) bool {
+	return e.soloObject == nil || g == e.soloObject || g.IsDescendantOf(e.soloObject)
 }
@@ -3,13 +3,18 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"test3d/internal/assets"
+	"test3d/internal/audio"
 	"test3d/internal/components"
 	"test3d/internal/engine"
 	"test3d/internal/world"
@@ -18,9 +23,19 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-// drawInspector draws the selected object's inspector on the right.
+// drawInspector draws the selected object's inspector on the right, or the
+// scene settings when nothing is selected.
 func (e *Editor) drawInspector() {
+	// Everything edited between mouse down and the end of the edit is one undo step
+	e.beginInspectorEdit()
+	defer e.endInspectorEdit()
+
 	if e.Selected == nil {
+		e.drawSceneSettings()
+		return
+	}
+	if sel := e.selection(); len(sel) > 1 {
+		e.drawMultiInspector(sel)
 		return
 	}
 
@@ -59,21 +74,33 @@ func (e *Editor) drawInspector() {
 
 	y := panelY + 8 - e.inspectorScroll
 
+	// Parent chain breadcrumb (nested objects only)
+	if e.Selected.Parent != nil {
+		y = e.drawParentBreadcrumb(panelX, y, panelW, mousePos)
+	}
+
 	// Name (editable)
 	y = e.drawNameField(panelX, y, panelW, mousePos)
 
 	// Tags (editable)
 	y = e.drawTagsField(panelX, y, panelW, mousePos)
 
+	// Prefab link (apply + overrides), or a button to make one
+	if e.Selected.PrefabPath != "" {
+		y = e.drawPrefabSection(panelX, y, panelW, mousePos)
+	} else {
+		y = e.drawSavePrefabButton(panelX, y, mousePos)
+	}
+
 	// Separator
-	rl.DrawLine(panelX+12, y+2, panelX+panelW-12, y+2, rl.NewColor(40, 40, 55, 255))
+	rl.DrawLine(panelX+12, y+2, panelX+panelW-12, y+2, colorLine)
 	y += 10
 
 	// Transform section
 	y = e.drawTransformSection(panelX, y, panelW)
 
 	// Separator
-	rl.DrawLine(panelX+12, y+2, panelX+panelW-12, y+2, rl.NewColor(40, 40, 55, 255))
+	rl.DrawLine(panelX+12, y+2, panelX+panelW-12, y+2, colorLine)
 	y += 10
 
 	// Components section header
@@ -116,7 +143,7 @@ func (e *Editor) drawInspector() {
 	// Draw background for button area to cover any scrolled content
 	rl.DrawRectangle(panelX, panelY+scrollableH, panelW, btnAreaH, colorBgPanel)
 	// Separator line above button
-	rl.DrawLine(panelX+12, panelY+scrollableH+2, panelX+panelW-12, panelY+scrollableH+2, rl.NewColor(40, 40, 55, 255))
+	rl.DrawLine(panelX+12, panelY+scrollableH+2, panelX+panelW-12, panelY+scrollableH+2, colorLine)
 
 	btnHovered := mouseInPanel && mousePos.X >= float32(btnX) && mousePos.X <= float32(btnX+btnW) &&
 		mousePos.Y >= float32(btnY) && mousePos.Y <= float32(btnY+btnH)
@@ -146,6 +173,64 @@ func (e *Editor) drawInspector() {
 	}
 }
 
+// drawParentBreadcrumb draws the selected object's parent chain ("Level > Building > Lamp")
+// with clickable ancestors, wrapping to new lines as needed. Returns the new Y position.
+func (e *Editor) drawParentBreadcrumb(panelX, y, panelW int32, mousePos rl.Vector2) int32 {
+	// Walk up to the root, then draw root first
+	var chain []*engine.GameObject
+	for g := e.Selected; g != nil; g = g.Parent {
+		chain = append([]*engine.GameObject{g}, chain...)
+	}
+
+	const fontSize = 14
+	const lineH = int32(18)
+	left := panelX + 12
+	right := panelX + panelW - 12
+	sepW := measureTextEx(editorFont, " > ", fontSize)
+	x := left
+
+	var clicked *engine.GameObject
+	for i, g := range chain {
+		w := measureTextEx(editorFont, g.Name, fontSize)
+		if x > left && x+w > right {
+			x = left
+			y += lineH
+		}
+
+		if g == e.Selected {
+			drawTextEx(editorFont, g.Name, x, y, fontSize, colorTextPrimary)
+			break
+		}
+
+		hovered := mousePos.X >= float32(x) && mousePos.X <= float32(x+w) &&
+			mousePos.Y >= float32(y) && mousePos.Y <= float32(y+lineH)
+		color := colorTextMuted
+		if hovered {
+			color = colorAccentLight
+			rl.DrawLine(x, y+fontSize+1, x+w, y+fontSize+1, color)
+			if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				clicked = g
+			}
+		}
+		drawTextEx(editorFont, g.Name, x, y, fontSize, color)
+		x += w
+
+		if i < len(chain)-1 {
+			drawTextEx(editorFont, " > ", x, y, fontSize, colorTextMuted)
+			x += sepW
+		}
+	}
+
+	if clicked != nil {
+		e.Selected = clicked
+		if e.IsUIEditModeActive() {
+			e.uiEditState.SelectedElement = clicked
+		}
+	}
+
+	return y + lineH + 4
+}
+
 // drawNameField draws the editable name field and returns the new Y position.
 func (e *Editor) drawNameField(panelX, y, panelW int32, mousePos rl.Vector2) int32 {
 	nameFieldW := panelW - 20
@@ -301,6 +386,129 @@ func (e *Editor) drawTagsField(panelX, y, panelW int32, mousePos rl.Vector2) int
 	return y + tagsFieldH + 6
 }
 
+// drawSavePrefabButton draws a Save as Prefab button for objects that aren't
+// prefab instances, and returns the new Y position.
+func (e *Editor) drawSavePrefabButton(panelX, y int32, mousePos rl.Vector2) int32 {
+	btnW := int32(120)
+	btnH := int32(22)
+	btnX := panelX + 12
+	btnHovered := mousePos.X >= float32(btnX) && mousePos.X <= float32(btnX+btnW) &&
+		mousePos.Y >= float32(y) && mousePos.Y <= float32(y+btnH)
+	btnColor := colorBgElement
+	if btnHovered {
+		btnColor = colorBgHover
+	}
+	rl.DrawRectangleRounded(rl.Rectangle{X: float32(btnX), Y: float32(y), Width: float32(btnW), Height: float32(btnH)}, 0.3, 4, btnColor)
+	drawTextEx(editorFont, "Save as Prefab", btnX+10, y+4, 14, colorTextSecondary)
+
+	if btnHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.saveSelectedAsPrefab()
+	}
+	return y + btnH + 6
+}
+
+// saveSelectedAsPrefab writes the selected object to assets/prefabs, named
+// after it with a suffix if that prefab already exists, and links it to the file
+func (e *Editor) saveSelectedAsPrefab() {
+	dir := filepath.Join("assets", "prefabs")
+	base := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(e.Selected.Name), " ", "_"))
+	if base == "" {
+		base = "prefab"
+	}
+	path := filepath.Join(dir, base+world.PrefabExt)
+	for i := 1; ; i++ {
+		_, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			e.setMsg("Save prefab failed: %v", err)
+			return
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s_%d%s", base, i, world.PrefabExt))
+	}
+
+	if err := e.world.SavePrefab(e.Selected, path); err != nil {
+		e.setMsg("Save prefab failed: %v", err)
+		return
+	}
+	if e.currentAssetPath == dir {
+		e.scanAssets()
+	}
+	e.setMsg("Saved prefab %s", filepath.Base(path))
+}
+
+// drawPrefabSection draws the prefab source, an Apply to Prefab button and the
+// list of overridden fields, and returns the new Y position.
+func (e *Editor) drawPrefabSection(panelX, y, panelW int32, mousePos rl.Vector2) int32 {
+	drawTextEx(editorFont, fmt.Sprintf("Prefab: %s", filepath.Base(e.Selected.PrefabPath)), panelX+12, y, 14, colorAccentLight)
+	y += 20
+
+	e.refreshPrefabOverrides(false)
+	overrides, err := e.prefabOverrides, e.prefabOverridesErr
+	if errors.Is(err, fs.ErrNotExist) {
+		drawTextEx(editorFont, "Prefab file missing", panelX+12, y, 14, rl.Red)
+		return y + 20
+	} else if err != nil {
+		drawTextEx(editorFont, err.Error(), panelX+12, y, 14, rl.Red)
+		return y + 20
+	}
+
+	// Apply to Prefab button
+	btnW := int32(120)
+	btnH := int32(22)
+	btnX := panelX + 12
+	btnY := y
+	btnHovered := mousePos.X >= float32(btnX) && mousePos.X <= float32(btnX+btnW) &&
+		mousePos.Y >= float32(btnY) && mousePos.Y <= float32(btnY+btnH)
+	btnColor := colorBgElement
+	if btnHovered {
+		btnColor = colorBgHover
+	}
+	rl.DrawRectangleRounded(rl.Rectangle{X: float32(btnX), Y: float32(btnY), Width: float32(btnW), Height: float32(btnH)}, 0.3, 4, btnColor)
+	drawTextEx(editorFont, "Apply to Prefab", btnX+8, btnY+4, 14, colorTextSecondary)
+
+	if btnHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		// Other instances are rebuilt, so the selection moves to the new
+		// objects and the undo history, which holds the old ones, is cleared
+		applied := e.Selected
+		if err := e.rebuildScene(func() error { return e.world.ApplyPrefab(applied) }); err != nil {
+			e.setMsg("Apply failed: %v", err)
+		} else {
+			e.setMsg("Applied to %s", filepath.Base(applied.PrefabPath))
+		}
+		e.refreshPrefabOverrides(true)
+		overrides = e.prefabOverrides
+	}
+	y += btnH + 4
+
+	// Overrides indicator
+	if len(overrides) == 0 {
+		drawTextEx(editorFont, "No overrides", panelX+12, y, 14, colorTextMuted)
+		return y + 20
+	}
+	drawTextEx(editorFont, fmt.Sprintf("Overrides (%d)", len(overrides)), panelX+12, y, 14, rl.Orange)
+	y += 18
+	for _, o := range overrides {
+		drawTextEx(editorFontMono, o, panelX+20, y, 13, colorTextMuted)
+		y += 16
+	}
+	return y + 4
+}
+
+// refreshPrefabOverrides recomputes the selected instance's overrides when the
+// selection changes, when forced, or at most twice a second otherwise, since
+// diffing serializes the whole instance.
+func (e *Editor) refreshPrefabOverrides(force bool) {
+	now := rl.GetTime()
+	if !force && e.prefabOverridesOf == e.Selected && now-e.lastOverridesCheck < 0.5 {
+		return
+	}
+	e.prefabOverridesOf = e.Selected
+	e.lastOverridesCheck = now
+	e.prefabOverrides, e.prefabOverridesErr = e.world.PrefabOverrides(e.Selected)
+}
+
 // applyTagsFromBuffer parses the tags edit buffer and applies it to the selected object.
 func (e *Editor) applyTagsFromBuffer() {
 	if e.Selected == nil {
@@ -309,8 +517,14 @@ func (e *Editor) applyTagsFromBuffer() {
 		return
 	}
 
-	// Parse comma-separated tags
-	parts := strings.Split(e.tagsEditBuffer, ",")
+	e.Selected.Tags = splitTags(e.tagsEditBuffer)
+	e.editingTags = false
+	e.tagsEditBuffer = ""
+}
+
+// splitTags parses a comma-separated tag list, dropping blank entries
+func splitTags(s string) []string {
+	parts := strings.Split(s, ",")
 	tags := make([]string, 0, len(parts))
 	for _, p := range parts {
 		tag := strings.TrimSpace(p)
@@ -318,10 +532,7 @@ func (e *Editor) applyTagsFromBuffer() {
 			tags = append(tags, tag)
 		}
 	}
-
-	e.Selected.Tags = tags
-	e.editingTags = false
-	e.tagsEditBuffer = ""
+	return tags
 }
 
 // drawTransformSection draws the transform properties and returns the new Y position.
@@ -341,10 +552,19 @@ func (e *Editor) drawTransformSection(panelX, y, panelW int32) int32 {
 	e.Selected.Transform.Position.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, "pos.z", e.Selected.Transform.Position.Z)
 	y += fieldH + 4
 
+	// Parented objects can also be placed in world space; edits are
+	// converted back to local values through the parent's transform
 	if e.Selected.Parent != nil {
+		drawTextEx(editorFont, "World", panelX+14, y+4, 14, colorTextMuted)
 		wPos := e.Selected.WorldPosition()
-		drawTextEx(editorFontMono, fmt.Sprintf("World %.1f, %.1f, %.1f", wPos.X, wPos.Y, wPos.Z), panelX+16, y, 14, colorTextMuted)
-		y += 18
+		edited := wPos
+		edited.X = e.drawFloatField(startX, y, fieldW, fieldH, "wpos.x", wPos.X)
+		edited.Y = e.drawFloatField(startX+fieldW+2, y, fieldW, fieldH, "wpos.y", wPos.Y)
+		edited.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, "wpos.z", wPos.Z)
+		if edited != wPos {
+			e.Selected.SetWorldPosition(edited)
+		}
+		y += fieldH + 4
 	}
 
 	// Rotation
@@ -354,16 +574,69 @@ func (e *Editor) drawTransformSection(panelX, y, panelW int32) int32 {
 	e.Selected.Transform.Rotation.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, "rot.z", e.Selected.Transform.Rotation.Z)
 	y += fieldH + 4
 
+	if e.Selected.Parent != nil {
+		drawTextEx(editorFont, "World", panelX+14, y+4, 14, colorTextMuted)
+		wRot := e.Selected.WorldRotation()
+		edited := wRot
+		edited.X = e.drawFloatField(startX, y, fieldW, fieldH, "wrot.x", wRot.X)
+		edited.Y = e.drawFloatField(startX+fieldW+2, y, fieldW, fieldH, "wrot.y", wRot.Y)
+		edited.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, "wrot.z", wRot.Z)
+		if edited != wRot {
+			e.Selected.SetWorldRotation(edited)
+		}
+		y += fieldH + 4
+	}
+
 	// Scale
 	drawTextEx(editorFont, "Scale", panelX+14, y+4, 16, colorTextMuted)
+	oldScale := e.Selected.Transform.Scale
 	e.Selected.Transform.Scale.X = e.drawFloatField(startX, y, fieldW, fieldH, "scale.x", e.Selected.Transform.Scale.X)
 	e.Selected.Transform.Scale.Y = e.drawFloatField(startX+fieldW+2, y, fieldW, fieldH, "scale.y", e.Selected.Transform.Scale.Y)
 	e.Selected.Transform.Scale.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, "scale.z", e.Selected.Transform.Scale.Z)
-	y += fieldH + 8
+	if e.scaleLocked {
+		e.Selected.Transform.Scale = proportionalScale(oldScale, e.Selected.Transform.Scale)
+	}
+	y += fieldH + 4
+
+	lockBounds := rl.Rectangle{X: float32(startX), Y: float32(y), Width: 16, Height: 16}
+	e.scaleLocked = gui.CheckBox(lockBounds, "Lock proportions", e.scaleLocked)
+	y += 16 + 8
 
 	return y
 }
 
+// minLockedScale is the smallest scale magnitude an axis can be set to while
+// proportions are locked. Reaching zero would lose the ratio between the axes.
+const minLockedScale = 0.001
+
+// proportionalScale keeps X/Y/Z in proportion when one of them was edited.
+// The edited axis stays at least minLockedScale away from zero so the other
+// axes can always be recovered. Axes that were zero can't carry a ratio, so
+// the edit is applied as-is.
+func proportionalScale(old, edited rl.Vector3) rl.Vector3 {
+	var ratio float32
+	switch {
+	case edited.X != old.X && old.X != 0:
+		ratio = clampAwayFromZero(edited.X, old.X) / old.X
+	case edited.Y != old.Y && old.Y != 0:
+		ratio = clampAwayFromZero(edited.Y, old.Y) / old.Y
+	case edited.Z != old.Z && old.Z != 0:
+		ratio = clampAwayFromZero(edited.Z, old.Z) / old.Z
+	default:
+		return edited
+	}
+	return rl.Vector3Scale(old, ratio)
+}
+
+// clampAwayFromZero keeps v at least minLockedScale from zero, on the side of
+// zero that old is on
+func clampAwayFromZero(v, old float32) float32 {
+	if old > 0 {
+		return max(v, minLockedScale)
+	}
+	return min(v, -minLockedScale)
+}
+
 // drawFloatField draws an editable float input field with drag-to-scrub support.
 func (e *Editor) drawFloatField(x, y, w, h int32, id string, value float32) float32 {
 	mousePos := rl.GetMousePosition()
@@ -640,8 +913,12 @@ func (e *Editor) drawComponentEntry(panelX, y, panelW int32, index int, c engine
 	xBtnX := panelX + panelW - 32
 	xBtnY := y + 3
 
-	// Draw header background - rounded
-	rl.DrawRectangleRounded(rl.Rectangle{X: float32(panelX + 10), Y: float32(y), Width: float32(panelW - 20), Height: float32(headerH)}, 0.15, 4, colorBgElement)
+	// Draw header background - rounded, outlined while focused for Ctrl+C
+	headerRect := rl.Rectangle{X: float32(panelX + 10), Y: float32(y), Width: float32(panelW - 20), Height: float32(headerH)}
+	rl.DrawRectangleRounded(headerRect, 0.15, 4, colorBgElement)
+	if e.focusedComponent == c {
+		rl.DrawRectangleRoundedLinesEx(headerRect, 0.15, 4, 1, colorAccent)
+	}
 	drawTextEx(editorFontBold, typeName, panelX+16, y+4, 16, colorTextSecondary)
 
 	// Draw X button - rounded
@@ -660,6 +937,9 @@ func (e *Editor) drawComponentEntry(panelX, y, panelW int32, index int, c engine
 	drawTextEx(editorFontBold, "x", xBtnX+5, xBtnY+2, 14, colorTextPrimary)
 
 	shouldRemove := xHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton)
+	if !xHovered && mouseInPanel && rl.CheckCollisionPointRec(mousePos, headerRect) && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.focusedComponent = c
+	}
 	y += headerH + 4
 
 	// Draw component-specific properties
@@ -681,8 +961,7 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 			drawTextEx(editorFont, fmt.Sprintf("Model: %s", filepath.Base(comp.FilePath)), indent, y, 15, colorTextMuted)
 			y += 20
 		} else {
-			drawTextEx(editorFont, fmt.Sprintf("Mesh: %s", comp.MeshType), indent, y, 15, colorTextMuted)
-			y += 20
+			y = e.drawMeshFields(indent, y, labelW, fieldW, fieldH, comp, compIdx)
 		}
 
 		// Material asset reference
@@ -695,6 +974,8 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 				oldMet := comp.Material.Metallic
 				oldRough := comp.Material.Roughness
 				oldEmit := comp.Material.Emissive
+				oldCutoff := comp.Material.AlphaCutoff
+				oldDoubleSided := comp.Material.DoubleSided
 
 				drawTextEx(editorFont, "Metallic", indent, y+4, 15, colorTextMuted)
 				comp.Material.Metallic = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".met", comp.Material.Metallic)
@@ -706,10 +987,21 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 
 				drawTextEx(editorFont, "Emissive", indent, y+4, 15, colorTextMuted)
 				comp.Material.Emissive = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".emit", comp.Material.Emissive)
+				y += fieldH + 2
+
+				// Alpha cutout threshold (0 = opaque)
+				drawTextEx(editorFont, "Cutoff", indent, y+4, 15, colorTextMuted)
+				cutoffBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+				comp.Material.AlphaCutoff = gui.Slider(cutoffBounds, "", fmt.Sprintf("%.2f", comp.Material.AlphaCutoff), comp.Material.AlphaCutoff, 0, 1)
+				y += fieldH + 4
+
+				doubleSidedBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+				comp.Material.DoubleSided = gui.CheckBox(doubleSidedBounds, "Double-sided", comp.Material.DoubleSided)
 				y += fieldH + 4
 
 				// Save material if any value changed
-				if comp.Material.Metallic != oldMet || comp.Material.Roughness != oldRough || comp.Material.Emissive != oldEmit {
+				if comp.Material.Metallic != oldMet || comp.Material.Roughness != oldRough || comp.Material.Emissive != oldEmit ||
+					comp.Material.AlphaCutoff != oldCutoff || comp.Material.DoubleSided != oldDoubleSided {
 					assets.SaveMaterial(comp.MaterialPath, comp.Material)
 				}
 			}
@@ -761,7 +1053,30 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 					fmt.Printf("Flipped normals for %s\n", comp.FilePath)
 				}
 			}
+
+			// Recalc Normals button with its smoothing angle (0 = hard edges, 180 = smooth)
+			recalcX := btnX + btnW + 6
+			recalcW := int32(120)
+			recalcHovered := mousePos.X >= float32(recalcX) && mousePos.X <= float32(recalcX+recalcW) &&
+				mousePos.Y >= float32(btnY) && mousePos.Y <= float32(btnY+btnH)
+			recalcColor := colorBgElement
+			if recalcHovered {
+				recalcColor = colorBgHover
+			}
+			rl.DrawRectangleRounded(rl.Rectangle{X: float32(recalcX), Y: float32(btnY), Width: float32(recalcW), Height: float32(btnH)}, 0.3, 4, recalcColor)
+			drawTextEx(editorFont, "Recalc Normals", recalcX+8, btnY+4, 14, colorTextSecondary)
+
+			if recalcHovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				e.recalculateNormals(comp.FilePath)
+			}
 			y += btnH + 4
+
+			drawTextEx(editorFont, "Smooth Angle", indent, y+4, 15, colorTextMuted)
+			angleBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+			e.normalsAngle = gui.Slider(angleBounds, "", fmt.Sprintf("%.0f", e.normalsAngle), e.normalsAngle, 0, 180)
+			y += fieldH + 4
+
+			y = e.drawImportSettings(comp.FilePath, indent, y, labelW, fieldW, fieldH)
 		}
 
 	case *components.BoxCollider:
@@ -773,30 +1088,122 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 		comp.Size.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".z", comp.Size.Z)
 		y += fieldH + 4
 
+		// Effective size after the object's scale (what physics actually uses)
+		wSize := comp.GetWorldSize()
+		drawTextEx(editorFontMono, fmt.Sprintf("World Size %.2f, %.2f, %.2f", wSize.X, wSize.Y, wSize.Z), indent+2, y, 14, colorTextMuted)
+		y += 18
+
 		// Offset
 		drawTextEx(editorFont, "Offset", indent, y+4, 15, colorTextMuted)
 		id = fmt.Sprintf("box%d.off", compIdx)
 		comp.Offset.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".x", comp.Offset.X)
 		comp.Offset.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".y", comp.Offset.Y)
 		comp.Offset.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".z", comp.Offset.Z)
+		y += fieldH + 4
+
+		// Triggers report overlaps instead of colliding
+		triggerBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.IsTrigger = gui.CheckBox(triggerBounds, "Is Trigger", comp.IsTrigger)
 		y += fieldH + 6
 
 	case *components.SphereCollider:
 		drawTextEx(editorFont, "Radius", indent, y+4, 15, colorTextMuted)
-		id := fmt.Sprintf("sphere%d.rad", compIdx)
-		comp.Radius = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id, comp.Radius)
+		id := fmt.Sprintf("sphere%d", compIdx)
+		comp.Radius = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".rad", comp.Radius)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Offset", indent, y+4, 15, colorTextMuted)
+		comp.Offset.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".off.x", comp.Offset.X)
+		comp.Offset.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".off.y", comp.Offset.Y)
+		comp.Offset.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".off.z", comp.Offset.Z)
+		y += fieldH + 4
+
+		triggerBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.IsTrigger = gui.CheckBox(triggerBounds, "Is Trigger", comp.IsTrigger)
+		y += fieldH + 6
+
+	case *components.CapsuleCollider:
+		drawTextEx(editorFont, "Radius", indent, y+4, 15, colorTextMuted)
+		id := fmt.Sprintf("capsule%d", compIdx)
+		comp.Radius = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".rad", comp.Radius), 0)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Height", indent, y+4, 15, colorTextMuted)
+		comp.Height = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".height", comp.Height), 0)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Offset", indent, y+4, 15, colorTextMuted)
+		comp.Offset.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".off.x", comp.Offset.X)
+		comp.Offset.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".off.y", comp.Offset.Y)
+		comp.Offset.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".off.z", comp.Offset.Z)
 		y += fieldH + 6
 
 	case *components.MeshCollider:
+		id := fmt.Sprintf("meshcol%d", compIdx)
+		oldPath := comp.ModelPath
+		drawTextEx(editorFont, "Model", indent, y+4, 15, colorTextMuted)
+		comp.ModelPath = e.drawTextField(indent+labelW, y, fieldW*3, fieldH, id+".model", comp.ModelPath)
+		y += fieldH + 4
+		if comp.ModelPath != oldPath {
+			if comp.Rebuild() || comp.ModelPath == "" {
+				e.world.PhysicsWorld.MarkStaticsDirty()
+			} else {
+				e.setMsg("Model not found: %s", comp.ModelPath)
+				comp.ModelPath = oldPath
+			}
+		}
+
 		// Show read-only info about the mesh collider
 		if comp.IsBuilt() {
-			info := fmt.Sprintf("%d triangles", comp.TriangleCount())
+			info := fmt.Sprintf("%d triangles, %d BVH nodes", comp.TriangleCount(), comp.NodeCount())
 			drawTextEx(editorFont, info, indent, y+4, 15, colorTextMuted)
 		} else {
 			drawTextEx(editorFont, "Not built", indent, y+4, 15, rl.Red)
 		}
 		y += fieldH + 6
 
+	case *components.Terrain:
+		id := fmt.Sprintf("terrain%d", compIdx)
+		oldHeightmap, oldSize := comp.Heightmap, comp.Size
+
+		drawTextEx(editorFont, "Heightmap", indent, y+4, 15, colorTextMuted)
+		if edited := e.drawTextField(indent+labelW, y, fieldW*3, fieldH, id+".map", comp.Heightmap); edited != comp.Heightmap {
+			if _, err := os.Stat(edited); edited != "" && err != nil {
+				e.setMsg("Heightmap not found: %s", edited)
+			} else {
+				comp.Heightmap = edited
+			}
+		}
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Size", indent, y+4, 15, colorTextMuted)
+		comp.Size.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".x", comp.Size.X)
+		comp.Size.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".y", comp.Size.Y)
+		comp.Size.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".z", comp.Size.Z)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Material", indent, y+4, 15, colorTextMuted)
+		comp.MaterialPath = e.drawTextField(indent+labelW, y, fieldW*3, fieldH, id+".mat", comp.MaterialPath)
+		y += fieldH + 2
+
+		if comp.MaterialPath == "" {
+			drawTextEx(editorFont, "Color", indent, y+4, 15, colorTextMuted)
+			colorPreview := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+			rl.DrawRectangleRec(colorPreview, comp.Color)
+			rl.DrawRectangleLinesEx(colorPreview, 1, rl.Gray)
+			comp.Color.R = uint8(e.drawFloatField(indent+labelW+fieldH+4, y, fieldW-10, fieldH, id+".r", float32(comp.Color.R)))
+			comp.Color.G = uint8(e.drawFloatField(indent+labelW+fieldH+4+fieldW-8, y, fieldW-10, fieldH, id+".g", float32(comp.Color.G)))
+			comp.Color.B = uint8(e.drawFloatField(indent+labelW+fieldH+4+2*(fieldW-8), y, fieldW-10, fieldH, id+".b", float32(comp.Color.B)))
+			y += fieldH + 2
+		}
+		y += 4
+
+		// Regenerate the mesh and collider when a heightmap is committed or the size changes
+		if comp.Heightmap != oldHeightmap || comp.Size != oldSize {
+			comp.BuildCollider()
+			e.world.PhysicsWorld.MarkStaticsDirty()
+		}
+
 	case *components.CharacterController:
 		// Height
 		drawTextEx(editorFont, "Height", indent, y+4, 15, colorTextMuted)
@@ -845,6 +1252,320 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 
 		kinematicBounds := rl.Rectangle{X: float32(indent + 110), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
 		comp.IsKinematic = gui.CheckBox(kinematicBounds, "Kinematic", comp.IsKinematic)
+		y += fieldH + 2
+
+		ccdBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.UseCCD = gui.CheckBox(ccdBounds, "Continuous (CCD)", comp.UseCCD)
+		y += fieldH + 6
+
+	case *components.Joint:
+		id := fmt.Sprintf("joint%d", compIdx)
+
+		target := "(none) - drag in Joint Mode [J]"
+		if other := comp.ConnectedObject(); other != nil {
+			target = other.Name
+		}
+		drawTextEx(editorFont, fmt.Sprintf("Connected: %s", target), indent, y+4, 15, colorAccentLight)
+		y += 20
+
+		drawTextEx(editorFont, "Anchor", indent, y+4, 15, colorTextMuted)
+		comp.Anchor.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".a.x", comp.Anchor.X)
+		comp.Anchor.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".a.y", comp.Anchor.Y)
+		comp.Anchor.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".a.z", comp.Anchor.Z)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Other", indent, y+4, 15, colorTextMuted)
+		comp.ConnectedAnchor.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".b.x", comp.ConnectedAnchor.X)
+		comp.ConnectedAnchor.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".b.y", comp.ConnectedAnchor.Y)
+		comp.ConnectedAnchor.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".b.z", comp.ConnectedAnchor.Z)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Rest Len", indent, y+4, 15, colorTextMuted)
+		comp.RestLength = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".rest", comp.RestLength)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Stiffness", indent, y+4, 15, colorTextMuted)
+		comp.Stiffness = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".k", comp.Stiffness)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Damping", indent, y+4, 15, colorTextMuted)
+		comp.Damping = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".damp", comp.Damping)
+		y += fieldH + 6
+
+	case *components.Rotator:
+		id := fmt.Sprintf("rotator%d", compIdx)
+		drawTextEx(editorFont, "Deg/s", indent, y+4, 15, colorTextMuted)
+		comp.Speed.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".x", comp.Speed.X)
+		comp.Speed.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".y", comp.Speed.Y)
+		comp.Speed.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".z", comp.Speed.Z)
+		y += fieldH + 6
+
+	case *components.Oscillator:
+		id := fmt.Sprintf("osc%d", compIdx)
+		drawTextEx(editorFont, "Axis", indent, y+4, 15, colorTextMuted)
+		comp.Axis.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".x", comp.Axis.X)
+		comp.Axis.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".y", comp.Axis.Y)
+		comp.Axis.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".z", comp.Axis.Z)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Amplitude", indent, y+4, 15, colorTextMuted)
+		comp.Amplitude = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".amp", comp.Amplitude)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Freq (Hz)", indent, y+4, 15, colorTextMuted)
+		comp.Frequency = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".freq", comp.Frequency)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Phase", indent, y+4, 15, colorTextMuted)
+		phaseBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Phase = gui.Slider(phaseBounds, "", fmt.Sprintf("%.2f", comp.Phase), comp.Phase, 0, 1)
+		y += fieldH + 6
+
+	case *components.FollowTarget:
+		id := fmt.Sprintf("follow%d", compIdx)
+		comp.Target.UID = e.drawGameObjectRefField(indent, y, labelW, fieldW*3+4, fieldH, "Target", comp.Target.UID)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Pos Offset", indent, y+4, 15, colorTextMuted)
+		comp.PositionOffset.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".pos.x", comp.PositionOffset.X)
+		comp.PositionOffset.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".pos.y", comp.PositionOffset.Y)
+		comp.PositionOffset.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".pos.z", comp.PositionOffset.Z)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Rot Offset", indent, y+4, 15, colorTextMuted)
+		comp.RotationOffset.X = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".rot.x", comp.RotationOffset.X)
+		comp.RotationOffset.Y = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, id+".rot.y", comp.RotationOffset.Y)
+		comp.RotationOffset.Z = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".rot.z", comp.RotationOffset.Z)
+		y += fieldH + 4
+
+		posBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.FollowPosition = gui.CheckBox(posBounds, "Position", comp.FollowPosition)
+		rotBounds := rl.Rectangle{X: float32(indent + 90), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.FollowRotation = gui.CheckBox(rotBounds, "Rotation", comp.FollowRotation)
+		localBounds := rl.Rectangle{X: float32(indent + 180), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.LocalOffset = gui.CheckBox(localBounds, "Local", comp.LocalOffset)
+		y += fieldH + 6
+
+	case *components.PatrolPath:
+		id := fmt.Sprintf("patrol%d", compIdx)
+		remove := -1
+		for p := range comp.Points {
+			pid := fmt.Sprintf("%s.%d", id, p)
+			drawTextEx(editorFont, fmt.Sprintf("Point %d", p+1), indent, y+4, 15, colorTextMuted)
+			comp.Points[p].X = e.drawFloatField(indent+labelW, y, fieldW-8, fieldH, pid+".x", comp.Points[p].X)
+			comp.Points[p].Y = e.drawFloatField(indent+labelW+fieldW-6, y, fieldW-8, fieldH, pid+".y", comp.Points[p].Y)
+			comp.Points[p].Z = e.drawFloatField(indent+labelW+2*(fieldW-6), y, fieldW-8, fieldH, pid+".z", comp.Points[p].Z)
+			if drawDialogButton(indent+labelW+3*(fieldW-6), y, 20, fieldH, "x", false) {
+				remove = p
+			}
+			y += fieldH + 2
+		}
+		if remove >= 0 {
+			comp.Points = append(comp.Points[:remove], comp.Points[remove+1:]...)
+		}
+
+		if drawDialogButton(indent, y+2, 100, fieldH, "+ Add Point", false) {
+			// Continue the path one unit past the last point
+			next := rl.Vector3{}
+			if n := len(comp.Points); n > 0 {
+				next = rl.Vector3Add(comp.Points[n-1], rl.Vector3{X: 1})
+			}
+			comp.Points = append(comp.Points, next)
+		}
+		y += fieldH + 8
+
+	case *components.PatrolMover:
+		id := fmt.Sprintf("patrolmover%d", compIdx)
+		comp.Path.UID = e.drawGameObjectRefField(indent, y, labelW, fieldW*3+4, fieldH, "Path", comp.Path.UID)
+		y += fieldH + 2
+		if comp.Path.UID != 0 && comp.PathComponent() == nil {
+			drawTextEx(editorFont, "Path object has no PatrolPath", indent, y, 14, rl.Orange)
+			y += 18
+		}
+
+		drawTextEx(editorFont, "Speed", indent, y+4, 15, colorTextMuted)
+		comp.Speed = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".speed", comp.Speed), 0)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "At End", indent, y+4, 15, colorTextMuted)
+		modeBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Mode = components.PatrolMode(gui.ComboBox(modeBounds, "Loop;Ping-Pong;Stop", int32(comp.Mode)))
+		y += fieldH + 4
+
+		faceBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.FaceMovement = gui.CheckBox(faceBounds, "Face movement", comp.FaceMovement)
+		y += fieldH + 6
+
+	case *components.TransformAnimation:
+		drawTextEx(editorFont, "Easing", indent, y+4, 15, colorTextMuted)
+		easingBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Easing = components.Easing(gui.ComboBox(easingBounds, "Linear;Ease In;Ease Out;Ease In-Out", int32(comp.Easing)))
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "At End", indent, y+4, 15, colorTextMuted)
+		modeBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Mode = components.AnimationMode(gui.ComboBox(modeBounds, "Once;Loop;Ping-Pong", int32(comp.Mode)))
+		y += fieldH + 4
+
+		playBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.PlayOnStart = gui.CheckBox(playBounds, "Play on start", comp.PlayOnStart)
+		y += fieldH + 4
+
+		keys := fmt.Sprintf("%d keys, %.2fs - edit in the timeline (K)", len(comp.Keyframes), comp.Duration())
+		drawTextEx(editorFont, keys, indent, y, 14, colorTextMuted)
+		y += 18 + 6
+
+	case *components.Health:
+		id := fmt.Sprintf("health%d", compIdx)
+		// Current / max as a bar, like the UIProgressBar it can drive
+		barW := fieldW*3 + 4
+		rl.DrawRectangle(indent+labelW, y+4, barW, fieldH-8, colorBgElement)
+		rl.DrawRectangle(indent+labelW, y+4, int32(float32(barW)*comp.Percent()), fieldH-8, rl.NewColor(80, 200, 80, 255))
+		drawTextEx(editorFont, "Health", indent, y+4, 15, colorTextMuted)
+		drawTextEx(editorFontMono, fmt.Sprintf("%g / %g", comp.Current, comp.Max), indent+labelW+6, y+4, 14, colorTextPrimary)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Current", indent, y+4, 15, colorTextMuted)
+		comp.Current = min(max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".cur", comp.Current), 0), comp.Max)
+		drawTextEx(editorFont, "Max", indent+labelW+fieldW+8, y+4, 15, colorTextMuted)
+		comp.Max = max(e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".max", comp.Max), 0)
+		y += fieldH + 4
+
+		invBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.Invincible = gui.CheckBox(invBounds, "Invincible", comp.Invincible)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Hit Dmg", indent, y+4, 15, colorTextMuted)
+		comp.ImpactDamage = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".dmg", comp.ImpactDamage), 0)
+		drawTextEx(editorFont, "Min", indent+labelW+fieldW+8, y+4, 15, colorTextMuted)
+		comp.MinImpulse = max(e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, id+".minimp", comp.MinImpulse), 0)
+		y += fieldH + 2
+
+		comp.Bar.UID = e.drawGameObjectRefField(indent, y, labelW, fieldW*3+4, fieldH, "Bar", comp.Bar.UID)
+		y += fieldH + 2
+		if comp.Bar.UID != 0 && comp.BarComponent() == nil {
+			drawTextEx(editorFont, "Bar object has no UIProgressBar", indent, y, 14, rl.Orange)
+			y += 18
+		}
+		y += 4
+
+	case *components.LODGroup:
+		id := fmt.Sprintf("lod%d", compIdx)
+		pathW := fieldW*2 - 10
+		distX := indent + labelW + pathW + 2
+		distW := fieldW - 20
+		drawTextEx(editorFont, "Model", indent+labelW, y, 14, colorTextMuted)
+		drawTextEx(editorFont, "From (m)", distX, y, 14, colorTextMuted)
+		y += 18
+
+		drawTextEx(editorFont, "LOD 0", indent, y+4, 15, colorTextMuted)
+		drawTextEx(editorFont, "ModelRenderer", indent+labelW+4, y+4, 15, colorTextSecondary)
+		y += fieldH + 2
+
+		remove := -1
+		for l := range comp.Levels {
+			lid := fmt.Sprintf("%s.%d", id, l)
+			drawTextEx(editorFont, fmt.Sprintf("LOD %d", l+1), indent, y+4, 15, colorTextMuted)
+			comp.Levels[l].ModelPath = e.drawTextField(indent+labelW, y, pathW, fieldH, lid+".model", comp.Levels[l].ModelPath)
+			comp.Levels[l].Distance = max(e.drawFloatField(distX, y, distW, fieldH, lid+".dist", comp.Levels[l].Distance), 0)
+			if drawDialogButton(distX+distW+2, y, 20, fieldH, "x", false) {
+				remove = l
+			}
+			y += fieldH + 2
+			if _, ok := comp.Model(l + 1); !ok && comp.Levels[l].ModelPath != "" {
+				drawTextEx(editorFont, "Model file not found", indent+labelW, y, 14, rl.Orange)
+				y += 18
+			}
+		}
+		if remove >= 0 {
+			comp.Levels = append(comp.Levels[:remove], comp.Levels[remove+1:]...)
+		}
+
+		drawTextEx(editorFont, "Cull", indent, y+4, 15, colorTextMuted)
+		comp.CullDistance = max(e.drawFloatField(distX, y, distW, fieldH, id+".cull", comp.CullDistance), 0)
+		drawTextEx(editorFont, "(0 = never)", indent+labelW+4, y+4, 14, colorTextMuted)
+		y += fieldH + 4
+
+		if drawDialogButton(indent, y, 100, fieldH, "+ Add Level", false) {
+			// Continue ten units past the farthest level
+			var next float32 = 20
+			for _, lv := range comp.Levels {
+				next = max(next, lv.Distance+10)
+			}
+			comp.Levels = append(comp.Levels, components.LODLevel{Distance: next})
+		}
+		current := "Culled"
+		if level := comp.Current(); level != components.LODCulled {
+			current = fmt.Sprintf("LOD %d", level)
+		}
+		drawTextEx(editorFont, "Active: "+current, indent+110, y+4, 15, colorTextSecondary)
+		y += fieldH + 8
+
+		if engine.GetComponent[*components.ModelRenderer](comp.GetGameObject()) == nil {
+			drawTextEx(editorFont, "Needs a ModelRenderer", indent, y, 14, rl.Orange)
+			y += 18
+		}
+
+	case *components.AudioSource:
+		id := fmt.Sprintf("audio%d", compIdx)
+		drawTextEx(editorFont, "Clip", indent, y+4, 15, colorTextMuted)
+		comp.AudioPath = e.drawTextField(indent+labelW, y, fieldW*3, fieldH, id+".clip", comp.AudioPath)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Volume", indent, y+4, 15, colorTextMuted)
+		volBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Volume = gui.Slider(volBounds, "", fmt.Sprintf("%.2f", comp.Volume), comp.Volume, 0, 1)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Max Dist", indent, y+4, 15, colorTextMuted)
+		comp.MaxDistance = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".maxdist", comp.MaxDistance), 0)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Channel", indent, y+4, 15, colorTextMuted)
+		channelBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Channel = audio.Channel(gui.ComboBox(channelBounds, "SFX;UI;Music", int32(comp.Channel)))
+		y += fieldH + 4
+
+		loopBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.Loop = gui.CheckBox(loopBounds, "Loop", comp.Loop)
+		startBounds := rl.Rectangle{X: float32(indent + 70), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.PlayOnStart = gui.CheckBox(startBounds, "On Start", comp.PlayOnStart)
+		spatialBounds := rl.Rectangle{X: float32(indent + 160), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.Spatial = gui.CheckBox(spatialBounds, "Spatial", comp.Spatial)
+		y += fieldH + 6
+
+		if comp.Spatial && !comp.Channel.Spatialized() {
+			drawTextEx(editorFont, "UI and Music always play in 2D", indent, y, 14, colorTextMuted)
+			y += 18
+		} else if comp.Spatial && components.ActiveAudioListener(e.world.Scene) == nil {
+			drawTextEx(editorFont, "No AudioListener in scene", indent, y, 14, rl.Orange)
+			y += 18
+		}
+
+	case *components.AudioListener:
+		if warning := components.AudioListenerWarning(e.world.Scene); warning != "" {
+			drawTextEx(editorFont, "Multiple listeners - only the first is used", indent, y, 14, rl.Orange)
+		} else {
+			drawTextEx(editorFont, "Spatial sounds are heard from here", indent, y, 14, colorTextMuted)
+		}
+		y += 20
+
+	case *components.OnCollisionEffect:
+		id := fmt.Sprintf("hitfx%d", compIdx)
+		soundBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.PlaySound = gui.CheckBox(soundBounds, "Play AudioSource", comp.PlaySound)
+		y += fieldH + 2
+		if comp.PlaySound && engine.GetComponent[*components.AudioSource](comp.GetGameObject()) == nil {
+			drawTextEx(editorFont, "Needs an AudioSource", indent, y, 14, rl.Orange)
+			y += 18
+		}
+
+		drawTextEx(editorFont, "Tag", indent, y+4, 15, colorTextMuted)
+		comp.Tag = e.drawTextField(indent+labelW, y, fieldW*2, fieldH, id+".tag", comp.Tag)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Min Impulse", indent, y+4, 15, colorTextMuted)
+		comp.MinImpulse = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".impulse", comp.MinImpulse), 0)
 		y += fieldH + 6
 
 	case *components.DirectionalLight:
@@ -860,7 +1581,14 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 		drawTextEx(editorFont, "Intensity", indent, y+4, 15, colorTextMuted)
 		sliderBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
 		comp.Intensity = gui.Slider(sliderBounds, "", fmt.Sprintf("%.1f", comp.Intensity), comp.Intensity, 0, 2)
-		y += fieldH + 6
+		y += fieldH + 4
+
+		shadowNote := "Casts shadows"
+		if e.world.Renderer.Light != comp {
+			shadowNote = "No shadows (another light casts them)"
+		}
+		drawTextEx(editorFont, shadowNote, indent, y, 14, colorTextMuted)
+		y += 20
 
 	case *components.PointLight:
 		id := fmt.Sprintf("pointlight%d", compIdx)
@@ -888,6 +1616,98 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 		comp.Radius = gui.Slider(radiusBounds, "", fmt.Sprintf("%.1f", comp.Radius), comp.Radius, 1, 50)
 		y += fieldH + 6
 
+	case *components.SpotLight:
+		id := fmt.Sprintf("spotlight%d", compIdx)
+
+		drawTextEx(editorFont, "Color", indent, y+4, 15, colorTextMuted)
+		colorPreview := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		rl.DrawRectangleRec(colorPreview, comp.Color)
+		rl.DrawRectangleLinesEx(colorPreview, 1, rl.Gray)
+		comp.Color.R = uint8(e.drawFloatField(indent+labelW+fieldH+4, y, fieldW-10, fieldH, id+".r", float32(comp.Color.R)))
+		comp.Color.G = uint8(e.drawFloatField(indent+labelW+fieldH+4+fieldW-8, y, fieldW-10, fieldH, id+".g", float32(comp.Color.G)))
+		comp.Color.B = uint8(e.drawFloatField(indent+labelW+fieldH+4+2*(fieldW-8), y, fieldW-10, fieldH, id+".b", float32(comp.Color.B)))
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Intensity", indent, y+4, 15, colorTextMuted)
+		intensityBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Intensity = gui.Slider(intensityBounds, "", fmt.Sprintf("%.1f", comp.Intensity), comp.Intensity, 0, 5)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "Range", indent, y+4, 15, colorTextMuted)
+		rangeBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+		comp.Range = gui.Slider(rangeBounds, "", fmt.Sprintf("%.1f", comp.Range), comp.Range, 1, 50)
+		y += fieldH + 4
+
+		// Cone angles in degrees; the inner one can't open past the outer one
+		drawTextEx(editorFont, "Inner", indent, y+4, 15, colorTextMuted)
+		comp.InnerAngle = min(max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".inner", comp.InnerAngle), 0), comp.OuterAngle)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Outer", indent, y+4, 15, colorTextMuted)
+		comp.OuterAngle = min(max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".outer", comp.OuterAngle), 0), 89)
+		y += fieldH + 6
+
+	case *components.Camera:
+		id := fmt.Sprintf("cam%d", compIdx)
+
+		drawTextEx(editorFont, "FOV", indent, y+4, 15, colorTextMuted)
+		comp.FOV = min(max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".fov", comp.FOV), 1), 179)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Near", indent, y+4, 15, colorTextMuted)
+		comp.Near = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".near", comp.Near)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Far", indent, y+4, 15, colorTextMuted)
+		comp.Far = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".far", comp.Far)
+		y += fieldH + 4
+
+		orthoBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		ortho := gui.CheckBox(orthoBounds, "Orthographic", comp.Projection == rl.CameraOrthographic)
+		comp.Projection = rl.CameraPerspective
+		if ortho {
+			comp.Projection = rl.CameraOrthographic
+		}
+		y += fieldH + 4
+
+		mainBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.IsMain = gui.CheckBox(mainBounds, "Main Camera", comp.IsMain)
+		y += fieldH + 6
+
+	case *components.MinimapCamera:
+		id := fmt.Sprintf("minimap%d", compIdx)
+
+		drawTextEx(editorFont, "Height", indent, y+4, 15, colorTextMuted)
+		comp.Height = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".height", comp.Height)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Zoom", indent, y+4, 15, colorTextMuted)
+		comp.Zoom = e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".zoom", comp.Zoom)
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Resolution", indent, y+4, 15, colorTextMuted)
+		comp.Resolution = int32(e.drawFloatField(indent+labelW, y, fieldW, fieldH, id+".res", float32(comp.Resolution)))
+		y += fieldH + 2
+
+		drawTextEx(editorFont, "Layers", indent, y+4, 15, colorTextMuted)
+		layers := strings.Join(comp.Layers, ", ")
+		if edited := e.drawTextField(indent+labelW, y, fieldW*3, fieldH, id+".layers", layers); edited != layers {
+			comp.Layers = splitTags(edited)
+		}
+		y += fieldH + 2
+		drawTextEx(editorFont, "Tags to draw, comma separated (empty = all)", indent, y, 14, colorTextMuted)
+		y += 22
+
+	case *components.UIImage:
+		comp.Source.UID = e.drawGameObjectRefField(indent, y, labelW, fieldW*3+4, fieldH, "Source", comp.Source.UID)
+		y += fieldH + 2
+		drawTextEx(editorFont, "MinimapCamera to show (empty = texture)", indent, y, 14, colorTextMuted)
+		y += 20
+
+		aspectBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+		comp.PreserveAspect = gui.CheckBox(aspectBounds, "Preserve Aspect", comp.PreserveAspect)
+		y += fieldH + 6
+
 	case *components.UIText:
 		id := fmt.Sprintf("uitext%d", compIdx)
 
@@ -964,6 +1784,38 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 					continue
 				}
 
+				// String fields marked `// @options: a,b,c` get a dropdown
+				if options := engine.GetScriptFieldOptions(c, k); options != nil {
+					current, _ := v.(string)
+					index := max(slices.Index(options, current), 0)
+					drawTextEx(editorFont, k, indent, y+4, 14, colorTextMuted)
+					comboBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+					if selected := int(gui.ComboBox(comboBounds, strings.Join(options, ";"), int32(index))); options[selected] != current {
+						engine.ApplyScriptProperty(c, k, options[selected])
+					}
+					y += fieldH + 4
+					continue
+				}
+
+				// Fields tagged `mirgo:"range:min,max"` get a bounded slider
+				if minVal, maxVal, ok := engine.GetScriptFieldRange(c, k); ok {
+					var val float32
+					switch f := v.(type) {
+					case float32:
+						val = f
+					case float64:
+						val = float32(f)
+					}
+					drawTextEx(editorFont, k, indent, y+4, 14, colorTextMuted)
+					sliderBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+					newVal := gui.Slider(sliderBounds, "", fmt.Sprintf("%.2f", val), val, minVal, maxVal)
+					if newVal != val {
+						engine.ApplyScriptProperty(c, k, float64(newVal))
+					}
+					y += fieldH + 4
+					continue
+				}
+
 				switch val := v.(type) {
 				case float32:
 					drawTextEx(editorFont, k, indent, y+4, 14, colorTextMuted)
@@ -1009,7 +1861,16 @@ func (e *Editor) drawComponentProperties(panelX, y int32, c engine.Component, co
 					y += 16
 				}
 			}
-			y += 4
+
+			// Test run: frames x deltaTime, outside play mode
+			drawTextEx(editorFont, "Test", indent, y+4, 14, colorTextMuted)
+			e.scriptTestFrames = int32(max(e.drawFloatField(indent+labelW, y, fieldW-20, fieldH, "scripttest.frames", float32(e.scriptTestFrames)), 1))
+			drawTextEx(editorFont, "x", indent+labelW+fieldW-16, y+4, 14, colorTextMuted)
+			e.scriptTestDt = max(e.drawFloatField(indent+labelW+fieldW-6, y, fieldW-20, fieldH, "scripttest.dt", e.scriptTestDt), 0)
+			if drawDialogButton(indent+labelW+2*fieldW-24, y, 50, fieldH, "Run", false) {
+				e.runScriptTest(c)
+			}
+			y += fieldH + 4
 		} else {
 			y += 16
 		}
@@ -1095,6 +1956,81 @@ func (e *Editor) drawGameObjectRefField(x, y, labelW, fieldW, fieldH int32, labe
 	return newUID
 }
 
+// meshSizeLabels names each meshSize entry of a generated mesh type
+var meshSizeLabels = map[string][]string{
+	"cube":    {"Width", "Height", "Length"},
+	"sphere":  {"Radius"},
+	"plane":   {"Width", "Length", "Subdiv X", "Subdiv Z"},
+	"capsule": {"Radius", "Height"},
+	"cone":    {"Radius", "Height"},
+	"torus":   {"Radius", "Thickness"},
+}
+
+// drawMeshFields draws the mesh type dropdown and size fields of a generated mesh,
+// regenerating the model when either changes.
+func (e *Editor) drawMeshFields(indent, y, labelW, fieldW, fieldH int32, comp *components.ModelRenderer, compIdx int) int32 {
+	id := fmt.Sprintf("mesh%d", compIdx)
+
+	current := int32(-1)
+	for i, t := range assets.PrimitiveMeshTypes {
+		if t == comp.MeshType {
+			current = int32(i)
+		}
+	}
+	if current < 0 {
+		drawTextEx(editorFont, fmt.Sprintf("Mesh: %s", comp.MeshType), indent, y, 15, colorTextMuted)
+		return y + 20
+	}
+
+	drawTextEx(editorFont, "Mesh", indent, y+4, 15, colorTextMuted)
+	bounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW * 2), Height: float32(fieldH)}
+	selected := gui.ComboBox(bounds, strings.Join(assets.PrimitiveMeshTypes, ";"), current)
+	y += fieldH + 4
+
+	changed := false
+	if selected != current {
+		comp.MeshType = assets.PrimitiveMeshTypes[selected]
+		comp.MeshSize = assets.DefaultMeshSize(comp.MeshType)
+		changed = true
+	}
+
+	labels := meshSizeLabels[comp.MeshType]
+	if len(comp.MeshSize) < len(labels) {
+		// Fill in values missing from older scene files
+		defaults := assets.DefaultMeshSize(comp.MeshType)
+		comp.MeshSize = append(comp.MeshSize, defaults[len(comp.MeshSize):]...)
+	}
+	for i, label := range labels {
+		drawTextEx(editorFont, label, indent, y+4, 15, colorTextMuted)
+		v := e.drawFloatField(indent+labelW, y, fieldW, fieldH, fmt.Sprintf("%s.%d", id, i), comp.MeshSize[i])
+		if v != comp.MeshSize[i] && v > 0 {
+			comp.MeshSize[i] = v
+			changed = true
+		}
+		y += fieldH + 2
+	}
+	y += 2
+
+	if changed {
+		e.regenerateMesh(comp)
+	}
+	return y
+}
+
+// regenerateMesh rebuilds a generated mesh's model from its MeshType and MeshSize
+func (e *Editor) regenerateMesh(comp *components.ModelRenderer) {
+	mesh, ok := assets.GenPrimitiveMesh(comp.MeshType, comp.MeshSize)
+	if !ok {
+		return
+	}
+	old := comp.Model
+	comp.Model = rl.LoadModelFromMesh(mesh)
+	comp.SetShader(e.world.Renderer.Shader)
+	if old.MeshCount > 0 && !assets.IsCachedModel(old) {
+		rl.UnloadModel(old)
+	}
+}
+
 // drawAddComponentMenu draws the dropdown menu for adding components.
 // justOpened prevents the menu from closing on the same frame it was opened.
 // The menu appears ABOVE the button (y is the button's top position).
@@ -1252,6 +2188,91 @@ func (e *Editor) drawAddComponentMenu(x, btnY, w int32, justOpened bool) {
 	}
 }
 
+// recalculateNormals recomputes a GLTF model's normals with mirgo-utils and
+// reloads it on every renderer using that file
+func (e *Editor) recalculateNormals(path string) {
+	angle := fmt.Sprintf("%.0f", e.normalsAngle)
+	output, err := exec.Command("./mirgo-utils", "recalcnormals", path, "--angle", angle).CombinedOutput()
+	if err != nil {
+		e.setMsg("Recalc normals failed: %v", err)
+		fmt.Printf("Recalc normals error: %v\nOutput: %s\n", err, string(output))
+		return
+	}
+
+	e.reloadModel(path)
+	e.setMsg("Recalculated normals for %s", path)
+}
+
+// reloadModel reloads a model file from disk and switches every renderer and
+// mesh collider in the scene to it. The replaced model stays loaded (see
+// assets.ReloadModel) for objects held by undo history or the clipboard.
+func (e *Editor) reloadModel(path string) {
+	model := assets.ReloadModel(path)
+	for _, g := range e.world.Scene.GameObjects {
+		mr := engine.GetComponent[*components.ModelRenderer](g)
+		if mr != nil && mr.FilePath == path {
+			mr.Model = model
+			mr.SetShader(e.world.Renderer.Shader)
+		}
+		if mc := engine.GetComponent[*components.MeshCollider](g); mc != nil &&
+			(mc.ModelPath == path || mc.ModelPath == "" && mr != nil && mr.FilePath == path) {
+			mc.Rebuild()
+		}
+	}
+}
+
+// drawImportSettings draws the editable import settings of a model file with
+// an Apply button that saves the sidecar and reloads the model. Returns the new Y.
+func (e *Editor) drawImportSettings(path string, indent, y, labelW, fieldW, fieldH int32) int32 {
+	if e.importSettingsPath != path {
+		e.importSettingsPath = path
+		e.importSettings = assets.LoadImportSettings(path)
+		e.importSettingsSaved = e.importSettings
+	}
+	s := &e.importSettings
+
+	y += 4
+	drawTextEx(editorFontBold, "Import Settings", indent, y, 15, colorTextSecondary)
+	y += 20
+
+	drawTextEx(editorFont, "Scale", indent, y+4, 15, colorTextMuted)
+	s.Scale = e.drawFloatField(indent+labelW, y, fieldW, fieldH, "import.scale", s.Scale)
+	y += fieldH + 2
+
+	drawTextEx(editorFont, "Rotation", indent, y+4, 15, colorTextMuted)
+	s.Rotation[0] = e.drawFloatField(indent+labelW, y, fieldW, fieldH, "import.rot.x", s.Rotation[0])
+	s.Rotation[1] = e.drawFloatField(indent+labelW+fieldW+2, y, fieldW, fieldH, "import.rot.y", s.Rotation[1])
+	s.Rotation[2] = e.drawFloatField(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, "import.rot.z", s.Rotation[2])
+	y += fieldH + 4
+
+	flipBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+	s.FlipNormals = gui.CheckBox(flipBounds, "Flip faces", s.FlipNormals)
+
+	changed := *s != e.importSettingsSaved
+	if drawDialogButton(indent+labelW+2*(fieldW+2), y, fieldW, fieldH, "Apply", changed) && changed {
+		e.applyImportSettings(path)
+	}
+	y += fieldH + 6
+	return y
+}
+
+// applyImportSettings saves the edited import settings next to the model and
+// reloads it on every renderer (and mesh collider) using that file. The old
+// model stays loaded for objects in the undo history or clipboard.
+func (e *Editor) applyImportSettings(path string) {
+	if e.importSettings.Scale == 0 {
+		e.importSettings.Scale = 1
+	}
+	if err := assets.SaveImportSettings(path, e.importSettings); err != nil {
+		e.setMsg("Saving import settings failed: %v", err)
+		return
+	}
+	e.importSettingsSaved = e.importSettings
+
+	e.reloadModel(path)
+	e.setMsg("Applied import settings to %s", filepath.Base(path))
+}
+
 // addComponent adds a new component of the given type to the selected object.
 func (e *Editor) addComponent(typeName string) {
 	if e.Selected == nil {
@@ -1262,12 +2283,9 @@ func (e *Editor) addComponent(typeName string) {
 		if compType.Name == typeName {
 			newComp := compType.Factory(e.world, e.Selected)
 			if newComp != nil {
-				e.Selected.AddComponent(newComp)
+				e.runUndoable(e.addComponentCommand(newComp))
 			}
 
-			// Re-register with physics world to update categorization
-			e.updatePhysicsRegistration(e.Selected)
-
 			e.saveMsg = fmt.Sprintf("Added %s", typeName)
 			e.saveMsgTime = rl.GetTime()
 			return
@@ -1282,15 +2300,7 @@ func (e *Editor) addScript(scriptName string) {
 
 	newComp := engine.CreateScript(scriptName, map[string]any{})
 	if newComp != nil {
-		e.Selected.AddComponent(newComp)
-
-		// Auto-attach PlayerCollision for any PlayerController script
-		if _, ok := newComp.(engine.PlayerController); ok {
-			e.Selected.AddComponent(&world.PlayerCollision{})
-		}
-
-		// Re-register with physics world to update categorization
-		e.updatePhysicsRegistration(e.Selected)
+		e.runUndoable(e.addComponentCommand(newComp))
 
 		e.saveMsg = fmt.Sprintf("Added %s", scriptName)
 		e.saveMsgTime = rl.GetTime()
@@ -1311,26 +2321,25 @@ func (e *Editor) removeComponentAtIndex(index int) {
 	comp := comps[index]
 	typeName := reflect.TypeOf(comp).Elem().Name()
 
-	// Cleanup for specific component types
-	switch c := comp.(type) {
-	case *components.ModelRenderer:
-		c.Unload()
-	case *components.DirectionalLight:
-		// Clear light reference if this is the active light
-		if e.world.Light == e.Selected {
-			e.world.Light = nil
-		}
-	}
-
-	e.Selected.RemoveComponentByIndex(index)
-
-	// Update physics world registration
-	e.updatePhysicsRegistration(e.Selected)
+	// The component stays loaded in the undo history rather than being unloaded
+	e.runUndoable(&componentCommand{obj: e.Selected, comp: comp, index: index, removed: true})
 
 	e.saveMsg = fmt.Sprintf("Removed %s", typeName)
 	e.saveMsgTime = rl.GetTime()
 }
 
+// addComponentCommand returns the undoable addition of comp to the selected
+// object, along with the PlayerCollision any PlayerController script needs
+func (e *Editor) addComponentCommand(comp engine.Component) undoCommand {
+	n := len(e.Selected.Components())
+	cmd := &componentCommand{obj: e.Selected, comp: comp, index: n}
+	_, isPlayer := comp.(engine.PlayerController)
+	if !isPlayer || engine.GetComponent[*world.PlayerCollision](e.Selected) != nil {
+		return cmd
+	}
+	return groupCommand{cmd, &componentCommand{obj: e.Selected, comp: &world.PlayerCollision{}, index: n + 1}}
+}
+
 // updatePhysicsRegistration removes and re-adds an object to the physics world
 // to update its categorization (static/kinematic/dynamic).
 func (e *Editor) updatePhysicsRegistration(g *engine.GameObject) {
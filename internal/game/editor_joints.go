@@ -0,0 +1,154 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const jointHandleRadius = 0.12
+
+// updateJointMode handles joint authoring input. Dragging from the selected
+// rigidbody onto another rigidbody connects them with a Joint; dragging an
+// anchor handle moves that end. Returns true if the input was consumed.
+func (e *Editor) updateJointMode(ray rl.Ray) bool {
+	// Moving an anchor handle
+	if e.jointHandle != nil {
+		if !rl.IsMouseButtonDown(rl.MouseLeftButton) {
+			e.endJointHandleDrag()
+			return true
+		}
+		forward, _ := e.getDirections()
+		if pt, ok := rayPlaneIntersect(ray.Position, ray.Direction, e.jointDragPoint, forward); ok {
+			if e.jointHandleEnd == 0 {
+				e.jointHandle.Anchor = components.WorldToLocalPoint(e.jointHandle.GetGameObject(), pt)
+			} else {
+				e.jointHandle.ConnectedAnchor = components.WorldToLocalPoint(e.jointHandle.ConnectedObject(), pt)
+			}
+		}
+		return true
+	}
+
+	// Dragging a new connection
+	if e.jointDragging {
+		if rl.IsMouseButtonDown(rl.MouseLeftButton) {
+			return true
+		}
+		e.jointDragging = false
+
+		hit, ok := e.world.EditorRaycast(ray.Position, ray.Direction, 1000)
+		if !ok || e.Selected == nil || hit.GameObject == e.Selected {
+			return true
+		}
+		target := hit.GameObject
+		if engine.GetComponent[*components.Rigidbody](target) == nil {
+			e.setMsg("%s has no Rigidbody", target.Name)
+			return true
+		}
+
+		joint := components.NewJoint()
+		joint.Connected.Set(target)
+		joint.Anchor = components.WorldToLocalPoint(e.Selected, e.jointDragPoint)
+		joint.ConnectedAnchor = components.WorldToLocalPoint(target, hit.Point)
+		joint.RestLength = rl.Vector3Distance(e.jointDragPoint, hit.Point)
+		e.runUndoable(e.addComponentCommand(joint))
+		e.setMsg("Joint: %s -> %s", e.Selected.Name, target.Name)
+		return true
+	}
+
+	if !rl.IsMouseButtonPressed(rl.MouseLeftButton) || e.mouseInPanel() || e.Selected == nil {
+		return false
+	}
+
+	// Grab an anchor handle of one of the selected object's joints
+	for _, c := range e.Selected.Components() {
+		joint, ok := c.(*components.Joint)
+		if !ok {
+			continue
+		}
+		ends := [2]rl.Vector3{joint.WorldAnchor(), joint.ConnectedWorldAnchor()}
+		for i, end := range ends {
+			if i == 1 && joint.ConnectedObject() == nil {
+				continue
+			}
+			if rl.GetRayCollisionSphere(ray, end, jointHandleRadius*1.5).Hit {
+				// An inspector edit still open is its own step
+				e.commitInspectorEdit()
+				e.jointHandleBefore = captureObject(e.Selected)
+				e.jointHandle = joint
+				e.jointHandleEnd = i
+				e.jointDragPoint = end
+				return true
+			}
+		}
+	}
+
+	// Start a connection from the selected rigidbody
+	if engine.GetComponent[*components.Rigidbody](e.Selected) == nil {
+		return false
+	}
+	hit, ok := e.world.EditorRaycast(ray.Position, ray.Direction, 1000)
+	if !ok || hit.GameObject != e.Selected {
+		return false
+	}
+	e.jointDragging = true
+	e.jointDragPoint = hit.Point
+	return true
+}
+
+// endJointHandleDrag drops the anchor handle being dragged, recording the
+// move as one undo step like an inspector edit of the anchor
+func (e *Editor) endJointHandleDrag() {
+	if e.jointHandle == nil {
+		return
+	}
+	e.jointHandle = nil
+	before := e.jointHandleBefore
+	e.jointHandleBefore = objectState{}
+	if after := captureObject(before.transform.obj); before.changed(after) {
+		e.pushUndo(&propertyCommand{before: []objectState{before}, after: []objectState{after}})
+	}
+}
+
+// drawJoints draws every joint as a line between its anchors, with handles
+// on the selected object's joints and a preview line while connecting.
+func (e *Editor) drawJoints() {
+	for _, g := range e.world.Scene.GameObjects {
+		for _, c := range g.Components() {
+			joint, ok := c.(*components.Joint)
+			if !ok || joint.ConnectedObject() == nil {
+				continue
+			}
+			a, b := joint.WorldAnchor(), joint.ConnectedWorldAnchor()
+
+			// Cyan at rest, orange when stretched, purple when compressed
+			color := rl.SkyBlue
+			stretch := rl.Vector3Distance(a, b) - joint.RestLength
+			if stretch > 0.05 {
+				color = rl.Orange
+			} else if stretch < -0.05 {
+				color = rl.Purple
+			}
+			rl.DrawLine3D(a, b, color)
+
+			if g == e.Selected || e.jointMode {
+				rl.DrawSphere(a, jointHandleRadius, color)
+				rl.DrawSphere(b, jointHandleRadius, color)
+			}
+		}
+	}
+
+	if e.jointDragging {
+		cam := e.GetRaylibCamera()
+		ray := rl.GetScreenToWorldRay(rl.GetMousePosition(), cam)
+		end := rl.Vector3Add(ray.Position, rl.Vector3Scale(ray.Direction, rl.Vector3Distance(ray.Position, e.jointDragPoint)))
+		if hit, ok := e.world.EditorRaycast(ray.Position, ray.Direction, 1000); ok {
+			end = hit.Point
+		}
+		rl.DrawLine3D(e.jointDragPoint, end, rl.Yellow)
+		rl.DrawSphere(e.jointDragPoint, jointHandleRadius, rl.Yellow)
+	}
+}
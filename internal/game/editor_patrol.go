@@ -0,0 +1,89 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/components"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const patrolHandleRadius float32 = 0.15
+
+// updatePatrolHandles lets the waypoints of the selected object's PatrolPath
+// be dragged in the camera-facing plane. Returns true if the input was consumed.
+func (e *Editor) updatePatrolHandles(ray rl.Ray) bool {
+	// Moving a waypoint
+	if e.patrolPath != nil {
+		if !rl.IsMouseButtonDown(rl.MouseLeftButton) || e.patrolPoint >= len(e.patrolPath.Points) {
+			e.patrolPath = nil
+			return true
+		}
+		forward, _ := e.getDirections()
+		if pt, ok := rayPlaneIntersect(ray.Position, ray.Direction, e.patrolDragPoint, forward); ok {
+			e.patrolPath.Points[e.patrolPoint] = components.WorldToLocalPoint(e.patrolPath.GetGameObject(), pt)
+		}
+		return true
+	}
+
+	if !rl.IsMouseButtonPressed(rl.MouseLeftButton) || e.mouseInPanel() || e.Selected == nil {
+		return false
+	}
+
+	// Grab the nearest waypoint under the cursor
+	for _, c := range e.Selected.Components() {
+		path, ok := c.(*components.PatrolPath)
+		if !ok {
+			continue
+		}
+		bestDist := float32(-1)
+		for i, p := range path.WorldPoints() {
+			hit := rl.GetRayCollisionSphere(ray, p, patrolHandleRadius*1.5)
+			if hit.Hit && (bestDist < 0 || hit.Distance < bestDist) {
+				bestDist = hit.Distance
+				e.patrolPath = path
+				e.patrolPoint = i
+				e.patrolDragPoint = p
+			}
+		}
+		if e.patrolPath != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// drawPatrolPaths draws every PatrolPath as a polyline through its waypoints,
+// with larger numbered handles on the selected object's paths
+func (e *Editor) drawPatrolPaths() {
+	for _, g := range e.world.Scene.GameObjects {
+		if !g.Active {
+			continue
+		}
+		for _, c := range g.Components() {
+			path, ok := c.(*components.PatrolPath)
+			if !ok || len(path.Points) == 0 {
+				continue
+			}
+			points := path.WorldPoints()
+			color := rl.NewColor(120, 220, 160, 255)
+			radius := patrolHandleRadius * 0.5
+			if g == e.Selected {
+				color = rl.NewColor(160, 255, 200, 255)
+				radius = patrolHandleRadius
+			}
+			for i, p := range points {
+				if i > 0 {
+					rl.DrawLine3D(points[i-1], p, color)
+				}
+				handleColor := color
+				if e.patrolPath == path && e.patrolPoint == i {
+					handleColor = rl.Yellow
+				}
+				rl.DrawSphere(p, radius, handleColor)
+			}
+			// First point marked so the direction of travel is clear
+			rl.DrawCubeWiresV(points[0], rl.Vector3{X: radius * 3, Y: radius * 3, Z: radius * 3}, color)
+		}
+	}
+}
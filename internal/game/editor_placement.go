@@ -0,0 +1,87 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	spawnDistance      = 5    // how far in front of the camera spawns go when nothing is hit
+	spawnRayDistance   = 100  // how far the placement ray looks for a surface
+	groundGridExtent   = 50   // half-size of the ground grid around the camera
+	groundGridMajorGap = 10   // every this many lines is drawn heavier
+	groundGridFade     = 0.08 // alpha of the minor grid lines
+)
+
+// spawnPosition returns where a newly spawned obj goes. With surface
+// placement on, it rests on the first surface along the ray through the
+// mouse (or the view center while the mouse is over a panel), or failing
+// that on whatever is below the point in front of the camera. Otherwise it
+// floats spawnDistance in front of the camera.
+func (e *Editor) spawnPosition(obj *engine.GameObject) rl.Vector3 {
+	forward, _ := e.getDirections()
+	inFront := rl.Vector3Add(e.camera.Position, rl.Vector3Scale(forward, spawnDistance))
+	if !e.placeOnSurface {
+		return inFront
+	}
+
+	ray := rl.Ray{Position: e.camera.Position, Direction: forward}
+	if !e.mouseInPanel() {
+		ray = rl.GetScreenToWorldRay(rl.GetMousePosition(), e.GetRaylibCamera())
+	}
+	hit, ok := e.surfaceHit(obj, ray.Position, ray.Direction)
+	if !ok {
+		hit, ok = e.surfaceHit(obj, inFront, rl.Vector3{Y: -1})
+	}
+	if !ok {
+		return inFront
+	}
+	return rl.Vector3Add(hit.Point, rl.Vector3Scale(hit.Normal, restHeight(obj)))
+}
+
+// surfaceHit returns the nearest object hit by the ray, ignoring obj itself
+// (prefab instances are already in the scene when they are placed)
+func (e *Editor) surfaceHit(obj *engine.GameObject, origin, direction rl.Vector3) (engine.RaycastResult, bool) {
+	for _, hit := range e.world.EditorRaycastAll(origin, direction, spawnRayDistance) {
+		if hit.GameObject != obj && !hit.GameObject.IsDescendantOf(obj) {
+			return hit, true
+		}
+	}
+	return engine.RaycastResult{}, false
+}
+
+// restHeight is how far above a surface obj's origin must be for the bottom
+// of its model to touch it
+func restHeight(obj *engine.GameObject) float32 {
+	mr := engine.GetComponent[*components.ModelRenderer](obj)
+	if mr == nil {
+		return 0
+	}
+	bounds := rl.GetModelBoundingBox(mr.Model)
+	return max(-bounds.Min.Y*obj.Transform.Scale.Y, 0)
+}
+
+// drawGroundGrid draws a reference grid on the y=0 plane around the camera,
+// one line per unit with heavier lines every groundGridMajorGap units
+func (e *Editor) drawGroundGrid() {
+	// Center on the camera, aligned to the major lines so the grid doesn't crawl
+	cx := float32(int(e.camera.Position.X/groundGridMajorGap)) * groundGridMajorGap
+	cz := float32(int(e.camera.Position.Z/groundGridMajorGap)) * groundGridMajorGap
+
+	minor := rl.Fade(colorTextMuted, groundGridFade)
+	major := rl.Fade(colorTextMuted, groundGridFade*3)
+	for i := -groundGridExtent; i <= groundGridExtent; i++ {
+		color := minor
+		if i%groundGridMajorGap == 0 {
+			color = major
+		}
+		x := cx + float32(i)
+		z := cz + float32(i)
+		rl.DrawLine3D(rl.Vector3{X: x, Z: cz - groundGridExtent}, rl.Vector3{X: x, Z: cz + groundGridExtent}, color)
+		rl.DrawLine3D(rl.Vector3{X: cx - groundGridExtent, Z: z}, rl.Vector3{X: cx + groundGridExtent, Z: z}, color)
+	}
+}
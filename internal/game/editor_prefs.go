@@ -9,7 +9,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"test3d/internal/world"
+	"test3d/internal/physics"
+	"test3d/internal/project"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -69,6 +70,9 @@ func (e *Editor) rebuildAndRelaunch() {
 		return
 	}
 
+	// The rebuild saves the scene, which must not hold simulation changes
+	e.stopSimulation()
+
 	// Check if a rebuild is already in progress
 	e.rebuildMutex.Lock()
 	if e.rebuildInProgress {
@@ -139,7 +143,7 @@ func (e *Editor) rebuildAndRelaunch() {
 		updateProgress(0.8, "Preparing reload...")
 
 		// Save the scene
-		if err := e.world.SaveScene(world.ScenePath); err != nil {
+		if err := e.world.SaveScene(project.Current.CurrentScene); err != nil {
 			handleError(fmt.Sprintf("Save failed: %v", err))
 			os.Remove(tempExec)
 			return
@@ -209,13 +213,13 @@ func (e *Editor) buildGame() {
 	e.saveMsg = "Building game..."
 	e.saveMsgTime = rl.GetTime()
 
-	cmd := exec.Command("./mirgo-utils", "build")
+	cmd := exec.Command("./mirgo-utils", project.Current.BuildArgs()...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		e.saveMsg = fmt.Sprintf("Build failed: %v", err)
 		fmt.Printf("Build error: %v\nOutput: %s\n", err, string(output))
 	} else {
-		e.saveMsg = "Build complete! See build/"
+		e.saveMsg = fmt.Sprintf("Build complete! See %s/", project.Current.BuildDir)
 		fmt.Printf("Build output:\n%s\n", string(output))
 	}
 	e.saveMsgTime = rl.GetTime()
@@ -259,6 +263,8 @@ func (e *Editor) checkScriptChanges() {
 		return
 	}
 
+	changed := false
+	var newest int64
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
 			continue
@@ -269,13 +275,49 @@ func (e *Editor) checkScriptChanges() {
 			continue
 		}
 		modTime := info.ModTime().UnixNano()
+		newest = max(newest, modTime)
 
 		// Check if file is new or modified
 		if oldTime, exists := e.scriptModTimes[path]; !exists || modTime != oldTime {
-			e.scriptsChanged = true
-			return
+			changed = true
 		}
 	}
+	if !changed {
+		return
+	}
+	e.scriptsChanged = true
+
+	// Restart the auto-reload debounce on every new save
+	if newest != e.newestScriptMod {
+		e.newestScriptMod = newest
+		e.lastScriptChange = rl.GetTime()
+		e.autoReloadPending = true
+	}
+}
+
+// autoReloadDelay is how long scripts must stay unchanged before an automatic
+// rebuild, so a burst of saves only rebuilds once
+const autoReloadDelay = 1.0
+
+// updateAutoReload rebuilds and relaunches once script changes have settled,
+// if auto-reload is on and no build is already running
+func (e *Editor) updateAutoReload() {
+	if !e.autoReloadScripts || !e.autoReloadPending || e.Paused || e.simulating {
+		return
+	}
+	if rl.GetTime()-e.lastScriptChange < autoReloadDelay {
+		return
+	}
+
+	e.rebuildMutex.Lock()
+	busy := e.rebuildInProgress
+	e.rebuildMutex.Unlock()
+	if busy {
+		return
+	}
+
+	e.autoReloadPending = false
+	e.rebuildAndRelaunch()
 }
 
 // EditorPrefs holds persistent editor preferences saved between sessions
@@ -293,6 +335,27 @@ type EditorPrefs struct {
 	AssetBrowserPath string     `json:"assetBrowserPath"`
 	HierarchyWidth   int32      `json:"hierarchyWidth"`
 	InspectorWidth   int32      `json:"inspectorWidth"`
+	BroadPhaseMode   string     `json:"broadPhaseMode,omitempty"`
+	AutoReload       bool       `json:"autoReloadScripts,omitempty"`
+
+	// Camera bookmarks by scene path, then slot (1-9)
+	Bookmarks map[string]map[int]CameraBookmark `json:"bookmarks,omitempty"`
+
+	// Asset paths pinned to the asset browser's favorites strip
+	FavoriteAssets []string `json:"favoriteAssets,omitempty"`
+
+	// Recently opened scene paths, newest first
+	RecentScenes []string `json:"recentScenes,omitempty"`
+
+	// Undo depth (0 = default)
+	MaxUndo int `json:"maxUndo,omitempty"`
+
+	// Gizmo snap increments (nil = defaults)
+	Snap *gizmoSnap `json:"snap,omitempty"`
+
+	// Placement toggles from the Snap popup, stored inverted so both default on
+	FreePlacement bool `json:"freePlacement,omitempty"`
+	HideGrid      bool `json:"hideGrid,omitempty"`
 }
 
 const editorPrefsFile = ".editor_prefs.json"
@@ -324,11 +387,20 @@ func (e *Editor) SavePrefs() {
 		CameraYaw:        e.camera.Yaw,
 		CameraPitch:      e.camera.Pitch,
 		CameraMoveSpeed:  e.camera.MoveSpeed,
-		ScenePath:        world.ScenePath,
+		ScenePath:        project.Current.CurrentScene,
 		AssetBrowserOpen: e.showAssetBrowser,
 		AssetBrowserPath: e.currentAssetPath,
 		HierarchyWidth:   e.hierarchyWidth,
 		InspectorWidth:   e.inspectorWidth,
+		BroadPhaseMode:   e.world.PhysicsWorld.BroadPhaseMode.String(),
+		AutoReload:       e.autoReloadScripts,
+		Bookmarks:        e.bookmarks,
+		FavoriteAssets:   e.favoriteAssets,
+		RecentScenes:     e.recentScenes,
+		MaxUndo:          e.maxUndo,
+		Snap:             &e.snap,
+		FreePlacement:    !e.placeOnSurface,
+		HideGrid:         !e.showGrid,
 	}
 
 	data, err := json.MarshalIndent(prefs, "", "  ")
@@ -360,6 +432,22 @@ func (e *Editor) ApplyPrefs(prefs *EditorPrefs) {
 	if prefs.InspectorWidth > 0 {
 		e.inspectorWidth = prefs.InspectorWidth
 	}
+	if mode, ok := physics.ParseBroadPhaseMode(prefs.BroadPhaseMode); ok {
+		e.world.PhysicsWorld.BroadPhaseMode = mode
+	}
+	e.autoReloadScripts = prefs.AutoReload
+	e.bookmarks = prefs.Bookmarks
+	e.favoriteAssets = prefs.FavoriteAssets
+	e.recentScenes = prefs.RecentScenes
+	if prefs.MaxUndo > 0 {
+		e.setMaxUndo(prefs.MaxUndo)
+	}
+	if prefs.Snap != nil {
+		e.snap = *prefs.Snap
+	}
+	e.placeOnSurface = !prefs.FreePlacement
+	e.showGrid = !prefs.HideGrid
+	e.addRecentScene(project.Current.CurrentScene)
 	e.showAssetBrowser = prefs.AssetBrowserOpen
 	if prefs.AssetBrowserPath != "" {
 		e.currentAssetPath = prefs.AssetBrowserPath
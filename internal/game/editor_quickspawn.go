@@ -0,0 +1,131 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/assets"
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// quickSpawnItem is an entry in the Shift+A quick-spawn palette
+type quickSpawnItem struct {
+	Name string
+	// Lift is how far above the surface under the cursor the object's origin
+	// goes, so it rests on the surface instead of sinking into it
+	Lift  float32
+	Setup func(e *Editor, g *engine.GameObject)
+}
+
+var quickSpawnItems = []quickSpawnItem{
+	{"Cube", 0.5, func(e *Editor, g *engine.GameObject) {
+		g.AddComponent(e.newPrimitiveRenderer("cube"))
+		g.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1}))
+	}},
+	{"Sphere", 0.5, func(e *Editor, g *engine.GameObject) {
+		g.AddComponent(e.newPrimitiveRenderer("sphere"))
+		g.AddComponent(components.NewSphereCollider(0.5))
+	}},
+	{"Point Light", 1, func(e *Editor, g *engine.GameObject) {
+		g.AddComponent(components.NewPointLight())
+	}},
+	{"Empty", 0, func(e *Editor, g *engine.GameObject) {}},
+}
+
+const (
+	quickSpawnItemH    = 22
+	quickSpawnW        = 150
+	quickSpawnDistance = 10 // how far in front of the camera to spawn when the cursor hits nothing
+)
+
+// openQuickSpawn opens the quick-spawn palette at the mouse. Whatever is picked
+// lands where the cursor was when the palette opened.
+func (e *Editor) openQuickSpawn() {
+	if e.mouseInPanel() {
+		return
+	}
+	e.quickSpawnAt = rl.GetMousePosition()
+	e.showQuickSpawn = true
+}
+
+// quickSpawnRect returns the palette bounds, kept on screen
+func (e *Editor) quickSpawnRect() rl.Rectangle {
+	h := float32(len(quickSpawnItems)*quickSpawnItemH + 24)
+	return rl.Rectangle{
+		X:      min(e.quickSpawnAt.X, float32(rl.GetScreenWidth())-quickSpawnW),
+		Y:      min(e.quickSpawnAt.Y, float32(rl.GetScreenHeight())-h),
+		Width:  quickSpawnW,
+		Height: h,
+	}
+}
+
+// drawQuickSpawn draws the palette and spawns the item clicked
+func (e *Editor) drawQuickSpawn() {
+	if !e.showQuickSpawn {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		e.showQuickSpawn = false
+		return
+	}
+
+	mousePos := rl.GetMousePosition()
+	bounds := e.quickSpawnRect()
+	rl.DrawRectangleRounded(bounds, 0.05, 4, colorBgPanel)
+	rl.DrawRectangleRoundedLinesEx(bounds, 0.05, 4, 1, colorBorder)
+	drawTextEx(editorFontBold, "Spawn", int32(bounds.X)+10, int32(bounds.Y)+4, 15, colorTextMuted)
+
+	for i, item := range quickSpawnItems {
+		row := rl.Rectangle{X: bounds.X, Y: bounds.Y + 24 + float32(i*quickSpawnItemH), Width: bounds.Width, Height: quickSpawnItemH}
+		hovered := rl.CheckCollisionPointRec(mousePos, row)
+		color := colorTextSecondary
+		if hovered {
+			rl.DrawRectangleRec(row, colorAccent)
+			color = colorTextPrimary
+		}
+		drawTextEx(editorFont, item.Name, int32(row.X)+10, int32(row.Y)+3, 15, color)
+
+		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			e.showQuickSpawn = false
+			e.quickSpawn(item)
+			return
+		}
+	}
+
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && !rl.CheckCollisionPointRec(mousePos, bounds) {
+		e.showQuickSpawn = false
+	}
+}
+
+// quickSpawn creates item on the surface under the point the palette was
+// opened at, or quickSpawnDistance in front of the camera if nothing is there
+func (e *Editor) quickSpawn(item quickSpawnItem) {
+	ray := rl.GetScreenToWorldRay(e.quickSpawnAt, e.GetRaylibCamera())
+	pos := rl.Vector3Add(ray.Position, rl.Vector3Scale(ray.Direction, quickSpawnDistance))
+	if hit, ok := e.world.EditorRaycast(ray.Position, ray.Direction, 1000); ok {
+		pos = rl.Vector3Add(hit.Point, rl.Vector3Scale(hit.Normal, item.Lift))
+	}
+
+	name := e.uniqueObjectName(item.Name)
+	obj := engine.NewGameObject(name)
+	obj.Transform.Position = pos
+	item.Setup(e, obj)
+
+	e.world.Scene.AddGameObject(obj)
+	e.world.PhysicsWorld.AddObject(obj)
+	e.Selected = obj
+	e.setMsg("Created %s", name)
+}
+
+// newPrimitiveRenderer returns a white ModelRenderer for a generated mesh at its default size
+func (e *Editor) newPrimitiveRenderer(meshType string) *components.ModelRenderer {
+	size := assets.DefaultMeshSize(meshType)
+	mesh, _ := assets.GenPrimitiveMesh(meshType, size)
+	renderer := components.NewModelRenderer(rl.LoadModelFromMesh(mesh), rl.White)
+	renderer.MeshType = meshType
+	renderer.MeshSize = size
+	renderer.SetShader(e.world.Renderer.Shader)
+	return renderer
+}
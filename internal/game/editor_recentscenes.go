@@ -0,0 +1,136 @@
+//go:build !game
+
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"test3d/internal/project"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// maxRecentScenes is how many scenes the Recent Scenes menu remembers
+const maxRecentScenes = 8
+
+const recentSceneItemH = 20
+
+// sceneButtonRect is the current scene button in the top bar
+var sceneButtonRect = rl.Rectangle{X: 466, Y: 6, Width: 170, Height: 24}
+
+// addRecentScene moves path to the front of the recent scenes list
+func (e *Editor) addRecentScene(path string) {
+	if path == "" {
+		return
+	}
+	path = filepath.ToSlash(path)
+	e.recentScenes = slices.DeleteFunc(e.recentScenes, func(p string) bool { return p == path })
+	e.recentScenes = slices.Insert(e.recentScenes, 0, path)
+	if len(e.recentScenes) > maxRecentScenes {
+		e.recentScenes = e.recentScenes[:maxRecentScenes]
+	}
+}
+
+// pruneRecentScenes drops scenes that were deleted or moved since they were opened
+func (e *Editor) pruneRecentScenes() {
+	e.recentScenes = slices.DeleteFunc(e.recentScenes, func(p string) bool {
+		_, err := os.Stat(p)
+		return err != nil
+	})
+}
+
+// sceneDisplayName is a scene path without its folder and extension
+func sceneDisplayName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// recentScenesMenuRect returns the bounds of the recent scenes menu below the scene button
+func (e *Editor) recentScenesMenuRect() rl.Rectangle {
+	return rl.Rectangle{
+		X:      sceneButtonRect.X,
+		Y:      sceneButtonRect.Y + sceneButtonRect.Height + 2,
+		Width:  240,
+		Height: float32(max(len(e.recentScenes), 1) * recentSceneItemH),
+	}
+}
+
+// drawSceneButton draws the current scene's name in the top bar. Clicking it
+// toggles the Recent Scenes menu, drawn later by drawRecentScenesMenu.
+func (e *Editor) drawSceneButton() {
+	mousePos := rl.GetMousePosition()
+	hovered := rl.CheckCollisionPointRec(mousePos, sceneButtonRect)
+	bgColor := colorBgElement
+	if hovered || e.showRecentScenes {
+		bgColor = colorBgHover
+	}
+	rl.DrawRectangleRounded(sceneButtonRect, 0.5, 8, bgColor)
+
+	label := sceneDisplayName(project.Current.CurrentScene)
+	for len(label) > 1 && measureTextEx(editorFont, label+" v", 16) > int32(sceneButtonRect.Width)-20 {
+		label = label[:len(label)-1]
+	}
+	drawTextEx(editorFont, label+" v", int32(sceneButtonRect.X)+10, int32(sceneButtonRect.Y)+4, 16, colorTextPrimary)
+
+	if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.showRecentScenes = !e.showRecentScenes
+		if e.showRecentScenes {
+			e.pruneRecentScenes()
+		}
+	}
+}
+
+// drawRecentScenesMenu lists the recently opened scenes and opens the one clicked
+func (e *Editor) drawRecentScenesMenu() {
+	if !e.showRecentScenes {
+		return
+	}
+	mousePos := rl.GetMousePosition()
+	bounds := e.recentScenesMenuRect()
+	rl.DrawRectangleRounded(bounds, 0.05, 4, colorBgPanel)
+	rl.DrawRectangleRoundedLinesEx(bounds, 0.05, 4, 1, colorBorder)
+
+	if len(e.recentScenes) == 0 {
+		drawTextEx(editorFont, "No recent scenes", int32(bounds.X)+10, int32(bounds.Y)+2, 15, colorTextMuted)
+	}
+
+	current := filepath.ToSlash(project.Current.CurrentScene)
+	for i, path := range e.recentScenes {
+		item := rl.Rectangle{X: bounds.X, Y: bounds.Y + float32(i*recentSceneItemH), Width: bounds.Width, Height: recentSceneItemH}
+		hovered := rl.CheckCollisionPointRec(mousePos, item)
+		if hovered {
+			rl.DrawRectangleRec(item, colorAccent)
+		}
+
+		color := colorTextSecondary
+		if path == current {
+			color = colorAccentLight
+		}
+		if hovered {
+			color = colorTextPrimary
+		}
+		name := sceneDisplayName(path)
+		drawTextEx(editorFont, name, int32(item.X)+10, int32(item.Y)+2, 15, color)
+		// Folder in muted text, for same-named scenes in different folders
+		dir := filepath.ToSlash(filepath.Dir(path))
+		dirX := int32(item.X) + 20 + measureTextEx(editorFont, name, 15)
+		if dirX+measureTextEx(editorFont, dir, 13) < int32(item.X+item.Width)-6 {
+			drawTextEx(editorFont, dir, dirX, int32(item.Y)+3, 13, colorTextMuted)
+		}
+
+		if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			e.showRecentScenes = false
+			if path != current {
+				e.openScene(path)
+			}
+			return
+		}
+	}
+
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && !rl.CheckCollisionPointRec(mousePos, bounds) &&
+		!rl.CheckCollisionPointRec(mousePos, sceneButtonRect) {
+		e.showRecentScenes = false
+	}
+}
@@ -0,0 +1,135 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+
+	"test3d/internal/project"
+	"test3d/internal/world"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// openSceneChanges diffs the scene in memory against the file on disk and
+// shows the result (Ctrl+Shift+S)
+func (e *Editor) openSceneChanges() {
+	changes, err := e.world.DiffScene(project.Current.CurrentScene)
+	if err != nil {
+		e.setMsg("Diff failed: %v", err)
+		return
+	}
+	e.sceneChanges = changes
+	e.sceneChangesScroll = 0
+	e.showSceneChanges = true
+}
+
+// drawSceneChanges draws the list of unsaved changes as a centered panel with
+// Save and Close buttons. Clicking a change selects its object.
+func (e *Editor) drawSceneChanges() {
+	if !e.showSceneChanges {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		e.showSceneChanges = false
+		return
+	}
+
+	const (
+		panelW = int32(560)
+		lineH  = int32(20)
+		pad    = int32(16)
+		btnW   = int32(80)
+		btnH   = int32(24)
+	)
+	screenW := int32(rl.GetScreenWidth())
+	screenH := int32(rl.GetScreenHeight())
+	panelH := min(screenH-120, int32(480))
+	panelX := (screenW - panelW) / 2
+	panelY := (screenH - panelH) / 2
+
+	rl.DrawRectangle(0, 0, screenW, screenH, rl.NewColor(0, 0, 0, 140))
+	bounds := rl.Rectangle{X: float32(panelX), Y: float32(panelY), Width: float32(panelW), Height: float32(panelH)}
+	rl.DrawRectangleRounded(bounds, 0.03, 8, colorBgPanel)
+	rl.DrawRectangleRoundedLines(bounds, 0.03, 8, colorAccent)
+
+	title := fmt.Sprintf("Scene Changes (%d)", len(e.sceneChanges))
+	drawTextEx(editorFontBold, title, panelX+pad, panelY+pad-4, 20, colorTextPrimary)
+	drawTextEx(editorFont, project.Current.CurrentScene, panelX+pad, panelY+pad+20, 14, colorTextMuted)
+
+	// Change list, clipped to the area above the buttons
+	listX := panelX + pad
+	listY := panelY + pad + 44
+	listH := panelH - (listY - panelY) - btnH - 2*pad
+	mousePos := rl.GetMousePosition()
+	listRect := rl.Rectangle{X: float32(listX), Y: float32(listY), Width: float32(panelW - 2*pad), Height: float32(listH)}
+
+	if rl.CheckCollisionPointRec(mousePos, listRect) {
+		e.sceneChangesScroll -= int32(rl.GetMouseWheelMove() * 20)
+	}
+	maxScroll := max(int32(len(e.sceneChanges))*lineH-listH, 0)
+	e.sceneChangesScroll = max(min(e.sceneChangesScroll, maxScroll), 0)
+
+	if len(e.sceneChanges) == 0 {
+		drawTextEx(editorFont, "No changes since the last save", listX, listY, 15, colorTextSecondary)
+	}
+
+	rl.BeginScissorMode(listX, listY, panelW-2*pad, listH)
+	for i, c := range e.sceneChanges {
+		y := listY + int32(i)*lineH - e.sceneChangesScroll
+		if y+lineH < listY || y > listY+listH {
+			continue
+		}
+		row := rl.Rectangle{X: float32(listX), Y: float32(y), Width: float32(panelW - 2*pad), Height: float32(lineH)}
+		obj := e.world.Scene.FindByUID(c.UID)
+		if obj != nil && rl.CheckCollisionPointRec(mousePos, row) && rl.CheckCollisionPointRec(mousePos, listRect) {
+			rl.DrawRectangleRec(row, colorBgHover)
+			if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				e.Selected = obj
+			}
+		}
+
+		marker, color := "~", rl.NewColor(240, 200, 90, 255)
+		switch c.Kind {
+		case world.ChangeAdded:
+			marker, color = "+", rl.NewColor(100, 220, 100, 255)
+		case world.ChangeRemoved:
+			marker, color = "-", rl.NewColor(255, 120, 120, 255)
+		}
+		drawTextEx(editorFontMono, marker, listX+4, y+2, 15, color)
+		drawTextEx(editorFontBold, c.Name, listX+22, y+2, 15, colorTextPrimary)
+		nameW := measureTextEx(editorFontBold, c.Name, 15)
+		drawTextEx(editorFont, c.Detail, listX+30+nameW, y+2, 15, colorTextSecondary)
+	}
+	rl.EndScissorMode()
+
+	// Buttons
+	btnY := panelY + panelH - pad - btnH
+	closeX := panelX + panelW - pad - btnW
+	saveX := closeX - btnW - 8
+	if drawDialogButton(saveX, btnY, btnW, btnH, "Save", len(e.sceneChanges) > 0) {
+		e.saveScene()
+		e.showSceneChanges = false
+	}
+	if drawDialogButton(closeX, btnY, btnW, btnH, "Close", false) {
+		e.showSceneChanges = false
+	}
+}
+
+// drawDialogButton draws a rounded button and returns true when it is clicked
+func drawDialogButton(x, y, w, h int32, label string, primary bool) bool {
+	mousePos := rl.GetMousePosition()
+	hovered := mousePos.X >= float32(x) && mousePos.X <= float32(x+w) &&
+		mousePos.Y >= float32(y) && mousePos.Y <= float32(y+h)
+	color := colorBgElement
+	if primary {
+		color = colorAccent
+	}
+	if hovered {
+		color = colorBgHover
+	}
+	rl.DrawRectangleRounded(rl.Rectangle{X: float32(x), Y: float32(y), Width: float32(w), Height: float32(h)}, 0.3, 4, color)
+	textW := measureTextEx(editorFont, label, 15)
+	drawTextEx(editorFont, label, x+(w-textW)/2, y+4, 15, colorTextPrimary)
+	return hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton)
+}
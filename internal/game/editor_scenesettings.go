@@ -0,0 +1,164 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+
+	"test3d/internal/world"
+
+	gui "github.com/gen2brain/raylib-go/raygui"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// drawSceneSettings fills the inspector with the scene's own settings while
+// nothing is selected. They are saved with the scene.
+func (e *Editor) drawSceneSettings() {
+	panelW := e.inspectorWidth
+	panelX := int32(rl.GetScreenWidth()) - panelW
+	panelY := int32(36)
+	panelH := int32(rl.GetScreenHeight()) - panelY
+
+	rl.DrawRectangle(panelX, panelY, panelW, panelH, colorBgPanel)
+	rl.DrawRectangle(panelX, panelY, 2, panelH, colorBorder)
+
+	const (
+		labelW = int32(80)
+		fieldH = int32(22)
+	)
+	indent := panelX + 14
+	fieldW := panelW - 28 - labelW - 40
+	y := panelY + 10
+
+	drawTextEx(editorFontBold, "Scene Settings", indent, y, 18, colorTextPrimary)
+	y += 28
+	rl.DrawLine(panelX+12, y, panelX+panelW-12, y, colorLine)
+	y += 10
+
+	// Bloom
+	bloom := &e.world.Settings.Bloom
+	drawTextEx(editorFontBold, "Bloom", indent, y, 16, colorAccentLight)
+	y += 24
+
+	enabledBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+	bloom.Enabled = gui.CheckBox(enabledBounds, "Enabled", bloom.Enabled)
+	y += fieldH + 6
+
+	drawTextEx(editorFont, "Threshold", indent, y+4, 15, colorTextMuted)
+	thresholdBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW), Height: float32(fieldH)}
+	bloom.Threshold = gui.Slider(thresholdBounds, "", fmt.Sprintf("%.2f", bloom.Threshold), bloom.Threshold, 0, 1)
+	y += fieldH + 4
+
+	drawTextEx(editorFont, "Intensity", indent, y+4, 15, colorTextMuted)
+	intensityBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW), Height: float32(fieldH)}
+	bloom.Intensity = gui.Slider(intensityBounds, "", fmt.Sprintf("%.2f", bloom.Intensity), bloom.Intensity, 0, 3)
+	y += fieldH + 8
+
+	drawTextEx(editorFont, "Glows on emissive and bright surfaces", indent, y, 14, colorTextMuted)
+	y += 28
+
+	// Fog
+	fog := &e.world.Settings.Fog
+	drawTextEx(editorFontBold, "Fog", indent, y, 16, colorAccentLight)
+	y += 24
+
+	fogBounds := rl.Rectangle{X: float32(indent), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+	fog.Enabled = gui.CheckBox(fogBounds, "Enabled", fog.Enabled)
+	y += fieldH + 6
+
+	drawTextEx(editorFont, "Color", indent, y+4, 15, colorTextMuted)
+	preview := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+	rl.DrawRectangleRec(preview, fog.RGBA())
+	rl.DrawRectangleLinesEx(preview, 1, rl.Gray)
+	channelW := (fieldW - fieldH - 4) / 3
+	for c := range fog.Color {
+		x := indent + labelW + fieldH + 4 + int32(c)*(channelW+2)
+		v := e.drawFloatField(x, y, channelW-2, fieldH, fmt.Sprintf("fog.color.%d", c), float32(fog.Color[c]))
+		fog.Color[c] = uint8(min(max(v, 0), 255))
+	}
+	y += fieldH + 4
+
+	drawTextEx(editorFont, "Mode", indent, y+4, 15, colorTextMuted)
+	modeBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW), Height: float32(fieldH)}
+	mode := int32(0)
+	if fog.Mode == world.FogExponential {
+		mode = 1
+	}
+	if gui.ComboBox(modeBounds, "Linear;Exponential", mode) == 1 {
+		fog.Mode = world.FogExponential
+	} else {
+		fog.Mode = world.FogLinear
+	}
+	y += fieldH + 4
+
+	if fog.Mode == world.FogExponential {
+		drawTextEx(editorFont, "Density", indent, y+4, 15, colorTextMuted)
+		densityBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW), Height: float32(fieldH)}
+		fog.Density = gui.Slider(densityBounds, "", fmt.Sprintf("%.3f", fog.Density), fog.Density, 0, 0.2)
+	} else {
+		drawTextEx(editorFont, "Start", indent, y+4, 15, colorTextMuted)
+		fog.Start = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, "fog.start", fog.Start), 0)
+		y += fieldH + 4
+
+		drawTextEx(editorFont, "End", indent, y+4, 15, colorTextMuted)
+		fog.End = max(e.drawFloatField(indent+labelW, y, fieldW, fieldH, "fog.end", fog.End), fog.Start)
+	}
+	y += fieldH + 16
+
+	// Ambient light
+	ambient := &e.world.Settings.Ambient
+	drawTextEx(editorFontBold, "Ambient Light", indent, y, 16, colorAccentLight)
+	y += 24
+
+	drawTextEx(editorFont, "Color", indent, y+4, 15, colorTextMuted)
+	ambientPreview := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldH), Height: float32(fieldH)}
+	rl.DrawRectangleRec(ambientPreview, rl.NewColor(ambient.Color[0], ambient.Color[1], ambient.Color[2], 255))
+	rl.DrawRectangleLinesEx(ambientPreview, 1, rl.Gray)
+	for c := range ambient.Color {
+		x := indent + labelW + fieldH + 4 + int32(c)*(channelW+2)
+		v := e.drawFloatField(x, y, channelW-2, fieldH, fmt.Sprintf("ambient.color.%d", c), float32(ambient.Color[c]))
+		ambient.Color[c] = uint8(min(max(v, 0), 255))
+	}
+	y += fieldH + 4
+
+	drawTextEx(editorFont, "Intensity", indent, y+4, 15, colorTextMuted)
+	ambientBounds := rl.Rectangle{X: float32(indent + labelW), Y: float32(y), Width: float32(fieldW), Height: float32(fieldH)}
+	ambient.Intensity = gui.Slider(ambientBounds, "", fmt.Sprintf("%.2f", ambient.Intensity), ambient.Intensity, 0, 4)
+	y += fieldH + 16
+
+	// Physics
+	phys := &e.world.Settings.Physics
+	drawTextEx(editorFontBold, "Physics", indent, y, 16, colorAccentLight)
+	y += 24
+
+	drawTextEx(editorFont, "Gravity", indent, y+4, 15, colorTextMuted)
+	oldGravity := phys.Gravity
+	axisW := (fieldW + 40) / 3
+	for i := range phys.Gravity {
+		x := indent + labelW + int32(i)*(axisW+2)
+		phys.Gravity[i] = e.drawFloatField(x, y, axisW-2, fieldH, fmt.Sprintf("physics.gravity.%d", i), phys.Gravity[i])
+	}
+	if phys.Gravity != oldGravity {
+		// Only on edits, so scripts changing gravity in play mode keep their value
+		e.world.PhysicsWorld.Gravity = phys.GravityVector()
+	}
+	y += fieldH + 8
+
+	drawTextEx(editorFont, "Default is (0, -20, 0); (0, 0, 0) for zero-G", indent, y, 14, colorTextMuted)
+	y += 28
+
+	// Editor-wide settings, kept in the editor preferences rather than the scene
+	rl.DrawLine(panelX+12, y, panelX+panelW-12, y, colorLine)
+	y += 10
+	drawTextEx(editorFontBold, "Editor", indent, y, 16, colorAccentLight)
+	y += 24
+
+	drawTextEx(editorFont, "Max Undo", indent, y+4, 15, colorTextMuted)
+	maxUndo := e.drawFloatField(indent+labelW, y, fieldW, fieldH, "editor.maxUndo", float32(e.maxUndo))
+	if n := int(maxUndo); n != e.maxUndo {
+		e.setMaxUndo(n)
+	}
+	y += fieldH + 8
+
+	drawTextEx(editorFont, e.undoStatus(), indent, y, 14, colorTextMuted)
+}
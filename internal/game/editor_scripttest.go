@@ -0,0 +1,40 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// runScriptTest runs a copy of the script's Start and Update outside play mode
+// (see engine.TestScript) and logs the outcome to the console
+func (e *Editor) runScriptTest(c engine.Component) {
+	frames := max(int(e.scriptTestFrames), 1)
+	result, err := engine.TestScript(c, frames, e.scriptTestDt)
+	if err != nil {
+		e.setMsg("Test failed: %v", err)
+		return
+	}
+
+	fmt.Printf("Test %s on %s: %d/%d frames at dt=%.4f\n", result.Name, c.GetGameObject().Name, result.Frames, frames, e.scriptTestDt)
+	if result.Panic != nil {
+		fmt.Printf("  panic in frame %d: %v\n%s", result.Frames+1, result.Panic, result.Stack)
+		e.setMsg("%s panicked in frame %d: %v", result.Name, result.Frames+1, result.Panic)
+		return
+	}
+
+	before, after := result.Before, result.After
+	fmt.Printf("  position %s -> %s\n", formatVec3(before.Position), formatVec3(after.Position))
+	fmt.Printf("  rotation %s -> %s\n", formatVec3(before.Rotation), formatVec3(after.Rotation))
+	fmt.Printf("  scale    %s -> %s\n", formatVec3(before.Scale), formatVec3(after.Scale))
+	e.setMsg("%s ran %d frames, moved to %s (see console)", result.Name, result.Frames, formatVec3(after.Position))
+}
+
+// formatVec3 formats a vector for log lines
+func formatVec3(v rl.Vector3) string {
+	return fmt.Sprintf("(%.2f, %.2f, %.2f)", v.X, v.Y, v.Z)
+}
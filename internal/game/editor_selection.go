@@ -0,0 +1,303 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Multi-selection: Selected is the primary object (the one the inspector and
+// gizmo follow) and SelectedSet holds every selected object, Selected
+// included. Code that just assigns Selected replaces the whole selection:
+// trackSelection notices the new object isn't in the set and starts a new
+// one with only it.
+
+// selectOnly makes g the only selected object (nil clears the selection)
+func (e *Editor) selectOnly(g *engine.GameObject) {
+	clear(e.SelectedSet)
+	if g != nil {
+		e.SelectedSet[g] = true
+	}
+	e.Selected = g
+}
+
+// rebuildScene runs rebuild, which replaces scene objects with new ones of
+// the same UIDs, and points the selection and solo at the new objects. The
+// undo history holds the old objects, so it is cleared. If rebuild fails,
+// nothing was replaced and the editor state is left alone.
+func (e *Editor) rebuildScene(rebuild func() error) error {
+	var selected []uint64
+	for _, obj := range e.selection() {
+		selected = append(selected, obj.UID)
+	}
+	primary := uint64(0)
+	if e.Selected != nil {
+		primary = e.Selected.UID
+	}
+	soloUID := uint64(0)
+	if e.soloObject != nil {
+		soloUID = e.soloObject.UID
+	}
+
+	if err := rebuild(); err != nil {
+		return err
+	}
+
+	clear(e.SelectedSet)
+	for _, uid := range selected {
+		if obj := e.world.Scene.FindByUID(uid); obj != nil {
+			e.SelectedSet[obj] = true
+		}
+	}
+	e.Selected = e.world.Scene.FindByUID(primary)
+	e.soloObject = nil
+	if soloUID != 0 {
+		e.soloObject = e.world.Scene.FindByUID(soloUID)
+	}
+	e.focusedComponent = nil
+	e.clearUndo()
+	e.clearSelectionHistory()
+	return nil
+}
+
+// isSelected reports whether g is part of the selection
+func (e *Editor) isSelected(g *engine.GameObject) bool {
+	return g == e.Selected || g != nil && e.SelectedSet[g] && e.SelectedSet[e.Selected]
+}
+
+// selection returns the selected objects in scene order
+func (e *Editor) selection() []*engine.GameObject {
+	if e.Selected == nil {
+		return nil
+	}
+	if len(e.SelectedSet) <= 1 || !e.SelectedSet[e.Selected] {
+		return []*engine.GameObject{e.Selected}
+	}
+	var sel []*engine.GameObject
+	for _, g := range e.world.Scene.GameObjects {
+		if e.SelectedSet[g] {
+			sel = append(sel, g)
+		}
+	}
+	return sel
+}
+
+// selectionRoots returns the selected objects that aren't under another
+// selected object, so moving or copying them handles each object once
+func selectionRoots(objs []*engine.GameObject) []*engine.GameObject {
+	var roots []*engine.GameObject
+	for _, obj := range objs {
+		covered := false
+		for _, other := range objs {
+			if other != obj && obj.IsDescendantOf(other) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			roots = append(roots, obj)
+		}
+	}
+	return roots
+}
+
+// toggleSelected adds g to the selection, making it the primary object, or
+// removes it (Ctrl+click in the hierarchy)
+func (e *Editor) toggleSelected(g *engine.GameObject) {
+	sel := e.selection()
+	clear(e.SelectedSet)
+	for _, s := range sel {
+		e.SelectedSet[s] = true
+	}
+	e.selectAnchor = g
+
+	if !e.SelectedSet[g] {
+		e.SelectedSet[g] = true
+		e.Selected = g
+		return
+	}
+	delete(e.SelectedSet, g)
+	if g == e.Selected {
+		e.Selected = nil
+		for _, s := range sel {
+			if e.SelectedSet[s] {
+				e.Selected = s // the last remaining one in scene order
+			}
+		}
+	}
+}
+
+// selectRange selects every listed object between the anchor of the last
+// click and g, with g as the primary object (Shift+click in the hierarchy)
+func (e *Editor) selectRange(list []*engine.GameObject, g *engine.GameObject) {
+	from, to := -1, -1
+	for i, obj := range list {
+		if obj == e.selectAnchor {
+			from = i
+		}
+		if obj == g {
+			to = i
+		}
+	}
+	if from < 0 || to < 0 {
+		e.selectOnly(g)
+		e.selectAnchor = g
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+	clear(e.SelectedSet)
+	for _, obj := range list[from : to+1] {
+		e.SelectedSet[obj] = true
+	}
+	e.Selected = g
+}
+
+// groupDragStart is where another selected object was when a gizmo drag of
+// Selected started
+type groupDragStart struct {
+	obj      *engine.GameObject
+	worldPos rl.Vector3
+	rotation rl.Vector3
+	scale    rl.Vector3
+}
+
+// startGroupDrag records the rest of the selection so it can follow a gizmo
+// drag of Selected. Ancestors of Selected stay put, since moving them would
+// carry Selected along twice.
+func (e *Editor) startGroupDrag() {
+	e.dragGroup = e.dragGroup[:0]
+	for _, obj := range selectionRoots(e.selection()) {
+		if obj == e.Selected || e.Selected.IsDescendantOf(obj) {
+			continue
+		}
+		e.dragGroup = append(e.dragGroup, groupDragStart{
+			obj:      obj,
+			worldPos: obj.WorldPosition(),
+			rotation: obj.Transform.Rotation,
+			scale:    obj.Transform.Scale,
+		})
+	}
+}
+
+// applyGroupDrag gives the rest of the selection the change the drag made to
+// Selected: the same world offset, rotation change and scale factor
+func (e *Editor) applyGroupDrag() {
+	if len(e.dragGroup) == 0 || e.Selected == nil {
+		return
+	}
+	offset := rl.Vector3Subtract(e.Selected.WorldPosition(), e.dragInitWorldPos)
+	turn := rl.Vector3Subtract(e.Selected.Transform.Rotation, e.dragInitRot)
+	factor := rl.Vector3{
+		X: scaleRatio(e.Selected.Transform.Scale.X, e.dragInitScale.X),
+		Y: scaleRatio(e.Selected.Transform.Scale.Y, e.dragInitScale.Y),
+		Z: scaleRatio(e.Selected.Transform.Scale.Z, e.dragInitScale.Z),
+	}
+	for _, s := range e.dragGroup {
+		s.obj.SetWorldPosition(rl.Vector3Add(s.worldPos, offset))
+		s.obj.Transform.Rotation = rl.Vector3Add(s.rotation, turn)
+		s.obj.Transform.Scale = rl.Vector3Multiply(s.scale, factor)
+	}
+}
+
+// scaleRatio is how much a scale axis grew, 1 for an axis that started at zero
+func scaleRatio(now, start float32) float32 {
+	if start == 0 {
+		return 1
+	}
+	return now / start
+}
+
+// duplicateSelection duplicates every selected object and selects the copies
+func (e *Editor) duplicateSelection() {
+	roots := selectionRoots(e.selection())
+	if len(roots) == 0 {
+		return
+	}
+	primary := e.Selected
+	copies := make([]*engine.GameObject, 0, len(roots))
+	for _, obj := range roots {
+		dup := e.world.DuplicateObject(obj)
+		copies = append(copies, dup)
+		if obj == primary || primary.IsDescendantOf(obj) {
+			primary = dup
+		}
+	}
+	if !slices.Contains(copies, primary) {
+		primary = copies[len(copies)-1]
+	}
+
+	clear(e.SelectedSet)
+	for _, dup := range copies {
+		e.SelectedSet[dup] = true
+	}
+	e.Selected = primary
+	if len(copies) > 1 {
+		e.setMsg("Duplicated %d objects", len(copies))
+	}
+}
+
+// drawMultiInspector replaces the inspector while several objects are
+// selected. The transform fields show the primary object's values; an edit
+// adds the same change to every selected object.
+func (e *Editor) drawMultiInspector(sel []*engine.GameObject) {
+	panelW := e.inspectorWidth
+	panelX := int32(rl.GetScreenWidth()) - panelW
+	panelY := int32(36)
+	panelH := int32(rl.GetScreenHeight()) - panelY
+
+	rl.DrawRectangle(panelX, panelY, panelW, panelH, colorBgPanel)
+	rl.DrawRectangle(panelX, panelY, 2, panelH, colorBorder)
+
+	y := panelY + 8
+	drawTextEx(editorFontBold, fmt.Sprintf("%d objects selected", len(sel)), panelX+12, y, 20, colorTextPrimary)
+	y += 28
+	drawTextEx(editorFont, "Values of "+e.Selected.Name+", edits move all", panelX+12, y, 14, colorTextMuted)
+	y += 24
+
+	rl.DrawLine(panelX+12, y+2, panelX+panelW-12, y+2, colorLine)
+	y += 10
+
+	drawTextEx(editorFontBold, "Transform", panelX+12, y, 18, colorTextSecondary)
+	y += 28
+
+	labelW := int32(45)
+	fieldW := (panelW - 38 - labelW) / 3
+	fieldH := int32(24)
+	startX := panelX + 12 + labelW
+
+	row := func(label, id string, value rl.Vector3) rl.Vector3 {
+		drawTextEx(editorFont, label, panelX+14, y+4, 16, colorTextMuted)
+		edited := value
+		edited.X = e.drawFloatField(startX, y, fieldW, fieldH, id+".x", value.X)
+		edited.Y = e.drawFloatField(startX+fieldW+2, y, fieldW, fieldH, id+".y", value.Y)
+		edited.Z = e.drawFloatField(startX+2*(fieldW+2), y, fieldW, fieldH, id+".z", value.Z)
+		y += fieldH + 4
+		return rl.Vector3Subtract(edited, value)
+	}
+
+	// Children of other selected objects already move with them
+	roots := selectionRoots(sel)
+	t := e.Selected.Transform
+	if d := row("Pos", "multi.pos", t.Position); d != (rl.Vector3{}) {
+		for _, obj := range roots {
+			obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, d)
+		}
+	}
+	if d := row("Rot", "multi.rot", t.Rotation); d != (rl.Vector3{}) {
+		for _, obj := range roots {
+			obj.Transform.Rotation = rl.Vector3Add(obj.Transform.Rotation, d)
+		}
+	}
+	if d := row("Scale", "multi.scale", t.Scale); d != (rl.Vector3{}) {
+		for _, obj := range roots {
+			obj.Transform.Scale = rl.Vector3Add(obj.Transform.Scale, d)
+		}
+	}
+}
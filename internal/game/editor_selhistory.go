@@ -0,0 +1,64 @@
+//go:build !game
+
+package game
+
+// selectionHistorySize is how many previous selections are remembered
+const selectionHistorySize = 8
+
+// trackSelection records the previous selection whenever Selected changes, so
+// every way of selecting (viewport, hierarchy, duplicate, ...) feeds the history.
+// Objects are remembered by UID and looked up again when needed, so a deleted
+// object is simply skipped instead of being selected after it left the scene.
+func (e *Editor) trackSelection() {
+	if e.Selected == e.lastSelected {
+		return
+	}
+	e.focusedComponent = nil // component focus belongs to the old selection
+	if !e.SelectedSet[e.Selected] {
+		// Selected was assigned directly, which replaces a multi-selection
+		e.selectOnly(e.Selected)
+	}
+	if prev := e.lastSelected; prev != nil {
+		e.forgetSelection(prev.UID)
+		e.selectionHistory = append(e.selectionHistory, prev.UID)
+		if len(e.selectionHistory) > selectionHistorySize {
+			e.selectionHistory = e.selectionHistory[1:]
+		}
+	}
+	e.lastSelected = e.Selected
+}
+
+// forgetSelection removes uid from the selection history
+func (e *Editor) forgetSelection(uid uint64) {
+	kept := e.selectionHistory[:0]
+	for _, h := range e.selectionHistory {
+		if h != uid {
+			kept = append(kept, h)
+		}
+	}
+	e.selectionHistory = kept
+}
+
+// selectPrevious swaps the selection with the most recent previous selection
+// that is still in the scene, so pressing it again flips back.
+func (e *Editor) selectPrevious() {
+	for len(e.selectionHistory) > 0 {
+		last := len(e.selectionHistory) - 1
+		uid := e.selectionHistory[last]
+		e.selectionHistory = e.selectionHistory[:last]
+		obj := e.world.Scene.FindByUID(uid)
+		if obj == nil || obj == e.Selected {
+			continue
+		}
+		e.Selected = obj
+		e.trackSelection()
+		return
+	}
+	e.setMsg("No previous selection")
+}
+
+// clearSelectionHistory drops the history, for when a different scene is opened
+func (e *Editor) clearSelectionHistory() {
+	e.selectionHistory = e.selectionHistory[:0]
+	e.lastSelected = nil
+}
@@ -0,0 +1,252 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/project"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// shortcut is one entry of the editor's keyboard shortcut table. Entries with
+// an action are dispatched by handleShortcuts; entries without one are handled
+// elsewhere (mouse input, play mode keys in game.go) and only listed in the help.
+type shortcut struct {
+	Keys        string // label shown in the help overlay
+	Description string
+	Category    string
+
+	key    int32
+	ctrl   bool // requires Cmd/Ctrl
+	shift  bool // requires Shift
+	always bool // fires while typing in a field or flying the camera
+	action func(e *Editor)
+}
+
+// shortcutCategories is the order categories appear in the help overlay
+var shortcutCategories = []string{"File", "Edit", "Transform", "View", "Camera", "Play"}
+
+// editorShortcuts is the single list of editor shortcuts. Add new key bindings
+// here so they show up in the help overlay. Entries without a category are
+// dispatched but not listed (e.g. the per-digit camera bookmark keys).
+var editorShortcuts = []shortcut{
+	{Keys: "Ctrl+S", Description: "Save scene", Category: "File", key: rl.KeyS, ctrl: true, action: (*Editor).saveScene},
+	{Keys: "Ctrl+Shift+S", Description: "Review changes before saving", Category: "File", key: rl.KeyS, ctrl: true, shift: true, action: (*Editor).openSceneChanges},
+	{Keys: "Ctrl+Shift+E", Description: "Export selection as scene", Category: "File", key: rl.KeyE, ctrl: true, shift: true, action: (*Editor).openExportScene},
+	{Keys: "Ctrl+Shift+V", Description: "Check for intersecting static colliders", Category: "File", key: rl.KeyV, ctrl: true, shift: true, action: (*Editor).openValidation},
+	{Keys: "Ctrl+B", Description: "Build game", Category: "File", key: rl.KeyB, ctrl: true, action: (*Editor).buildGame},
+	{Keys: "Ctrl+R", Description: "Rebuild scripts and relaunch", Category: "File", key: rl.KeyR, ctrl: true, action: (*Editor).rebuildAndRelaunch},
+	{Keys: "Ctrl+Shift+R", Description: "Regenerate scripts and list new ones", Category: "File", key: rl.KeyR, ctrl: true, shift: true, action: (*Editor).regenerateScripts},
+
+	{Keys: "Ctrl+Z", Description: "Undo", Category: "Edit", key: rl.KeyZ, ctrl: true, action: (*Editor).undo},
+	{Keys: "Ctrl+Shift+Z", Description: "Redo", Category: "Edit", key: rl.KeyZ, ctrl: true, shift: true, action: (*Editor).redo},
+	{Keys: "Ctrl+D", Description: "Duplicate selected objects", Category: "Edit", key: rl.KeyD, ctrl: true, action: (*Editor).duplicateSelection},
+	{Keys: "Ctrl+C", Description: "Copy selected object or focused component", Category: "Edit", key: rl.KeyC, ctrl: true, action: (*Editor).copySelection},
+	{Keys: "Ctrl+V", Description: "Paste object, or component onto selection", Category: "Edit", key: rl.KeyV, ctrl: true, action: (*Editor).pasteClipboard},
+	{Keys: "Shift+A", Description: "Spawn a primitive at the cursor", Category: "Edit", key: rl.KeyA, shift: true, action: (*Editor).openQuickSpawn},
+	{Keys: "Delete / Backspace", Description: "Delete selected objects", Category: "Edit", key: rl.KeyDelete, action: (*Editor).deleteSelectedObject},
+	{key: rl.KeyBackspace, action: (*Editor).deleteSelectedObject},
+	{key: rl.KeyBackspace, ctrl: true, action: (*Editor).deleteSelectedObject},
+	{Keys: "U", Description: "Toggle UI edit mode", Category: "Edit"},
+
+	{Keys: "W", Description: "Move gizmo", Category: "Transform", key: rl.KeyW, action: func(e *Editor) { e.gizmoMode = GizmoMove }},
+	{Keys: "E", Description: "Rotate gizmo", Category: "Transform", key: rl.KeyE, action: func(e *Editor) { e.gizmoMode = GizmoRotate }},
+	{Keys: "R", Description: "Scale gizmo", Category: "Transform", key: rl.KeyR, action: func(e *Editor) { e.gizmoMode = GizmoScale }},
+	{Keys: "Shift+Drag", Description: "Scale uniformly", Category: "Transform"},
+	{Keys: "V+Drag", Description: "Snap to vertex while moving", Category: "Transform"},
+	{Keys: "Ctrl+Drag", Description: "Snap to the grid (Snap button sets steps)", Category: "Transform"},
+	{Keys: "J", Description: "Toggle joint mode", Category: "Transform", key: rl.KeyJ, action: (*Editor).toggleJointMode},
+	{Keys: "K", Description: "Toggle keyframe timeline", Category: "Transform", key: rl.KeyK, action: (*Editor).toggleTimeline},
+
+	{Keys: "`", Description: "Select previous object", Category: "View", key: rl.KeyGrave, action: (*Editor).selectPrevious},
+	{Keys: "F", Description: "Focus selected object", Category: "View", key: rl.KeyF, action: func(e *Editor) {
+		if e.Selected != nil {
+			e.focusOnObject(e.Selected)
+		}
+	}},
+	{Keys: "Tab", Description: "Toggle asset browser", Category: "View", key: rl.KeyTab, always: true, action: (*Editor).toggleAssetBrowser},
+	{Keys: "F1", Description: "Toggle debug overlay", Category: "View"},
+	{Keys: "F2", Description: "Show physics grid", Category: "View", key: rl.KeyF2, always: true, action: func(e *Editor) { e.showPhysicsGrid = !e.showPhysicsGrid }},
+	{Keys: "F3", Description: "Cycle broad-phase mode", Category: "View"},
+	{Keys: "F4", Description: "Show colliders (Game Mode)", Category: "View"},
+	{Keys: "F5", Description: "Show velocity arrows", Category: "View"},
+	{Keys: "Ctrl+Shift+T", Description: "Save theme to editor_theme.json", Category: "View", key: rl.KeyT, ctrl: true, shift: true, action: (*Editor).saveTheme},
+	{Keys: "?", Description: "Show this help", Category: "View", key: rl.KeySlash, shift: true, action: func(e *Editor) { e.showShortcutHelp = !e.showShortcutHelp }},
+
+	{Keys: "RMB+Drag", Description: "Look around", Category: "Camera"},
+	{Keys: "RMB+WASD", Description: "Fly (Q/E down/up)", Category: "Camera"},
+	{Keys: "Shift+Scroll", Description: "Adjust fly speed", Category: "Camera"},
+	{Keys: "Double-click", Description: "Focus object in hierarchy", Category: "Camera"},
+	{Keys: "Shift+1..9", Description: "Jump to camera bookmark", Category: "Camera"},
+	{Keys: "Ctrl+Shift+1..9", Description: "Save camera bookmark", Category: "Camera"},
+
+	{Keys: "Ctrl+P", Description: "Toggle play mode", Category: "Play"},
+	{Keys: "Ctrl+Shift+P", Description: "Pause / resume", Category: "Play"},
+	{Keys: "Esc", Description: "Toggle mouse capture (in game)", Category: "Play"},
+}
+
+// handleShortcuts runs the action of every shortcut pressed this frame
+func (e *Editor) handleShortcuts() {
+	ctrl := rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper) ||
+		rl.IsKeyDown(rl.KeyRightControl) || rl.IsKeyDown(rl.KeyRightSuper)
+	shift := rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift)
+	isEditingText := e.editingText()
+	busy := isEditingText || rl.IsMouseButtonDown(rl.MouseRightButton)
+
+	for _, s := range editorShortcuts {
+		if s.action == nil || !rl.IsKeyPressed(s.key) || s.ctrl != ctrl {
+			continue
+		}
+		// Shift only has to match when the shortcut asks for it, so
+		// shortcuts keep working while Shift is held for something else,
+		// unless the same key has its own Shift binding
+		if s.shift && !shift || !s.shift && shift && hasShiftVariant(s) {
+			continue
+		}
+		if !s.always && !s.ctrl && busy {
+			continue
+		}
+		// The help overlay is modal: only "?" itself works while it's open
+		if e.showShortcutHelp && (s.key != rl.KeySlash || !s.shift) {
+			continue
+		}
+		s.action(e)
+	}
+}
+
+// hasShiftVariant reports whether another shortcut binds Shift plus the same key
+func hasShiftVariant(s shortcut) bool {
+	for _, o := range editorShortcuts {
+		if o.shift && o.key == s.key && o.ctrl == s.ctrl && o.action != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// saveScene writes the current scene to disk (not while paused or simulating,
+// since the scene holds runtime changes)
+func (e *Editor) saveScene() {
+	if e.Paused {
+		return
+	}
+	if e.simulating {
+		e.setMsg("Stop the simulation before saving")
+		return
+	}
+	if err := e.world.SaveScene(project.Current.CurrentScene); err != nil {
+		e.setMsg("Save failed: %v", err)
+	} else {
+		e.setMsg("Scene saved!")
+	}
+}
+
+// toggleJointMode turns joint authoring on or off, ending any drag in progress
+func (e *Editor) toggleJointMode() {
+	e.jointMode = !e.jointMode
+	e.jointDragging = false
+	e.endJointHandleDrag()
+}
+
+// toggleAssetBrowser shows or hides the asset browser, rescanning when opened
+func (e *Editor) toggleAssetBrowser() {
+	e.showAssetBrowser = !e.showAssetBrowser
+	if e.showAssetBrowser {
+		if e.currentAssetPath == "" {
+			e.currentAssetPath = "assets"
+		}
+		e.scanAssets()
+	}
+}
+
+// drawShortcutHelp draws the shortcut table as a centered panel, one column per
+// group of categories. Escape or a click closes it.
+func (e *Editor) drawShortcutHelp() {
+	if !e.showShortcutHelp {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) || rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.showShortcutHelp = false
+		return
+	}
+
+	const (
+		keyW    = int32(130)
+		descW   = int32(230)
+		colW    = keyW + descW
+		lineH   = int32(20)
+		headerH = int32(26)
+		pad     = int32(20)
+		columns = 2
+	)
+
+	// Split the categories over the columns, keeping each category together
+	type entry struct {
+		header string
+		s      shortcut
+	}
+	var cols [columns][]entry
+	total := len(editorShortcuts) + len(shortcutCategories)
+	col, height := 0, 0
+	for _, cat := range shortcutCategories {
+		var items []entry
+		for _, s := range editorShortcuts {
+			if s.Category == cat {
+				items = append(items, entry{s: s})
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if col < columns-1 && height >= total/columns {
+			col++
+			height = 0
+		}
+		cols[col] = append(cols[col], entry{header: cat})
+		cols[col] = append(cols[col], items...)
+		height += len(items) + 1
+	}
+
+	// Panel size from the tallest column
+	var panelH int32
+	for _, c := range cols {
+		var h int32
+		for _, it := range c {
+			if it.header != "" {
+				h += headerH
+			} else {
+				h += lineH
+			}
+		}
+		panelH = max(panelH, h)
+	}
+	panelW := columns*colW + (columns+1)*pad
+	panelH += 2*pad + 30
+	panelX := (int32(rl.GetScreenWidth()) - panelW) / 2
+	panelY := (int32(rl.GetScreenHeight()) - panelH) / 2
+
+	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), rl.NewColor(0, 0, 0, 140))
+	bounds := rl.Rectangle{X: float32(panelX), Y: float32(panelY), Width: float32(panelW), Height: float32(panelH)}
+	rl.DrawRectangleRounded(bounds, 0.03, 8, colorBgPanel)
+	rl.DrawRectangleRoundedLines(bounds, 0.03, 8, colorAccent)
+
+	drawTextEx(editorFontBold, "Keyboard Shortcuts", panelX+pad, panelY+pad-4, 20, colorTextPrimary)
+	hint := "Esc or click to close"
+	drawTextEx(editorFont, hint, panelX+panelW-pad-measureTextEx(editorFont, hint, 14), panelY+pad, 14, colorTextMuted)
+
+	for i, c := range cols {
+		x := panelX + pad + int32(i)*(colW+pad)
+		y := panelY + pad + 30
+		for _, it := range c {
+			if it.header != "" {
+				y += 6
+				drawTextEx(editorFontBold, it.header, x, y, 16, colorAccentLight)
+				y += headerH - 6
+				continue
+			}
+			drawTextEx(editorFontMono, it.s.Keys, x, y, 15, colorTextSecondary)
+			drawTextEx(editorFont, it.s.Description, x+keyW, y, 15, colorTextPrimary)
+			y += lineH
+		}
+	}
+}
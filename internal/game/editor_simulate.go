@@ -0,0 +1,107 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Simulate runs physics and scripts on the open scene while the editor stays
+// up, without saving or rebuilding. Stop puts the scene back the way it was
+// from a snapshot taken in memory when the simulation started.
+
+// simulateButtonRect is the Simulate/Stop button in the top bar
+var simulateButtonRect = rl.Rectangle{X: 651, Y: 6, Width: 90, Height: 24}
+
+// toggleSimulation starts the simulation, or stops it if it is running
+func (e *Editor) toggleSimulation() {
+	if e.simulating {
+		e.stopSimulation()
+	} else {
+		e.startSimulation()
+	}
+}
+
+// startSimulation snapshots the scene and starts stepping it each frame. Not
+// while paused, since the scene already holds play mode changes.
+func (e *Editor) startSimulation() {
+	if e.simulating || e.Paused {
+		return
+	}
+	snapshot, err := e.world.SnapshotScene()
+	if err != nil {
+		e.setMsg("Simulate failed: %v", err)
+		return
+	}
+	e.simSnapshot = snapshot
+	e.simulating = true
+	e.dragging = false
+	engine.ResetTime()
+
+	// Solo the object only if it is still in the scene, as play mode does
+	if e.soloObject != nil && e.world.Scene.FindByUID(e.soloObject.UID) != e.soloObject {
+		e.soloObject = nil
+	}
+	e.world.Solo = e.soloObject
+	e.setMsg("Simulating - Stop restores the scene")
+}
+
+// stopSimulation ends the simulation and rebuilds the scene from the snapshot.
+// The rebuilt objects keep their UIDs, so the selection and solo carry over,
+// but the undo history pointed at the old objects and is cleared.
+func (e *Editor) stopSimulation() {
+	if !e.simulating {
+		return
+	}
+	e.rebuildScene(func() error {
+		e.world.ResetSceneTo(e.simSnapshot)
+		e.world.Solo = nil
+		return nil
+	})
+	e.simSnapshot = nil
+	e.simulating = false
+	e.dragging = false
+	engine.ResetTime()
+	e.setMsg("Simulation stopped, scene restored")
+}
+
+// updateSimulation advances a running simulation by one frame, the way play
+// mode updates the world
+func (e *Editor) updateSimulation(deltaTime float32) {
+	if !e.simulating {
+		return
+	}
+	e.world.Scene.Start()
+	e.world.Update(engine.AdvanceTime(deltaTime))
+}
+
+// drawSimulateButton draws the Simulate button in the top bar, which turns
+// into Stop while the simulation runs
+func (e *Editor) drawSimulateButton() {
+	if e.Paused {
+		return
+	}
+	mousePos := rl.GetMousePosition()
+	hovered := rl.CheckCollisionPointRec(mousePos, simulateButtonRect)
+	label := "Simulate"
+	bgColor := colorBgElement
+	textColor := colorTextSecondary
+	switch {
+	case e.simulating:
+		label = "Stop"
+		bgColor = colorAccent
+		textColor = colorTextPrimary
+	case hovered:
+		bgColor = colorBgHover
+		textColor = colorTextPrimary
+	}
+	rl.DrawRectangleRounded(simulateButtonRect, 0.5, 8, bgColor)
+	textW := measureTextEx(editorFont, label, 16)
+	drawTextEx(editorFont, label, int32(simulateButtonRect.X)+(int32(simulateButtonRect.Width)-textW)/2, int32(simulateButtonRect.Y)+4, 16, textColor)
+
+	if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.toggleSimulation()
+	}
+}
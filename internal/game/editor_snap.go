@@ -0,0 +1,143 @@
+//go:build !game
+
+package game
+
+import (
+	"math"
+
+	gui "github.com/gen2brain/raylib-go/raygui"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// gizmoSnap holds the increments a gizmo drag snaps to while Ctrl is held. A
+// zero increment leaves that kind of drag free.
+type gizmoSnap struct {
+	Move   float32 `json:"move"`   // world units
+	Rotate float32 `json:"rotate"` // degrees
+	Scale  float32 `json:"scale"`
+}
+
+var defaultGizmoSnap = gizmoSnap{Move: 0.25, Rotate: 15, Scale: 0.1}
+
+// snapButtonRect is the Snap settings button in the top bar
+var snapButtonRect = rl.Rectangle{X: 400, Y: 6, Width: 56, Height: 24}
+
+// snapTo rounds v to the nearest multiple of step (no-op for step <= 0)
+func snapTo(v, step float32) float32 {
+	if step <= 0 {
+		return v
+	}
+	return float32(math.Round(float64(v/step))) * step
+}
+
+// snapVector snaps the components of v that differ from start, so a drag
+// along one axis leaves the others where they were
+func snapVector(v, start rl.Vector3, step float32) rl.Vector3 {
+	if v.X != start.X {
+		v.X = snapTo(v.X, step)
+	}
+	if v.Y != start.Y {
+		v.Y = snapTo(v.Y, step)
+	}
+	if v.Z != start.Z {
+		v.Z = snapTo(v.Z, step)
+	}
+	return v
+}
+
+// snapScaleVector is snapVector for scale, which never snaps down to zero
+func snapScaleVector(v, start rl.Vector3, step float32) rl.Vector3 {
+	if step <= 0 {
+		return v
+	}
+	v = snapVector(v, start, step)
+	if v.X == 0 && start.X != 0 {
+		v.X = step
+	}
+	if v.Y == 0 && start.Y != 0 {
+		v.Y = step
+	}
+	if v.Z == 0 && start.Z != 0 {
+		v.Z = step
+	}
+	return v
+}
+
+// snapping reports whether the current gizmo drag should snap (Ctrl/Cmd held)
+func snapping() bool {
+	return rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyLeftSuper) ||
+		rl.IsKeyDown(rl.KeyRightControl) || rl.IsKeyDown(rl.KeyRightSuper)
+}
+
+// snapSettingsRect returns the bounds of the snap settings popup below its button
+func snapSettingsRect() rl.Rectangle {
+	return rl.Rectangle{X: snapButtonRect.X, Y: snapButtonRect.Y + snapButtonRect.Height + 2, Width: 200, Height: 166}
+}
+
+// drawSnapButton draws the Snap button in the top bar, lit while a drag is
+// snapping. Clicking it toggles the settings popup.
+func (e *Editor) drawSnapButton() {
+	mousePos := rl.GetMousePosition()
+	hovered := rl.CheckCollisionPointRec(mousePos, snapButtonRect)
+	bgColor := colorBgElement
+	textColor := colorTextSecondary
+	switch {
+	case e.dragging && snapping():
+		bgColor = colorAccent
+		textColor = colorTextPrimary
+	case hovered || e.showSnapSettings:
+		bgColor = colorBgHover
+		textColor = colorTextPrimary
+	}
+	rl.DrawRectangleRounded(snapButtonRect, 0.5, 8, bgColor)
+	drawTextEx(editorFont, "Snap", int32(snapButtonRect.X)+12, int32(snapButtonRect.Y)+4, 16, textColor)
+
+	if hovered && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		e.showSnapSettings = !e.showSnapSettings
+	}
+}
+
+// drawSnapSettings draws the popup for editing the snap increments and the
+// placement toggles
+func (e *Editor) drawSnapSettings() {
+	if !e.showSnapSettings {
+		return
+	}
+	mousePos := rl.GetMousePosition()
+	bounds := snapSettingsRect()
+	rl.DrawRectangleRounded(bounds, 0.05, 4, colorBgPanel)
+	rl.DrawRectangleRoundedLinesEx(bounds, 0.05, 4, 1, colorBorder)
+
+	x := int32(bounds.X) + 10
+	y := int32(bounds.Y) + 8
+	drawTextEx(editorFont, "Hold Ctrl while dragging to snap", x, y, 14, colorTextMuted)
+	y += 22
+
+	fieldX := x + 70
+	fieldW := int32(bounds.Width) - 90
+	rows := []struct {
+		label string
+		id    string
+		value *float32
+	}{
+		{"Move", "snap.move", &e.snap.Move},
+		{"Rotate", "snap.rotate", &e.snap.Rotate},
+		{"Scale", "snap.scale", &e.snap.Scale},
+	}
+	for _, r := range rows {
+		drawTextEx(editorFont, r.label, x, y+3, 15, colorTextSecondary)
+		*r.value = max(e.drawFloatField(fieldX, y, fieldW, 22, r.id, *r.value), 0)
+		y += 26
+	}
+
+	y += 4
+	e.placeOnSurface = gui.CheckBox(rl.Rectangle{X: float32(x), Y: float32(y), Width: 16, Height: 16}, "Place spawns on surfaces", e.placeOnSurface)
+	y += 24
+	e.showGrid = gui.CheckBox(rl.Rectangle{X: float32(x), Y: float32(y), Width: 16, Height: 16}, "Show ground grid", e.showGrid)
+
+	// Clicking elsewhere closes the popup (the button toggles it itself)
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && !rl.CheckCollisionPointRec(mousePos, bounds) &&
+		!rl.CheckCollisionPointRec(mousePos, snapButtonRect) {
+		e.showSnapSettings = false
+	}
+}
@@ -32,6 +32,8 @@ func (e *Editor) GetRaylibCamera() rl.Camera3D {
 	return rl.Camera3D{}
 }
 func (e *Editor) Draw3D()                   {}
+func (e *Editor) ToggleVelocityArrows()     {}
+func (e *Editor) DrawVelocityArrows()       {}
 func (e *Editor) DrawUI()                   {}
 func (e *Editor) SavePrefs()                {}
 func (e *Editor) ApplyPrefs(_ *EditorPrefs) {}
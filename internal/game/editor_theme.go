@@ -0,0 +1,196 @@
+//go:build !game
+
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// editorThemeFile holds color overrides for the editor. Missing colors keep
+// their defaults, and the file is re-read while the editor runs.
+const editorThemeFile = "editor_theme.json"
+
+// themeColor is an rl.Color stored as "#rrggbb" or "#rrggbbaa" in the theme file
+type themeColor rl.Color
+
+func (c themeColor) MarshalJSON() ([]byte, error) {
+	if c.A == 255 {
+		return json.Marshal(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+	}
+	return json.Marshal(fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A))
+}
+
+func (c *themeColor) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	var r, g, b, a uint8
+	switch len(s) {
+	case 7:
+		a = 255
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+			return fmt.Errorf("color %q: %w", s, err)
+		}
+	case 9:
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x%02x", &r, &g, &b, &a); err != nil {
+			return fmt.Errorf("color %q: %w", s, err)
+		}
+	default:
+		return fmt.Errorf("color %q: want #rrggbb or #rrggbbaa", s)
+	}
+	*c = themeColor{R: r, G: g, B: b, A: a}
+	return nil
+}
+
+// Theme is the editor color palette. applyTheme copies it into the color*
+// package vars that the drawing code uses.
+type Theme struct {
+	BgDark    themeColor `json:"bgDark"`
+	BgPanel   themeColor `json:"bgPanel"`
+	BgElement themeColor `json:"bgElement"`
+	BgHover   themeColor `json:"bgHover"`
+	BgActive  themeColor `json:"bgActive"`
+
+	Accent       themeColor `json:"accent"`
+	AccentLight  themeColor `json:"accentLight"`
+	AccentHover  themeColor `json:"accentHover"`
+	AccentActive themeColor `json:"accentActive"`
+
+	TextPrimary   themeColor `json:"textPrimary"`
+	TextSecondary themeColor `json:"textSecondary"`
+	TextMuted     themeColor `json:"textMuted"`
+
+	Border       themeColor `json:"border"`
+	BorderHover  themeColor `json:"borderHover"`
+	BorderWidget themeColor `json:"borderWidget"` // raygui control outlines
+	Line         themeColor `json:"line"`         // raygui separators
+	Selection    themeColor `json:"selection"`
+}
+
+// DefaultTheme returns the built-in indigo dark theme
+func DefaultTheme() Theme {
+	return Theme{
+		BgDark:    themeColor(rl.NewColor(10, 10, 15, 255)),
+		BgPanel:   themeColor(rl.NewColor(18, 18, 24, 245)),
+		BgElement: themeColor(rl.NewColor(28, 28, 38, 255)),
+		BgHover:   themeColor(rl.NewColor(38, 38, 52, 255)),
+		BgActive:  themeColor(rl.NewColor(48, 48, 65, 255)),
+
+		Accent:       themeColor(rl.NewColor(108, 99, 255, 255)),
+		AccentLight:  themeColor(rl.NewColor(167, 139, 250, 255)),
+		AccentHover:  themeColor(rl.NewColor(130, 120, 255, 255)),
+		AccentActive: themeColor(rl.NewColor(90, 80, 220, 255)),
+
+		TextPrimary:   themeColor(rl.NewColor(255, 255, 255, 255)),
+		TextSecondary: themeColor(rl.NewColor(200, 200, 208, 255)),
+		TextMuted:     themeColor(rl.NewColor(119, 119, 119, 255)),
+
+		Border:       themeColor(rl.NewColor(255, 255, 255, 13)),
+		BorderHover:  themeColor(rl.NewColor(108, 99, 255, 100)),
+		BorderWidget: themeColor(rl.NewColor(50, 50, 65, 255)),
+		Line:         themeColor(rl.NewColor(40, 40, 55, 255)),
+		Selection:    themeColor(rl.NewColor(108, 99, 255, 60)),
+	}
+}
+
+// currentTheme is the theme last applied
+var currentTheme = DefaultTheme()
+
+// themeModTime is the theme file's mod time when it was last loaded (0 = no file)
+var themeModTime int64
+
+// loadTheme reads editorThemeFile over the defaults. A missing or broken file
+// gives the default theme.
+func loadTheme() Theme {
+	theme := DefaultTheme()
+	themeModTime = 0
+	info, err := os.Stat(editorThemeFile)
+	if err != nil {
+		return theme
+	}
+	themeModTime = info.ModTime().UnixNano()
+
+	data, err := os.ReadFile(editorThemeFile)
+	if err != nil {
+		return theme
+	}
+	if err := json.Unmarshal(data, &theme); err != nil {
+		fmt.Printf("Failed to parse %s: %v\n", editorThemeFile, err)
+		return DefaultTheme()
+	}
+	return theme
+}
+
+// applyTheme makes t the editor's palette, for both our own drawing and raygui
+func applyTheme(t Theme) {
+	setThemeColors(t)
+	applyRayguiColors()
+}
+
+// setThemeColors copies t into the color* vars
+func setThemeColors(t Theme) {
+	currentTheme = t
+
+	colorBgDark = rl.Color(t.BgDark)
+	colorBgPanel = rl.Color(t.BgPanel)
+	colorBgElement = rl.Color(t.BgElement)
+	colorBgHover = rl.Color(t.BgHover)
+	colorBgActive = rl.Color(t.BgActive)
+
+	colorAccent = rl.Color(t.Accent)
+	colorAccentLight = rl.Color(t.AccentLight)
+	colorAccentHover = rl.Color(t.AccentHover)
+	colorAccentActive = rl.Color(t.AccentActive)
+
+	colorTextPrimary = rl.Color(t.TextPrimary)
+	colorTextSecondary = rl.Color(t.TextSecondary)
+	colorTextMuted = rl.Color(t.TextMuted)
+
+	colorBorder = rl.Color(t.Border)
+	colorBorderHover = rl.Color(t.BorderHover)
+	colorBorderWidget = rl.Color(t.BorderWidget)
+	colorLine = rl.Color(t.Line)
+	colorSelection = rl.Color(t.Selection)
+}
+
+// saveTheme writes the current theme to editorThemeFile, giving a complete
+// file to start editing from
+func (e *Editor) saveTheme() {
+	data, err := json.MarshalIndent(currentTheme, "", "  ")
+	if err != nil {
+		e.setMsg("Failed to save theme: %v", err)
+		return
+	}
+	if err := os.WriteFile(editorThemeFile, data, 0644); err != nil {
+		e.setMsg("Failed to save theme: %v", err)
+		return
+	}
+	if info, err := os.Stat(editorThemeFile); err == nil {
+		themeModTime = info.ModTime().UnixNano()
+	}
+	e.setMsg("Saved %s", editorThemeFile)
+}
+
+// checkThemeChanges reloads the theme when editorThemeFile changes on disk
+func (e *Editor) checkThemeChanges() {
+	// Only check every 0.5 seconds
+	if rl.GetTime()-e.lastThemeCheck < 0.5 {
+		return
+	}
+	e.lastThemeCheck = rl.GetTime()
+
+	var modTime int64
+	if info, err := os.Stat(editorThemeFile); err == nil {
+		modTime = info.ModTime().UnixNano()
+	}
+	if modTime == themeModTime {
+		return
+	}
+	applyTheme(loadTheme())
+	e.setMsg("Reloaded %s", editorThemeFile)
+}
@@ -0,0 +1,227 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	timelineH             = 90
+	timelineDefaultLength = 5    // seconds shown when the animation is shorter
+	timelineSnap          = 0.05 // keys and the playhead land on multiples of this
+	timelineKeyHitRadius  = 6
+)
+
+// toggleTimeline shows or hides the keyframe timeline (K)
+func (e *Editor) toggleTimeline() {
+	e.showTimeline = !e.showTimeline
+	e.timelinePlaying = false
+}
+
+// timelineRect returns the bounds of the timeline, along the bottom of the
+// viewport above the asset browser and left of the material editor
+func (e *Editor) timelineRect() rl.Rectangle {
+	screenW := float32(rl.GetScreenWidth())
+	bottom := float32(rl.GetScreenHeight())
+	if e.showAssetBrowser {
+		bottom -= 150
+	}
+	w := screenW - float32(e.hierarchyWidth) - float32(e.inspectorWidth)
+	if e.showAssetBrowser && e.selectedMaterial != nil {
+		w -= materialEditorW
+	}
+	return rl.Rectangle{X: float32(e.hierarchyWidth), Y: bottom - timelineH, Width: w, Height: timelineH}
+}
+
+// selectedAnimation returns the selected object's TransformAnimation, or nil
+func (e *Editor) selectedAnimation() *components.TransformAnimation {
+	if e.Selected == nil {
+		return nil
+	}
+	return engine.GetComponent[*components.TransformAnimation](e.Selected)
+}
+
+// poseAtPlayhead moves the selected object to where its animation has it at
+// the playhead, so the next key captured starts from that pose
+func (e *Editor) poseAtPlayhead(anim *components.TransformAnimation) {
+	k, ok := anim.Sample(e.timelineTime)
+	if !ok {
+		return
+	}
+	e.Selected.Transform.Position = k.Position
+	e.Selected.Transform.Rotation = k.Rotation
+	e.Selected.Transform.Scale = k.Scale
+	e.Selected.Transform.MarkRotationDirty()
+}
+
+// updateTimeline advances the playhead while the timeline is previewing,
+// looping at the last key whatever the animation's mode
+func (e *Editor) updateTimeline(deltaTime float32) {
+	if !e.showTimeline || !e.timelinePlaying {
+		return
+	}
+	anim := e.selectedAnimation()
+	if anim == nil || anim.Duration() <= 0 {
+		e.timelinePlaying = false
+		return
+	}
+	e.timelineTime = float32(math.Mod(float64(e.timelineTime+deltaTime), float64(anim.Duration())))
+	e.poseAtPlayhead(anim)
+}
+
+// drawTimeline draws the keyframe timeline for the selected object. Click or
+// drag on the track to scrub, drag a key to retime it, and + Key to capture
+// the object's current transform at the playhead.
+func (e *Editor) drawTimeline() {
+	if !e.showTimeline {
+		return
+	}
+	if e.timelineObject != e.Selected {
+		e.timelineObject = e.Selected
+		e.timelineKey = -1
+		e.timelineDragKey = -1
+		e.timelinePlaying = false
+	}
+	if e.timelineLength <= 0 {
+		e.timelineLength = timelineDefaultLength
+	}
+
+	bounds := e.timelineRect()
+	x, y, w := int32(bounds.X), int32(bounds.Y), int32(bounds.Width)
+	rl.DrawRectangleRec(bounds, colorBgPanel)
+	rl.DrawRectangle(x, y, w, 1, colorBorder)
+	drawTextEx(editorFontBold, "Timeline", x+10, y+8, 14, colorTextSecondary)
+
+	if e.Selected == nil {
+		drawTextEx(editorFont, "Select an object to animate it", x+90, y+8, 14, colorTextMuted)
+		return
+	}
+	anim := e.selectedAnimation()
+
+	// Header: object and key info on the left, controls on the right
+	info := e.Selected.Name
+	if anim == nil {
+		info += " - no TransformAnimation, + Key adds one"
+	} else if e.timelineKey >= 0 && e.timelineKey < len(anim.Keyframes) {
+		info += fmt.Sprintf(" - key %d at %.2fs", e.timelineKey+1, anim.Keyframes[e.timelineKey].Time)
+	} else {
+		info += fmt.Sprintf(" - %d keys, %.2fs", len(anim.Keyframes), anim.Duration())
+	}
+	drawTextEx(editorFont, info, x+90, y+8, 14, colorTextMuted)
+
+	btnY, btnH := y+5, int32(20)
+	lengthX := x + w - 60
+	drawTextEx(editorFont, "Length", lengthX-48, btnY+3, 14, colorTextMuted)
+	e.timelineLength = max(e.drawFloatField(lengthX, btnY, 50, btnH, "timeline.length", e.timelineLength), 0.5)
+
+	playX := lengthX - 48 - 8 - 50
+	playLabel := "Play"
+	if e.timelinePlaying {
+		playLabel = "Stop"
+	}
+	if drawDialogButton(playX, btnY, 50, btnH, playLabel, false) && anim != nil {
+		e.timelinePlaying = !e.timelinePlaying
+	}
+	deleteX := playX - 6 - 60
+	if drawDialogButton(deleteX, btnY, 60, btnH, "Delete", false) && anim != nil &&
+		e.timelineKey >= 0 && e.timelineKey < len(anim.Keyframes) {
+		anim.Keyframes = slices.Delete(anim.Keyframes, e.timelineKey, e.timelineKey+1)
+		e.timelineKey = -1
+	}
+	keyX := deleteX - 6 - 60
+	if drawDialogButton(keyX, btnY, 60, btnH, "+ Key", true) {
+		if anim == nil {
+			anim = components.NewTransformAnimation()
+			e.Selected.AddComponent(anim)
+			e.setMsg("Added TransformAnimation")
+		}
+		e.timelineKey = anim.SetKey(e.timelineTime, &e.Selected.Transform)
+	}
+
+	// Track: ruler, keys and playhead
+	length := e.timelineLength
+	if anim != nil {
+		length = max(length, anim.Duration())
+	}
+	trackX0 := x + 16
+	trackX1 := x + w - 16
+	trackY := y + 36
+	trackH := int32(timelineH - 44)
+	timeToX := func(t float32) int32 {
+		return trackX0 + int32(t/length*float32(trackX1-trackX0))
+	}
+	xToTime := func(px float32) float32 {
+		t := (px - float32(trackX0)) / float32(trackX1-trackX0) * length
+		t = float32(math.Round(float64(t/timelineSnap))) * timelineSnap
+		return min(max(t, 0), length)
+	}
+
+	rl.DrawRectangle(trackX0, trackY, trackX1-trackX0, trackH, colorBgElement)
+	step := float32(1)
+	if length > 20 {
+		step = 5
+	} else if length <= 2 {
+		step = 0.25
+	}
+	for t := float32(0); t <= length+0.001; t += step {
+		tx := timeToX(t)
+		rl.DrawRectangle(tx, trackY, 1, 6, colorTextMuted)
+		drawTextEx(editorFontMono, fmt.Sprintf("%g", t), tx+2, trackY+4, 11, colorTextMuted)
+	}
+
+	keyY := trackY + trackH/2 + 6
+	if anim != nil {
+		for i, k := range anim.Keyframes {
+			color := colorTextSecondary
+			if i == e.timelineKey {
+				color = colorAccentLight
+			}
+			rl.DrawPoly(rl.Vector2{X: float32(timeToX(k.Time)), Y: float32(keyY)}, 4, 7, 0, color)
+		}
+	}
+	playheadX := timeToX(min(e.timelineTime, length))
+	rl.DrawRectangle(playheadX, trackY, 2, trackH, rl.NewColor(230, 80, 80, 255))
+
+	// Mouse: pick a key or scrub on press, then follow the drag
+	mousePos := rl.GetMousePosition()
+	track := rl.Rectangle{X: float32(trackX0 - timelineKeyHitRadius), Y: float32(trackY), Width: float32(trackX1 - trackX0 + 2*timelineKeyHitRadius), Height: float32(trackH)}
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mousePos, track) {
+		e.timelineDragKey = -1
+		e.timelineScrubbing = true
+		if anim != nil {
+			for i, k := range anim.Keyframes {
+				if absF(mousePos.X-float32(timeToX(k.Time))) <= timelineKeyHitRadius {
+					e.timelineDragKey = i
+					e.timelineScrubbing = false
+				}
+			}
+		}
+		e.timelineKey = e.timelineDragKey
+		e.timelinePlaying = false
+	}
+	if rl.IsMouseButtonDown(rl.MouseLeftButton) {
+		t := xToTime(mousePos.X)
+		if anim != nil && e.timelineDragKey >= 0 && e.timelineDragKey < len(anim.Keyframes) {
+			e.timelineDragKey = anim.MoveKey(e.timelineDragKey, t)
+			e.timelineKey = e.timelineDragKey
+			e.timelineTime = t
+			e.poseAtPlayhead(anim)
+		} else if e.timelineScrubbing {
+			e.timelineTime = t
+			if anim != nil {
+				e.poseAtPlayhead(anim)
+			}
+		}
+	} else {
+		e.timelineDragKey = -1
+		e.timelineScrubbing = false
+	}
+}
@@ -15,35 +15,42 @@ var editorFontBold rl.Font // Outfit Bold - headers
 var editorFontMono rl.Font // JetBrains Mono - numeric values
 var editorFontsLoaded bool
 
-// Theme colors - Indigo/purple dark theme matching the website
+// Theme colors, set from the loaded Theme (see editor_theme.go). The
+// defaults are the indigo/purple dark theme matching the website.
 var (
 	// Base backgrounds (dark with slight blue tint)
-	colorBgDark    = rl.NewColor(10, 10, 15, 255) // Darkest - nav bg
-	colorBgPanel   = rl.NewColor(18, 18, 24, 245) // Panel backgrounds
-	colorBgElement = rl.NewColor(28, 28, 38, 255) // Input fields, buttons
-	colorBgHover   = rl.NewColor(38, 38, 52, 255) // Hover state
-	colorBgActive  = rl.NewColor(48, 48, 65, 255) // Active/pressed state
+	colorBgDark    rl.Color // Darkest - nav bg
+	colorBgPanel   rl.Color // Panel backgrounds
+	colorBgElement rl.Color // Input fields, buttons
+	colorBgHover   rl.Color // Hover state
+	colorBgActive  rl.Color // Active/pressed state
 
 	// Accent colors - indigo/purple gradient
-	colorAccent       = rl.NewColor(108, 99, 255, 255)  // Primary indigo #6c63ff
-	colorAccentLight  = rl.NewColor(167, 139, 250, 255) // Light purple #a78bfa
-	colorAccentHover  = rl.NewColor(130, 120, 255, 255) // Hover indigo
-	colorAccentActive = rl.NewColor(90, 80, 220, 255)   // Pressed indigo
+	colorAccent       rl.Color // Primary indigo #6c63ff
+	colorAccentLight  rl.Color // Light purple #a78bfa
+	colorAccentHover  rl.Color // Hover indigo
+	colorAccentActive rl.Color // Pressed indigo
 
 	// Text colors
-	colorTextPrimary   = rl.NewColor(255, 255, 255, 255) // White
-	colorTextSecondary = rl.NewColor(200, 200, 208, 255) // Light gray #c8c8d0
-	colorTextMuted     = rl.NewColor(119, 119, 119, 255) // Muted #777
+	colorTextPrimary   rl.Color // White
+	colorTextSecondary rl.Color // Light gray #c8c8d0
+	colorTextMuted     rl.Color // Muted #777
 
 	// Borders
-	colorBorder      = rl.NewColor(255, 255, 255, 13) // rgba(255,255,255,0.05)
-	colorBorderHover = rl.NewColor(108, 99, 255, 100) // Indigo border on hover
+	colorBorder       rl.Color // rgba(255,255,255,0.05)
+	colorBorderHover  rl.Color // Indigo border on hover
+	colorBorderWidget rl.Color // raygui control outlines
+	colorLine         rl.Color // raygui separators
 
 	// Selection highlight (indigo tinted)
-	colorSelection = rl.NewColor(108, 99, 255, 60) // Indigo with transparency
+	colorSelection rl.Color // Indigo with transparency
 )
 
-// initRayguiStyle sets up the modern indigo dark theme
+func init() {
+	setThemeColors(DefaultTheme())
+}
+
+// initRayguiStyle loads the fonts and the editor theme
 func initRayguiStyle() {
 	// Load fonts at high resolution for smooth scaling
 	if !editorFontsLoaded {
@@ -78,6 +85,11 @@ func initRayguiStyle() {
 		}
 	}
 
+	applyTheme(loadTheme())
+}
+
+// applyRayguiColors pushes the theme colors into raygui's default style
+func applyRayguiColors() {
 	// Background colors - dark with blue tint
 	gui.SetStyle(gui.DEFAULT, gui.BACKGROUND_COLOR, gui.NewColorPropertyValue(colorBgDark))
 	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_NORMAL, gui.NewColorPropertyValue(colorBgElement))
@@ -90,11 +102,11 @@ func initRayguiStyle() {
 	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_PRESSED, gui.NewColorPropertyValue(colorTextPrimary))
 
 	// Border colors - subtle with indigo on focus
-	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_NORMAL, gui.NewColorPropertyValue(rl.NewColor(50, 50, 65, 255)))
+	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_NORMAL, gui.NewColorPropertyValue(colorBorderWidget))
 	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_FOCUSED, gui.NewColorPropertyValue(colorAccent))
 
 	// Line color (for separators)
-	gui.SetStyle(gui.DEFAULT, gui.LINE_COLOR, gui.NewColorPropertyValue(rl.NewColor(40, 40, 55, 255)))
+	gui.SetStyle(gui.DEFAULT, gui.LINE_COLOR, gui.NewColorPropertyValue(colorLine))
 
 	// Text size
 	gui.SetStyle(gui.DEFAULT, gui.TEXT_SIZE, 15)
@@ -109,6 +121,14 @@ func drawTextEx(font rl.Font, text string, x, y int32, size float32, color rl.Co
 	}
 }
 
+// measureTextEx returns the width of text drawn with drawTextEx
+func measureTextEx(font rl.Font, text string, size float32) int32 {
+	if font.Texture.ID > 0 {
+		return int32(rl.MeasureTextEx(font, text, size, 0).X)
+	}
+	return rl.MeasureText(text, int32(size))
+}
+
 // colorName returns a human-readable name for common colors.
 func colorName(c rl.Color) string {
 	switch c {
@@ -0,0 +1,123 @@
+//go:build !game
+
+package game
+
+import (
+	"fmt"
+
+	"test3d/internal/components"
+	"test3d/internal/engine"
+	"test3d/internal/physics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// openValidation checks the scene for level-design problems and shows the
+// result (Ctrl+Shift+V)
+func (e *Editor) openValidation() {
+	e.runValidation()
+	e.validationScroll = 0
+	e.showValidation = true
+}
+
+// runValidation rebuilds the static mesh colliders at their current transform,
+// since they don't follow objects moved in the editor, and looks for static
+// colliders that intersect
+func (e *Editor) runValidation() {
+	statics := e.world.PhysicsWorld.Statics
+	for _, g := range statics {
+		if mc := engine.GetComponent[*components.MeshCollider](g); mc != nil {
+			mc.Rebuild()
+		}
+	}
+	e.validationOverlaps = physics.FindStaticOverlaps(statics)
+}
+
+// drawValidation draws the validation results as a centered panel with Recheck
+// and Close buttons. Clicking an overlap selects and focuses its first object.
+func (e *Editor) drawValidation() {
+	if !e.showValidation {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		e.showValidation = false
+		return
+	}
+
+	const (
+		panelW = int32(560)
+		lineH  = int32(20)
+		pad    = int32(16)
+		btnW   = int32(80)
+		btnH   = int32(24)
+	)
+	screenW := int32(rl.GetScreenWidth())
+	screenH := int32(rl.GetScreenHeight())
+	panelH := min(screenH-120, int32(480))
+	panelX := (screenW - panelW) / 2
+	panelY := (screenH - panelH) / 2
+
+	rl.DrawRectangle(0, 0, screenW, screenH, rl.NewColor(0, 0, 0, 140))
+	bounds := rl.Rectangle{X: float32(panelX), Y: float32(panelY), Width: float32(panelW), Height: float32(panelH)}
+	rl.DrawRectangleRounded(bounds, 0.03, 8, colorBgPanel)
+	rl.DrawRectangleRoundedLines(bounds, 0.03, 8, colorAccent)
+
+	title := fmt.Sprintf("Scene Validation (%d)", len(e.validationOverlaps))
+	drawTextEx(editorFontBold, title, panelX+pad, panelY+pad-4, 20, colorTextPrimary)
+	drawTextEx(editorFont, "Static colliders that intersect each other", panelX+pad, panelY+pad+20, 14, colorTextMuted)
+
+	// Overlap list, clipped to the area above the buttons
+	listX := panelX + pad
+	listY := panelY + pad + 44
+	listH := panelH - (listY - panelY) - btnH - 2*pad
+	mousePos := rl.GetMousePosition()
+	listRect := rl.Rectangle{X: float32(listX), Y: float32(listY), Width: float32(panelW - 2*pad), Height: float32(listH)}
+
+	if rl.CheckCollisionPointRec(mousePos, listRect) {
+		e.validationScroll -= int32(rl.GetMouseWheelMove() * 20)
+	}
+	maxScroll := max(int32(len(e.validationOverlaps))*lineH-listH, 0)
+	e.validationScroll = max(min(e.validationScroll, maxScroll), 0)
+
+	if len(e.validationOverlaps) == 0 {
+		drawTextEx(editorFont, "No problems found", listX, listY, 15, colorTextSecondary)
+	}
+
+	warnColor := rl.NewColor(240, 200, 90, 255)
+	rl.BeginScissorMode(listX, listY, panelW-2*pad, listH)
+	for i, o := range e.validationOverlaps {
+		y := listY + int32(i)*lineH - e.validationScroll
+		if y+lineH < listY || y > listY+listH {
+			continue
+		}
+		row := rl.Rectangle{X: float32(listX), Y: float32(y), Width: float32(panelW - 2*pad), Height: float32(lineH)}
+		if rl.CheckCollisionPointRec(mousePos, row) && rl.CheckCollisionPointRec(mousePos, listRect) {
+			rl.DrawRectangleRec(row, colorBgHover)
+			if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				e.Selected = o.A
+				e.focusOnObject(o.A)
+				e.showValidation = false
+			}
+		}
+
+		drawTextEx(editorFontMono, "!", listX+4, y+2, 15, warnColor)
+		x := listX + 22
+		drawTextEx(editorFontBold, o.A.Name, x, y+2, 15, colorTextPrimary)
+		x += measureTextEx(editorFontBold, o.A.Name, 15) + 8
+		drawTextEx(editorFont, "intersects", x, y+2, 15, colorTextSecondary)
+		x += measureTextEx(editorFont, "intersects", 15) + 8
+		drawTextEx(editorFontBold, o.B.Name, x, y+2, 15, colorTextPrimary)
+	}
+	rl.EndScissorMode()
+
+	// Buttons
+	btnY := panelY + panelH - pad - btnH
+	closeX := panelX + panelW - pad - btnW
+	recheckX := closeX - btnW - 8
+	if drawDialogButton(recheckX, btnY, btnW, btnH, "Recheck", true) {
+		e.runValidation()
+	}
+	if drawDialogButton(closeX, btnY, btnW, btnH, "Close", false) {
+		e.showValidation = false
+	}
+}
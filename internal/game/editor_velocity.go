@@ -0,0 +1,57 @@
+//go:build !game
+
+package game
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	velocityArrowScale        = 0.25 // arrow length per unit/s: where the body will be in a quarter second
+	angularVelocityArrowScale = 0.01 // arrow length per degree/s: 360°/s draws 3.6 units
+	minArrowLength            = 0.05 // shorter arrows (bodies at rest) aren't drawn
+)
+
+var (
+	velocityArrowColor        = rl.Yellow
+	angularVelocityArrowColor = rl.Magenta
+)
+
+// ToggleVelocityArrows turns the velocity arrows on or off (F5)
+func (e *Editor) ToggleVelocityArrows() {
+	e.showVelocityArrows = !e.showVelocityArrows
+}
+
+// DrawVelocityArrows draws an arrow along each dynamic body's velocity and
+// another along its spin axis, sized by angular speed. Call inside
+// BeginMode3D/EndMode3D.
+func (e *Editor) DrawVelocityArrows() {
+	if !e.showVelocityArrows {
+		return
+	}
+	for _, g := range e.world.PhysicsWorld.Objects {
+		rb := engine.GetComponent[*components.Rigidbody](g)
+		if !g.Active || rb == nil {
+			continue
+		}
+		center := g.WorldPosition()
+		drawArrow(center, rl.Vector3Scale(rb.Velocity, velocityArrowScale), velocityArrowColor)
+		drawArrow(center, rl.Vector3Scale(rb.AngularVelocity, angularVelocityArrowScale), angularVelocityArrowColor)
+	}
+}
+
+// drawArrow draws a line from start along v with a cone at the tip
+func drawArrow(start, v rl.Vector3, color rl.Color) {
+	length := rl.Vector3Length(v)
+	if length < minArrowLength {
+		return
+	}
+	tip := rl.Vector3Add(start, v)
+	headLen := min(length*0.3, 0.3)
+	headBase := rl.Vector3Subtract(tip, rl.Vector3Scale(v, headLen/length))
+	rl.DrawLine3D(start, headBase, color)
+	rl.DrawCylinderEx(headBase, tip, headLen*0.4, 0, 8, color)
+}
@@ -0,0 +1,156 @@
+//go:build !game
+
+package game
+
+import (
+	"unsafe"
+
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// vertexSnapMaxDots caps how many of the target's vertices are drawn as dots
+const vertexSnapMaxDots = 4000
+
+// vertexSnap is the state of a vertex-snapping move drag (hold V while moving).
+// The dragged object's vertex nearest the cursor is picked when snapping starts;
+// from then on that vertex is pinned to the nearest vertex of the object under
+// the cursor.
+type vertexSnap struct {
+	active    bool
+	offset    rl.Vector3 // source vertex relative to the selected object's world position
+	source    rl.Vector3 // source vertex in world space (after the move)
+	target    *engine.GameObject
+	targetPos rl.Vector3   // chosen target vertex
+	targetAll []rl.Vector3 // target's vertices, drawn as candidates
+}
+
+// updateVertexSnap moves the selection so its snap vertex lands on a vertex
+// of the object under the cursor. Returns false when there is nothing to snap
+// (V released, no mesh, or nothing under the cursor) so the regular drag runs.
+func (e *Editor) updateVertexSnap(ray rl.Ray) bool {
+	if e.gizmoMode != GizmoMove || !rl.IsKeyDown(rl.KeyV) {
+		e.vertexSnap = vertexSnap{}
+		return false
+	}
+
+	// Pick the source vertex once, when snapping starts
+	if !e.vertexSnap.active {
+		verts := modelWorldVertices(e.Selected)
+		if len(verts) == 0 {
+			return false
+		}
+		cam := e.GetRaylibCamera()
+		mouse := rl.GetMousePosition()
+		best := verts[0]
+		bestDist := float32(-1)
+		for _, v := range verts {
+			d := rl.Vector2Distance(rl.GetWorldToScreen(v, cam), mouse)
+			if bestDist < 0 || d < bestDist {
+				best, bestDist = v, d
+			}
+		}
+		e.vertexSnap.active = true
+		e.vertexSnap.offset = rl.Vector3Subtract(best, e.Selected.WorldPosition())
+	}
+
+	// Find the object under the cursor, ignoring the one being dragged
+	var candidates []*engine.GameObject
+	for _, g := range e.world.Scene.GameObjects {
+		if g != e.Selected && !g.IsDescendantOf(e.Selected) {
+			candidates = append(candidates, g)
+		}
+	}
+	hit, ok := e.world.PhysicsWorld.EditorRaycast(ray.Position, ray.Direction, 1000, candidates)
+	if !ok {
+		e.vertexSnap.target = nil
+		e.vertexSnap.targetAll = nil
+		return false
+	}
+	if hit.GameObject != e.vertexSnap.target {
+		e.vertexSnap.target = hit.GameObject
+		e.vertexSnap.targetAll = modelWorldVertices(hit.GameObject)
+	}
+	if len(e.vertexSnap.targetAll) == 0 {
+		return false
+	}
+
+	e.vertexSnap.targetPos = nearestVertex(e.vertexSnap.targetAll, hit.Point)
+	newWorldPos := rl.Vector3Subtract(e.vertexSnap.targetPos, e.vertexSnap.offset)
+	e.applyWorldMove(rl.Vector3Subtract(newWorldPos, e.dragInitWorldPos))
+	e.vertexSnap.source = rl.Vector3Add(e.Selected.WorldPosition(), e.vertexSnap.offset)
+	return true
+}
+
+// drawVertexSnap draws the target's vertices as dots, with the chosen target
+// vertex and the dragged object's snap vertex highlighted
+func (e *Editor) drawVertexSnap() {
+	if !e.dragging || !e.vertexSnap.active {
+		return
+	}
+	rl.DrawRenderBatchActive()
+	rl.DisableDepthTest()
+
+	dotSize := func(p rl.Vector3, scale float32) rl.Vector3 {
+		s := rl.Vector3Distance(p, e.camera.Position) * 0.006 * scale
+		return rl.Vector3{X: s, Y: s, Z: s}
+	}
+
+	verts := e.vertexSnap.targetAll
+	if len(verts) > vertexSnapMaxDots {
+		verts = verts[:vertexSnapMaxDots]
+	}
+	for _, v := range verts {
+		rl.DrawCubeV(v, dotSize(v, 1), rl.NewColor(120, 200, 255, 200))
+	}
+	if e.vertexSnap.target != nil {
+		rl.DrawCubeV(e.vertexSnap.targetPos, dotSize(e.vertexSnap.targetPos, 2.5), rl.Yellow)
+		rl.DrawCubeV(e.vertexSnap.source, dotSize(e.vertexSnap.source, 2), rl.Orange)
+	}
+
+	rl.DrawRenderBatchActive()
+	rl.EnableDepthTest()
+}
+
+// modelWorldVertices returns the object's mesh vertices in world space, using
+// the same transform ModelRenderer draws with. Nil if it has no loaded model.
+func modelWorldVertices(g *engine.GameObject) []rl.Vector3 {
+	mr := engine.GetComponent[*components.ModelRenderer](g)
+	if mr == nil || mr.Model.MeshCount == 0 || mr.Model.Meshes == nil {
+		return nil
+	}
+
+	scale := g.WorldScale()
+	pos := g.WorldPosition()
+	scaleMatrix := rl.MatrixScale(scale.X, scale.Y, scale.Z)
+	rotMatrix := g.WorldRotationMatrix()
+	transMatrix := rl.MatrixTranslate(pos.X, pos.Y, pos.Z)
+	transform := rl.MatrixMultiply(rl.MatrixMultiply(scaleMatrix, rotMatrix), transMatrix)
+
+	var out []rl.Vector3
+	for _, mesh := range unsafe.Slice(mr.Model.Meshes, mr.Model.MeshCount) {
+		if mesh.Vertices == nil {
+			continue
+		}
+		vertices := unsafe.Slice(mesh.Vertices, mesh.VertexCount*3)
+		for i := int32(0); i < mesh.VertexCount; i++ {
+			v := rl.Vector3{X: vertices[i*3], Y: vertices[i*3+1], Z: vertices[i*3+2]}
+			out = append(out, rl.Vector3Transform(v, transform))
+		}
+	}
+	return out
+}
+
+// nearestVertex returns the vertex closest to p (verts must not be empty)
+func nearestVertex(verts []rl.Vector3, p rl.Vector3) rl.Vector3 {
+	best := verts[0]
+	bestDist := rl.Vector3DistanceSqr(best, p)
+	for _, v := range verts[1:] {
+		if d := rl.Vector3DistanceSqr(v, p); d < bestDist {
+			best, bestDist = v, d
+		}
+	}
+	return best
+}
@@ -2,20 +2,32 @@ package game
 
 import (
 	"fmt"
+	"os"
 	"time"
 
+	"test3d/internal/assets"
+	"test3d/internal/audio"
 	"test3d/internal/components"
 	"test3d/internal/engine"
+	"test3d/internal/physics"
+	"test3d/internal/project"
 	"test3d/internal/world"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+// showCollidersEnv turns the collider overlay on at startup when set to "1"
+const showCollidersEnv = "MIRGO_SHOW_COLLIDERS"
+
 type Game struct {
 	World     *world.World
 	editor    *Editor
 	DebugMode bool
 
+	// ShowColliders draws collider wireframes in play mode (F4, or
+	// MIRGO_SHOW_COLLIDERS=1 at startup) so a built game can be debugged
+	ShowColliders bool
+
 	// Debug timing (ms)
 	updateMs float64
 	shadowMs float64
@@ -24,8 +36,9 @@ type Game struct {
 
 func New() *Game {
 	return &Game{
-		World:     world.New(),
-		DebugMode: false,
+		World:         world.New(),
+		DebugMode:     false,
+		ShowColliders: os.Getenv(showCollidersEnv) == "1",
 	}
 }
 
@@ -53,9 +66,23 @@ func (g *Game) Run(restoreEditor bool) {
 
 	rl.SetTargetFPS(120)
 
-	// Load the scene from prefs if available
+	initInput()
+
+	// Load project settings, then reopen the last edited scene from prefs if available
+	if p, err := project.Load(project.File); err != nil {
+		fmt.Printf("Failed to load %s: %v\n", project.File, err)
+	} else {
+		project.Current = p
+	}
+	assets.MaxTextureSize = project.Current.MaxTextureSize
+	g.World.PhysicsWorld.CollisionCooldown = float32(project.Current.CollisionCooldownMs) / 1000
+	vol := project.Current.Audio
+	audio.SetMasterVolume(vol.Master)
+	audio.SetChannelVolume(audio.ChannelSFX, vol.SFX)
+	audio.SetChannelVolume(audio.ChannelUI, vol.UI)
+	audio.SetChannelVolume(audio.ChannelMusic, vol.Music)
 	if prefs != nil && prefs.ScenePath != "" {
-		world.ScenePath = prefs.ScenePath
+		project.Current.CurrentScene = prefs.ScenePath
 	}
 
 	// Initialize world after OpenGL context is created
@@ -103,6 +130,7 @@ func (g *Game) Run(restoreEditor bool) {
 }
 
 func (g *Game) Update() {
+	defer engine.AdvanceInput()
 	updateStart := time.Now()
 	deltaTime := rl.GetFrameTime()
 
@@ -111,6 +139,27 @@ func (g *Game) Update() {
 		g.DebugMode = !g.DebugMode
 	}
 
+	// F3 cycles the physics broad-phase mode (auto -> cpu -> gpu -> compare)
+	if rl.IsKeyPressed(rl.KeyF3) {
+		pw := g.World.PhysicsWorld
+		pw.BroadPhaseMode = (pw.BroadPhaseMode + 1) % (physics.BroadPhaseCompare + 1)
+		if pw.GPUAvailable() || pw.BroadPhaseMode == physics.BroadPhaseAuto || pw.BroadPhaseMode == physics.BroadPhaseForceCPU {
+			fmt.Printf("Physics broad-phase mode: %s\n", pw.BroadPhaseMode)
+		} else {
+			fmt.Printf("Physics broad-phase mode: %s (GPU unavailable, running on CPU)\n", pw.BroadPhaseMode)
+		}
+	}
+
+	// F4 toggles the collider overlay (drawn only in play mode)
+	if rl.IsKeyPressed(rl.KeyF4) {
+		g.ShowColliders = !g.ShowColliders
+	}
+
+	// F5 toggles the editor's velocity arrows (a no-op in built games)
+	if rl.IsKeyPressed(rl.KeyF5) {
+		g.editor.ToggleVelocityArrows()
+	}
+
 	// Escape to toggle mouse capture (only in play mode)
 	if rl.IsKeyPressed(rl.KeyEscape) && !g.editor.Active {
 		if rl.IsCursorHidden() {
@@ -160,6 +209,9 @@ func (g *Game) Update() {
 	// (e.g., after exiting editor mode with modified properties)
 	g.World.Scene.Start()
 
+	// Advance the game clock; scripts get the scaled delta
+	deltaTime = engine.AdvanceTime(deltaTime)
+
 	// Update world (physics + all game objects including player)
 	g.World.Update(deltaTime)
 
@@ -213,11 +265,21 @@ func (g *Game) Draw() {
 		shadowStart := time.Now()
 		g.World.Renderer.DrawShadowMap(g.World.Scene.GameObjects)
 		g.shadowMs = float64(time.Since(shadowStart).Microseconds()) / 1000.0
+
+		// Minimap render-to-texture passes
+		g.World.Renderer.DrawMinimaps(g.World.Scene.GameObjects)
 	}
 
 	// Main render
+	background := rl.NewColor(20, 20, 30, 255)
+	g.World.Renderer.Fog = g.World.Settings.Fog
+	g.World.Renderer.Ambient = g.World.Settings.Ambient
+	if g.World.Settings.Fog.Enabled {
+		// Clear to the fog color so the horizon melts into it
+		background = g.World.Settings.Fog.RGBA()
+	}
 	rl.BeginDrawing()
-	rl.ClearBackground(rl.NewColor(20, 20, 30, 255))
+	rl.ClearBackground(background)
 
 	if uiEditMode {
 		// Draw 2D UI editor view instead of 3D scene
@@ -225,12 +287,19 @@ func (g *Game) Draw() {
 	} else {
 		// Normal 3D rendering
 		drawStart := time.Now()
+		bloom := g.World.Renderer.BeginBloom(g.World.Settings.Bloom, background)
 		rl.BeginMode3D(camera)
 		g.World.Renderer.DrawWithShadows(camera, g.World.Scene.GameObjects)
 		if g.editor.Active {
 			g.editor.Draw3D()
+		} else if g.ShowColliders {
+			world.DrawColliders(g.World.Scene.GameObjects)
 		}
+		g.editor.DrawVelocityArrows()
 		rl.EndMode3D()
+		if bloom {
+			g.World.Renderer.EndBloom(g.World.Settings.Bloom)
+		}
 		g.drawMs = float64(time.Since(drawStart).Microseconds()) / 1000.0
 	}
 
@@ -262,6 +331,10 @@ func (g *Game) DrawUI() {
 	rl.DrawLine(cx-size, cy, cx+size, cy, rl.White)
 	rl.DrawLine(cx, cy-size, cx, cy+size, rl.White)
 
+	if g.World.Solo != nil {
+		rl.DrawText(fmt.Sprintf("SOLO: %s", g.World.Solo.Name), 10, int32(rl.GetScreenHeight())-26, 16, rl.Orange)
+	}
+
 	if g.DebugMode {
 		previewSize := int32(256)
 		screenW := int32(rl.GetScreenWidth())
@@ -290,5 +363,25 @@ func (g *Game) DrawUI() {
 		culled := g.World.Renderer.CulledObjects
 		total := drawn + culled
 		rl.DrawText(fmt.Sprintf("Drawn: %d / %d (culled: %d)", drawn, total, culled), 10, 195, 16, rl.SkyBlue)
+
+		// Physics phase breakdown
+		stats := g.World.PhysicsWorld.Stats()
+		mode := "CPU"
+		if stats.GPU {
+			mode = "GPU"
+		}
+		if stats.GPU && stats.Mode == physics.BroadPhaseCompare {
+			mode = fmt.Sprintf("CPU+GPU, %d mismatched", stats.BroadPhaseMismatches)
+		}
+		if !g.World.PhysicsWorld.GPUAvailable() {
+			mode = "CPU, no GPU"
+		}
+		rl.DrawText(fmt.Sprintf("Physics: %.2f ms x%d steps (%s, %d pairs, %d contacts)  [F3] %s", float64(stats.Total.Microseconds())/1000.0, stats.Steps, mode, stats.BroadPhasePairs, stats.Contacts, stats.Mode), 10, 220, 16, rl.Orange)
+		y := int32(240)
+		for _, phase := range stats.Phases() {
+			rl.DrawText(fmt.Sprintf("  %-14s %.2f ms", phase.Name, float64(phase.Duration.Microseconds())/1000.0), 10, y, 14, rl.Orange)
+			y += 16
+		}
+		rl.DrawText(fmt.Sprintf("  %d islands, %d / %d asleep", stats.Islands, stats.Sleeping, g.World.PhysicsWorld.DynamicObjectCount()), 10, y, 14, rl.Orange)
 	}
 }
@@ -0,0 +1,7 @@
+//go:build !game
+
+package game
+
+// initInput picks the input backend for the editor. Play mode always reads
+// the real devices; replays are only for built games.
+func initInput() {}
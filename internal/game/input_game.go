@@ -0,0 +1,29 @@
+//go:build game
+
+package game
+
+import (
+	"fmt"
+	"os"
+
+	"test3d/internal/engine"
+)
+
+// replayEnv names a recorded-input file to play instead of reading the devices,
+// so a built game can be driven headlessly with deterministic input
+const replayEnv = "MIRGO_INPUT_REPLAY"
+
+// initInput picks the input backend for the built game
+func initInput() {
+	path := os.Getenv(replayEnv)
+	if path == "" {
+		return
+	}
+	replay, err := engine.LoadRecordedInput(path)
+	if err != nil {
+		fmt.Printf("Failed to load input replay %s: %v\n", path, err)
+		return
+	}
+	engine.SetInputBackend(replay)
+	fmt.Printf("Playing input replay %s (%d frames)\n", path, len(replay.Frames))
+}
@@ -3,110 +3,552 @@
 package game
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"unsafe"
+
 	"test3d/internal/components"
 	"test3d/internal/engine"
+	"test3d/internal/world"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-const maxUndoStack = 50
+// defaultMaxUndo is the undo depth unless the editor prefs set maxUndo
+const defaultMaxUndo = 50
 
-// UndoActionType represents the type of action that can be undone
-type UndoActionType int
+// undoCommand is one step of editor history. Undo reverts it and Redo applies
+// it again; the two are called alternately any number of times.
+type undoCommand interface {
+	Undo(e *Editor)
+	Redo(e *Editor)
+	// Bytes approximates the memory the command keeps alive
+	Bytes() int
+}
 
-const (
-	UndoTransform UndoActionType = iota
-	UndoDelete
-)
+// --- Commands ---
+
+// transformState is an object's local transform at some point
+type transformState struct {
+	obj      *engine.GameObject
+	position rl.Vector3
+	rotation rl.Vector3
+	scale    rl.Vector3
+}
+
+func captureTransform(obj *engine.GameObject) transformState {
+	return transformState{
+		obj:      obj,
+		position: obj.Transform.Position,
+		rotation: obj.Transform.Rotation,
+		scale:    obj.Transform.Scale,
+	}
+}
+
+func (s transformState) apply() {
+	s.obj.Transform.Position = s.position
+	s.obj.Transform.Rotation = s.rotation
+	s.obj.Transform.Scale = s.scale
+}
+
+// transformCommand is a gizmo drag of one or more objects. The primary
+// selection comes first, and is selected again by undo and redo.
+type transformCommand struct {
+	before []transformState
+	after  []transformState
+}
+
+func (c *transformCommand) Undo(e *Editor) {
+	for _, s := range c.before {
+		s.apply()
+	}
+	e.Selected = c.before[0].obj
+}
+
+func (c *transformCommand) Redo(e *Editor) {
+	for _, s := range c.after {
+		s.apply()
+	}
+	e.Selected = c.after[0].obj
+}
+
+func (c *transformCommand) Bytes() int {
+	return int(unsafe.Sizeof(*c)) + (len(c.before)+len(c.after))*int(unsafe.Sizeof(transformState{}))
+}
+
+// placement is where an object sits in the hierarchy: its parent, sibling and
+// scene list positions, and local transform
+type placement struct {
+	parent     *engine.GameObject
+	childIndex int
+	sceneIndex int
+	transform  transformState
+}
+
+func (e *Editor) capturePlacement(obj *engine.GameObject) placement {
+	p := placement{
+		parent:     obj.Parent,
+		childIndex: -1,
+		sceneIndex: e.indexOutsideBlock(obj, e.world.Scene.IndexOf(obj)),
+		transform:  captureTransform(obj),
+	}
+	if obj.Parent != nil {
+		p.childIndex = obj.Parent.ChildIndex(obj)
+	}
+	return p
+}
+
+// reparentCommand is a hierarchy drag-and-drop
+type reparentCommand struct {
+	before placement
+	after  placement
+}
+
+func (c *reparentCommand) Undo(e *Editor) { e.restorePlacement(c.before) }
+func (c *reparentCommand) Redo(e *Editor) { e.restorePlacement(c.after) }
+
+func (c *reparentCommand) Bytes() int { return int(unsafe.Sizeof(*c)) }
+
+// restorePlacement puts an object still in the scene back under its saved
+// parent, at its saved positions and local transform
+func (e *Editor) restorePlacement(p placement) {
+	obj := p.transform.obj
+	if obj.Parent != nil {
+		obj.Parent.RemoveChild(obj)
+	}
+	if p.parent != nil {
+		p.parent.InsertChild(obj, p.childIndex)
+	}
+	p.transform.apply()
+	e.world.Scene.MoveGameObject(obj, p.sceneIndex)
+	e.Selected = obj
+}
+
+// deleteCommand is the deletion of one or more objects. The deleted objects
+// live on only here, so undo can put the very same objects back.
+type deleteCommand struct {
+	objs []placement
+}
+
+func (c *deleteCommand) Undo(e *Editor) {
+	// Last to first, so earlier sibling and scene positions are still valid
+	for i := len(c.objs) - 1; i >= 0; i-- {
+		p := c.objs[i]
+		obj := p.transform.obj
+
+		// Re-add to the scene at its old position, and to its parent
+		// (or physics, which only holds root objects)
+		e.world.Scene.AddGameObject(obj)
+		e.world.Scene.MoveGameObject(obj, p.sceneIndex)
+		if p.parent != nil {
+			p.parent.InsertChild(obj, p.childIndex)
+		} else {
+			e.world.PhysicsWorld.AddObject(obj)
+		}
+
+		// Re-apply shader to model renderer if present
+		if mr := engine.GetComponent[*components.ModelRenderer](obj); mr != nil {
+			mr.SetShader(e.world.Renderer.Shader)
+		}
+	}
+
+	e.selectOnly(c.objs[0].transform.obj)
+	if len(c.objs) == 1 {
+		e.setMsg("Restored %s", c.objs[0].transform.obj.Name)
+	} else {
+		e.setMsg("Restored %d objects", len(c.objs))
+	}
+}
+
+func (c *deleteCommand) Redo(e *Editor) {
+	for _, p := range c.objs {
+		// Remove from scene and physics, but keep model loaded (for undo)
+		e.world.EditorDestroy(p.transform.obj)
+	}
+	e.selectOnly(nil)
+	if len(c.objs) == 1 {
+		e.setMsg("Deleted %s", c.objs[0].transform.obj.Name)
+	} else {
+		e.setMsg("Deleted %d objects", len(c.objs))
+	}
+}
+
+// Bytes counts the deleted objects at the size of their serialized
+// components, children included
+func (c *deleteCommand) Bytes() int {
+	size := int(unsafe.Sizeof(*c))
+	for _, p := range c.objs {
+		size += int(unsafe.Sizeof(p)) + objectBytes(p.transform.obj)
+	}
+	return size
+}
+
+// componentCommand is adding a component to an object or removing one
+type componentCommand struct {
+	obj     *engine.GameObject
+	comp    engine.Component
+	index   int  // position among the object's components
+	removed bool // the command removes comp rather than adding it
+}
+
+func (c *componentCommand) Undo(e *Editor) {
+	if c.removed {
+		e.attachComponent(c.obj, c.comp, c.index)
+	} else {
+		e.detachComponent(c.obj, c.comp)
+	}
+}
+
+func (c *componentCommand) Redo(e *Editor) {
+	if c.removed {
+		e.detachComponent(c.obj, c.comp)
+	} else {
+		e.attachComponent(c.obj, c.comp, c.index)
+	}
+}
+
+func (c *componentCommand) Bytes() int {
+	return int(unsafe.Sizeof(*c)) + len(world.CopyComponent(c.comp))
+}
+
+// attachComponent puts comp on obj at index, taking over as the scene light
+// if there is none
+func (e *Editor) attachComponent(obj *engine.GameObject, comp engine.Component, index int) {
+	obj.InsertComponent(comp, index)
+	if light, ok := comp.(*components.DirectionalLight); ok && e.world.Light == nil {
+		e.world.Light = obj
+		e.world.Renderer.SetLight(light)
+	}
+	e.updatePhysicsRegistration(obj)
+	e.Selected = obj
+}
+
+// detachComponent takes comp off obj. Its resources stay loaded, since the
+// undo history may put it back.
+func (e *Editor) detachComponent(obj *engine.GameObject, comp engine.Component) {
+	obj.RemoveComponent(comp)
+	if _, ok := comp.(*components.DirectionalLight); ok && e.world.Light == obj &&
+		engine.GetComponent[*components.DirectionalLight](obj) == nil {
+		e.world.Light = nil
+	}
+	if e.focusedComponent == comp {
+		e.focusedComponent = nil
+	}
+	e.updatePhysicsRegistration(obj)
+	e.Selected = obj
+}
+
+// objectState is everything the inspector edits on an object: its name, tags,
+// transform and the properties of its components
+type objectState struct {
+	name      string
+	tags      []string
+	transform transformState
+	comps     []engine.Component
+	props     []json.RawMessage // world.CopyComponent of each of comps
+}
+
+func captureObject(obj *engine.GameObject) objectState {
+	s := objectState{
+		name:      obj.Name,
+		tags:      slices.Clone(obj.Tags),
+		transform: captureTransform(obj),
+		comps:     slices.Clone(obj.Components()),
+	}
+	for _, c := range s.comps {
+		s.props = append(s.props, world.CopyComponent(c))
+	}
+	return s
+}
+
+// changed reports whether other differs from s in anything restore would set
+func (s objectState) changed(other objectState) bool {
+	if s.name != other.name || !slices.Equal(s.tags, other.tags) || s.transform != other.transform {
+		return true
+	}
+	for i, c := range s.comps {
+		j := slices.Index(other.comps, c)
+		if j >= 0 && !bytes.Equal(s.props[i], other.props[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// restore sets the object back to s. Components added or removed since are
+// left alone; that has its own command.
+func (s objectState) restore(e *Editor) {
+	obj := s.transform.obj
+	obj.Name = s.name
+	obj.Tags = slices.Clone(s.tags)
+	s.transform.apply()
+	for i, c := range s.comps {
+		if s.props[i] != nil && slices.Contains(obj.Components(), c) {
+			e.world.RestoreComponent(c, s.props[i])
+		}
+	}
+	e.world.PhysicsWorld.MarkStaticsDirty()
+}
+
+// propertyCommand is an inspector edit of one or more objects: a scrub, a
+// typed value, a toggle
+type propertyCommand struct {
+	before []objectState
+	after  []objectState
+}
+
+func (c *propertyCommand) Undo(e *Editor) {
+	for _, s := range c.before {
+		s.restore(e)
+	}
+	e.Selected = c.before[0].transform.obj
+}
+
+func (c *propertyCommand) Redo(e *Editor) {
+	for _, s := range c.after {
+		s.restore(e)
+	}
+	e.Selected = c.after[0].transform.obj
+}
+
+func (c *propertyCommand) Bytes() int {
+	size := int(unsafe.Sizeof(*c))
+	for _, s := range slices.Concat(c.before, c.after) {
+		size += int(unsafe.Sizeof(s)) + len(s.name)
+		for _, p := range s.props {
+			size += len(p)
+		}
+	}
+	return size
+}
 
-// UndoState captures state for undo operations
-type UndoState struct {
-	Type     UndoActionType
-	Object   *engine.GameObject
-	Position rl.Vector3
-	Rotation rl.Vector3
-	Scale    rl.Vector3
+// groupCommand is several commands undone and redone as one step
+type groupCommand []undoCommand
 
-	// For delete undo - we store enough info to recreate
-	DeletedName   string
-	DeletedParent *engine.GameObject
+func (g groupCommand) Undo(e *Editor) {
+	for i := len(g) - 1; i >= 0; i-- {
+		g[i].Undo(e)
+	}
 }
 
-// pushUndo saves the current transform state of the selected object
-func (e *Editor) pushUndo() {
-	if e.Selected == nil {
+func (g groupCommand) Redo(e *Editor) {
+	for _, c := range g {
+		c.Redo(e)
+	}
+}
+
+func (g groupCommand) Bytes() int {
+	size := int(unsafe.Sizeof(g))
+	for _, c := range g {
+		size += c.Bytes()
+	}
+	return size
+}
+
+// --- Recording ---
+
+// beginTransformUndo remembers the selection's transforms as a gizmo drag
+// starts, primary selection first
+func (e *Editor) beginTransformUndo() {
+	// An inspector edit still open (say a field committed by this click) is
+	// its own step
+	e.commitInspectorEdit()
+
+	e.pendingTransform = e.pendingTransform[:0]
+	e.pendingTransform = append(e.pendingTransform, captureTransform(e.Selected))
+	for _, obj := range e.selection() {
+		if obj != e.Selected {
+			e.pendingTransform = append(e.pendingTransform, captureTransform(obj))
+		}
+	}
+}
+
+// commitTransformUndo records the drag that just ended as one undo step, if
+// it moved anything
+func (e *Editor) commitTransformUndo() {
+	if len(e.pendingTransform) == 0 {
 		return
 	}
-	state := UndoState{
-		Type:     UndoTransform,
-		Object:   e.Selected,
-		Position: e.Selected.Transform.Position,
-		Rotation: e.Selected.Transform.Rotation,
-		Scale:    e.Selected.Transform.Scale,
+	cmd := &transformCommand{before: slices.Clone(e.pendingTransform)}
+	e.pendingTransform = e.pendingTransform[:0]
+	moved := false
+	for _, s := range cmd.before {
+		after := captureTransform(s.obj)
+		moved = moved || after != s
+		cmd.after = append(cmd.after, after)
+	}
+	if moved {
+		e.pushUndo(cmd)
 	}
-	e.addUndoState(state)
 }
 
-// pushDeleteUndo saves the deleted object so it can be restored
-func (e *Editor) pushDeleteUndo(obj *engine.GameObject) {
-	state := UndoState{
-		Type:          UndoDelete,
-		Object:        obj,
-		DeletedName:   obj.Name,
-		DeletedParent: obj.Parent,
-		Position:      obj.Transform.Position,
-		Rotation:      obj.Transform.Rotation,
-		Scale:         obj.Transform.Scale,
+// beginInspectorEdit snapshots the selection when the mouse goes down on the
+// inspector, and endInspectorEdit records whatever changed once the mouse is
+// up and no field is being typed in. A scrub or an edit typed into a field is
+// one undo step however many frames it takes.
+func (e *Editor) beginInspectorEdit() {
+	if e.inspectorEdit != nil || !rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		return
+	}
+	panelX := float32(rl.GetScreenWidth()) - float32(e.inspectorWidth)
+	if rl.GetMousePosition().X < panelX {
+		return
+	}
+	sel := e.selection()
+	if len(sel) == 0 {
+		return
+	}
+	e.inspectorEdit = make([]objectState, 0, len(sel))
+	for _, obj := range sel {
+		e.inspectorEdit = append(e.inspectorEdit, captureObject(obj))
 	}
-	e.addUndoState(state)
 }
 
-func (e *Editor) addUndoState(state UndoState) {
-	// Cap stack size
-	if len(e.undoStack) >= maxUndoStack {
-		e.undoStack = e.undoStack[1:]
+func (e *Editor) endInspectorEdit() {
+	if rl.IsMouseButtonDown(rl.MouseLeftButton) || e.fieldDragging || e.editingText() {
+		return
 	}
-	e.undoStack = append(e.undoStack, state)
+	e.commitInspectorEdit()
 }
 
-// undo restores the last saved state
-func (e *Editor) undo() {
-	if len(e.undoStack) == 0 {
+// commitInspectorEdit records the inspector edit in progress right away
+func (e *Editor) commitInspectorEdit() {
+	if e.inspectorEdit == nil {
 		return
 	}
-	// Pop last state
-	state := e.undoStack[len(e.undoStack)-1]
-	e.undoStack = e.undoStack[:len(e.undoStack)-1]
+	cmd := &propertyCommand{before: e.inspectorEdit}
+	e.inspectorEdit = nil
+	changed := false
+	for _, s := range cmd.before {
+		after := captureObject(s.transform.obj)
+		changed = changed || s.changed(after)
+		cmd.after = append(cmd.after, after)
+	}
+	if changed {
+		e.pushUndo(cmd)
+	}
+}
 
-	switch state.Type {
-	case UndoTransform:
-		// Restore transform
-		if state.Object != nil {
-			state.Object.Transform.Position = state.Position
-			state.Object.Transform.Rotation = state.Rotation
-			state.Object.Transform.Scale = state.Scale
-			e.Selected = state.Object
-		}
+// rebaseInspectorEdit re-snapshots an inspector edit in progress, so changes
+// made by undo or redo meanwhile aren't recorded as part of it
+func (e *Editor) rebaseInspectorEdit() {
+	for i, s := range e.inspectorEdit {
+		e.inspectorEdit[i] = captureObject(s.transform.obj)
+	}
+}
 
-	case UndoDelete:
-		// Restore deleted object
-		if state.Object != nil {
-			// Re-add to scene and physics
-			e.world.Scene.AddGameObject(state.Object)
-			e.world.PhysicsWorld.AddObject(state.Object)
+// pushReparentUndo records a hierarchy move, given the object's placement
+// from before it
+func (e *Editor) pushReparentUndo(before placement) {
+	e.pushUndo(&reparentCommand{before: before, after: e.capturePlacement(before.transform.obj)})
+}
 
-			// Restore parent relationship if any
-			if state.DeletedParent != nil {
-				state.Object.Parent = state.DeletedParent
-			}
+// runUndoable applies cmd and records it
+func (e *Editor) runUndoable(cmd undoCommand) {
+	cmd.Redo(e)
+	e.pushUndo(cmd)
+}
 
-			// Re-apply shader to model renderer if present
-			if mr := engine.GetComponent[*components.ModelRenderer](state.Object); mr != nil {
-				mr.SetShader(e.world.Renderer.Shader)
-			}
+// pushUndo records a command that has already been applied. It starts a new
+// line of history, so whatever could be redone is dropped.
+func (e *Editor) pushUndo(cmd undoCommand) {
+	e.undoStack = append(e.undoStack, cmd)
+	clear(e.redoStack)
+	e.redoStack = e.redoStack[:0]
+	e.trimUndo()
+}
+
+// --- History ---
+
+// setMaxUndo changes the undo depth, dropping the oldest entries beyond it
+func (e *Editor) setMaxUndo(n int) {
+	e.maxUndo = max(n, 1)
+	e.trimUndo()
+}
+
+// trimUndo drops the oldest entries beyond maxUndo and refreshes the memory
+// estimate. Call it after anything changes the undo or redo stack.
+func (e *Editor) trimUndo() {
+	if n := len(e.undoStack) - e.maxUndo; n > 0 {
+		// slices.Delete zeroes the vacated tail, so deleted objects the
+		// dropped entries held can be collected
+		e.undoStack = slices.Delete(e.undoStack, 0, n)
+	}
+	e.undoBytes = 0
+	for _, cmd := range slices.Concat(e.undoStack, e.redoStack) {
+		e.undoBytes += cmd.Bytes()
+	}
+}
 
-			e.Selected = state.Object
-			e.setMsg("Restored %s", state.DeletedName)
+// clearUndo empties the undo history, e.g. when another scene is opened
+func (e *Editor) clearUndo() {
+	clear(e.undoStack)
+	e.undoStack = e.undoStack[:0]
+	clear(e.redoStack)
+	e.redoStack = e.redoStack[:0]
+	e.pendingTransform = e.pendingTransform[:0]
+	e.inspectorEdit = nil
+	e.undoBytes = 0
+}
+
+// undoStatus summarizes the undo history for the editor overlay
+func (e *Editor) undoStatus() string {
+	return fmt.Sprintf("Undo %d/%d  Redo %d  ~%s", len(e.undoStack), e.maxUndo, len(e.redoStack), formatBytes(e.undoBytes))
+}
+
+// objectBytes approximates the size of g and its children by their serialized components
+func objectBytes(g *engine.GameObject) int {
+	size := int(unsafe.Sizeof(*g)) + len(g.Name)
+	for _, c := range g.Components() {
+		if s, ok := c.(engine.Serializable); ok {
+			if data, err := json.Marshal(s.Serialize()); err == nil {
+				size += len(data)
+			}
 		}
 	}
+	for _, child := range g.Children {
+		size += objectBytes(child)
+	}
+	return size
+}
+
+// formatBytes formats a byte count as B, KB or MB
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// undo reverts the last recorded step, moving it to the redo stack
+func (e *Editor) undo() {
+	if len(e.undoStack) == 0 || e.dragging {
+		return
+	}
+	cmd := e.undoStack[len(e.undoStack)-1]
+	e.undoStack[len(e.undoStack)-1] = nil
+	e.undoStack = e.undoStack[:len(e.undoStack)-1]
+	cmd.Undo(e)
+	e.redoStack = append(e.redoStack, cmd)
+	e.rebaseInspectorEdit()
+	e.trimUndo()
+}
+
+// redo applies the last undone step again, moving it back to the undo stack
+func (e *Editor) redo() {
+	if len(e.redoStack) == 0 || e.dragging {
+		return
+	}
+	cmd := e.redoStack[len(e.redoStack)-1]
+	e.redoStack[len(e.redoStack)-1] = nil
+	e.redoStack = e.redoStack[:len(e.redoStack)-1]
+	cmd.Redo(e)
+	e.undoStack = append(e.undoStack, cmd)
+	e.rebaseInspectorEdit()
+	e.trimUndo()
 }
@@ -0,0 +1,217 @@
+package physics
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"test3d/internal/components"
+	"test3d/internal/compute"
+	"test3d/internal/engine"
+	"time"
+)
+
+// BroadPhaseMode selects between the CPU spatial hash and the GPU broad-phase.
+type BroadPhaseMode int
+
+const (
+	BroadPhaseAuto     BroadPhaseMode = iota // GPU above GPUBroadPhaseThreshold objects
+	BroadPhaseForceCPU                       // always use the spatial hash
+	BroadPhaseForceGPU                       // always use the GPU if available
+	BroadPhaseCompare                        // run both, log mismatched pairs, resolve CPU pairs
+)
+
+var broadPhaseModeNames = [...]string{"auto", "cpu", "gpu", "compare"}
+
+func (m BroadPhaseMode) String() string {
+	if m < 0 || int(m) >= len(broadPhaseModeNames) {
+		return fmt.Sprintf("BroadPhaseMode(%d)", int(m))
+	}
+	return broadPhaseModeNames[m]
+}
+
+// ParseBroadPhaseMode parses "auto", "cpu", "gpu" or "compare".
+func ParseBroadPhaseMode(s string) (BroadPhaseMode, bool) {
+	for i, name := range broadPhaseModeNames {
+		if strings.EqualFold(s, name) {
+			return BroadPhaseMode(i), true
+		}
+	}
+	return BroadPhaseAuto, false
+}
+
+// shouldUseGPU decides whether this step's broad-phase runs on the GPU
+func (p *PhysicsWorld) shouldUseGPU() bool {
+	if p.gpuBroadPhase == nil {
+		return false
+	}
+	switch p.BroadPhaseMode {
+	case BroadPhaseForceCPU:
+		return false
+	case BroadPhaseForceGPU, BroadPhaseCompare:
+		return true
+	default:
+		return len(p.Objects) >= GPUBroadPhaseThreshold
+	}
+}
+
+// cpuBroadPhasePairs returns candidate pairs from the spatial hash, each pair once
+func (p *PhysicsWorld) cpuBroadPhasePairs() [][2]*engine.GameObject {
+	p.rebuildGrid()
+
+	// Track checked pairs to avoid duplicate checks
+	checked := make(map[CollisionPair]bool)
+	var pairs [][2]*engine.GameObject
+
+	for _, obj := range p.Objects {
+		for _, other := range p.getNeighborObjects(obj) {
+			if obj == other {
+				continue
+			}
+			key := makePair(obj, other)
+			if checked[key] {
+				continue
+			}
+			checked[key] = true
+			pairs = append(pairs, [2]*engine.GameObject{obj, other})
+		}
+	}
+	return pairs
+}
+
+// resolveGPUPairs runs the narrow-phase on the pairs the GPU broad-phase
+// found. Contacts between sphere bodies are computed on the GPU in one batch,
+// so the CPU only applies the impulses; other pairs go through
+// resolveCollision. Returns how many pairs used GPU contacts.
+func (p *PhysicsWorld) resolveGPUPairs(spheres []compute.Sphere, pairs []compute.CollisionPair) int {
+	var spherePairs []compute.CollisionPair
+	for _, pair := range pairs {
+		if int(pair.A) >= len(p.Objects) || int(pair.B) >= len(p.Objects) {
+			continue
+		}
+		a, b := p.Objects[pair.A], p.Objects[pair.B]
+		if isSphereBody(a) && isSphereBody(b) {
+			spherePairs = append(spherePairs, pair)
+		} else {
+			p.resolveCollision(a, b)
+		}
+	}
+	if len(spherePairs) == 0 {
+		return 0
+	}
+
+	contacts := p.gpuBroadPhase.ResolveSpheres(spheres, spherePairs)
+	for i, pair := range spherePairs {
+		p.resolveSphereContact(p.Objects[pair.A], p.Objects[pair.B], contacts[i])
+	}
+
+	// ResolveSpheres falls back to the CPU by itself, the step goes on either way
+	if err := p.gpuBroadPhase.NarrowPhaseError(); err != nil {
+		if time.Since(p.lastLogTime) >= time.Second {
+			p.lastLogTime = time.Now()
+			log.Printf("Physics: GPU narrow-phase failed, using CPU this frame: %v", err)
+		}
+		return 0
+	}
+	return len(spherePairs)
+}
+
+// isSphereBody reports whether resolveCollision treats g as a plain sphere: a
+// solid SphereCollider on a rigidbody, without a capsule
+func isSphereBody(g *engine.GameObject) bool {
+	return engine.GetComponent[*components.Rigidbody](g) != nil &&
+		engine.GetComponent[*components.SphereCollider](g) != nil &&
+		!hasCapsule(g) && !IsTrigger(g)
+}
+
+// compareBroadPhase runs both broad-phases, logs pairs they disagree on, and
+// resolves the CPU pairs. Returns the CPU pair count and the mismatch count.
+func (p *PhysicsWorld) compareBroadPhase() (int, int) {
+	spheres := p.buildBoundingSpheres()
+	gpuPairs, err := p.gpuBroadPhase.DetectPairs(spheres)
+	cpuPairs := p.cpuBroadPhasePairs()
+
+	mismatches := 0
+	if err != nil {
+		if time.Since(p.lastLogTime) >= time.Second {
+			p.lastLogTime = time.Now()
+			log.Printf("Physics: compare mode GPU broad-phase failed: %v", err)
+		}
+	} else {
+		mismatches = p.logBroadPhaseMismatches(spheres, cpuPairs, gpuPairs)
+	}
+
+	for _, pair := range cpuPairs {
+		p.resolveCollision(pair[0], pair[1])
+	}
+	return len(cpuPairs), mismatches
+}
+
+// logBroadPhaseMismatches compares the GPU's overlapping pairs with the CPU candidates
+// that pass the same bounding-sphere test, logging differences at most once a second.
+func (p *PhysicsWorld) logBroadPhaseMismatches(spheres []compute.Sphere, cpuPairs [][2]*engine.GameObject, gpuPairs []compute.CollisionPair) int {
+	index := make(map[*engine.GameObject]uint32, len(p.Objects))
+	for i, obj := range p.Objects {
+		index[obj] = uint32(i)
+	}
+	key := func(a, b uint32) [2]uint32 {
+		if a > b {
+			a, b = b, a
+		}
+		return [2]uint32{a, b}
+	}
+
+	cpuSet := make(map[[2]uint32]bool)
+	for _, pair := range cpuPairs {
+		a, b := index[pair[0]], index[pair[1]]
+		sa, sb := spheres[a], spheres[b]
+		dx, dy, dz := sa.X-sb.X, sa.Y-sb.Y, sa.Z-sb.Z
+		r := sa.Radius + sb.Radius
+		if dx*dx+dy*dy+dz*dz < r*r {
+			cpuSet[key(a, b)] = true
+		}
+	}
+	gpuSet := make(map[[2]uint32]bool, len(gpuPairs))
+	for _, pair := range gpuPairs {
+		gpuSet[key(pair.A, pair.B)] = true
+	}
+
+	var missed, extra [][2]uint32
+	for k := range cpuSet {
+		if !gpuSet[k] {
+			missed = append(missed, k)
+		}
+	}
+	for k := range gpuSet {
+		if !cpuSet[k] {
+			extra = append(extra, k)
+		}
+	}
+
+	mismatches := len(missed) + len(extra)
+	if mismatches > 0 && time.Since(p.lastLogTime) >= time.Second {
+		p.lastLogTime = time.Now()
+		log.Printf("Physics: broad-phase mismatch - GPU missed %d, GPU extra %d (CPU %d, GPU %d pairs)",
+			len(missed), len(extra), len(cpuSet), len(gpuSet))
+		for i, k := range missed {
+			if i == 3 {
+				break
+			}
+			log.Printf("  missed by GPU: %s <-> %s", p.pairName(k[0]), p.pairName(k[1]))
+		}
+		for i, k := range extra {
+			if i == 3 {
+				break
+			}
+			log.Printf("  extra from GPU: %s <-> %s", p.pairName(k[0]), p.pairName(k[1]))
+		}
+	}
+	return mismatches
+}
+
+// pairName returns a dynamic object's name for logging
+func (p *PhysicsWorld) pairName(i uint32) string {
+	if int(i) >= len(p.Objects) {
+		return fmt.Sprintf("#%d (out of range)", i)
+	}
+	return fmt.Sprintf("%s (uid %d)", p.Objects[i].Name, p.Objects[i].UID)
+}
@@ -0,0 +1,277 @@
+package physics
+
+import (
+	"math"
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// A capsule is a sphere swept along a segment, so every capsule test finds the
+// point of the segment nearest the other shape and treats the capsule as a
+// sphere there.
+
+// closestPointOnSegment returns the point of segment a-b nearest to p
+func closestPointOnSegment(a, b, p rl.Vector3) rl.Vector3 {
+	ab := rl.Vector3Subtract(b, a)
+	lenSq := rl.Vector3DotProduct(ab, ab)
+	if lenSq < 1e-8 {
+		return a
+	}
+	t := clamp(rl.Vector3DotProduct(rl.Vector3Subtract(p, a), ab)/lenSq, 0, 1)
+	return rl.Vector3Add(a, rl.Vector3Scale(ab, t))
+}
+
+// closestPointsOnSegments returns the nearest pair of points between segments
+// p1-q1 and p2-q2 (Ericson, Real-Time Collision Detection 5.1.9)
+func closestPointsOnSegments(p1, q1, p2, q2 rl.Vector3) (rl.Vector3, rl.Vector3) {
+	d1 := rl.Vector3Subtract(q1, p1)
+	d2 := rl.Vector3Subtract(q2, p2)
+	r := rl.Vector3Subtract(p1, p2)
+	a := rl.Vector3DotProduct(d1, d1)
+	e := rl.Vector3DotProduct(d2, d2)
+	f := rl.Vector3DotProduct(d2, r)
+
+	const epsilon = 1e-8
+	var s, t float32
+	switch {
+	case a <= epsilon && e <= epsilon:
+		return p1, p2
+	case a <= epsilon:
+		t = clamp(f/e, 0, 1)
+	default:
+		c := rl.Vector3DotProduct(d1, r)
+		if e <= epsilon {
+			s = clamp(-c/a, 0, 1)
+		} else {
+			b := rl.Vector3DotProduct(d1, d2)
+			if denom := a*e - b*b; denom > epsilon {
+				s = clamp((b*f-c*e)/denom, 0, 1)
+			}
+			t = (b*s + f) / e
+			if t < 0 {
+				t = 0
+				s = clamp(-c/a, 0, 1)
+			} else if t > 1 {
+				t = 1
+				s = clamp((b-c)/a, 0, 1)
+			}
+		}
+	}
+	return rl.Vector3Add(p1, rl.Vector3Scale(d1, s)), rl.Vector3Add(p2, rl.Vector3Scale(d2, t))
+}
+
+// closestPointOnSegmentToOBB returns the point of segment a-b nearest to the
+// box, found by projecting back and forth between the two convex shapes
+func closestPointOnSegmentToOBB(a, b rl.Vector3, o OBB) rl.Vector3 {
+	p := closestPointOnSegment(a, b, o.Center)
+	for range 8 {
+		next := closestPointOnSegment(a, b, ClosestPointOnOBB(o, p))
+		if rl.Vector3Distance(next, p) < 1e-5 {
+			break
+		}
+		p = next
+	}
+	return p
+}
+
+// sphereOverlap returns the push for a sphere at a out of a sphere at b: the
+// normal pointing from b to a and the overlap depth
+func sphereOverlap(a rl.Vector3, radiusA float32, b rl.Vector3, radiusB float32) (rl.Vector3, float32, bool) {
+	diff := rl.Vector3Subtract(a, b)
+	dist := rl.Vector3Length(diff)
+	if dist >= radiusA+radiusB {
+		return rl.Vector3{}, 0, false
+	}
+	if dist < 0.0001 {
+		return rl.Vector3{Y: 1}, radiusA + radiusB, true // centers coincide, push up
+	}
+	return rl.Vector3Scale(diff, 1/dist), radiusA + radiusB - dist, true
+}
+
+// sphereOBBOverlap is sphereOverlap for a sphere against an oriented box. A
+// center inside the box is pushed out through the nearest face.
+func sphereOBBOverlap(center rl.Vector3, radius float32, o OBB) (rl.Vector3, float32, bool) {
+	closest := ClosestPointOnOBB(o, center)
+	if rl.Vector3Distance(center, closest) >= 0.0001 {
+		return sphereOverlap(center, radius, closest, 0)
+	}
+
+	local := rl.Vector3Subtract(center, o.Center)
+	half := [3]float32{o.HalfSize.X, o.HalfSize.Y, o.HalfSize.Z}
+	var normal rl.Vector3
+	depth := float32(math.MaxFloat32)
+	for i, axis := range o.Axes {
+		d := rl.Vector3DotProduct(local, axis)
+		if pen := half[i] - absf(d); pen < depth {
+			depth = pen
+			normal = axis
+			if d < 0 {
+				normal = rl.Vector3Negate(axis)
+			}
+		}
+	}
+	return normal, depth + radius, true
+}
+
+// hasCapsule reports whether g has a CapsuleCollider
+func hasCapsule(g *engine.GameObject) bool {
+	return engine.GetComponent[*components.CapsuleCollider](g) != nil
+}
+
+// capsuleContact returns how to push a out of b when either has a
+// CapsuleCollider and the other a capsule, sphere or box collider: the normal
+// pointing from b toward a and the overlap depth
+func capsuleContact(a, b *engine.GameObject) (rl.Vector3, float32, bool) {
+	if capsule := engine.GetComponent[*components.CapsuleCollider](a); capsule != nil {
+		return capsuleAgainst(capsule, b)
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](b); capsule != nil {
+		normal, depth, ok := capsuleAgainst(capsule, a)
+		return rl.Vector3Negate(normal), depth, ok
+	}
+	return rl.Vector3{}, 0, false
+}
+
+// capsuleAgainst is capsuleContact with the capsule first
+func capsuleAgainst(c *components.CapsuleCollider, other *engine.GameObject) (rl.Vector3, float32, bool) {
+	a, b := c.Segment()
+	radius := c.GetWorldRadius()
+
+	if oc := engine.GetComponent[*components.CapsuleCollider](other); oc != nil {
+		oa, ob := oc.Segment()
+		p, q := closestPointsOnSegments(a, b, oa, ob)
+		return sphereOverlap(p, radius, q, oc.GetWorldRadius())
+	}
+	if sphere := engine.GetComponent[*components.SphereCollider](other); sphere != nil {
+		center := sphere.GetCenter()
+		return sphereOverlap(closestPointOnSegment(a, b, center), radius, center, sphere.GetWorldRadius())
+	}
+	if box := engine.GetComponent[*components.BoxCollider](other); box != nil {
+		obb := boxColliderOBB(other, box)
+		return sphereOBBOverlap(closestPointOnSegmentToOBB(a, b, obb), radius, obb)
+	}
+	return rl.Vector3{}, 0, false
+}
+
+// capsuleMeshPush returns the push out of a mesh collider for a capsule,
+// testing spheres spaced at most a radius apart along its segment
+func capsuleMeshPush(mesh *components.MeshCollider, c *components.CapsuleCollider) (bool, rl.Vector3) {
+	a, b := c.Segment()
+	radius := c.GetWorldRadius()
+	steps := 1
+	if radius > 0 {
+		steps = max(int(math.Ceil(float64(rl.Vector3Distance(a, b)/radius))), 1)
+	}
+
+	var total rl.Vector3
+	hit := false
+	for i := 0; i <= steps; i++ {
+		center := rl.Vector3Lerp(a, b, float32(i)/float32(steps))
+		ok, push := mesh.SphereIntersect(center, radius)
+		if !ok {
+			continue
+		}
+		hit = true
+		// Keep the largest push along each axis, as SphereIntersect does per triangle
+		if absf(push.X) > absf(total.X) {
+			total.X = push.X
+		}
+		if absf(push.Y) > absf(total.Y) {
+			total.Y = push.Y
+		}
+		if absf(push.Z) > absf(total.Z) {
+			total.Z = push.Z
+		}
+	}
+	return hit, total
+}
+
+// capsuleBoundingRadius returns the radius around the object's position that
+// encloses its capsule collider
+func capsuleBoundingRadius(obj *engine.GameObject, c *components.CapsuleCollider) float32 {
+	offset := rl.Vector3Distance(obj.WorldPosition(), c.GetCenter())
+	return offset + c.GetWorldHeight()/2
+}
+
+// resolveCapsuleCollision handles two dynamic rigidbodies when at least one
+// has a capsule collider
+func (p *PhysicsWorld) resolveCapsuleCollision(a, b *engine.GameObject, rbA, rbB *components.Rigidbody) {
+	normal, penetration, ok := capsuleContact(a, b)
+	if !ok {
+		return
+	}
+	p.recordCollision(a, b)
+
+	ratioA, ratioB := pushRatios(rbA, rbB)
+	push := p.correction(penetration)
+	a.Transform.Position = rl.Vector3Add(a.Transform.Position, rl.Vector3Scale(normal, push*ratioA))
+	b.Transform.Position = rl.Vector3Subtract(b.Transform.Position, rl.Vector3Scale(normal, push*ratioB))
+
+	relVel := rl.Vector3Subtract(rbA.Velocity, rbB.Velocity)
+	velAlongNormal := rl.Vector3DotProduct(relVel, normal)
+	if velAlongNormal > 0 {
+		return
+	}
+
+	e := restitution((rbA.Bounciness+rbB.Bounciness)/2, velAlongNormal)
+	invMassSum := rbA.InverseMass() + rbB.InverseMass()
+	if invMassSum == 0 {
+		return // both immovable
+	}
+	impulse := rl.Vector3Scale(normal, -(1+e)*velAlongNormal/invMassSum)
+	rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, rbA.InverseMass()))
+	rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, rbB.InverseMass()))
+	applyContactFriction(rbA, rbB, normal)
+}
+
+// resolveCapsuleStaticCollision handles a dynamic object against a static one
+// when either has a capsule collider
+func (p *PhysicsWorld) resolveCapsuleStaticCollision(obj, static *engine.GameObject, rb *components.Rigidbody) {
+	normal, penetration, ok := capsuleContact(obj, static)
+	if !ok {
+		return
+	}
+	p.recordCollision(obj, static)
+
+	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, rl.Vector3Scale(normal, p.correction(penetration)))
+
+	velAlongNormal := rl.Vector3DotProduct(rb.Velocity, normal)
+	if velAlongNormal < 0 {
+		reflect := rl.Vector3Scale(normal, -(1+restitution(rb.Bounciness, velAlongNormal))*velAlongNormal)
+		rb.Velocity = rl.Vector3Add(rb.Velocity, reflect)
+		rb.Velocity.X *= (1 - rb.Friction)
+		rb.Velocity.Z *= (1 - rb.Friction)
+	}
+}
+
+// resolveCapsuleKinematicCollision handles a kinematic object pushing a
+// dynamic one when either has a capsule collider
+func (p *PhysicsWorld) resolveCapsuleKinematicCollision(kinematic, obj *engine.GameObject, rbKin, rbObj *components.Rigidbody) {
+	normal, penetration, ok := capsuleContact(obj, kinematic)
+	if !ok {
+		return
+	}
+	p.recordCollision(kinematic, obj)
+
+	// Push the dynamic object fully out (kinematic doesn't move)
+	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, rl.Vector3Scale(normal, penetration))
+
+	// Pass on the kinematic's velocity into the object
+	if kinVel := rl.Vector3DotProduct(rbKin.Velocity, normal); kinVel > 0 {
+		rbObj.Velocity = rl.Vector3Add(rbObj.Velocity, rl.Vector3Scale(normal, kinVel*1.5))
+	}
+}
+
+// resolveCapsuleKinematicStaticCollision pushes a kinematic object out of a
+// static one when either has a capsule collider. The rounded bottom of a
+// capsule character rides up over low edges by itself.
+func (p *PhysicsWorld) resolveCapsuleKinematicStaticCollision(kinematic, static *engine.GameObject) {
+	normal, penetration, ok := capsuleContact(kinematic, static)
+	if !ok {
+		return
+	}
+	p.recordCollision(kinematic, static)
+	kinematic.Transform.Position = rl.Vector3Add(kinematic.Transform.Position, rl.Vector3Scale(normal, penetration))
+}
@@ -0,0 +1,75 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ccdSphere returns the radius of the sphere swept for continuous collision
+// detection and its world-space center. It is the sphere inside the object's
+// collider, so the sweep never stops the object short of a surface it wasn't
+// already touching.
+func ccdSphere(obj *engine.GameObject) (float32, rl.Vector3, bool) {
+	if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
+		return sphere.GetWorldRadius(), sphere.GetCenter(), true
+	}
+	if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
+		half := boxColliderOBB(obj, box).HalfSize
+		return min(half.X, half.Y, half.Z), box.GetCenter(), true
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+		return capsule.GetWorldRadius(), capsule.GetCenter(), true
+	}
+	return 0, rl.Vector3{}, false
+}
+
+// sweepCCD stops a fast body from tunnelling through thin statics, mesh
+// colliders and kinematics. It sweeps the body's collider from prev to where
+// integration just moved it and, if something is in the way, moves it back to
+// the point of contact and bounces its velocity off the surface. The rest of
+// the move slides along the surface, so a body skimming a floor doesn't stick
+// to it. Moves shorter than the swept radius are left to the normal overlap
+// tests, which can't miss them. Kinematics are swept against where they are
+// now; they are never swept themselves, since scripts place them directly.
+func (p *PhysicsWorld) sweepCCD(obj *engine.GameObject, rb *components.Rigidbody, prev rl.Vector3) {
+	radius, center, ok := ccdSphere(obj)
+	if !ok || radius <= 0 || IsTrigger(obj) {
+		return
+	}
+	move := rl.Vector3Subtract(obj.Transform.Position, prev)
+	dist := rl.Vector3Length(move)
+	if dist < radius {
+		return
+	}
+	dir := rl.Vector3Scale(move, 1/dist)
+	origin := rl.Vector3Subtract(center, move)
+
+	cast := func(other *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+		h, ok := sphereCastObject(origin, dir, radius, other, maxDistance)
+		// A hit at 0 is an overlap the body started in; the overlap tests own it
+		return h, ok && h.Distance > 0
+	}
+	// A miss leaves hit.Distance at dist, so kinematics are searched as far
+	hit, ok := shapeCast(p.Statics, origin, dir, radius, dist, cast)
+	if h, hitKinematic := shapeCast(p.Kinematics, origin, dir, radius, hit.Distance, cast); hitKinematic {
+		hit, ok = h, true
+	}
+	if !ok {
+		return
+	}
+
+	// Stop at contact, then slide the rest of the way along the surface
+	rest := rl.Vector3Scale(dir, dist-hit.Distance)
+	if into := rl.Vector3DotProduct(rest, hit.Normal); into < 0 {
+		rest = rl.Vector3Subtract(rest, rl.Vector3Scale(hit.Normal, into))
+	}
+	obj.Transform.Position = rl.Vector3Add(rl.Vector3Add(prev, rl.Vector3Scale(dir, hit.Distance)), rest)
+
+	p.recordCollision(obj, hit.GameObject)
+	if velAlongNormal := rl.Vector3DotProduct(rb.Velocity, hit.Normal); velAlongNormal < 0 {
+		reflect := rl.Vector3Scale(hit.Normal, -(1+restitution(rb.Bounciness, velAlongNormal))*velAlongNormal)
+		rb.Velocity = rl.Vector3Add(rb.Velocity, reflect)
+	}
+}
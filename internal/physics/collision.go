@@ -2,6 +2,7 @@ package physics
 
 import (
 	"test3d/internal/components"
+	"test3d/internal/compute"
 	"test3d/internal/engine"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -15,11 +16,23 @@ func (p *PhysicsWorld) resolveCollision(a, b *engine.GameObject) {
 		return
 	}
 
+	// Triggers only report the overlap (even between sleeping bodies, or
+	// they'd see a false exit)
+	if IsTrigger(a) || IsTrigger(b) {
+		p.detectTrigger(a, b)
+		return
+	}
+
 	// Skip if both objects are sleeping
 	if rbA.IsSleeping && rbB.IsSleeping {
 		return
 	}
 
+	if hasCapsule(a) || hasCapsule(b) {
+		p.resolveCapsuleCollision(a, b, rbA, rbB)
+		return
+	}
+
 	// Check for sphere colliders first
 	sphereA := engine.GetComponent[*components.SphereCollider](a)
 	sphereB := engine.GetComponent[*components.SphereCollider](b)
@@ -48,8 +61,8 @@ func (p *PhysicsWorld) resolveCollision(a, b *engine.GameObject) {
 		return
 	}
 
-	obbA := NewOBBFromBox(boxA.GetCenter(), boxA.Size, a.WorldRotation(), a.WorldScale())
-	obbB := NewOBBFromBox(boxB.GetCenter(), boxB.Size, b.WorldRotation(), b.WorldScale())
+	obbA := NewOBBFromBox(boxA.GetCenter(), boxA.Size, a.WorldQuaternion(), a.WorldScale())
+	obbB := NewOBBFromBox(boxB.GetCenter(), boxB.Size, b.WorldQuaternion(), b.WorldScale())
 
 	pushOut := obbA.ResolveOBB(obbB)
 	if pushOut.X == 0 && pushOut.Y == 0 && pushOut.Z == 0 {
@@ -59,13 +72,12 @@ func (p *PhysicsWorld) resolveCollision(a, b *engine.GameObject) {
 	// Record collision for callbacks
 	p.recordCollision(a, b)
 
-	// Split the push based on mass ratio
-	totalMass := rbA.Mass + rbB.Mass
-	ratioA := rbB.Mass / totalMass
-	ratioB := rbA.Mass / totalMass
+	// Split the positional correction based on mass ratio
+	ratioA, ratioB := pushRatios(rbA, rbB)
+	push := p.correctionVec(pushOut)
 
-	a.Transform.Position = rl.Vector3Add(a.Transform.Position, rl.Vector3Scale(pushOut, ratioA))
-	b.Transform.Position = rl.Vector3Subtract(b.Transform.Position, rl.Vector3Scale(pushOut, ratioB))
+	a.Transform.Position = rl.Vector3Add(a.Transform.Position, rl.Vector3Scale(push, ratioA))
+	b.Transform.Position = rl.Vector3Subtract(b.Transform.Position, rl.Vector3Scale(push, ratioB))
 
 	// Bounce velocities
 	pushLen := rl.Vector3Length(pushOut)
@@ -84,16 +96,20 @@ func (p *PhysicsWorld) resolveCollision(a, b *engine.GameObject) {
 	}
 
 	// Restitution (bounciness)
-	e := (rbA.Bounciness + rbB.Bounciness) / 2
+	e := restitution((rbA.Bounciness+rbB.Bounciness)/2, velAlongNormal)
 
 	// Impulse magnitude
 	j := -(1 + e) * velAlongNormal
-	j /= (1/rbA.Mass + 1/rbB.Mass)
+	invMassSum := rbA.InverseMass() + rbB.InverseMass()
+	if invMassSum == 0 {
+		return // both immovable
+	}
+	j /= invMassSum
 
 	// Apply impulse
 	impulse := rl.Vector3Scale(normal, j)
-	rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, 1/rbA.Mass))
-	rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, 1/rbB.Mass))
+	rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, rbA.InverseMass()))
+	rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, rbB.InverseMass()))
 
 	// Apply torque to boxes - contact point is on surface in direction of normal
 	halfSizeA := rl.Vector3{X: boxA.Size.X / 2, Y: boxA.Size.Y / 2, Z: boxA.Size.Z / 2}
@@ -106,116 +122,98 @@ func (p *PhysicsWorld) resolveCollision(a, b *engine.GameObject) {
 	torqueA := cross(rA, impulse)
 	torqueB := cross(rB, rl.Vector3Scale(impulse, -1))
 
-	rbA.AngularVelocity = rl.Vector3Add(rbA.AngularVelocity, rl.Vector3Scale(torqueA, torqueScale/rbA.Mass))
-	rbB.AngularVelocity = rl.Vector3Add(rbB.AngularVelocity, rl.Vector3Scale(torqueB, torqueScale/rbB.Mass))
+	rbA.AngularVelocity = rl.Vector3Add(rbA.AngularVelocity, rl.Vector3Scale(torqueA, torqueScale*rbA.InverseMass()))
+	rbB.AngularVelocity = rl.Vector3Add(rbB.AngularVelocity, rl.Vector3Scale(torqueB, torqueScale*rbB.InverseMass()))
 }
 
 // resolveSphereVsSphere handles collision between two spheres
 func (p *PhysicsWorld) resolveSphereVsSphere(a, b *engine.GameObject, rbA, rbB *components.Rigidbody, sA, sB *components.SphereCollider) {
 	diff := rl.Vector3Subtract(a.Transform.Position, b.Transform.Position)
 	dist := rl.Vector3Length(diff)
-	minDist := sA.Radius + sB.Radius
+	radiusA, radiusB := sA.GetWorldRadius(), sB.GetWorldRadius()
+	minDist := radiusA + radiusB
 
 	if dist >= minDist || dist < 0.0001 {
 		return
 	}
+	p.applySphereContact(a, b, rbA, rbB, radiusA, radiusB, rl.Vector3Scale(diff, 1/dist), minDist-dist)
+}
+
+// resolveSphereContact is resolveSphereVsSphere for a contact computed by the
+// GPU narrow-phase, from the positions the bodies had at the broad-phase
+func (p *PhysicsWorld) resolveSphereContact(a, b *engine.GameObject, c compute.Contact) {
+	rbA := engine.GetComponent[*components.Rigidbody](a)
+	rbB := engine.GetComponent[*components.Rigidbody](b)
+	if c.Depth <= 0 || rbA.IsSleeping && rbB.IsSleeping {
+		return
+	}
+	radiusA := engine.GetComponent[*components.SphereCollider](a).GetWorldRadius()
+	radiusB := engine.GetComponent[*components.SphereCollider](b).GetWorldRadius()
+	p.applySphereContact(a, b, rbA, rbB, radiusA, radiusB, rl.Vector3{X: c.NX, Y: c.NY, Z: c.NZ}, c.Depth)
+}
 
+// applySphereContact separates two touching spheres along normal (pointing
+// from b to a) and applies the collision impulse
+func (p *PhysicsWorld) applySphereContact(a, b *engine.GameObject, rbA, rbB *components.Rigidbody, radiusA, radiusB float32, normal rl.Vector3, penetration float32) {
 	// Record collision for callbacks
 	p.recordCollision(a, b)
 
-	// Collision normal
-	normal := rl.Vector3Scale(diff, 1/dist)
-	penetration := minDist - dist
-
-	// Split push based on mass
-	totalMass := rbA.Mass + rbB.Mass
-	ratioA := rbB.Mass / totalMass
-	ratioB := rbA.Mass / totalMass
+	// Split the positional correction based on mass
+	ratioA, ratioB := pushRatios(rbA, rbB)
+	push := p.correction(penetration)
 
-	a.Transform.Position = rl.Vector3Add(a.Transform.Position, rl.Vector3Scale(normal, penetration*ratioA))
-	b.Transform.Position = rl.Vector3Subtract(b.Transform.Position, rl.Vector3Scale(normal, penetration*ratioB))
+	a.Transform.Position = rl.Vector3Add(a.Transform.Position, rl.Vector3Scale(normal, push*ratioA))
+	b.Transform.Position = rl.Vector3Subtract(b.Transform.Position, rl.Vector3Scale(normal, push*ratioB))
 
 	// Relative velocity
 	relVel := rl.Vector3Subtract(rbA.Velocity, rbB.Velocity)
 	velAlongNormal := rl.Vector3DotProduct(relVel, normal)
 
-	// Static friction and normal force - if objects are nearly at rest and stacked
-	relSpeed := rl.Vector3Length(relVel)
-	if relSpeed < 0.5 && penetration < 0.1 {
-		// Apply strong damping to settle stacked objects
-		friction := (rbA.Friction + rbB.Friction) / 2
-		rbA.Velocity = rl.Vector3Scale(rbA.Velocity, 1.0-friction)
-		rbB.Velocity = rl.Vector3Scale(rbB.Velocity, 1.0-friction)
-
-		// Apply normal force to counter gravity if contact normal points upward
-		// This prevents slow sinking through stacked objects
-		if normal.Y > 0.5 { // Contact points upward (B supports A)
-			// A is on top of B - apply upward normal force to A
-			normalForce := rl.Vector3Scale(rl.Vector3{X: 0, Y: 1, Z: 0}, -p.Gravity.Y*rbA.Mass)
-			if existing, ok := p.normalForces[a]; ok {
-				p.normalForces[a] = rl.Vector3Add(existing, normalForce)
-			} else {
-				p.normalForces[a] = normalForce
-			}
-		} else if normal.Y < -0.5 { // Contact points downward (A supports B)
-			// B is on top of A - apply upward normal force to B
-			normalForce := rl.Vector3Scale(rl.Vector3{X: 0, Y: 1, Z: 0}, -p.Gravity.Y*rbB.Mass)
-			if existing, ok := p.normalForces[b]; ok {
-				p.normalForces[b] = rl.Vector3Add(existing, normalForce)
-			} else {
-				p.normalForces[b] = normalForce
-			}
-		}
-
-		// If velocity is very low, zero it out to stop jitter
-		if rl.Vector3Length(rbA.Velocity) < 0.1 {
-			rbA.Velocity = rl.Vector3{}
-		}
-		if rl.Vector3Length(rbB.Velocity) < 0.1 {
-			rbB.Velocity = rl.Vector3{}
-		}
-		return
-	}
-
 	if velAlongNormal > 0 {
 		return
 	}
 
 	// Restitution
-	e := (rbA.Bounciness + rbB.Bounciness) / 2
+	e := restitution((rbA.Bounciness+rbB.Bounciness)/2, velAlongNormal)
 
 	// Impulse
 	j := -(1 + e) * velAlongNormal
-	j /= (1/rbA.Mass + 1/rbB.Mass)
+	invMassSum := rbA.InverseMass() + rbB.InverseMass()
+	if invMassSum == 0 {
+		return // both immovable
+	}
+	j /= invMassSum
 
 	impulse := rl.Vector3Scale(normal, j)
-	rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, 1/rbA.Mass))
-	rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, 1/rbB.Mass))
+	rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, rbA.InverseMass()))
+	rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, rbB.InverseMass()))
+	applyContactFriction(rbA, rbB, normal)
 
 	// Torque for spheres - contact point is on surface along normal
-	rA := rl.Vector3Scale(normal, -sA.Radius)
-	rB := rl.Vector3Scale(normal, sB.Radius)
+	rA := rl.Vector3Scale(normal, -radiusA)
+	rB := rl.Vector3Scale(normal, radiusB)
 
 	torqueScale := float32(50.0)
 	torqueA := cross(rA, impulse)
 	torqueB := cross(rB, rl.Vector3Scale(impulse, -1))
 
-	rbA.AngularVelocity = rl.Vector3Add(rbA.AngularVelocity, rl.Vector3Scale(torqueA, torqueScale/rbA.Mass))
-	rbB.AngularVelocity = rl.Vector3Add(rbB.AngularVelocity, rl.Vector3Scale(torqueB, torqueScale/rbB.Mass))
+	rbA.AngularVelocity = rl.Vector3Add(rbA.AngularVelocity, rl.Vector3Scale(torqueA, torqueScale*rbA.InverseMass()))
+	rbB.AngularVelocity = rl.Vector3Add(rbB.AngularVelocity, rl.Vector3Scale(torqueB, torqueScale*rbB.InverseMass()))
 }
 
 // resolveSphereVsBox handles collision between a sphere and a box (supports rotated boxes via OBB)
 func (p *PhysicsWorld) resolveSphereVsBox(sphereObj, boxObj *engine.GameObject, rbSphere, rbBox *components.Rigidbody, sphere *components.SphereCollider, box *components.BoxCollider) {
 	sphereCenter := sphereObj.Transform.Position
-	obb := NewOBBFromBox(box.GetCenter(), box.Size, boxObj.WorldRotation(), boxObj.WorldScale())
+	obb := NewOBBFromBox(box.GetCenter(), box.Size, boxObj.WorldQuaternion(), boxObj.WorldScale())
 
 	// Find closest point on OBB to sphere center
 	closest := ClosestPointOnOBB(obb, sphereCenter)
 
 	diff := rl.Vector3Subtract(sphereCenter, closest)
 	dist := rl.Vector3Length(diff)
+	radius := sphere.GetWorldRadius()
 
-	if dist >= sphere.Radius || dist < 0.0001 {
+	if dist >= radius || dist < 0.0001 {
 		return
 	}
 
@@ -224,15 +222,14 @@ func (p *PhysicsWorld) resolveSphereVsBox(sphereObj, boxObj *engine.GameObject,
 
 	// Normal points from box to sphere
 	normal := rl.Vector3Scale(diff, 1/dist)
-	penetration := sphere.Radius - dist
+	penetration := radius - dist
 
-	// Split push based on mass
-	totalMass := rbSphere.Mass + rbBox.Mass
-	ratioSphere := rbBox.Mass / totalMass
-	ratioBox := rbSphere.Mass / totalMass
+	// Split the positional correction based on mass
+	ratioSphere, ratioBox := pushRatios(rbSphere, rbBox)
+	push := p.correction(penetration)
 
-	sphereObj.Transform.Position = rl.Vector3Add(sphereObj.Transform.Position, rl.Vector3Scale(normal, penetration*ratioSphere))
-	boxObj.Transform.Position = rl.Vector3Subtract(boxObj.Transform.Position, rl.Vector3Scale(normal, penetration*ratioBox))
+	sphereObj.Transform.Position = rl.Vector3Add(sphereObj.Transform.Position, rl.Vector3Scale(normal, push*ratioSphere))
+	boxObj.Transform.Position = rl.Vector3Subtract(boxObj.Transform.Position, rl.Vector3Scale(normal, push*ratioBox))
 
 	// Relative velocity
 	relVel := rl.Vector3Subtract(rbSphere.Velocity, rbBox.Velocity)
@@ -243,21 +240,25 @@ func (p *PhysicsWorld) resolveSphereVsBox(sphereObj, boxObj *engine.GameObject,
 	}
 
 	// Restitution
-	e := (rbSphere.Bounciness + rbBox.Bounciness) / 2
+	e := restitution((rbSphere.Bounciness+rbBox.Bounciness)/2, velAlongNormal)
 
 	// Impulse
 	j := -(1 + e) * velAlongNormal
-	j /= (1/rbSphere.Mass + 1/rbBox.Mass)
+	invMassSum := rbSphere.InverseMass() + rbBox.InverseMass()
+	if invMassSum == 0 {
+		return // both immovable
+	}
+	j /= invMassSum
 
 	impulse := rl.Vector3Scale(normal, j)
-	rbSphere.Velocity = rl.Vector3Add(rbSphere.Velocity, rl.Vector3Scale(impulse, 1/rbSphere.Mass))
-	rbBox.Velocity = rl.Vector3Subtract(rbBox.Velocity, rl.Vector3Scale(impulse, 1/rbBox.Mass))
+	rbSphere.Velocity = rl.Vector3Add(rbSphere.Velocity, rl.Vector3Scale(impulse, rbSphere.InverseMass()))
+	rbBox.Velocity = rl.Vector3Subtract(rbBox.Velocity, rl.Vector3Scale(impulse, rbBox.InverseMass()))
 
 	// Torque only for spheres (AABB boxes don't rotate)
-	rSphere := rl.Vector3Scale(normal, -sphere.Radius)
+	rSphere := rl.Vector3Scale(normal, -radius)
 	torqueScale := float32(50.0)
 	torqueSphere := cross(rSphere, impulse)
-	rbSphere.AngularVelocity = rl.Vector3Add(rbSphere.AngularVelocity, rl.Vector3Scale(torqueSphere, torqueScale/rbSphere.Mass))
+	rbSphere.AngularVelocity = rl.Vector3Add(rbSphere.AngularVelocity, rl.Vector3Scale(torqueSphere, torqueScale*rbSphere.InverseMass()))
 }
 
 // resolveStaticCollision handles dynamic object colliding with static object
@@ -266,6 +267,18 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 	if rb == nil {
 		return
 	}
+	if IsTrigger(obj) || IsTrigger(static) {
+		p.detectTrigger(obj, static)
+		return
+	}
+	if rb.IsImmovable() {
+		return
+	}
+
+	if hasCapsule(obj) || hasCapsule(static) {
+		p.resolveCapsuleStaticCollision(obj, static, rb)
+		return
+	}
 
 	// Check for sphere collider
 	sphere := engine.GetComponent[*components.SphereCollider](obj)
@@ -282,8 +295,8 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 		return
 	}
 
-	obbObj := NewOBBFromBox(colObj.GetCenter(), colObj.Size, obj.WorldRotation(), obj.WorldScale())
-	obbStatic := NewOBBFromBox(colStatic.GetCenter(), colStatic.Size, static.WorldRotation(), static.WorldScale())
+	obbObj := NewOBBFromBox(colObj.GetCenter(), colObj.Size, obj.WorldQuaternion(), obj.WorldScale())
+	obbStatic := NewOBBFromBox(colStatic.GetCenter(), colStatic.Size, static.WorldQuaternion(), static.WorldScale())
 
 	pushOut := obbObj.ResolveOBB(obbStatic)
 	if pushOut.X == 0 && pushOut.Y == 0 && pushOut.Z == 0 {
@@ -293,8 +306,8 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 	// Record collision for callbacks
 	p.recordCollision(obj, static)
 
-	// Push fully out (static doesn't move)
-	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, pushOut)
+	// Positional correction (static doesn't move)
+	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, p.correctionVec(pushOut))
 
 	// Reflect velocity
 	pushLen := rl.Vector3Length(pushOut)
@@ -305,8 +318,8 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 
 	velAlongNormal := rl.Vector3DotProduct(rb.Velocity, normal)
 	if velAlongNormal < 0 {
-		// Reflect and apply bounciness
-		reflect := rl.Vector3Scale(normal, -2*velAlongNormal*rb.Bounciness)
+		// Stop the approach and bounce back by the bounciness
+		reflect := rl.Vector3Scale(normal, -(1+restitution(rb.Bounciness, velAlongNormal))*velAlongNormal)
 		rb.Velocity = rl.Vector3Add(rb.Velocity, reflect)
 
 		// Apply friction perpendicular to normal
@@ -319,7 +332,7 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 		torque := cross(r, reflect)
 		// Convert to degrees and scale up significantly
 		torqueScale := float32(500.0) // Much higher to make rotation visible
-		rb.AngularVelocity = rl.Vector3Add(rb.AngularVelocity, rl.Vector3Scale(torque, torqueScale/rb.Mass))
+		rb.AngularVelocity = rl.Vector3Add(rb.AngularVelocity, rl.Vector3Scale(torque, torqueScale*rb.InverseMass()))
 
 		// Friction on angular velocity when on ground
 		if normal.Y > 0.5 {
@@ -332,15 +345,16 @@ func (p *PhysicsWorld) resolveStaticCollision(obj, static *engine.GameObject) {
 // resolveSphereVsStaticBox handles sphere colliding with static box (floor, walls)
 func (p *PhysicsWorld) resolveSphereVsStaticBox(obj, static *engine.GameObject, rb *components.Rigidbody, sphere *components.SphereCollider, box *components.BoxCollider) {
 	sphereCenter := obj.Transform.Position
-	obb := NewOBBFromBox(box.GetCenter(), box.Size, static.WorldRotation(), static.WorldScale())
+	obb := NewOBBFromBox(box.GetCenter(), box.Size, static.WorldQuaternion(), static.WorldScale())
 
 	// Find closest point on OBB to sphere center
 	closest := ClosestPointOnOBB(obb, sphereCenter)
 
 	diff := rl.Vector3Subtract(sphereCenter, closest)
 	dist := rl.Vector3Length(diff)
+	radius := sphere.GetWorldRadius()
 
-	if dist >= sphere.Radius || dist < 0.0001 {
+	if dist >= radius || dist < 0.0001 {
 		return
 	}
 
@@ -349,15 +363,15 @@ func (p *PhysicsWorld) resolveSphereVsStaticBox(obj, static *engine.GameObject,
 
 	// Normal points from box to sphere
 	normal := rl.Vector3Scale(diff, 1/dist)
-	penetration := sphere.Radius - dist
+	penetration := radius - dist
 
-	// Push sphere out
-	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, rl.Vector3Scale(normal, penetration))
+	// Positional correction
+	obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, rl.Vector3Scale(normal, p.correction(penetration)))
 
-	// Reflect velocity
+	// Stop the approach and bounce back by the bounciness
 	velAlongNormal := rl.Vector3DotProduct(rb.Velocity, normal)
 	if velAlongNormal < 0 {
-		reflect := rl.Vector3Scale(normal, -2*velAlongNormal*rb.Bounciness)
+		reflect := rl.Vector3Scale(normal, -(1+restitution(rb.Bounciness, velAlongNormal))*velAlongNormal)
 		rb.Velocity = rl.Vector3Add(rb.Velocity, reflect)
 
 		// Apply friction
@@ -365,10 +379,10 @@ func (p *PhysicsWorld) resolveSphereVsStaticBox(obj, static *engine.GameObject,
 		rb.Velocity.Z *= (1 - rb.Friction)
 
 		// Apply torque - contact point is on sphere surface
-		r := rl.Vector3Scale(normal, -sphere.Radius)
+		r := rl.Vector3Scale(normal, -radius)
 		torque := cross(r, reflect)
 		torqueScale := float32(30.0)
-		rb.AngularVelocity = rl.Vector3Add(rb.AngularVelocity, rl.Vector3Scale(torque, torqueScale/rb.Mass))
+		rb.AngularVelocity = rl.Vector3Add(rb.AngularVelocity, rl.Vector3Scale(torque, torqueScale*rb.InverseMass()))
 
 		// Friction on angular velocity when on ground
 		if normal.Y > 0.5 {
@@ -382,15 +396,23 @@ func (p *PhysicsWorld) resolveSphereVsStaticBox(obj, static *engine.GameObject,
 func (p *PhysicsWorld) resolveKinematicCollision(kinematic, obj *engine.GameObject) {
 	rbKin := engine.GetComponent[*components.Rigidbody](kinematic)
 	rbObj := engine.GetComponent[*components.Rigidbody](obj)
+	if IsTrigger(kinematic) || IsTrigger(obj) {
+		p.detectTrigger(kinematic, obj)
+		return
+	}
+	if rbKin != nil && rbObj != nil && !rbObj.IsImmovable() && (hasCapsule(kinematic) || hasCapsule(obj)) {
+		p.resolveCapsuleKinematicCollision(kinematic, obj, rbKin, rbObj)
+		return
+	}
 	colKin := engine.GetComponent[*components.BoxCollider](kinematic)
 	colObj := engine.GetComponent[*components.BoxCollider](obj)
 
-	if rbKin == nil || rbObj == nil || colKin == nil || colObj == nil {
+	if rbKin == nil || rbObj == nil || colKin == nil || colObj == nil || rbObj.IsImmovable() {
 		return
 	}
 
-	obbKin := NewOBBFromBox(colKin.GetCenter(), colKin.Size, kinematic.WorldRotation(), kinematic.WorldScale())
-	obbObj := NewOBBFromBox(colObj.GetCenter(), colObj.Size, obj.WorldRotation(), obj.WorldScale())
+	obbKin := NewOBBFromBox(colKin.GetCenter(), colKin.Size, kinematic.WorldQuaternion(), kinematic.WorldScale())
+	obbObj := NewOBBFromBox(colObj.GetCenter(), colObj.Size, obj.WorldQuaternion(), obj.WorldScale())
 
 	pushOut := obbKin.ResolveOBB(obbObj)
 	if pushOut.X == 0 && pushOut.Y == 0 && pushOut.Z == 0 {
@@ -425,6 +447,15 @@ const MaxStepHeight = 0.5
 // resolveKinematicStaticCollision handles kinematic objects (player) colliding with static objects (walls)
 // Includes stair/step climbing support
 func (p *PhysicsWorld) resolveKinematicStaticCollision(kinematic, static *engine.GameObject) {
+	if IsTrigger(kinematic) || IsTrigger(static) {
+		p.detectTrigger(kinematic, static)
+		return
+	}
+	if hasCapsule(kinematic) || hasCapsule(static) {
+		p.resolveCapsuleKinematicStaticCollision(kinematic, static)
+		return
+	}
+
 	colKin := engine.GetComponent[*components.BoxCollider](kinematic)
 	colStatic := engine.GetComponent[*components.BoxCollider](static)
 
@@ -432,8 +463,8 @@ func (p *PhysicsWorld) resolveKinematicStaticCollision(kinematic, static *engine
 		return
 	}
 
-	obbKin := NewOBBFromBox(colKin.GetCenter(), colKin.Size, kinematic.WorldRotation(), kinematic.WorldScale())
-	obbStatic := NewOBBFromBox(colStatic.GetCenter(), colStatic.Size, static.WorldRotation(), static.WorldScale())
+	obbKin := NewOBBFromBox(colKin.GetCenter(), colKin.Size, kinematic.WorldQuaternion(), kinematic.WorldScale())
+	obbStatic := NewOBBFromBox(colStatic.GetCenter(), colStatic.Size, static.WorldQuaternion(), static.WorldScale())
 
 	pushOut := obbKin.ResolveOBB(obbStatic)
 	if pushOut.X == 0 && pushOut.Y == 0 && pushOut.Z == 0 {
@@ -467,7 +498,7 @@ func (p *PhysicsWorld) resolveKinematicStaticCollision(kinematic, static *engine
 					Z: colKin.GetCenter().Z,
 				},
 				colKin.Size,
-				kinematic.WorldRotation(),
+				kinematic.WorldQuaternion(),
 				kinematic.WorldScale(),
 			)
 
@@ -488,15 +519,21 @@ func (p *PhysicsWorld) resolveKinematicStaticCollision(kinematic, static *engine
 // resolveKinematicMeshCollision handles kinematic objects (player) colliding with mesh colliders
 func (p *PhysicsWorld) resolveKinematicMeshCollision(kinematic, static *engine.GameObject) {
 	meshCol := engine.GetComponent[*components.MeshCollider](static)
-	if meshCol == nil || !meshCol.IsBuilt() {
+	if meshCol == nil || !meshCol.IsBuilt() || IsTrigger(kinematic) {
 		return
 	}
 
-	// Get the kinematic's collider - try box first, then sphere
+	// Get the kinematic's collider - capsule, then box, then sphere
+	capsuleCol := engine.GetComponent[*components.CapsuleCollider](kinematic)
 	boxCol := engine.GetComponent[*components.BoxCollider](kinematic)
 	sphereCol := engine.GetComponent[*components.SphereCollider](kinematic)
 
-	if boxCol != nil {
+	if capsuleCol != nil {
+		if hit, push := capsuleMeshPush(meshCol, capsuleCol); hit {
+			p.recordCollision(kinematic, static)
+			kinematic.Transform.Position = rl.Vector3Add(kinematic.Transform.Position, push)
+		}
+	} else if boxCol != nil {
 		// Approximate box as sphere for mesh collision
 		center := boxCol.GetCenter()
 		size := boxCol.GetWorldSize()
@@ -516,7 +553,7 @@ func (p *PhysicsWorld) resolveKinematicMeshCollision(kinematic, static *engine.G
 		}
 	} else if sphereCol != nil {
 		center := sphereCol.GetCenter()
-		radius := sphereCol.Radius
+		radius := sphereCol.GetWorldRadius()
 
 		if hit, push := meshCol.SphereIntersect(center, radius); hit {
 			p.recordCollision(kinematic, static)
@@ -533,20 +570,25 @@ func (p *PhysicsWorld) resolveDynamicMeshCollision(obj, static *engine.GameObjec
 	}
 
 	rb := engine.GetComponent[*components.Rigidbody](obj)
-	if rb == nil {
+	if rb == nil || rb.IsImmovable() || IsTrigger(obj) {
 		return
 	}
 
 	// Get the object's collider
+	capsuleCol := engine.GetComponent[*components.CapsuleCollider](obj)
 	sphereCol := engine.GetComponent[*components.SphereCollider](obj)
 	boxCol := engine.GetComponent[*components.BoxCollider](obj)
 
 	var center rl.Vector3
 	var radius float32
+	var hit bool
+	var push rl.Vector3
 
-	if sphereCol != nil {
+	if capsuleCol != nil {
+		hit, push = capsuleMeshPush(meshCol, capsuleCol)
+	} else if sphereCol != nil {
 		center = sphereCol.GetCenter()
-		radius = sphereCol.Radius
+		radius = sphereCol.GetWorldRadius()
 	} else if boxCol != nil {
 		center = boxCol.GetCenter()
 		size := boxCol.GetWorldSize()
@@ -561,21 +603,23 @@ func (p *PhysicsWorld) resolveDynamicMeshCollision(obj, static *engine.GameObjec
 	} else {
 		return
 	}
+	if capsuleCol == nil {
+		hit, push = meshCol.SphereIntersect(center, radius)
+	}
 
-	if hit, push := meshCol.SphereIntersect(center, radius); hit {
+	if hit {
 		p.recordCollision(obj, static)
 
-		// Push out
-		obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, push)
+		// Positional correction
+		obj.Transform.Position = rl.Vector3Add(obj.Transform.Position, p.correctionVec(push))
 
-		// Reflect velocity
+		// Stop the approach and bounce back by the bounciness
 		pushLen := rl.Vector3Length(push)
 		if pushLen > 0.0001 {
 			normal := rl.Vector3Scale(push, 1.0/pushLen)
 			dot := rl.Vector3DotProduct(rb.Velocity, normal)
 			if dot < 0 {
-				// Reflect with bounciness
-				reflect := rl.Vector3Scale(normal, -2*dot*rb.Bounciness)
+				reflect := rl.Vector3Scale(normal, -(1+restitution(rb.Bounciness, dot))*dot)
 				rb.Velocity = rl.Vector3Add(rb.Velocity, reflect)
 				// Apply friction
 				rb.Velocity = rl.Vector3Scale(rb.Velocity, 1.0-rb.Friction)
@@ -0,0 +1,56 @@
+package physics
+
+import (
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GridCellBounds returns the world-space box covered by a spatial grid cell.
+// Cells come from truncating position/CellSize toward zero, so cell 0 on each
+// axis spans (-CellSize, CellSize) and is twice as wide as the others.
+func GridCellBounds(key CellKey) AABB {
+	axis := func(c int) (float32, float32) {
+		switch {
+		case c > 0:
+			return float32(c) * CellSize, float32(c+1) * CellSize
+		case c < 0:
+			return float32(c-1) * CellSize, float32(c) * CellSize
+		default:
+			return -CellSize, CellSize
+		}
+	}
+	minX, maxX := axis(key.X)
+	minY, maxY := axis(key.Y)
+	minZ, maxZ := axis(key.Z)
+	return AABB{
+		Min: rl.Vector3{X: minX, Y: minY, Z: minZ},
+		Max: rl.Vector3{X: maxX, Y: maxY, Z: maxZ},
+	}
+}
+
+// GridOccupancy returns the number of dynamic objects in each occupied grid cell,
+// using current positions. For debug visualization.
+func (p *PhysicsWorld) GridOccupancy() map[CellKey]int {
+	p.rebuildGrid()
+	counts := make(map[CellKey]int, len(p.grid))
+	for key, objs := range p.grid {
+		counts[key] = len(objs)
+	}
+	return counts
+}
+
+// NeighborCells returns the grid cells the broad-phase checks for an object
+// (every cell its collider bounds span). Returns nil for objects too large
+// for the grid, which are checked against everything.
+func (p *PhysicsWorld) NeighborCells(obj *engine.GameObject) []CellKey {
+	span := objectCellSpan(obj)
+	if span.count > maxObjectCells {
+		return nil
+	}
+	cells := make([]CellKey, 0, span.count)
+	span.each(func(key CellKey) {
+		cells = append(cells, key)
+	})
+	return cells
+}
@@ -0,0 +1,53 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	groundProbeSkin     = 0.05 // rays start this far above the feet
+	groundProbeDistance = 0.1  // and reach this far below them
+)
+
+// updateGrounding runs the ground check for every CharacterController at the
+// end of each step. It is the only thing that sets IsGrounded/GroundNormal,
+// so scripts see one result per step whichever way the character moved.
+func (p *PhysicsWorld) updateGrounding() {
+	for _, list := range [][]*engine.GameObject{p.Statics, p.Kinematics, p.Objects} {
+		for _, g := range list {
+			if !g.Active {
+				continue
+			}
+			cc := engine.GetComponent[*components.CharacterController](g)
+			if cc == nil {
+				continue
+			}
+			hit, ok := p.probeGround(g, cc)
+			cc.SetGround(ok, hit.Normal)
+		}
+	}
+}
+
+// probeGround casts short rays down from the center and four edges of the
+// character's feet and returns the closest hit
+func (p *PhysicsWorld) probeGround(g *engine.GameObject, cc *components.CharacterController) (RaycastHit, bool) {
+	feet := g.Transform.Position
+	feet.Y -= cc.Height/2 - groundProbeSkin
+	r := cc.Radius * 0.9
+	offsets := []rl.Vector3{{}, {X: r}, {X: -r}, {Z: r}, {Z: -r}}
+
+	var closest RaycastHit
+	found := false
+	down := rl.Vector3{Y: -1}
+	for _, off := range offsets {
+		hit, ok := p.RaycastIgnoring(rl.Vector3Add(feet, off), down, groundProbeSkin+groundProbeDistance, g)
+		if ok && (!found || hit.Distance < closest.Distance) {
+			closest = hit
+			found = true
+		}
+	}
+	return closest, found
+}
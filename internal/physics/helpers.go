@@ -27,6 +27,63 @@ func clamp(v, min, max float32) float32 {
 	return v
 }
 
+// pushRatios returns how much of a separating push each of two rigidbodies takes,
+// split by inverse mass. Immovable (mass <= 0) bodies take none of it.
+func pushRatios(rbA, rbB *components.Rigidbody) (float32, float32) {
+	invA, invB := rbA.InverseMass(), rbB.InverseMass()
+	invSum := invA + invB
+	if invSum == 0 {
+		return 0, 0
+	}
+	return invA / invSum, invB / invSum
+}
+
+// restitutionThreshold is the approach speed below which contacts don't
+// bounce, so resting bodies settle instead of hopping
+const restitutionThreshold = 1.0
+
+// restitution returns the bounciness to use for a contact approaching at
+// velAlongNormal (negative when closing)
+func restitution(bounciness, velAlongNormal float32) float32 {
+	if -velAlongNormal < restitutionThreshold {
+		return 0
+	}
+	return bounciness
+}
+
+// correction returns how far to push two bodies apart for a penetration depth:
+// Baumgarte of the depth beyond the allowed slop
+func (p *PhysicsWorld) correction(penetration float32) float32 {
+	if penetration <= p.Slop {
+		return 0
+	}
+	return (penetration - p.Slop) * p.Baumgarte
+}
+
+// correctionVec scales a full push-out vector down to the corrective push
+func (p *PhysicsWorld) correctionVec(pushOut rl.Vector3) rl.Vector3 {
+	depth := rl.Vector3Length(pushOut)
+	if depth < 0.0001 {
+		return rl.Vector3{}
+	}
+	return rl.Vector3Scale(pushOut, p.correction(depth)/depth)
+}
+
+// applyContactFriction removes part of the sliding velocity between two
+// touching bodies, split by inverse mass
+func applyContactFriction(rbA, rbB *components.Rigidbody, normal rl.Vector3) {
+	invA, invB := rbA.InverseMass(), rbB.InverseMass()
+	invSum := invA + invB
+	if invSum == 0 {
+		return
+	}
+	relVel := rl.Vector3Subtract(rbA.Velocity, rbB.Velocity)
+	tangent := rl.Vector3Subtract(relVel, rl.Vector3Scale(normal, rl.Vector3DotProduct(relVel, normal)))
+	dv := rl.Vector3Scale(tangent, (rbA.Friction+rbB.Friction)/2)
+	rbA.Velocity = rl.Vector3Subtract(rbA.Velocity, rl.Vector3Scale(dv, invA/invSum))
+	rbB.Velocity = rl.Vector3Add(rbB.Velocity, rl.Vector3Scale(dv, invB/invSum))
+}
+
 // estimateContactPoint estimates the contact point on an object's surface given a push direction
 func estimateContactPoint(center rl.Vector3, halfSize rl.Vector3, pushDir rl.Vector3) rl.Vector3 {
 	// Contact is on the face in the direction of the push
@@ -49,7 +106,7 @@ func applyBoxFlatteningTorque(obj *engine.GameObject, rb *components.Rigidbody,
 		return // Not grounded (falling or jumping)
 	}
 
-	quat := obj.Transform.GetQuaternion()
+	quat := obj.WorldQuaternion()
 	worldUp := rl.Vector3{X: 0, Y: 1, Z: 0}
 
 	// Box's 6 face normals in local space
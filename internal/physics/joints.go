@@ -0,0 +1,73 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// applyJoints pushes the ends of every spring joint toward their rest length.
+// Forces are applied to linear velocity only; integration moves the bodies.
+func (p *PhysicsWorld) applyJoints(deltaTime float32) {
+	for _, list := range [][]*engine.GameObject{p.Objects, p.Kinematics, p.Statics} {
+		for _, obj := range list {
+			joint := engine.GetComponent[*components.Joint](obj)
+			if joint == nil {
+				continue
+			}
+			other := joint.ConnectedObject()
+			if other == nil || other == obj {
+				continue
+			}
+			p.applySpring(joint, obj, other, deltaTime)
+		}
+	}
+}
+
+// applySpring applies a damped spring force between two joint ends
+func (p *PhysicsWorld) applySpring(joint *components.Joint, a, b *engine.GameObject, deltaTime float32) {
+	rbA := movableBody(a)
+	rbB := movableBody(b)
+	if rbA == nil && rbB == nil {
+		return
+	}
+
+	anchorA := joint.WorldAnchor()
+	anchorB := joint.ConnectedWorldAnchor()
+	delta := rl.Vector3Subtract(anchorB, anchorA)
+	length := rl.Vector3Length(delta)
+	if length < 0.0001 {
+		return
+	}
+	dir := rl.Vector3Scale(delta, 1/length)
+
+	// Relative velocity along the spring (positive = ends moving apart)
+	var velA, velB rl.Vector3
+	if rbA != nil {
+		velA = rbA.Velocity
+	}
+	if rbB != nil {
+		velB = rbB.Velocity
+	}
+	stretchSpeed := rl.Vector3DotProduct(rl.Vector3Subtract(velB, velA), dir)
+
+	force := joint.Stiffness*(length-joint.RestLength) + joint.Damping*stretchSpeed
+	impulse := rl.Vector3Scale(dir, force*deltaTime)
+
+	if rbA != nil {
+		rbA.Velocity = rl.Vector3Add(rbA.Velocity, rl.Vector3Scale(impulse, rbA.InverseMass()))
+	}
+	if rbB != nil {
+		rbB.Velocity = rl.Vector3Subtract(rbB.Velocity, rl.Vector3Scale(impulse, rbB.InverseMass()))
+	}
+}
+
+// movableBody returns the object's rigidbody if physics can move it
+func movableBody(g *engine.GameObject) *components.Rigidbody {
+	rb := engine.GetComponent[*components.Rigidbody](g)
+	if rb == nil || rb.IsKinematic || rb.IsImmovable() {
+		return nil
+	}
+	return rb
+}
@@ -13,18 +13,10 @@ type OBB struct {
 	Axes     [3]rl.Vector3 // Local X, Y, Z axes (rotated)
 }
 
-// NewOBB creates an OBB from center, size, and euler rotation (degrees)
-func NewOBB(center, size, rotation rl.Vector3) OBB {
-	// Convert to radians
-	rx := float64(rotation.X) * math.Pi / 180
-	ry := float64(rotation.Y) * math.Pi / 180
-	rz := float64(rotation.Z) * math.Pi / 180
-
-	// Build rotation matrix (same order as your engine: X, Y, Z)
-	rotX := rl.MatrixRotateX(float32(rx))
-	rotY := rl.MatrixRotateY(float32(ry))
-	rotZ := rl.MatrixRotateZ(float32(rz))
-	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+// NewOBB creates an OBB from center, size, and rotation (such as
+// GameObject.WorldQuaternion)
+func NewOBB(center, size rl.Vector3, rotation rl.Quaternion) OBB {
+	rotMatrix := rl.QuaternionToMatrix(rotation)
 
 	// Extract rotated axes
 	axes := [3]rl.Vector3{
@@ -235,7 +227,7 @@ func clampf(v, min, max float32) float32 {
 
 // NewOBBFromBox creates an OBB from center, size, rotation, and scale
 // This is a convenience function to avoid import cycles
-func NewOBBFromBox(center, size, rotation, scale rl.Vector3) OBB {
+func NewOBBFromBox(center, size rl.Vector3, rotation rl.Quaternion, scale rl.Vector3) OBB {
 	// Apply scale to size
 	scaledSize := rl.Vector3{
 		X: size.X * scale.X,
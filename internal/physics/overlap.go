@@ -0,0 +1,148 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// overlapQuery is a query volume: its bounds for the grid lookup and a test
+// against each kind of collider
+type overlapQuery struct {
+	bounds  AABB
+	box     func(obb OBB) bool
+	sphere  func(center rl.Vector3, radius float32) bool
+	capsule func(a, b rl.Vector3, radius float32) bool
+	mesh    func(mesh *components.MeshCollider) bool
+}
+
+// OverlapSphere returns every object whose collider overlaps the sphere, from
+// dynamics, kinematics and statics alike. Triggers are included, objects
+// without a collider are not. Mesh colliders are tested at the transform they
+// were last built with.
+func (p *PhysicsWorld) OverlapSphere(center rl.Vector3, radius float32) []*engine.GameObject {
+	radius = absf(radius)
+	d := radius * 2
+	return p.overlap(overlapQuery{
+		bounds: NewAABBFromCenter(center, rl.Vector3{X: d, Y: d, Z: d}),
+		box: func(obb OBB) bool {
+			gap, _, _ := sphereOBBGap(center, radius, obb)
+			return gap <= 0
+		},
+		sphere: func(c rl.Vector3, r float32) bool {
+			return rl.Vector3Distance(center, c) <= radius+r
+		},
+		capsule: func(a, b rl.Vector3, r float32) bool {
+			gap, _, _ := capsuleGap(center, radius, a, b, r)
+			return gap <= 0
+		},
+		mesh: func(mesh *components.MeshCollider) bool {
+			hit, _ := mesh.SphereIntersect(center, radius)
+			return hit
+		},
+	})
+}
+
+// OverlapBox returns every object whose collider overlaps the box with the
+// given half extents, rotated by rot (Euler degrees, like Transform.Rotation).
+// See OverlapSphere for what is included.
+func (p *PhysicsWorld) OverlapBox(center, halfExtents, rot rl.Vector3) []*engine.GameObject {
+	halfExtents = rl.Vector3{X: absf(halfExtents.X), Y: absf(halfExtents.Y), Z: absf(halfExtents.Z)}
+	rotation := rl.QuaternionFromEuler(rot.X*rl.Deg2rad, rot.Y*rl.Deg2rad, rot.Z*rl.Deg2rad)
+	return p.overlap(boxQuery(NewOBB(center, rl.Vector3Scale(halfExtents, 2), rotation)))
+}
+
+// boxQuery is the overlapQuery for an oriented box
+func boxQuery(query OBB) overlapQuery {
+	// Half-diagonal covers any rotation
+	d := rl.Vector3Length(query.HalfSize) * 2
+	return overlapQuery{
+		bounds: NewAABBFromCenter(query.Center, rl.Vector3{X: d, Y: d, Z: d}),
+		box: func(obb OBB) bool {
+			gap, _, _ := obbOBBGap(query, obb)
+			return gap <= 0
+		},
+		sphere: func(c rl.Vector3, r float32) bool {
+			gap, _, _ := sphereOBBGap(c, r, query)
+			return gap <= 0
+		},
+		capsule: func(a, b rl.Vector3, r float32) bool {
+			// The capsule is a sphere at the point of its segment nearest the box
+			gap, _, _ := sphereOBBGap(closestPointOnSegmentToOBB(a, b, query), r, query)
+			return gap <= 0
+		},
+		mesh: func(mesh *components.MeshCollider) bool {
+			return meshOverlapsShape(mesh, obbShape(query), 0)
+		},
+	}
+}
+
+// overlap returns the candidates from the grids whose colliders pass q's tests
+func (p *PhysicsWorld) overlap(q overlapQuery) []*engine.GameObject {
+	var result []*engine.GameObject
+	for _, obj := range p.overlapCandidates(q.bounds) {
+		if q.overlaps(obj) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// overlapCandidates returns each object whose grid cells meet bounds once:
+// dynamics from the dynamic grid, statics from the static grid, plus every
+// kinematic (there are few and they move every frame) and every object too
+// big for the grids
+func (p *PhysicsWorld) overlapCandidates(bounds AABB) []*engine.GameObject {
+	span := cellRange(bounds)
+	if span.count > maxObjectCells {
+		return p.collidableObjects() // huge query, cheaper to test everything
+	}
+
+	// The grids are brought up to date by the first query after a step and
+	// shared by the rest, so objects a script moves in between are looked up
+	// in the cells they were in at that query
+	if !p.gridFresh {
+		p.rebuildGrid()
+		p.refreshStaticGrid()
+	} else if p.staticsDirty {
+		p.rebuildStaticGrid()
+	}
+
+	seen := make(map[*engine.GameObject]bool)
+	var result []*engine.GameObject
+	add := func(objs []*engine.GameObject) {
+		for _, obj := range objs {
+			if !seen[obj] {
+				seen[obj] = true
+				result = append(result, obj)
+			}
+		}
+	}
+	add(p.largeObjects)
+	add(p.Kinematics)
+	add(p.largeStatics)
+	span.each(func(key CellKey) {
+		add(p.grid[key])
+		add(p.staticGrid[key])
+	})
+	return result
+}
+
+// overlaps reports whether any of obj's colliders passes q's test
+func (q overlapQuery) overlaps(obj *engine.GameObject) bool {
+	if box := engine.GetComponent[*components.BoxCollider](obj); box != nil && q.box(boxColliderOBB(obj, box)) {
+		return true
+	}
+	if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil && q.sphere(sphere.GetCenter(), sphere.GetWorldRadius()) {
+		return true
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+		a, b := capsule.Segment()
+		if q.capsule(a, b, capsule.GetWorldRadius()) {
+			return true
+		}
+	}
+	mesh := engine.GetComponent[*components.MeshCollider](obj)
+	return mesh != nil && mesh.IsBuilt() && q.mesh(mesh)
+}
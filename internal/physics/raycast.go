@@ -1,7 +1,9 @@
 package physics
 
 import (
+	"cmp"
 	"math"
+	"slices"
 	"test3d/internal/components"
 	"test3d/internal/engine"
 
@@ -17,43 +19,79 @@ type RaycastHit struct {
 
 // Raycast checks for intersection with all collidable objects and returns the closest hit
 func (p *PhysicsWorld) Raycast(origin, direction rl.Vector3, maxDistance float32) (RaycastHit, bool) {
+	return p.RaycastIgnoring(origin, direction, maxDistance, nil)
+}
+
+// RaycastIgnoring is Raycast skipping ignore and its children, e.g. so a
+// ray cast from inside a character doesn't hit the character itself
+func (p *PhysicsWorld) RaycastIgnoring(origin, direction rl.Vector3, maxDistance float32, ignore *engine.GameObject) (RaycastHit, bool) {
 	direction = rl.Vector3Normalize(direction)
 	var closestHit RaycastHit
 	closestHit.Distance = maxDistance
 	hit := false
 
-	// Check all object lists
-	allObjects := make([]*engine.GameObject, 0, len(p.Objects)+len(p.Kinematics)+len(p.Statics))
-	allObjects = append(allObjects, p.Objects...)
-	allObjects = append(allObjects, p.Kinematics...)
-	allObjects = append(allObjects, p.Statics...)
-
-	for _, obj := range allObjects {
-		// Check box collider
-		if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
-			if hitInfo, ok := raycastBox(origin, direction, box, maxDistance); ok {
-				if hitInfo.Distance < closestHit.Distance {
-					closestHit = hitInfo
-					closestHit.GameObject = obj
-					hit = true
-				}
-			}
+	for _, obj := range p.collidableObjects() {
+		if ignore != nil && (obj == ignore || obj.IsDescendantOf(ignore)) {
+			continue
 		}
-		// Check sphere collider
-		if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
-			if hitInfo, ok := raycastSphere(origin, direction, sphere, maxDistance); ok {
-				if hitInfo.Distance < closestHit.Distance {
-					closestHit = hitInfo
-					closestHit.GameObject = obj
-					hit = true
-				}
-			}
+		if hitInfo, ok := raycastColliders(origin, direction, obj, closestHit.Distance); ok && hitInfo.Distance < closestHit.Distance {
+			closestHit = hitInfo
+			hit = true
 		}
 	}
 
 	return closestHit, hit
 }
 
+// RaycastAll returns every collider the ray passes through within maxDistance,
+// nearest first, one hit per object (its nearest collider)
+func (p *PhysicsWorld) RaycastAll(origin, direction rl.Vector3, maxDistance float32) []RaycastHit {
+	direction = rl.Vector3Normalize(direction)
+	var hits []RaycastHit
+	for _, obj := range p.collidableObjects() {
+		if hitInfo, ok := raycastColliders(origin, direction, obj, maxDistance); ok {
+			hits = append(hits, hitInfo)
+		}
+	}
+	slices.SortStableFunc(hits, func(a, b RaycastHit) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return hits
+}
+
+// raycastColliders tests the ray against obj's box, sphere and mesh colliders
+// and returns the nearest hit. Triggers are never hit.
+func raycastColliders(origin, direction rl.Vector3, obj *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+	if IsTrigger(obj) {
+		return RaycastHit{}, false
+	}
+	closest := RaycastHit{Distance: maxDistance}
+	hit := false
+	if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
+		if hitInfo, ok := raycastBox(origin, direction, box, closest.Distance); ok && hitInfo.Distance <= closest.Distance {
+			closest, hit = hitInfo, true
+		}
+	}
+	if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
+		if hitInfo, ok := raycastSphere(origin, direction, sphere, closest.Distance); ok && hitInfo.Distance <= closest.Distance {
+			closest, hit = hitInfo, true
+		}
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+		if hitInfo, ok := raycastCapsule(origin, direction, capsule, closest.Distance); ok && hitInfo.Distance <= closest.Distance {
+			closest, hit = hitInfo, true
+		}
+	}
+	if mesh := engine.GetComponent[*components.MeshCollider](obj); mesh != nil {
+		if t, normal, ok := mesh.Raycast(origin, direction, closest.Distance); ok {
+			point := rl.Vector3Add(origin, rl.Vector3Scale(direction, t))
+			closest, hit = RaycastHit{Point: point, Normal: normal, Distance: t}, true
+		}
+	}
+	closest.GameObject = obj
+	return closest, hit
+}
+
 func raycastBox(origin, direction rl.Vector3, box *components.BoxCollider, maxDistance float32) (RaycastHit, bool) {
 	center := box.GetCenter()
 	// Use world-scaled size with absolute values to handle negative sizes
@@ -154,9 +192,10 @@ func raycastBox(origin, direction rl.Vector3, box *components.BoxCollider, maxDi
 }
 
 func raycastSphere(origin, direction rl.Vector3, sphere *components.SphereCollider, maxDistance float32) (RaycastHit, bool) {
-	center := sphere.GetCenter()
-	radius := sphere.Radius
+	return raycastSphereAt(origin, direction, sphere.GetCenter(), sphere.GetWorldRadius(), maxDistance)
+}
 
+func raycastSphereAt(origin, direction, center rl.Vector3, radius, maxDistance float32) (RaycastHit, bool) {
 	oc := rl.Vector3Subtract(origin, center)
 	a := rl.Vector3DotProduct(direction, direction)
 	b := 2.0 * rl.Vector3DotProduct(oc, direction)
@@ -181,6 +220,16 @@ func raycastSphere(origin, direction rl.Vector3, sphere *components.SphereCollid
 	return RaycastHit{Point: point, Normal: normal, Distance: t}, true
 }
 
+// raycastCapsule marches the ray up to the capsule by its distance from the
+// capsule's segment, which can't overshoot since the capsule is convex
+func raycastCapsule(origin, direction rl.Vector3, capsule *components.CapsuleCollider, maxDistance float32) (RaycastHit, bool) {
+	a, b := capsule.Segment()
+	radius := capsule.GetWorldRadius()
+	return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+		return capsuleGap(pos, 0, a, b, radius)
+	})
+}
+
 func abs(x float32) float32 {
 	if x < 0 {
 		return -x
@@ -188,8 +237,27 @@ func abs(x float32) float32 {
 	return x
 }
 
+// EditorMarkerRadius is the pick radius of the editor marker drawn at empty objects
+const EditorMarkerRadius = 0.3
+
+// IsEmptyObject reports whether an object has nothing the editor can draw or pick
+// it by (no model, terrain, collider, light or camera), so it gets a marker instead
+func IsEmptyObject(obj *engine.GameObject) bool {
+	return engine.GetComponent[*components.ModelRenderer](obj) == nil &&
+		engine.GetComponent[*components.Terrain](obj) == nil &&
+		engine.GetComponent[*components.BoxCollider](obj) == nil &&
+		engine.GetComponent[*components.SphereCollider](obj) == nil &&
+		engine.GetComponent[*components.CapsuleCollider](obj) == nil &&
+		engine.GetComponent[*components.MeshCollider](obj) == nil &&
+		engine.GetComponent[*components.CharacterController](obj) == nil &&
+		engine.GetComponent[*components.PointLight](obj) == nil &&
+		engine.GetComponent[*components.SpotLight](obj) == nil &&
+		engine.GetComponent[*components.Camera](obj) == nil
+}
+
 // EditorRaycast performs raycast against all objects, including those without colliders
-// by using their ModelRenderer's bounding box as a fallback
+// by using their ModelRenderer's bounding box as a fallback. Empty objects are picked
+// by a small sphere around their position.
 func (p *PhysicsWorld) EditorRaycast(origin, direction rl.Vector3, maxDistance float32, allObjects []*engine.GameObject) (RaycastHit, bool) {
 	direction = rl.Vector3Normalize(direction)
 	var closestHit RaycastHit
@@ -197,66 +265,95 @@ func (p *PhysicsWorld) EditorRaycast(origin, direction rl.Vector3, maxDistance f
 	hit := false
 
 	for _, obj := range allObjects {
-		if !obj.Active {
-			continue
+		if hitInfo, ok := editorRaycastObject(origin, direction, obj, maxDistance); ok && hitInfo.Distance < closestHit.Distance {
+			closestHit = hitInfo
+			hit = true
 		}
+	}
 
-		// First try box collider
-		if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
-			if hitInfo, ok := raycastBox(origin, direction, box, maxDistance); ok {
-				if hitInfo.Distance < closestHit.Distance {
-					closestHit = hitInfo
-					closestHit.GameObject = obj
-					hit = true
-				}
-			}
-			continue // If has collider, don't check model bounds
-		}
+	return closestHit, hit
+}
 
-		// Try sphere collider
-		if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
-			if hitInfo, ok := raycastSphere(origin, direction, sphere, maxDistance); ok {
-				if hitInfo.Distance < closestHit.Distance {
-					closestHit = hitInfo
-					closestHit.GameObject = obj
-					hit = true
-				}
-			}
-			continue // If has collider, don't check model bounds
+// EditorRaycastAll is EditorRaycast returning every object the ray hits,
+// nearest first, for picking objects hidden behind others
+func (p *PhysicsWorld) EditorRaycastAll(origin, direction rl.Vector3, maxDistance float32, allObjects []*engine.GameObject) []RaycastHit {
+	direction = rl.Vector3Normalize(direction)
+	var hits []RaycastHit
+	for _, obj := range allObjects {
+		if hitInfo, ok := editorRaycastObject(origin, direction, obj, maxDistance); ok {
+			hits = append(hits, hitInfo)
 		}
+	}
+	slices.SortStableFunc(hits, func(a, b RaycastHit) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return hits
+}
+
+// editorRaycastObject tests the ray against one object the way EditorRaycast
+// does: its collider if it has one, else its model bounds, else its marker
+func editorRaycastObject(origin, direction rl.Vector3, obj *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+	if !obj.Active {
+		return RaycastHit{}, false
+	}
+
+	// First try box collider
+	if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
+		hitInfo, ok := raycastBox(origin, direction, box, maxDistance)
+		hitInfo.GameObject = obj
+		return hitInfo, ok // If has collider, don't check model bounds
+	}
 
-		// No collider - try ModelRenderer bounding box
-		if mr := engine.GetComponent[*components.ModelRenderer](obj); mr != nil {
-			// Get model bounding box in model space
-			bounds := rl.GetModelBoundingBox(mr.Model)
-			pos := obj.WorldPosition()
-			scale := obj.WorldScale()
-
-			// Calculate half-extents (size/2) from bounds, scaled
-			halfX := (bounds.Max.X - bounds.Min.X) / 2 * abs(scale.X)
-			halfY := (bounds.Max.Y - bounds.Min.Y) / 2 * abs(scale.Y)
-			halfZ := (bounds.Max.Z - bounds.Min.Z) / 2 * abs(scale.Z)
-
-			// World bounding box centered at object position
-			worldMin := rl.Vector3{X: pos.X - halfX, Y: pos.Y - halfY, Z: pos.Z - halfZ}
-			worldMax := rl.Vector3{X: pos.X + halfX, Y: pos.Y + halfY, Z: pos.Z + halfZ}
-			worldBounds := rl.NewBoundingBox(worldMin, worldMax)
-
-			// Use Raylib's ray-box collision
-			ray := rl.Ray{Position: origin, Direction: direction}
-			collision := rl.GetRayCollisionBox(ray, worldBounds)
-
-			if collision.Hit && collision.Distance < closestHit.Distance && collision.Distance <= maxDistance {
-				closestHit = RaycastHit{
-					GameObject: obj,
-					Point:      collision.Point,
-					Normal:     collision.Normal,
-					Distance:   collision.Distance,
-				}
-				hit = true
-			}
+	// Try sphere collider
+	if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
+		hitInfo, ok := raycastSphere(origin, direction, sphere, maxDistance)
+		hitInfo.GameObject = obj
+		return hitInfo, ok // If has collider, don't check model bounds
+	}
+
+	// Try capsule collider
+	if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+		hitInfo, ok := raycastCapsule(origin, direction, capsule, maxDistance)
+		hitInfo.GameObject = obj
+		return hitInfo, ok // If has collider, don't check model bounds
+	}
+
+	// No collider - try ModelRenderer bounding box
+	if mr := engine.GetComponent[*components.ModelRenderer](obj); mr != nil {
+		// Get model bounding box in model space
+		bounds := rl.GetModelBoundingBox(mr.Model)
+		pos := obj.WorldPosition()
+		scale := obj.WorldScale()
+
+		// Calculate half-extents (size/2) from bounds, scaled
+		halfX := (bounds.Max.X - bounds.Min.X) / 2 * abs(scale.X)
+		halfY := (bounds.Max.Y - bounds.Min.Y) / 2 * abs(scale.Y)
+		halfZ := (bounds.Max.Z - bounds.Min.Z) / 2 * abs(scale.Z)
+
+		// World bounding box centered at object position
+		worldMin := rl.Vector3{X: pos.X - halfX, Y: pos.Y - halfY, Z: pos.Z - halfZ}
+		worldMax := rl.Vector3{X: pos.X + halfX, Y: pos.Y + halfY, Z: pos.Z + halfZ}
+		worldBounds := rl.NewBoundingBox(worldMin, worldMax)
+
+		// Use Raylib's ray-box collision
+		ray := rl.Ray{Position: origin, Direction: direction}
+		collision := rl.GetRayCollisionBox(ray, worldBounds)
+		if !collision.Hit || collision.Distance > maxDistance {
+			return RaycastHit{}, false
 		}
+		return RaycastHit{
+			GameObject: obj,
+			Point:      collision.Point,
+			Normal:     collision.Normal,
+			Distance:   collision.Distance,
+		}, true
 	}
 
-	return closestHit, hit
+	// Empty object - pick the editor marker
+	if IsEmptyObject(obj) {
+		hitInfo, ok := raycastSphereAt(origin, direction, obj.WorldPosition(), EditorMarkerRadius, maxDistance)
+		hitInfo.GameObject = obj
+		return hitInfo, ok
+	}
+	return RaycastHit{}, false
 }
@@ -0,0 +1,332 @@
+package physics
+
+import (
+	"math"
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	shapeCastMaxSteps = 64    // advancement steps before giving up on a grazing cast
+	shapeCastSkin     = 0.001 // gap at which the cast shape counts as touching
+)
+
+// SphereCast sweeps a sphere of radius from origin along direction and returns
+// the first collider it touches. Distance is how far the sphere's center
+// travelled, Point is the contact on the collider's surface and Normal points
+// out of the collider toward the sphere. A sphere that already overlaps a
+// collider at origin hits it at distance 0.
+// Mesh colliders, terrain included, are swept against the triangles the cast
+// can reach.
+func (p *PhysicsWorld) SphereCast(origin, direction rl.Vector3, radius, maxDistance float32) (RaycastHit, bool) {
+	direction = rl.Vector3Normalize(direction)
+	return shapeCast(p.collidableObjects(), origin, direction, radius, maxDistance, func(obj *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+		return sphereCastObject(origin, direction, radius, obj, maxDistance)
+	})
+}
+
+// sphereCastObject sweeps a sphere against obj's box, sphere, capsule or mesh collider
+func sphereCastObject(origin, direction rl.Vector3, radius float32, obj *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+	if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
+		obb := boxColliderOBB(obj, box)
+		return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+			return sphereOBBGap(pos, radius, obb)
+		})
+	}
+	if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
+		return sphereCastSphere(origin, direction, radius, sphere.GetCenter(), sphere.GetWorldRadius(), maxDistance)
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+		a, b := capsule.Segment()
+		capRadius := capsule.GetWorldRadius()
+		return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+			return capsuleGap(pos, radius, a, b, capRadius)
+		})
+	}
+	if mesh := engine.GetComponent[*components.MeshCollider](obj); mesh != nil {
+		return meshCast(mesh, origin, direction, radius, maxDistance, func(pos rl.Vector3, tri *components.Triangle) (float32, rl.Vector3) {
+			diff := rl.Vector3Subtract(pos, tri.ClosestPoint(pos))
+			dist := rl.Vector3Length(diff)
+			if dist < 0.0001 {
+				return -radius, tri.Normal
+			}
+			return dist - radius, rl.Vector3Scale(diff, 1/dist)
+		})
+	}
+	return RaycastHit{}, false
+}
+
+// BoxCast sweeps an axis-aligned box with the given half extents from origin
+// along direction and returns the first collider it touches, like SphereCast.
+// Rotated BoxColliders are tested as oriented boxes, mesh colliders triangle
+// by triangle.
+func (p *PhysicsWorld) BoxCast(origin, direction, halfExtents rl.Vector3, maxDistance float32) (RaycastHit, bool) {
+	direction = rl.Vector3Normalize(direction)
+	halfExtents = rl.Vector3{X: absf(halfExtents.X), Y: absf(halfExtents.Y), Z: absf(halfExtents.Z)}
+	cast := NewAABBasOBB(origin, rl.Vector3Scale(halfExtents, 2))
+	return shapeCast(p.collidableObjects(), origin, direction, rl.Vector3Length(halfExtents), maxDistance, func(obj *engine.GameObject, maxDistance float32) (RaycastHit, bool) {
+		if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
+			obb := boxColliderOBB(obj, box)
+			return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+				cast.Center = pos
+				return obbOBBGap(cast, obb)
+			})
+		}
+		if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
+			center, r := sphere.GetCenter(), sphere.GetWorldRadius()
+			return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+				cast.Center = pos
+				gap, _, normal := sphereOBBGap(center, r, cast)
+				// sphereOBBGap measures from the cast box, flip it to point at the box
+				normal = rl.Vector3Negate(normal)
+				return gap, rl.Vector3Add(center, rl.Vector3Scale(normal, r)), normal
+			})
+		}
+		if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+			a, b := capsule.Segment()
+			r := capsule.GetWorldRadius()
+			return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+				cast.Center = pos
+				// The capsule is a sphere at the point of its segment nearest the box
+				center := closestPointOnSegmentToOBB(a, b, cast)
+				gap, _, normal := sphereOBBGap(center, r, cast)
+				normal = rl.Vector3Negate(normal)
+				return gap, rl.Vector3Add(center, rl.Vector3Scale(normal, r)), normal
+			})
+		}
+		if mesh := engine.GetComponent[*components.MeshCollider](obj); mesh != nil {
+			return meshCast(mesh, origin, direction, rl.Vector3Length(halfExtents), maxDistance, func(pos rl.Vector3, tri *components.Triangle) (float32, rl.Vector3) {
+				cast.Center = pos
+				return obbTriangleGap(cast, tri)
+			})
+		}
+		return RaycastHit{}, false
+	})
+}
+
+// meshCast sweeps a convex shape against the triangles of mesh the sweep can
+// reach, reach being the radius of a sphere around the shape. gap returns the
+// distance from the shape at pos to one triangle (negative when overlapping)
+// and the normal pointing from the triangle to the shape. The mesh's gap is
+// the smallest of them, so castAdvance never steps through any triangle.
+func meshCast(mesh *components.MeshCollider, origin, direction rl.Vector3, reach, maxDistance float32, gap func(pos rl.Vector3, tri *components.Triangle) (float32, rl.Vector3)) (RaycastHit, bool) {
+	end := rl.Vector3Add(origin, rl.Vector3Scale(direction, maxDistance))
+	pad := rl.Vector3{X: reach, Y: reach, Z: reach}
+	tris := mesh.TrianglesInBounds(components.AABB{
+		Min: rl.Vector3Subtract(rl.Vector3Min(origin, end), pad),
+		Max: rl.Vector3Add(rl.Vector3Max(origin, end), pad),
+	})
+	if len(tris) == 0 {
+		return RaycastHit{}, false
+	}
+	return castAdvance(origin, direction, maxDistance, func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3) {
+		best := float32(math.MaxFloat32)
+		var nearest *components.Triangle
+		var normal rl.Vector3
+		for _, idx := range tris {
+			tri := &mesh.Triangles[idx]
+			if d, n := gap(pos, tri); d < best {
+				best, nearest, normal = d, tri, n
+			}
+		}
+		return best, nearest.ClosestPoint(pos), normal
+	})
+}
+
+// shapeCast runs cast against every object in objs whose bounds the swept
+// shape can reach and keeps the nearest hit. Triggers are skipped. bound is the radius of a sphere
+// around the cast shape, used to skip colliders the sweep passes far from.
+func shapeCast(objs []*engine.GameObject, origin, direction rl.Vector3, bound, maxDistance float32, cast func(obj *engine.GameObject, maxDistance float32) (RaycastHit, bool)) (RaycastHit, bool) {
+	closest := RaycastHit{Distance: maxDistance}
+	hit := false
+	for _, obj := range objs {
+		if IsTrigger(obj) {
+			continue
+		}
+		bounds, ok := colliderBounds(obj)
+		if !ok {
+			continue
+		}
+		center := rl.Vector3Scale(rl.Vector3Add(bounds.Min, bounds.Max), 0.5)
+		radius := rl.Vector3Distance(bounds.Min, bounds.Max) / 2
+		if rl.Vector3Distance(origin, center) > radius+bound {
+			if _, ok := raycastSphereAt(origin, direction, center, radius+bound, closest.Distance); !ok {
+				continue
+			}
+		}
+		if h, ok := cast(obj, closest.Distance); ok && h.Distance <= closest.Distance {
+			closest = h
+			closest.GameObject = obj
+			hit = true
+		}
+	}
+	return closest, hit
+}
+
+// collidableObjects returns every object the physics world tests against:
+// dynamics, kinematics and statics
+func (p *PhysicsWorld) collidableObjects() []*engine.GameObject {
+	all := make([]*engine.GameObject, 0, len(p.Objects)+len(p.Kinematics)+len(p.Statics))
+	all = append(all, p.Objects...)
+	all = append(all, p.Kinematics...)
+	return append(all, p.Statics...)
+}
+
+// boxColliderOBB returns the oriented box covered by a BoxCollider
+func boxColliderOBB(obj *engine.GameObject, box *components.BoxCollider) OBB {
+	obb := NewOBBFromBox(box.GetCenter(), box.Size, obj.WorldQuaternion(), obj.WorldScale())
+	obb.HalfSize = rl.Vector3{X: absf(obb.HalfSize.X), Y: absf(obb.HalfSize.Y), Z: absf(obb.HalfSize.Z)}
+	return obb
+}
+
+// castAdvance moves a convex shape from origin along direction until it
+// touches a convex target. gap returns the distance between the shape placed
+// at pos and the target (negative when overlapping), the contact point on the
+// target and the normal pointing from the target to the shape. Since the gap
+// is the shortest distance between the two, stepping the shape forward by it
+// can never pass through the target.
+func castAdvance(origin, direction rl.Vector3, maxDistance float32, gap func(pos rl.Vector3) (float32, rl.Vector3, rl.Vector3)) (RaycastHit, bool) {
+	t := float32(0)
+	for range shapeCastMaxSteps {
+		d, point, normal := gap(rl.Vector3Add(origin, rl.Vector3Scale(direction, t)))
+		if d <= shapeCastSkin {
+			if t == 0 && d < 0 {
+				// Started overlapping: report it as a hit head-on
+				normal = rl.Vector3Negate(direction)
+			}
+			return RaycastHit{Point: point, Normal: normal, Distance: t}, true
+		}
+		t += d
+		if t > maxDistance {
+			break
+		}
+	}
+	return RaycastHit{}, false
+}
+
+// sphereOBBGap is the gap function for a sphere against an oriented box
+func sphereOBBGap(center rl.Vector3, radius float32, obb OBB) (float32, rl.Vector3, rl.Vector3) {
+	closest := ClosestPointOnOBB(obb, center)
+	diff := rl.Vector3Subtract(center, closest)
+	dist := rl.Vector3Length(diff)
+	if dist < 0.0001 {
+		// Center inside the box
+		return -radius, closest, rl.Vector3{X: 0, Y: 1, Z: 0}
+	}
+	return dist - radius, closest, rl.Vector3Scale(diff, 1/dist)
+}
+
+// capsuleGap is the gap function for a sphere of radius (0 for a ray) against
+// a capsule with segment a-b
+func capsuleGap(pos rl.Vector3, radius float32, a, b rl.Vector3, capRadius float32) (float32, rl.Vector3, rl.Vector3) {
+	closest := closestPointOnSegment(a, b, pos)
+	diff := rl.Vector3Subtract(pos, closest)
+	dist := rl.Vector3Length(diff)
+	if dist < 0.0001 {
+		return -radius - capRadius, closest, rl.Vector3{X: 0, Y: 1, Z: 0}
+	}
+	normal := rl.Vector3Scale(diff, 1/dist)
+	return dist - radius - capRadius, rl.Vector3Add(closest, rl.Vector3Scale(normal, capRadius)), normal
+}
+
+// obbOBBGap is the gap function for two oriented boxes. The gap is the largest
+// separation along the 15 separating axes, which never exceeds the true
+// distance, so advancing by it stays safe and reaches zero on contact.
+func obbOBBGap(a, b OBB) (float32, rl.Vector3, rl.Vector3) {
+	t := rl.Vector3Subtract(a.Center, b.Center)
+	best := float32(-math.MaxFloat32)
+	var normal rl.Vector3
+
+	test := func(axis rl.Vector3) {
+		length := rl.Vector3Length(axis)
+		if length < 0.0001 {
+			return // parallel edges give no axis
+		}
+		axis = rl.Vector3Scale(axis, 1/length)
+		dist := rl.Vector3DotProduct(t, axis)
+		sep := absf(dist) - obbProjectedRadius(a, axis) - obbProjectedRadius(b, axis)
+		if sep > best {
+			best = sep
+			if dist < 0 {
+				axis = rl.Vector3Negate(axis)
+			}
+			normal = axis
+		}
+	}
+	for i := range 3 {
+		test(a.Axes[i])
+		test(b.Axes[i])
+	}
+	for i := range 3 {
+		for j := range 3 {
+			test(cross(a.Axes[i], b.Axes[j]))
+		}
+	}
+
+	return best, ClosestPointOnOBB(b, a.Center), normal
+}
+
+// obbTriangleGap is the gap between a box and a triangle, measured like
+// obbOBBGap along the box axes, the triangle normal and the cross products of
+// their edges. The normal points from the triangle to the box.
+func obbTriangleGap(o OBB, tri *components.Triangle) (float32, rl.Vector3) {
+	points := []rl.Vector3{tri.V0, tri.V1, tri.V2}
+	best := float32(-math.MaxFloat32)
+	var normal rl.Vector3
+
+	test := func(axis rl.Vector3) {
+		length := rl.Vector3Length(axis)
+		if length < 0.0001 {
+			return
+		}
+		axis = rl.Vector3Scale(axis, 1/length)
+		center, r := rl.Vector3DotProduct(o.Center, axis), obbProjectedRadius(o, axis)
+		lo, hi := projectPoints(points, axis)
+		// The box can be separated on either side of the triangle
+		if sep := center - r - hi; sep > best {
+			best, normal = sep, axis
+		}
+		if sep := lo - center - r; sep > best {
+			best, normal = sep, rl.Vector3Negate(axis)
+		}
+	}
+	for i := range 3 {
+		test(o.Axes[i])
+	}
+	test(tri.Normal)
+	edges := [3]rl.Vector3{
+		rl.Vector3Subtract(tri.V1, tri.V0),
+		rl.Vector3Subtract(tri.V2, tri.V1),
+		rl.Vector3Subtract(tri.V0, tri.V2),
+	}
+	for i := range 3 {
+		for _, e := range edges {
+			test(cross(o.Axes[i], e))
+		}
+	}
+	return best, normal
+}
+
+// obbProjectedRadius is the half-length of o's shadow on axis
+func obbProjectedRadius(o OBB, axis rl.Vector3) float32 {
+	return o.HalfSize.X*absf(rl.Vector3DotProduct(o.Axes[0], axis)) +
+		o.HalfSize.Y*absf(rl.Vector3DotProduct(o.Axes[1], axis)) +
+		o.HalfSize.Z*absf(rl.Vector3DotProduct(o.Axes[2], axis))
+}
+
+// sphereCastSphere sweeps a sphere against a sphere collider, which is a ray
+// against a sphere grown by the cast radius
+func sphereCastSphere(origin, direction rl.Vector3, radius float32, center rl.Vector3, targetRadius, maxDistance float32) (RaycastHit, bool) {
+	if rl.Vector3Distance(origin, center) <= radius+targetRadius {
+		point := rl.Vector3Add(center, rl.Vector3Scale(rl.Vector3Normalize(rl.Vector3Subtract(origin, center)), targetRadius))
+		return RaycastHit{Point: point, Normal: rl.Vector3Negate(direction), Distance: 0}, true
+	}
+	hit, ok := raycastSphereAt(origin, direction, center, radius+targetRadius, maxDistance)
+	if !ok {
+		return RaycastHit{}, false
+	}
+	hit.Point = rl.Vector3Add(center, rl.Vector3Scale(hit.Normal, targetRadius))
+	return hit, true
+}
@@ -0,0 +1,126 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// updateSleepIslands groups touching dynamic bodies into islands and sleeps or wakes
+// each island as a whole: a fast-moving member wakes the island, and the island sleeps
+// once every member has been at rest for SleepTimeThreshold. Statics, kinematics and
+// immovable bodies don't join islands. Returns the island and sleeping body counts.
+func (p *PhysicsWorld) updateSleepIslands() (int, int) {
+	n := len(p.Objects)
+	index := make(map[*engine.GameObject]int, n)
+	rbs := make([]*components.Rigidbody, n)
+	parent := make([]int, n)
+	for i, obj := range p.Objects {
+		index[obj] = i
+		rbs[i] = engine.GetComponent[*components.Rigidbody](obj)
+		parent[i] = i
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[ra] = rb
+		}
+	}
+	linkable := func(i int) bool {
+		return rbs[i] != nil && !rbs[i].IsImmovable()
+	}
+
+	// Touching dynamic bodies share an island
+	for pair := range p.currentCollisions {
+		a, okA := index[pair.A]
+		b, okB := index[pair.B]
+		if okA && okB && linkable(a) && linkable(b) {
+			union(a, b)
+		}
+	}
+
+	// Sleeping bodies don't generate contacts, so keep the islands they fell asleep in
+	for i, obj := range p.Objects {
+		if rbs[i] == nil || !rbs[i].IsSleeping {
+			continue
+		}
+		for _, member := range p.sleepingIslands[obj] {
+			if j, ok := index[member]; ok && linkable(j) {
+				union(i, j)
+			}
+		}
+	}
+
+	islands := make(map[int][]int)
+	for i := range p.Objects {
+		if rbs[i] == nil {
+			continue
+		}
+		root := find(i)
+		islands[root] = append(islands[root], i)
+	}
+
+	wakeThreshold := float32(components.SleepVelocityThreshold * 2.0)
+	p.sleepingIslands = make(map[*engine.GameObject][]*engine.GameObject)
+	sleeping := 0
+
+	for _, members := range islands {
+		moving, allReady := false, true
+		for _, i := range members {
+			rb := rbs[i]
+			if rb.IsSleeping {
+				continue
+			}
+			if rl.Vector3Length(rb.Velocity) > wakeThreshold {
+				moving = true
+			}
+			if !rb.ReadyToSleep() {
+				allReady = false
+			}
+		}
+
+		objs := make([]*engine.GameObject, len(members))
+		for k, i := range members {
+			rb := rbs[i]
+			switch {
+			case moving && rb.IsSleeping:
+				rb.Wake()
+			case !moving && allReady && !rb.IsSleeping:
+				rb.Sleep()
+			}
+			objs[k] = p.Objects[i]
+		}
+
+		for k, i := range members {
+			if rbs[i].IsSleeping {
+				p.sleepingIslands[objs[k]] = objs
+				sleeping++
+			}
+		}
+	}
+
+	return len(islands), sleeping
+}
+
+// wakeIsland wakes a sleeping body and every body it fell asleep with
+func (p *PhysicsWorld) wakeIsland(obj *engine.GameObject) {
+	members := p.sleepingIslands[obj]
+	if len(members) == 0 {
+		members = []*engine.GameObject{obj}
+	}
+	for _, m := range members {
+		if rb := engine.GetComponent[*components.Rigidbody](m); rb != nil && rb.IsSleeping {
+			rb.Wake()
+		}
+		delete(p.sleepingIslands, m)
+	}
+}
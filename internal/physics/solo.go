@@ -0,0 +1,61 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// frozenBody is the state of a dynamic body held in place while another
+// object is soloed
+type frozenBody struct {
+	obj             *engine.GameObject
+	rb              *components.Rigidbody
+	position        rl.Vector3
+	rotation        rl.Vector3
+	velocity        rl.Vector3
+	angularVelocity rl.Vector3
+}
+
+// isFrozen reports whether obj sits outside the soloed subtree
+func (p *PhysicsWorld) isFrozen(obj *engine.GameObject) bool {
+	return p.Solo != nil && obj != p.Solo && !obj.IsDescendantOf(p.Solo)
+}
+
+// freezeBodies records the dynamic bodies outside the solo so their state can
+// be put back after the step. Soloed bodies still collide with them.
+func (p *PhysicsWorld) freezeBodies() []frozenBody {
+	if p.Solo == nil {
+		return nil
+	}
+	var frozen []frozenBody
+	for _, obj := range p.Objects {
+		rb := engine.GetComponent[*components.Rigidbody](obj)
+		if rb == nil || !p.isFrozen(obj) {
+			continue
+		}
+		frozen = append(frozen, frozenBody{
+			obj:             obj,
+			rb:              rb,
+			position:        obj.Transform.Position,
+			rotation:        obj.Transform.Rotation,
+			velocity:        rb.Velocity,
+			angularVelocity: rb.AngularVelocity,
+		})
+	}
+	return frozen
+}
+
+// thawBodies undoes whatever contacts did to the frozen bodies this step
+func thawBodies(frozen []frozenBody) {
+	for _, f := range frozen {
+		f.obj.Transform.Position = f.position
+		if f.obj.Transform.Rotation != f.rotation {
+			f.obj.Transform.Rotation = f.rotation
+			f.obj.Transform.MarkRotationDirty()
+		}
+		f.rb.Velocity = f.velocity
+		f.rb.AngularVelocity = f.angularVelocity
+	}
+}
@@ -0,0 +1,174 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// maxObjectCells is the most grid cells a single object may be inserted into.
+// Bigger objects (floors, terrain) go in a separate list checked against everything.
+const maxObjectCells = 4096
+
+// colliderBounds returns a world-space AABB enclosing all of an object's colliders.
+// Returns false if the object has no collider.
+func colliderBounds(g *engine.GameObject) (AABB, bool) {
+	var bounds AABB
+	found := false
+	add := func(b AABB) {
+		if !found {
+			bounds = b
+			found = true
+			return
+		}
+		bounds.Min = rl.Vector3Min(bounds.Min, b.Min)
+		bounds.Max = rl.Vector3Max(bounds.Max, b.Max)
+	}
+
+	if box := engine.GetComponent[*components.BoxCollider](g); box != nil {
+		// Half-diagonal covers any rotation
+		r := rl.Vector3Length(box.GetWorldSize()) * 0.5
+		add(NewAABBFromCenter(box.GetCenter(), rl.Vector3{X: r * 2, Y: r * 2, Z: r * 2}))
+	}
+	if sphere := engine.GetComponent[*components.SphereCollider](g); sphere != nil {
+		d := sphere.GetWorldRadius() * 2
+		add(NewAABBFromCenter(sphere.GetCenter(), rl.Vector3{X: d, Y: d, Z: d}))
+	}
+	if capsule := engine.GetComponent[*components.CapsuleCollider](g); capsule != nil {
+		a, b := capsule.Segment()
+		r := capsule.GetWorldRadius()
+		pad := rl.Vector3{X: r, Y: r, Z: r}
+		add(AABB{
+			Min: rl.Vector3Subtract(rl.Vector3Min(a, b), pad),
+			Max: rl.Vector3Add(rl.Vector3Max(a, b), pad),
+		})
+	}
+	if mesh := engine.GetComponent[*components.MeshCollider](g); mesh != nil && mesh.IsBuilt() {
+		b := mesh.GetBounds()
+		add(AABB{Min: b.Min, Max: b.Max})
+	}
+
+	return bounds, found
+}
+
+// cellSpan is an inclusive box of grid cells
+type cellSpan struct {
+	lo, hi CellKey
+	count  int
+}
+
+// cellRange returns the grid cells spanned by an AABB
+func cellRange(b AABB) cellSpan {
+	lo := posToCell(b.Min)
+	hi := posToCell(b.Max)
+	count := (hi.X - lo.X + 1) * (hi.Y - lo.Y + 1) * (hi.Z - lo.Z + 1)
+	return cellSpan{lo: lo, hi: hi, count: count}
+}
+
+// objectCellSpan returns the cells an object's collider bounds span,
+// or just the cell of its position if it has no collider
+func objectCellSpan(obj *engine.GameObject) cellSpan {
+	if bounds, ok := colliderBounds(obj); ok {
+		return cellRange(bounds)
+	}
+	cell := posToCell(obj.Transform.Position)
+	return cellSpan{lo: cell, hi: cell, count: 1}
+}
+
+// each calls fn for every cell in the span
+func (s cellSpan) each(fn func(CellKey)) {
+	for x := s.lo.X; x <= s.hi.X; x++ {
+		for y := s.lo.Y; y <= s.hi.Y; y++ {
+			for z := s.lo.Z; z <= s.hi.Z; z++ {
+				fn(CellKey{x, y, z})
+			}
+		}
+	}
+}
+
+// MarkStaticsDirty forces the static grid to be rebuilt on the next Update.
+func (p *PhysicsWorld) MarkStaticsDirty() {
+	p.staticsDirty = true
+}
+
+// refreshStaticGrid rebuilds the static grid if statics were added, removed or moved
+func (p *PhysicsWorld) refreshStaticGrid() {
+	if !p.staticsDirty {
+		// Statics can be moved by scripts or the list reset directly -
+		// rebuild if any bounds changed or the set of colliders differs
+		colliders := 0
+		for _, static := range p.Statics {
+			bounds, ok := colliderBounds(static)
+			if old, had := p.staticBounds[static]; ok != had || bounds != old {
+				p.staticsDirty = true
+				break
+			}
+			if ok {
+				colliders++
+			}
+		}
+		if colliders != len(p.staticBounds) {
+			p.staticsDirty = true
+		}
+	}
+	if p.staticsDirty {
+		p.rebuildStaticGrid()
+	}
+}
+
+// rebuildStaticGrid inserts every static collider into each cell its bounds overlap
+func (p *PhysicsWorld) rebuildStaticGrid() {
+	p.staticGrid = make(map[CellKey][]*engine.GameObject)
+	p.staticBounds = make(map[*engine.GameObject]AABB, len(p.Statics))
+	p.largeStatics = p.largeStatics[:0]
+
+	for _, static := range p.Statics {
+		bounds, ok := colliderBounds(static)
+		if !ok {
+			continue // nothing to collide with
+		}
+		p.staticBounds[static] = bounds
+
+		span := cellRange(bounds)
+		if span.count > maxObjectCells {
+			p.largeStatics = append(p.largeStatics, static)
+			continue
+		}
+		span.each(func(key CellKey) {
+			p.staticGrid[key] = append(p.staticGrid[key], static)
+		})
+	}
+
+	p.staticsDirty = false
+}
+
+// nearbyStatics returns the statics whose grid cells overlap the object's collider bounds
+func (p *PhysicsWorld) nearbyStatics(obj *engine.GameObject) []*engine.GameObject {
+	bounds, ok := colliderBounds(obj)
+	if !ok {
+		return nil
+	}
+
+	span := cellRange(bounds)
+	if span.count > maxObjectCells {
+		return p.Statics // huge object, cheaper to test everything
+	}
+
+	if p.staticSeen == nil {
+		p.staticSeen = make(map[*engine.GameObject]bool)
+	}
+	clear(p.staticSeen)
+
+	// Cap capacity so appending never writes into largeStatics
+	result := p.largeStatics[:len(p.largeStatics):len(p.largeStatics)]
+	span.each(func(key CellKey) {
+		for _, static := range p.staticGrid[key] {
+			if !p.staticSeen[static] {
+				p.staticSeen[static] = true
+				result = append(result, static)
+			}
+		}
+	})
+	return result
+}
@@ -0,0 +1,211 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// staticOverlapTolerance is how deep two static shapes must interpenetrate on
+// every axis to count as overlapping, so pieces that merely touch (a wall
+// standing on a floor, tiles sharing an edge) aren't reported
+const staticOverlapTolerance = 0.01
+
+// StaticOverlap is a pair of static objects whose colliders intersect
+type StaticOverlap struct {
+	A, B *engine.GameObject
+}
+
+// convexShape is a convex point set with the directions needed to separate it:
+// face normals and edge directions
+type convexShape struct {
+	points []rl.Vector3
+	faces  []rl.Vector3
+	edges  []rl.Vector3
+}
+
+// FindStaticOverlaps tests every pair of static objects with box or mesh
+// colliders for intersecting geometry. Statics never collide with each other
+// at runtime, so this is a level-design check (walls clipping through each
+// other), not part of the simulation. Triggers are meant to overlap and are
+// skipped. Mesh colliders are tested at the transform they were last built with.
+func FindStaticOverlaps(statics []*engine.GameObject) []StaticOverlap {
+	type candidate struct {
+		obj    *engine.GameObject
+		bounds AABB
+	}
+	var candidates []candidate
+	for _, g := range statics {
+		if !hasStaticShape(g) || IsTrigger(g) {
+			continue
+		}
+		if bounds, ok := colliderBounds(g); ok {
+			candidates = append(candidates, candidate{g, bounds})
+		}
+	}
+
+	var overlaps []StaticOverlap
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			a, b := candidates[i], candidates[j]
+			if a.obj.IsDescendantOf(b.obj) || b.obj.IsDescendantOf(a.obj) {
+				continue // parts of one piece are meant to touch
+			}
+			if a.bounds.Intersects(b.bounds) && staticShapesOverlap(a.obj, b.obj) {
+				overlaps = append(overlaps, StaticOverlap{A: a.obj, B: b.obj})
+			}
+		}
+	}
+	return overlaps
+}
+
+// hasStaticShape reports whether g has a collider FindStaticOverlaps can test
+func hasStaticShape(g *engine.GameObject) bool {
+	if engine.GetComponent[*components.BoxCollider](g) != nil {
+		return true
+	}
+	mc := engine.GetComponent[*components.MeshCollider](g)
+	return mc != nil && mc.IsBuilt()
+}
+
+// staticShapesOverlap tests the box and mesh colliders of a against those of b
+func staticShapesOverlap(a, b *engine.GameObject) bool {
+	boxA := staticBoxShape(a)
+	boxB := staticBoxShape(b)
+	meshA := engine.GetComponent[*components.MeshCollider](a)
+	meshB := engine.GetComponent[*components.MeshCollider](b)
+	if meshA != nil && !meshA.IsBuilt() {
+		meshA = nil
+	}
+	if meshB != nil && !meshB.IsBuilt() {
+		meshB = nil
+	}
+
+	switch {
+	case boxA != nil && boxB != nil && convexOverlap(*boxA, *boxB, staticOverlapTolerance):
+		return true
+	case boxA != nil && meshB != nil && meshOverlapsShape(meshB, *boxA, staticOverlapTolerance):
+		return true
+	case meshA != nil && boxB != nil && meshOverlapsShape(meshA, *boxB, staticOverlapTolerance):
+		return true
+	case meshA != nil && meshB != nil && meshesOverlap(meshA, meshB):
+		return true
+	}
+	return false
+}
+
+// staticBoxShape returns g's box collider as a convex shape, or nil
+func staticBoxShape(g *engine.GameObject) *convexShape {
+	box := engine.GetComponent[*components.BoxCollider](g)
+	if box == nil {
+		return nil
+	}
+	obb := NewOBBFromBox(box.GetCenter(), box.Size, g.WorldQuaternion(), g.WorldScale())
+	shape := obbShape(obb)
+	return &shape
+}
+
+// obbShape returns the corners and axes of an OBB
+func obbShape(o OBB) convexShape {
+	x := rl.Vector3Scale(o.Axes[0], o.HalfSize.X)
+	y := rl.Vector3Scale(o.Axes[1], o.HalfSize.Y)
+	z := rl.Vector3Scale(o.Axes[2], o.HalfSize.Z)
+	points := make([]rl.Vector3, 0, 8)
+	for _, sx := range []float32{-1, 1} {
+		for _, sy := range []float32{-1, 1} {
+			for _, sz := range []float32{-1, 1} {
+				offset := rl.Vector3Add(rl.Vector3Add(rl.Vector3Scale(x, sx), rl.Vector3Scale(y, sy)), rl.Vector3Scale(z, sz))
+				points = append(points, rl.Vector3Add(o.Center, offset))
+			}
+		}
+	}
+	axes := o.Axes[:]
+	return convexShape{points: points, faces: axes, edges: axes}
+}
+
+// triangleShape returns a triangle as a flat convex shape
+func triangleShape(t *components.Triangle) convexShape {
+	return convexShape{
+		points: []rl.Vector3{t.V0, t.V1, t.V2},
+		faces:  []rl.Vector3{t.Normal},
+		edges: []rl.Vector3{
+			rl.Vector3Subtract(t.V1, t.V0),
+			rl.Vector3Subtract(t.V2, t.V1),
+			rl.Vector3Subtract(t.V0, t.V2),
+		},
+	}
+}
+
+// shapeBounds returns the AABB around a shape's points
+func shapeBounds(s convexShape) components.AABB {
+	b := components.AABB{Min: s.points[0], Max: s.points[0]}
+	for _, p := range s.points[1:] {
+		b.Min = rl.Vector3Min(b.Min, p)
+		b.Max = rl.Vector3Max(b.Max, p)
+	}
+	return b
+}
+
+// meshOverlapsShape tests a convex shape against the mesh triangles near it
+func meshOverlapsShape(m *components.MeshCollider, s convexShape, tolerance float32) bool {
+	for _, idx := range m.TrianglesInBounds(shapeBounds(s)) {
+		if convexOverlap(triangleShape(&m.Triangles[idx]), s, tolerance) {
+			return true
+		}
+	}
+	return false
+}
+
+// meshesOverlap tests the triangles of a against the triangles of b near each one
+func meshesOverlap(a, b *components.MeshCollider) bool {
+	ba, bb := a.GetBounds(), b.GetBounds()
+	shared := components.AABB{Min: rl.Vector3Max(ba.Min, bb.Min), Max: rl.Vector3Min(ba.Max, bb.Max)}
+	for _, idx := range a.TrianglesInBounds(shared) {
+		if meshOverlapsShape(b, triangleShape(&a.Triangles[idx]), staticOverlapTolerance) {
+			return true
+		}
+	}
+	return false
+}
+
+// convexOverlap reports whether two convex shapes interpenetrate by more than
+// tolerance along every axis, using the separating axis theorem: face normals
+// of both shapes and the cross products of their edges
+func convexOverlap(a, b convexShape, tolerance float32) bool {
+	axes := make([]rl.Vector3, 0, len(a.faces)+len(b.faces)+len(a.edges)*len(b.edges))
+	axes = append(axes, a.faces...)
+	axes = append(axes, b.faces...)
+	for _, ea := range a.edges {
+		for _, eb := range b.edges {
+			axes = append(axes, cross(ea, eb))
+		}
+	}
+
+	for _, axis := range axes {
+		length := rl.Vector3Length(axis)
+		if length < 1e-6 {
+			continue // parallel edges
+		}
+		axis = rl.Vector3Scale(axis, 1/length)
+		minA, maxA := projectPoints(a.points, axis)
+		minB, maxB := projectPoints(b.points, axis)
+		// Each shape must reach past the other's near side, which also
+		// works for flat triangles whose extent along their normal is zero
+		if maxA <= minB+tolerance || maxB <= minA+tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// projectPoints returns the extent of points along axis
+func projectPoints(points []rl.Vector3, axis rl.Vector3) (float32, float32) {
+	lo := rl.Vector3DotProduct(points[0], axis)
+	hi := lo
+	for _, p := range points[1:] {
+		d := rl.Vector3DotProduct(p, axis)
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
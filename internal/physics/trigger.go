@@ -0,0 +1,132 @@
+package physics
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// IsTrigger reports whether g's box or sphere collider is a trigger. Triggers
+// report overlaps through engine.TriggerHandler but never push, block or get
+// hit by raycasts and shape casts.
+func IsTrigger(g *engine.GameObject) bool {
+	if box := engine.GetComponent[*components.BoxCollider](g); box != nil && box.IsTrigger {
+		return true
+	}
+	sphere := engine.GetComponent[*components.SphereCollider](g)
+	return sphere != nil && sphere.IsTrigger
+}
+
+// detectTrigger records a and b as overlapping this frame if their colliders
+// intersect. Nothing is moved.
+func (p *PhysicsWorld) detectTrigger(a, b *engine.GameObject) {
+	if collidersOverlap(a, b) {
+		p.currentTriggers[makePair(a, b)] = true
+	}
+}
+
+// collidersOverlap tests the box, sphere and capsule colliders of a and b for
+// intersection
+func collidersOverlap(a, b *engine.GameObject) bool {
+	if hasCapsule(a) || hasCapsule(b) {
+		_, _, ok := capsuleContact(a, b)
+		return ok
+	}
+
+	sphereA := engine.GetComponent[*components.SphereCollider](a)
+	sphereB := engine.GetComponent[*components.SphereCollider](b)
+	boxA := engine.GetComponent[*components.BoxCollider](a)
+	boxB := engine.GetComponent[*components.BoxCollider](b)
+
+	switch {
+	case sphereA != nil && sphereB != nil:
+		_, _, ok := sphereOverlap(sphereA.GetCenter(), sphereA.GetWorldRadius(), sphereB.GetCenter(), sphereB.GetWorldRadius())
+		return ok
+	case sphereA != nil && boxB != nil:
+		gap, _, _ := sphereOBBGap(sphereA.GetCenter(), sphereA.GetWorldRadius(), boxColliderOBB(b, boxB))
+		return gap < 0
+	case boxA != nil && sphereB != nil:
+		gap, _, _ := sphereOBBGap(sphereB.GetCenter(), sphereB.GetWorldRadius(), boxColliderOBB(a, boxA))
+		return gap < 0
+	case boxA != nil && boxB != nil:
+		gap, _, _ := obbOBBGap(boxColliderOBB(a, boxA), boxColliderOBB(b, boxB))
+		return gap < 0
+	}
+	return false
+}
+
+// characterBox returns the box a character moves itself with: the bounds of
+// its CharacterController, or the BoxCollider of a player moved by
+// PlayerCollision. Characters bypass the collision phases, so triggers are
+// found for them through this box instead.
+func characterBox(g *engine.GameObject) (OBB, bool) {
+	if cc := engine.GetComponent[*components.CharacterController](g); cc != nil {
+		return NewAABBasOBB(g.WorldPosition(), rl.Vector3{X: cc.Radius * 2, Y: cc.Height, Z: cc.Radius * 2}), true
+	}
+	if engine.FindComponent[engine.PlayerController](g) == nil {
+		return OBB{}, false
+	}
+	box := engine.GetComponent[*components.BoxCollider](g)
+	if box == nil || box.IsTrigger {
+		return OBB{}, false
+	}
+	return boxColliderOBB(g, box), true
+}
+
+// detectCharacterTriggers records every trigger overlapping a character's box
+// this frame
+func (p *PhysicsWorld) detectCharacterTriggers() {
+	for _, list := range [][]*engine.GameObject{p.Statics, p.Kinematics, p.Objects} {
+		for _, g := range list {
+			box, ok := characterBox(g)
+			if !ok || !g.Active {
+				continue
+			}
+			q := boxQuery(box)
+			for _, other := range p.overlapCandidates(q.bounds) {
+				if other != g && IsTrigger(other) && q.overlaps(other) {
+					p.currentTriggers[makePair(g, other)] = true
+				}
+			}
+		}
+	}
+}
+
+// dispatchTriggerCallbacks sends OnTriggerEnter/Exit to handlers on both
+// objects of every pair that started or stopped overlapping this frame
+func (p *PhysicsWorld) dispatchTriggerCallbacks() {
+	for pair := range p.currentTriggers {
+		if !p.activeTriggers[pair] {
+			notifyTriggerEnter(pair.A, pair.B)
+			notifyTriggerEnter(pair.B, pair.A)
+		}
+	}
+	for pair := range p.activeTriggers {
+		if !p.currentTriggers[pair] {
+			notifyTriggerExit(pair.A, pair.B)
+			notifyTriggerExit(pair.B, pair.A)
+		}
+	}
+
+	// Swap buffers
+	p.activeTriggers = p.currentTriggers
+}
+
+// notifyTriggerEnter calls OnTriggerEnter on all handlers in obj
+func notifyTriggerEnter(obj, other *engine.GameObject) {
+	for _, comp := range obj.Components() {
+		if handler, ok := comp.(engine.TriggerHandler); ok {
+			handler.OnTriggerEnter(other)
+		}
+	}
+}
+
+// notifyTriggerExit calls OnTriggerExit on all handlers in obj
+func notifyTriggerExit(obj, other *engine.GameObject) {
+	for _, comp := range obj.Components() {
+		if handler, ok := comp.(engine.TriggerHandler); ok {
+			handler.OnTriggerExit(other)
+		}
+	}
+}
@@ -49,18 +49,137 @@ type PhysicsWorld struct {
 	Statics    []*engine.GameObject // no rigidbody (walls, floor)
 	grid       map[CellKey][]*engine.GameObject
 
-	// Collision tracking for callbacks
-	activeCollisions  map[CollisionPair]bool // collisions from last frame
-	currentCollisions map[CollisionPair]bool // collisions this frame
+	// Dynamic objects spanning too many cells to insert, checked against everything
+	largeObjects []*engine.GameObject
+
+	// gridFresh is set while the dynamic grid matches the bodies' positions,
+	// so spatial queries between steps rebuild it once and then share it
+	gridFresh bool
 
-	// Normal forces - accumulated during collision resolution, applied before gravity
-	normalForces map[*engine.GameObject]rl.Vector3
+	// Static spatial grid - statics are inserted into every cell their bounds overlap.
+	// Rebuilt lazily when statics are added, removed or moved.
+	staticGrid   map[CellKey][]*engine.GameObject
+	staticBounds map[*engine.GameObject]AABB
+	largeStatics []*engine.GameObject // too big for the grid, checked against everything
+	staticSeen   map[*engine.GameObject]bool
+	staticsDirty bool
+
+	// Collision tracking for callbacks
+	activeCollisions  map[CollisionPair]bool    // collisions from last frame
+	currentCollisions map[CollisionPair]bool    // collisions this frame
+	impacts           map[CollisionPair]float32 // strongest impact impulse per pair this frame
+
+	// Trigger overlaps, tracked apart from collisions so they never join
+	// sleep islands or fire OnCollisionEnter
+	activeTriggers  map[CollisionPair]bool // overlaps from last frame
+	currentTriggers map[CollisionPair]bool // overlaps this frame
+
+	// CollisionCooldown (seconds) stops a pair that touches again within this
+	// long of its last OnCollisionEnter from firing enter/exit again, so a
+	// bouncing ball doesn't machine-gun its handlers (0 = off)
+	CollisionCooldown float32
+	elapsed           float32                   // simulated seconds, for the cooldown
+	lastEnter         map[CollisionPair]float32 // when each pair last fired OnCollisionEnter
+	muted             map[CollisionPair]bool    // touching pairs whose enter the cooldown swallowed
+
+	// Sleeping body -> bodies it fell asleep with (rebuilt every step)
+	sleepingIslands map[*engine.GameObject][]*engine.GameObject
+
+	// Positional correction: each step pushes overlapping bodies apart by
+	// Baumgarte (0-1) of their penetration beyond Slop, instead of all of it.
+	// Resting contacts keep a slop-deep overlap, which keeps stacks from jittering.
+	Slop      float32
+	Baumgarte float32
+
+	// FixedUpdate steps the simulation FixedDeltaTime seconds at a time,
+	// whatever the frame rate, carrying leftover frame time over to the next
+	// frame (0 = one step of the frame's own length). After a stall it runs at
+	// most MaxSubsteps steps and drops the rest of the backlog, rather than
+	// falling further behind each frame.
+	FixedDeltaTime float32
+	MaxSubsteps    int
+	accumulator    float32 // frame time not yet simulated
+
+	// Solo, when set, limits simulation to this object and its children.
+	// Every other dynamic body keeps its position and velocity (editor debugging).
+	Solo *engine.GameObject
+
+	// Overrides the automatic CPU/GPU broad-phase choice (for A/B testing)
+	BroadPhaseMode BroadPhaseMode
 
 	// GPU broad-phase (nil if compute unavailable or object count too low)
 	gpuBroadPhase   *compute.BroadPhase
 	useGPU          bool      // switches on when object count exceeds threshold
 	lastLoggedCount int       // prevents duplicate logs at same object count
 	lastLogTime     time.Time // rate-limit collision pair logs
+
+	// Per-phase timings and counts from the last FixedUpdate
+	stats PhysicsStats
+}
+
+// PhysicsStats breaks down the cost of the last FixedUpdate by phase. The
+// timings are summed over its steps; the counts are from its last step.
+type PhysicsStats struct {
+	Integrate        time.Duration // 1. forces + integration
+	BroadPhase       time.Duration // 2. broad-phase + dynamic vs dynamic narrow-phase
+	KinematicDynamic time.Duration // 3.
+	DynamicStatic    time.Duration // 4.
+	KinematicStatic  time.Duration // 5.
+	KinematicMesh    time.Duration // 6.
+	DynamicMesh      time.Duration // 7.
+	Callbacks        time.Duration // 8. collision and trigger enter/exit dispatch
+	Total            time.Duration
+
+	Steps                int            // fixed steps the last FixedUpdate ran
+	BroadPhasePairs      int            // candidate pairs handed to the narrow-phase
+	BroadPhaseMismatches int            // pairs CPU and GPU disagreed on (compare mode only)
+	GPUContacts          int            // sphere pairs whose contacts the GPU narrow-phase computed
+	Contacts             int            // colliding pairs recorded this step
+	Islands              int            // groups of touching dynamic bodies
+	Sleeping             int            // dynamic bodies asleep after this step
+	GPU                  bool           // broad-phase ran on the GPU
+	Mode                 BroadPhaseMode // broad-phase mode in effect
+}
+
+// setTimings replaces s's timings with t's, keeping its counts
+func (s *PhysicsStats) setTimings(t PhysicsStats) {
+	s.Integrate, s.BroadPhase = t.Integrate, t.BroadPhase
+	s.KinematicDynamic, s.DynamicStatic = t.KinematicDynamic, t.DynamicStatic
+	s.KinematicStatic, s.KinematicMesh, s.DynamicMesh = t.KinematicStatic, t.KinematicMesh, t.DynamicMesh
+	s.Callbacks, s.Total = t.Callbacks, t.Total
+}
+
+// addTimings adds t's timings to s's
+func (s *PhysicsStats) addTimings(t PhysicsStats) {
+	s.Integrate += t.Integrate
+	s.BroadPhase += t.BroadPhase
+	s.KinematicDynamic += t.KinematicDynamic
+	s.DynamicStatic += t.DynamicStatic
+	s.KinematicStatic += t.KinematicStatic
+	s.KinematicMesh += t.KinematicMesh
+	s.DynamicMesh += t.DynamicMesh
+	s.Callbacks += t.Callbacks
+	s.Total += t.Total
+}
+
+// PhaseTiming is a named phase duration, for display.
+type PhaseTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Phases returns the phase timings in execution order.
+func (s PhysicsStats) Phases() []PhaseTiming {
+	return []PhaseTiming{
+		{"Integrate", s.Integrate},
+		{"Broad-phase", s.BroadPhase},
+		{"Kin vs Dyn", s.KinematicDynamic},
+		{"Dyn vs Static", s.DynamicStatic},
+		{"Kin vs Static", s.KinematicStatic},
+		{"Kin vs Mesh", s.KinematicMesh},
+		{"Dyn vs Mesh", s.DynamicMesh},
+		{"Callbacks", s.Callbacks},
+	}
 }
 
 // GPUBroadPhaseThreshold is the minimum object count before GPU broad-phase kicks in.
@@ -70,30 +189,73 @@ const GPUBroadPhaseThreshold = 750
 // MaxPhysicsObjects is the maximum objects the GPU broad-phase can handle.
 const MaxPhysicsObjects = 50000
 
+// Default positional correction settings (see PhysicsWorld.Slop)
+const (
+	DefaultSlop      = 0.01
+	DefaultBaumgarte = 0.2
+)
+
+// Default fixed timestep settings (see PhysicsWorld.FixedDeltaTime)
+const (
+	DefaultFixedDeltaTime = 1.0 / 60
+	DefaultMaxSubsteps    = 5
+)
+
+// DefaultGravity is the Y acceleration of a new physics world (units/sec²)
+const DefaultGravity = -20.0
+
 // NewPhysicsWorld creates a new physics world
 func NewPhysicsWorld() *PhysicsWorld {
 	return &PhysicsWorld{
-		Gravity:           rl.Vector3{X: 0, Y: -20.0, Z: 0},
+		Gravity:           rl.Vector3{X: 0, Y: DefaultGravity, Z: 0},
 		Objects:           make([]*engine.GameObject, 0),
 		Kinematics:        make([]*engine.GameObject, 0),
 		Statics:           make([]*engine.GameObject, 0),
 		grid:              make(map[CellKey][]*engine.GameObject),
 		activeCollisions:  make(map[CollisionPair]bool),
 		currentCollisions: make(map[CollisionPair]bool),
-		normalForces:      make(map[*engine.GameObject]rl.Vector3),
+		impacts:           make(map[CollisionPair]float32),
+		activeTriggers:    make(map[CollisionPair]bool),
+		currentTriggers:   make(map[CollisionPair]bool),
+		lastEnter:         make(map[CollisionPair]float32),
+		muted:             make(map[CollisionPair]bool),
+		Slop:              DefaultSlop,
+		Baumgarte:         DefaultBaumgarte,
+		FixedDeltaTime:    DefaultFixedDeltaTime,
+		MaxSubsteps:       DefaultMaxSubsteps,
+	}
+}
+
+// newGPUBroadPhase creates the GPU broad-phase (replaced in tests to
+// simulate machines without a usable GPU)
+var newGPUBroadPhase = func() (*compute.BroadPhase, error) {
+	if _, err := compute.Initialize(); err != nil {
+		return nil, err
 	}
+	return compute.NewBroadPhase(MaxPhysicsObjects, MaxPhysicsObjects*20, compute.DefaultWorkgroupSize)
 }
 
-// InitGPU initializes GPU broad-phase. Call after compute.Initialize().
-func (p *PhysicsWorld) InitGPU() {
+// InitGPU sets up the GPU broad-phase, initializing compute if needed. When
+// there is no usable GPU it logs why once and returns the error; the world
+// then stays on the CPU broad-phase whatever BroadPhaseMode says.
+func (p *PhysicsWorld) InitGPU() error {
 	if p.gpuBroadPhase != nil {
-		return // Already initialized
+		return nil // Already initialized
 	}
-	bp, err := compute.NewBroadPhase(MaxPhysicsObjects, MaxPhysicsObjects*20)
-	if err == nil && bp != nil {
-		p.gpuBroadPhase = bp
-		log.Printf("Physics: GPU broad-phase ready (threshold: %d objects)", GPUBroadPhaseThreshold)
+	bp, err := newGPUBroadPhase()
+	if err != nil {
+		log.Printf("Physics: GPU broad-phase unavailable, using CPU: %v", err)
+		return err
 	}
+	p.gpuBroadPhase = bp
+	log.Printf("Physics: GPU broad-phase ready (threshold: %d objects)", GPUBroadPhaseThreshold)
+	return nil
+}
+
+// GPUAvailable reports whether the GPU broad-phase was set up, so the
+// broad-phase can run on the GPU (see UsingGPU for whether it currently does)
+func (p *PhysicsWorld) GPUAvailable() bool {
+	return p.gpuBroadPhase != nil
 }
 
 // rebuildGrid clears and repopulates the spatial hash grid
@@ -103,11 +265,21 @@ func (p *PhysicsWorld) rebuildGrid() {
 		delete(p.grid, k)
 	}
 
-	// Insert all dynamic objects
+	p.largeObjects = p.largeObjects[:0]
+
+	// Insert each dynamic object into every cell its bounds overlap,
+	// so objects bigger than a cell still share a cell with anything they touch
 	for _, obj := range p.Objects {
-		cell := posToCell(obj.Transform.Position)
-		p.grid[cell] = append(p.grid[cell], obj)
+		span := objectCellSpan(obj)
+		if span.count > maxObjectCells {
+			p.largeObjects = append(p.largeObjects, obj)
+			continue
+		}
+		span.each(func(key CellKey) {
+			p.grid[key] = append(p.grid[key], obj)
+		})
 	}
+	p.gridFresh = true
 }
 
 // buildBoundingSpheres creates sphere bounds for all dynamic objects
@@ -120,11 +292,13 @@ func (p *PhysicsWorld) buildBoundingSpheres() []compute.Sphere {
 
 		// Get actual collider radius
 		if sphere := engine.GetComponent[*components.SphereCollider](obj); sphere != nil {
-			radius = sphere.Radius
+			radius = sphere.GetWorldRadius()
 		} else if box := engine.GetComponent[*components.BoxCollider](obj); box != nil {
 			// Use half-diagonal of box as bounding sphere radius
 			size := box.GetWorldSize()
 			radius = rl.Vector3Length(size) * 0.5
+		} else if capsule := engine.GetComponent[*components.CapsuleCollider](obj); capsule != nil {
+			radius = capsuleBoundingRadius(obj, capsule)
 		}
 
 		spheres[i] = compute.Sphere{
@@ -138,20 +312,18 @@ func (p *PhysicsWorld) buildBoundingSpheres() []compute.Sphere {
 	return spheres
 }
 
-// getNeighborObjects returns all objects in same cell and 26 neighboring cells
+// getNeighborObjects returns all objects sharing a cell with the cells the object's bounds span.
+// May contain duplicates and the object itself.
 func (p *PhysicsWorld) getNeighborObjects(obj *engine.GameObject) []*engine.GameObject {
-	cell := posToCell(obj.Transform.Position)
-	var neighbors []*engine.GameObject
-
-	// Check 3x3x3 cube of cells centered on object's cell
-	for dx := -1; dx <= 1; dx++ {
-		for dy := -1; dy <= 1; dy++ {
-			for dz := -1; dz <= 1; dz++ {
-				key := CellKey{cell.X + dx, cell.Y + dy, cell.Z + dz}
-				neighbors = append(neighbors, p.grid[key]...)
-			}
-		}
+	span := objectCellSpan(obj)
+	if span.count > maxObjectCells {
+		return p.Objects // too big for the grid, check against everything
 	}
+
+	neighbors := append([]*engine.GameObject(nil), p.largeObjects...)
+	span.each(func(key CellKey) {
+		neighbors = append(neighbors, p.grid[key]...)
+	})
 	return neighbors
 }
 
@@ -160,10 +332,12 @@ func (p *PhysicsWorld) AddObject(g *engine.GameObject) {
 	rb := engine.GetComponent[*components.Rigidbody](g)
 	if rb == nil {
 		p.Statics = append(p.Statics, g)
+		p.staticsDirty = true
 	} else if rb.IsKinematic {
 		p.Kinematics = append(p.Kinematics, g)
 	} else {
 		p.Objects = append(p.Objects, g)
+		p.gridFresh = false
 	}
 }
 
@@ -173,6 +347,7 @@ func (p *PhysicsWorld) RemoveObject(g *engine.GameObject) {
 	for i, obj := range p.Objects {
 		if obj == g {
 			p.Objects = append(p.Objects[:i], p.Objects[i+1:]...)
+			p.gridFresh = false
 			return
 		}
 	}
@@ -187,6 +362,7 @@ func (p *PhysicsWorld) RemoveObject(g *engine.GameObject) {
 	for i, obj := range p.Statics {
 		if obj == g {
 			p.Statics = append(p.Statics[:i], p.Statics[i+1:]...)
+			p.staticsDirty = true
 			return
 		}
 	}
@@ -205,54 +381,134 @@ func (p *PhysicsWorld) UsingGPU() bool {
 	return p.useGPU
 }
 
+// Stats returns the timings and pair counts from the last FixedUpdate.
+func (p *PhysicsWorld) Stats() PhysicsStats {
+	return p.stats
+}
+
 // DynamicObjectCount returns the number of dynamic physics objects
 func (p *PhysicsWorld) DynamicObjectCount() int {
 	return len(p.Objects)
 }
 
-// Update runs one physics simulation step
+// FixedUpdate advances the simulation by a frame's deltaTime in fixed steps
+// of FixedDeltaTime, so collisions and damping behave the same at any frame
+// rate. Forces added this frame apply to each step and are cleared after the
+// last. Returns how many steps ran, which is 0 when the frame was shorter than
+// a step.
+func (p *PhysicsWorld) FixedUpdate(deltaTime float32) int {
+	defer p.clearForces()
+	if p.FixedDeltaTime <= 0 {
+		p.Update(deltaTime)
+		p.stats.Steps = 1
+		return 1
+	}
+	p.accumulator += deltaTime
+	steps := 0
+	var timings PhysicsStats
+	for p.accumulator >= p.FixedDeltaTime {
+		if steps >= max(p.MaxSubsteps, 1) {
+			// Spiral of death guard: let the simulation fall behind real time
+			p.accumulator = 0
+			break
+		}
+		p.Update(p.FixedDeltaTime)
+		timings.addTimings(p.stats)
+		p.accumulator -= p.FixedDeltaTime
+		steps++
+	}
+	p.stats.setTimings(timings)
+	p.stats.Steps = steps
+	return steps
+}
+
+// clearForces drops the forces scripts added to dynamic bodies this frame
+func (p *PhysicsWorld) clearForces() {
+	for _, obj := range p.Objects {
+		if rb := engine.GetComponent[*components.Rigidbody](obj); rb != nil {
+			rb.ClearForces()
+		}
+	}
+}
+
+// Update runs one physics simulation step. Forces scripts added stay on the
+// bodies until FixedUpdate clears them.
 func (p *PhysicsWorld) Update(deltaTime float32) {
 	// Reset current frame collisions
 	p.currentCollisions = make(map[CollisionPair]bool)
+	p.currentTriggers = make(map[CollisionPair]bool)
+	clear(p.impacts)
+	p.elapsed += deltaTime
+
+	var stats PhysicsStats
+	start := time.Now()
+	phaseStart := start
+	endPhase := func(d *time.Duration) {
+		now := time.Now()
+		*d = now.Sub(phaseStart)
+		phaseStart = now
+	}
+
+	frozen := p.freezeBodies()
 
-	// 1. Apply forces (gravity + normal forces from previous frame) and integrate velocity
+	// Spring joints add velocity before integration (sleeping bodies wake below if pulled hard enough)
+	p.applyJoints(deltaTime)
+
+	// 1. Apply forces (gravity) and integrate velocity
 	for _, obj := range p.Objects {
 		rb := engine.GetComponent[*components.Rigidbody](obj)
-		if rb == nil {
+		if rb == nil || p.isFrozen(obj) {
 			continue
 		}
 
+		// Forces from scripts always wake the body, however small
+		if rb.ApplyForces(deltaTime) && rb.IsSleeping {
+			p.wakeIsland(obj)
+		}
+
 		// Skip sleeping objects
 		if rb.IsSleeping {
+			// Wake on force: a script or explosion gave the body real velocity.
+			// Tiny residual impulses from resting contacts are discarded.
+			if rl.Vector3Length(rb.Velocity) <= components.SleepVelocityThreshold &&
+				rl.Vector3Length(rb.AngularVelocity) <= components.SleepAngularThreshold {
+				rb.Velocity = rl.Vector3{}
+				rb.AngularVelocity = rl.Vector3{}
+				continue
+			}
+			p.wakeIsland(obj)
+		}
+
+		// Zero/negative mass is infinite mass - the body doesn't move
+		if rb.IsImmovable() {
+			rb.Velocity = rl.Vector3{}
+			rb.AngularVelocity = rl.Vector3{}
 			continue
 		}
 
 		// Apply gravity
 		if rb.UseGravity {
-			gravityAccel := rl.Vector3Scale(p.Gravity, deltaTime)
-
-			// Apply normal force from last frame to counter gravity (prevents sinking)
-			if normalForce, hasNormal := p.normalForces[obj]; hasNormal {
-				// Normal force counters gravity
-				normalAccel := rl.Vector3Scale(normalForce, deltaTime/rb.Mass)
-				gravityAccel = rl.Vector3Add(gravityAccel, normalAccel)
-			}
-
-			rb.Velocity = rl.Vector3Add(rb.Velocity, gravityAccel)
+			rb.Velocity = rl.Vector3Add(rb.Velocity, rl.Vector3Scale(p.Gravity, deltaTime))
 		}
 
 		// Integrate position
+		prev := obj.Transform.Position
 		obj.Transform.Position = rl.Vector3Add(
 			obj.Transform.Position,
 			rl.Vector3Scale(rb.Velocity, deltaTime),
 		)
+		if rb.UseCCD {
+			p.sweepCCD(obj, rb, prev)
+		}
 
-		// Integrate rotation for all rigidbodies (now that we have OBB collision)
-		obj.Transform.Rotation = rl.Vector3Add(
-			obj.Transform.Rotation,
-			rl.Vector3Scale(rb.AngularVelocity, deltaTime),
-		)
-		obj.Transform.MarkRotationDirty() // Quaternion cache needs refresh
+		// Integrate rotation as a quaternion, turning about the world-space
+		// angular velocity axis. Adding the velocity to the Euler angles instead
+		// wobbles once the X and Z angles grow (gimbal lock).
+		if spin := rl.Vector3Length(rb.AngularVelocity); spin > 0 {
+			axis := rl.Vector3Scale(rb.AngularVelocity, 1/spin)
+			step := rl.QuaternionFromAxisAngle(axis, spin*deltaTime*rl.Deg2rad)
+			obj.SetWorldQuaternion(rl.QuaternionMultiply(step, obj.WorldQuaternion()))
+		}
 
 		// Apply angular damping (time-based so it's framerate independent)
 		damping := float32(1.0) - (1.0-rb.AngularDamping)*deltaTime*60
@@ -266,17 +522,16 @@ func (p *PhysicsWorld) Update(deltaTime float32) {
 			applyBoxFlatteningTorque(obj, rb, boxCollider, deltaTime)
 		}
 
-		// Check if object should go to sleep
-		rb.TrySleep(deltaTime)
+		// Track rest time - islands are put to sleep together after the collision phases
+		rb.UpdateSleepTimer(deltaTime)
 	}
 
-	// Clear normal forces - they will be recalculated during collision resolution
-	p.normalForces = make(map[*engine.GameObject]rl.Vector3)
+	endPhase(&stats.Integrate)
 
 	// 2. Broad-phase collision detection
-	// Use GPU when object count is high enough to benefit
+	// Use GPU when object count is high enough to benefit, unless BroadPhaseMode forces a choice
 	wasUsingGPU := p.useGPU
-	p.useGPU = p.gpuBroadPhase != nil && len(p.Objects) >= GPUBroadPhaseThreshold
+	p.useGPU = p.shouldUseGPU()
 
 	// Log when GPU kicks in or out, and periodically show object count
 	if p.useGPU && !wasUsingGPU {
@@ -292,50 +547,49 @@ func (p *PhysicsWorld) Update(deltaTime float32) {
 		log.Printf("Physics: %d objects (%s)", len(p.Objects), mode)
 	}
 
-	if p.useGPU {
+	switch {
+	case p.useGPU && p.BroadPhaseMode == BroadPhaseCompare:
+		// Diagnostic: run both and resolve the CPU pairs
+		stats.BroadPhasePairs, stats.BroadPhaseMismatches = p.compareBroadPhase()
+	case p.useGPU:
 		// GPU broad-phase: get collision pairs from compute shader
 		spheres := p.buildBoundingSpheres()
 		pairs, err := p.gpuBroadPhase.DetectPairs(spheres)
-		if err == nil {
+		if err != nil {
+			// Don't lose collisions: resolve this frame on the CPU instead
+			if time.Since(p.lastLogTime) >= time.Second {
+				p.lastLogTime = time.Now()
+				log.Printf("Physics: GPU broad-phase failed, using CPU this frame: %v", err)
+			}
+			cpuPairs := p.cpuBroadPhasePairs()
+			stats.BroadPhasePairs = len(cpuPairs)
+			for _, pair := range cpuPairs {
+				p.resolveCollision(pair[0], pair[1])
+			}
+		} else {
 			// Log collision pairs once per second
 			if len(pairs) > 0 && time.Since(p.lastLogTime) >= time.Second {
 				p.lastLogTime = time.Now()
 				log.Printf("Physics: GPU detected %d collision pairs (%d objects)", len(pairs), len(p.Objects))
 			}
 			// Narrow-phase only on pairs the GPU found
-			for _, pair := range pairs {
-				if int(pair.A) < len(p.Objects) && int(pair.B) < len(p.Objects) {
-					p.resolveCollision(p.Objects[pair.A], p.Objects[pair.B])
-				}
-			}
+			stats.BroadPhasePairs = len(pairs)
+			stats.GPUContacts = p.resolveGPUPairs(spheres, pairs)
 		}
-	} else {
+	default:
 		// CPU broad-phase: spatial hashing
-		p.rebuildGrid()
-
-		// Track checked pairs to avoid duplicate checks
-		checked := make(map[[2]uintptr]bool)
-
-		for _, obj := range p.Objects {
-			neighbors := p.getNeighborObjects(obj)
-			for _, other := range neighbors {
-				if obj == other {
-					continue
-				}
-				// Create consistent pair key using pointer addresses (smaller first)
-				ptrA, ptrB := uintptr(unsafe.Pointer(obj)), uintptr(unsafe.Pointer(other))
-				if ptrA > ptrB {
-					ptrA, ptrB = ptrB, ptrA
-				}
-				key := [2]uintptr{ptrA, ptrB}
-				if checked[key] {
-					continue
-				}
-				checked[key] = true
-				p.resolveCollision(obj, other)
-			}
+		pairs := p.cpuBroadPhasePairs()
+		for _, pair := range pairs {
+			p.resolveCollision(pair[0], pair[1])
 		}
+		stats.BroadPhasePairs = len(pairs)
 	}
+	stats.GPU = p.useGPU
+	stats.Mode = p.BroadPhaseMode
+	endPhase(&stats.BroadPhase)
+
+	// Statics are only tested against nearby objects from here on
+	p.refreshStaticGrid()
 
 	// 3. Kinematic vs Dynamic collision (kinematic pushes dynamic)
 	for _, kinematic := range p.Kinematics {
@@ -343,37 +597,61 @@ func (p *PhysicsWorld) Update(deltaTime float32) {
 			p.resolveKinematicCollision(kinematic, obj)
 		}
 	}
+	endPhase(&stats.KinematicDynamic)
 
 	// 4. Rigidbody vs Static collision
 	for _, obj := range p.Objects {
-		for _, static := range p.Statics {
+		for _, static := range p.nearbyStatics(obj) {
 			p.resolveStaticCollision(obj, static)
 		}
 	}
+	endPhase(&stats.DynamicStatic)
 
 	// 5. Kinematic vs Static collision (player vs walls/static objects)
 	for _, kinematic := range p.Kinematics {
-		for _, static := range p.Statics {
+		for _, static := range p.nearbyStatics(kinematic) {
 			p.resolveKinematicStaticCollision(kinematic, static)
 		}
 	}
+	endPhase(&stats.KinematicStatic)
 
 	// 6. Kinematic vs MeshCollider (player vs terrain/complex geometry)
 	for _, kinematic := range p.Kinematics {
-		for _, static := range p.Statics {
+		for _, static := range p.nearbyStatics(kinematic) {
 			p.resolveKinematicMeshCollision(kinematic, static)
 		}
 	}
+	endPhase(&stats.KinematicMesh)
 
 	// 7. Dynamic vs MeshCollider
 	for _, obj := range p.Objects {
-		for _, static := range p.Statics {
+		for _, static := range p.nearbyStatics(obj) {
 			p.resolveDynamicMeshCollision(obj, static)
 		}
 	}
+	endPhase(&stats.DynamicMesh)
+
+	thawBodies(frozen)
+
+	// Bodies moved since the broad-phase, queries must rebuild the grid
+	p.gridFresh = false
 
-	// 8. Dispatch collision callbacks
+	// Sleep or wake touching bodies together
+	stats.Islands, stats.Sleeping = p.updateSleepIslands()
+
+	// Characters move themselves, so only their trigger overlaps and ground
+	// checks are found here
+	p.detectCharacterTriggers()
+	p.updateGrounding()
+
+	// 8. Dispatch collision and trigger callbacks
+	stats.Contacts = len(p.currentCollisions)
 	p.dispatchCollisionCallbacks()
+	p.dispatchTriggerCallbacks()
+	endPhase(&stats.Callbacks)
+
+	stats.Total = time.Since(start)
+	p.stats = stats
 }
 
 // recordCollision marks a collision pair as active this frame and wakes sleeping objects
@@ -381,11 +659,15 @@ func (p *PhysicsWorld) recordCollision(a, b *engine.GameObject) {
 	pair := makePair(a, b)
 	p.currentCollisions[pair] = true
 
-	// Wake sleeping rigidbodies only if collision has significant relative velocity
-	// This prevents micro-collisions from waking settled stacks
 	rbA := engine.GetComponent[*components.Rigidbody](a)
 	rbB := engine.GetComponent[*components.Rigidbody](b)
 
+	// Keep the hardest hit of the frame for ImpactHandlers (velocities are
+	// still the ones from before the collision is resolved)
+	p.impacts[pair] = max(p.impacts[pair], impactImpulse(rbA, rbB))
+
+	// Wake sleeping rigidbodies only if collision has significant relative velocity
+	// This prevents micro-collisions from waking settled stacks
 	if rbA != nil && rbB != nil {
 		relVel := rl.Vector3Subtract(rbA.Velocity, rbB.Velocity)
 		relSpeed := rl.Vector3Length(relVel)
@@ -395,42 +677,109 @@ func (p *PhysicsWorld) recordCollision(a, b *engine.GameObject) {
 
 		if relSpeed > wakeThreshold {
 			if rbA.IsSleeping {
-				rbA.Wake()
+				p.wakeIsland(a)
 			}
 			if rbB.IsSleeping {
-				rbB.Wake()
+				p.wakeIsland(b)
 			}
 		}
 	}
 }
 
-// dispatchCollisionCallbacks sends OnCollisionEnter/Exit to handlers
+// impactImpulse estimates how hard two bodies hit: their relative speed times
+// the pair's effective mass, i.e. the impulse that would stop them dead. It is
+// taken before the collision is resolved. Objects without a rigidbody count as
+// immovable and still.
+func impactImpulse(rbA, rbB *components.Rigidbody) float32 {
+	var velA, velB rl.Vector3
+	var invMassSum float32
+	if rbA != nil {
+		velA = rbA.Velocity
+		invMassSum += rbA.InverseMass()
+	}
+	if rbB != nil {
+		velB = rbB.Velocity
+		invMassSum += rbB.InverseMass()
+	}
+	if invMassSum == 0 {
+		return 0
+	}
+	return rl.Vector3Length(rl.Vector3Subtract(velA, velB)) / invMassSum
+}
+
+// dispatchCollisionCallbacks sends OnCollisionEnter/Exit to handlers, then runs
+// the tag callbacks registered with engine.OnCollisionWithTag for new collisions
 func (p *PhysicsWorld) dispatchCollisionCallbacks() {
 	// Find new collisions (enter)
+	var entered []CollisionPair
 	for pair := range p.currentCollisions {
 		if !p.activeCollisions[pair] {
+			// Touching again too soon: stay quiet until the pair separates
+			if p.coolingDown(pair) {
+				p.muted[pair] = true
+				continue
+			}
+			if p.CollisionCooldown > 0 {
+				p.lastEnter[pair] = p.elapsed
+			}
+
 			// New collision - call OnCollisionEnter
-			p.notifyCollisionEnter(pair.A, pair.B)
-			p.notifyCollisionEnter(pair.B, pair.A)
+			impulse := p.impacts[pair]
+			p.notifyCollisionEnter(pair.A, pair.B, impulse)
+			p.notifyCollisionEnter(pair.B, pair.A, impulse)
+			entered = append(entered, pair)
 		}
 	}
 
 	// Find ended collisions (exit)
 	for pair := range p.activeCollisions {
 		if !p.currentCollisions[pair] {
+			// No exit for an enter that never fired
+			if p.muted[pair] {
+				delete(p.muted, pair)
+				continue
+			}
+
 			// Collision ended - call OnCollisionExit
 			p.notifyCollisionExit(pair.A, pair.B)
 			p.notifyCollisionExit(pair.B, pair.A)
 		}
 	}
 
+	// Tag-filtered callbacks
+	for _, pair := range entered {
+		pair.A.NotifyTagCollision(pair.B)
+		pair.B.NotifyTagCollision(pair.A)
+	}
+
+	// Forget separated pairs once their cooldown has run out
+	for pair, t := range p.lastEnter {
+		if !p.currentCollisions[pair] && p.elapsed-t >= p.CollisionCooldown {
+			delete(p.lastEnter, pair)
+		}
+	}
+
 	// Swap buffers
 	p.activeCollisions = p.currentCollisions
 }
 
-// notifyCollisionEnter calls OnCollisionEnter on all handlers in obj
-func (p *PhysicsWorld) notifyCollisionEnter(obj, other *engine.GameObject) {
+// coolingDown reports whether pair fired OnCollisionEnter less than
+// CollisionCooldown ago
+func (p *PhysicsWorld) coolingDown(pair CollisionPair) bool {
+	if p.CollisionCooldown <= 0 {
+		return false
+	}
+	t, ok := p.lastEnter[pair]
+	return ok && p.elapsed-t < p.CollisionCooldown
+}
+
+// notifyCollisionEnter calls OnCollisionEnter on all handlers in obj, after
+// telling impact handlers how hard the hit was
+func (p *PhysicsWorld) notifyCollisionEnter(obj, other *engine.GameObject, impulse float32) {
 	for _, comp := range obj.Components() {
+		if handler, ok := comp.(engine.ImpactHandler); ok {
+			handler.OnCollisionImpact(other, impulse)
+		}
 		if handler, ok := comp.(engine.CollisionHandler); ok {
 			handler.OnCollisionEnter(other)
 		}
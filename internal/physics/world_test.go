@@ -0,0 +1,1321 @@
+package physics
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"test3d/internal/components"
+	"test3d/internal/compute"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func newBody(name string, pos rl.Vector3, mass float32, sphere bool) *engine.GameObject {
+	obj := engine.NewGameObject(name)
+	obj.Transform.Position = pos
+	rb := components.NewRigidbody()
+	rb.Mass = mass
+	rb.CanSleep = false
+	obj.AddComponent(rb)
+	if sphere {
+		obj.AddComponent(components.NewSphereCollider(0.5))
+	} else {
+		obj.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1}))
+	}
+	return obj
+}
+
+func isFiniteVec(v rl.Vector3) bool {
+	for _, f := range []float32{v.X, v.Y, v.Z} {
+		if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestZeroMassDoesNotNaN(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	// Overlapping bodies so every dynamic-vs-dynamic path runs against the zero-mass ones
+	bodies := []*engine.GameObject{
+		newBody("ZeroBox", rl.Vector3{X: 0, Y: 1, Z: 0}, 0, false),
+		newBody("Box", rl.Vector3{X: 0.3, Y: 1.5, Z: 0}, 1, false),
+		newBody("NegSphere", rl.Vector3{X: 3, Y: 1, Z: 0}, -2, true),
+		newBody("Sphere", rl.Vector3{X: 3.4, Y: 1.4, Z: 0}, 1, true),
+		newBody("ZeroSphere", rl.Vector3{X: 0.5, Y: 2, Z: 0}, 0, true),
+	}
+	for _, b := range bodies {
+		p.AddObject(b)
+	}
+
+	for i := 0; i < 120; i++ {
+		p.Update(1.0 / 60.0)
+	}
+
+	for _, b := range bodies {
+		rb := engine.GetComponent[*components.Rigidbody](b)
+		if !isFiniteVec(b.Transform.Position) || !isFiniteVec(rb.Velocity) || !isFiniteVec(rb.AngularVelocity) {
+			t.Errorf("%s went non-finite: pos=%v vel=%v angVel=%v", b.Name, b.Transform.Position, rb.Velocity, rb.AngularVelocity)
+		}
+	}
+}
+
+func TestZeroMassIsImmovable(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	start := rl.Vector3{X: 0, Y: 5, Z: 0}
+	zero := newBody("Zero", start, 0, false)
+	other := newBody("Other", rl.Vector3{X: 0.5, Y: 5, Z: 0}, 1, false)
+	p.AddObject(zero)
+	p.AddObject(other)
+
+	p.Update(1.0 / 60.0)
+
+	if zero.Transform.Position != start {
+		t.Errorf("zero-mass body moved: %v", zero.Transform.Position)
+	}
+	if other.Transform.Position.X <= 0.5 {
+		t.Errorf("dynamic body should be pushed away from the zero-mass body, got x=%v", other.Transform.Position.X)
+	}
+}
+
+func TestPushRatios(t *testing.T) {
+	a, b := components.NewRigidbody(), components.NewRigidbody()
+
+	a.Mass, b.Mass = 1, 3
+	if ra, rb := pushRatios(a, b); ra != 0.75 || rb != 0.25 {
+		t.Errorf("pushRatios(1, 3) = %v, %v; want 0.75, 0.25", ra, rb)
+	}
+
+	a.Mass = 0
+	if ra, rb := pushRatios(a, b); ra != 0 || rb != 1 {
+		t.Errorf("pushRatios(0, 3) = %v, %v; want 0, 1", ra, rb)
+	}
+
+	b.Mass = -1
+	if ra, rb := pushRatios(a, b); ra != 0 || rb != 0 {
+		t.Errorf("pushRatios(0, -1) = %v, %v; want 0, 0", ra, rb)
+	}
+}
+
+func TestLargeObjectBroadPhase(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{}
+
+	// A 20-unit platform spans several cells; the small box touches its far end,
+	// more than one cell away from the platform's center cell
+	big := engine.NewGameObject("Big")
+	big.Transform.Position = rl.Vector3{X: 12, Y: 0, Z: 0}
+	big.AddComponent(components.NewRigidbody())
+	big.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 1}))
+	small := newBody("Small", rl.Vector3{X: 21.8, Y: 0, Z: 0}, 1, false)
+	p.AddObject(big)
+	p.AddObject(small)
+
+	p.Update(1.0 / 60.0)
+
+	if !p.activeCollisions[makePair(big, small)] {
+		t.Error("collision between large object and object in a distant cell was missed")
+	}
+}
+
+func TestSleepIslandSleepsAndWakesTogether(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{Y: -5} // gentle enough for a box stack to settle
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	// Two-box stack, B resting on A
+	a := newBody("A", rl.Vector3{X: 0, Y: 1, Z: 0}, 1, false)
+	b := newBody("B", rl.Vector3{X: 0, Y: 2, Z: 0}, 1, false)
+	rbA := engine.GetComponent[*components.Rigidbody](a)
+	rbB := engine.GetComponent[*components.Rigidbody](b)
+	rbA.CanSleep, rbB.CanSleep = true, true
+	p.AddObject(a)
+	p.AddObject(b)
+
+	// Small steps, like the 120 FPS editor loop
+	for i := 0; i < 600 && !(rbA.IsSleeping || rbB.IsSleeping); i++ {
+		p.Update(1.0 / 120.0)
+		if rbA.IsSleeping != rbB.IsSleeping {
+			t.Fatalf("step %d: touching bodies fell asleep separately (A=%v, B=%v)", i, rbA.IsSleeping, rbB.IsSleeping)
+		}
+	}
+	if !rbA.IsSleeping {
+		t.Fatal("resting bodies never fell asleep")
+	}
+
+	// Kicking one body wakes its island
+	rbA.Velocity = rl.Vector3{X: -5}
+	p.Update(1.0 / 120.0)
+	if rbA.IsSleeping || rbB.IsSleeping {
+		t.Errorf("kicking A should wake both (A=%v, B=%v)", rbA.IsSleeping, rbB.IsSleeping)
+	}
+}
+
+func TestRestingContactSettlesWithinSlop(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	// Floor top is at 0.5, so a resting unit box sits at 1.0
+	box := newBody("Box", rl.Vector3{X: 0, Y: 1.5, Z: 0}, 1, false)
+	ball := newBody("Ball", rl.Vector3{X: 3, Y: 1.5, Z: 0}, 1, true)
+	p.AddObject(box)
+	p.AddObject(ball)
+
+	minY := map[*engine.GameObject]float32{box: 10, ball: 10}
+	maxY := map[*engine.GameObject]float32{}
+	for i := 0; i < 480; i++ {
+		p.Update(1.0 / 120.0)
+		if i < 360 {
+			continue
+		}
+		// Last second: bodies should sit still, not jitter
+		for _, obj := range []*engine.GameObject{box, ball} {
+			minY[obj] = min(minY[obj], obj.Transform.Position.Y)
+			maxY[obj] = max(maxY[obj], obj.Transform.Position.Y)
+		}
+	}
+
+	for _, obj := range []*engine.GameObject{box, ball} {
+		y := obj.Transform.Position.Y
+		if y > 1.0 || y < 1.0-5*DefaultSlop {
+			t.Errorf("%s should rest about a slop deep at 1.0, got %v", obj.Name, y)
+		}
+		if maxY[obj]-minY[obj] > 0.005 {
+			t.Errorf("%s jittered by %v while resting", obj.Name, maxY[obj]-minY[obj])
+		}
+	}
+}
+
+func TestSoloFreezesOtherBodies(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	soloed := newBody("Soloed", rl.Vector3{X: 0, Y: 5, Z: 0}, 1, false)
+	frozen := newBody("Frozen", rl.Vector3{X: 5, Y: 5, Z: 0}, 1, false)
+	// Overlaps the soloed body; contacts must not move it either
+	touching := newBody("Touching", rl.Vector3{X: 0.8, Y: 5, Z: 0}, 1, false)
+	engine.GetComponent[*components.Rigidbody](frozen).Velocity = rl.Vector3{X: 1}
+	p.AddObject(soloed)
+	p.AddObject(frozen)
+	p.AddObject(touching)
+	p.Solo = soloed
+
+	for i := 0; i < 30; i++ {
+		p.Update(1.0 / 60.0)
+	}
+
+	if soloed.Transform.Position.Y >= 5 {
+		t.Errorf("soloed body did not fall: y = %.3f", soloed.Transform.Position.Y)
+	}
+	if frozen.Transform.Position != (rl.Vector3{X: 5, Y: 5, Z: 0}) {
+		t.Errorf("frozen body moved to %v", frozen.Transform.Position)
+	}
+	if v := engine.GetComponent[*components.Rigidbody](frozen).Velocity; v != (rl.Vector3{X: 1}) {
+		t.Errorf("frozen body velocity changed to %v", v)
+	}
+	if touching.Transform.Position != (rl.Vector3{X: 0.8, Y: 5, Z: 0}) {
+		t.Errorf("frozen body pushed by contact to %v", touching.Transform.Position)
+	}
+
+	// Clearing the solo resumes the frozen body with its old velocity
+	p.Solo = nil
+	p.Update(1.0 / 60.0)
+	if frozen.Transform.Position.X <= 5 {
+		t.Error("frozen body did not resume after the solo was cleared")
+	}
+}
+
+func TestRaycastIgnoringSkipsObjectAndChildren(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 10, Y: 1, Z: 10}))
+	character := engine.NewGameObject("Character")
+	character.Transform.Position = rl.Vector3{Y: 2}
+	character.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 2, Z: 1}))
+	feet := engine.NewGameObject("Feet")
+	feet.Transform.Position = rl.Vector3{Y: 1.2}
+	feet.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 0.2, Z: 1}))
+	character.AddChild(feet)
+	p.AddObject(floor)
+	p.AddObject(character)
+	p.AddObject(feet)
+
+	origin := rl.Vector3{Y: 2}
+	down := rl.Vector3{Y: -1}
+	if hit, ok := p.Raycast(origin, down, 10); !ok || hit.GameObject == floor {
+		t.Fatalf("plain raycast should hit the character first, got %v (ok=%v)", hit.GameObject, ok)
+	}
+	hit, ok := p.RaycastIgnoring(origin, down, 10, character)
+	if !ok || hit.GameObject != floor {
+		t.Fatalf("expected to hit the floor, got %v (ok=%v)", hit.GameObject, ok)
+	}
+	if hit.Normal.Y < 0.99 {
+		t.Errorf("expected an upward floor normal, got %v", hit.Normal)
+	}
+}
+
+func TestCharacterGroundingRespectsSlopeLimit(t *testing.T) {
+	newGround := func(verts []float32) *engine.GameObject {
+		mesh := rl.Mesh{VertexCount: int32(len(verts) / 3), TriangleCount: int32(len(verts) / 9), Vertices: &verts[0]}
+		ground := engine.NewGameObject("Ground")
+		mc := components.NewMeshCollider()
+		ground.AddComponent(mc)
+		mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+		return ground
+	}
+	flat := []float32{
+		-5, 0, -5, -5, 0, 5, 5, 0, 5,
+		-5, 0, -5, 5, 0, 5, 5, 0, -5,
+	}
+	// y = sqrt(3) x, a 60 degree slope rising along +X
+	s3 := float32(math.Sqrt(3))
+	steep := []float32{
+		-1, -s3, -2, -1, -s3, 2, 1, s3, 2,
+		-1, -s3, -2, 1, s3, 2, 1, s3, -2,
+	}
+	newCharacter := func(p *PhysicsWorld) *components.CharacterController {
+		g := engine.NewGameObject("Character")
+		g.Transform.Position = rl.Vector3{Y: 0.92} // feet just above y=0
+		cc := components.NewCharacterController()
+		g.AddComponent(cc)
+		p.AddObject(g)
+		return cc
+	}
+
+	p := NewPhysicsWorld()
+	p.AddObject(newGround(flat))
+	cc := newCharacter(p)
+	p.Update(1.0 / 60.0)
+	if !cc.IsGrounded() || cc.GroundNormal().Y < 0.99 {
+		t.Fatalf("character on flat ground: grounded=%v normal=%v", cc.IsGrounded(), cc.GroundNormal())
+	}
+	// Moving between steps keeps the step's result instead of its own guess
+	cc.SimpleMove(rl.Vector3{X: 1}, 1.0/60.0)
+	if !cc.IsGrounded() {
+		t.Error("SimpleMove should not overwrite the step's ground check")
+	}
+	cc.SetVelocityY(5)
+	p.Update(1.0 / 60.0)
+	if cc.IsGrounded() {
+		t.Error("a character moving up from a jump should not be grounded")
+	}
+
+	p = NewPhysicsWorld()
+	p.AddObject(newGround(steep))
+	cc = newCharacter(p)
+	p.Update(1.0 / 60.0)
+	if cc.IsGrounded() || absf(cc.GroundNormal().Y-0.5) > 0.01 {
+		t.Errorf("60 degree slope over a 45 degree limit: grounded=%v normal=%v", cc.IsGrounded(), cc.GroundNormal())
+	}
+	cc.SlopeLimit = 70
+	p.Update(1.0 / 60.0)
+	if !cc.IsGrounded() {
+		t.Error("60 degree slope within a 70 degree limit should count as ground")
+	}
+}
+
+func TestSpringJointPullsBodiesToRestLength(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{}
+
+	scene := engine.NewScene("test")
+	a := newBody("A", rl.Vector3{X: 0, Y: 0, Z: 0}, 1, true)
+	b := newBody("B", rl.Vector3{X: 4, Y: 0, Z: 0}, 1, true)
+	scene.AddGameObject(a)
+	scene.AddGameObject(b)
+
+	joint := components.NewJoint()
+	joint.Connected.Set(b)
+	joint.RestLength = 2
+	joint.Damping = 5
+	a.AddComponent(joint)
+
+	p.AddObject(a)
+	p.AddObject(b)
+
+	for i := 0; i < 600; i++ {
+		p.Update(1.0 / 120.0)
+	}
+
+	dist := rl.Vector3Distance(a.Transform.Position, b.Transform.Position)
+	if dist < 1.8 || dist > 2.2 {
+		t.Errorf("spring should settle near its rest length 2, got %v", dist)
+	}
+	if a.Transform.Position.X <= 0 || b.Transform.Position.X >= 4 {
+		t.Errorf("both ends should be pulled inward: A=%v B=%v", a.Transform.Position, b.Transform.Position)
+	}
+}
+
+func TestEditorRaycastPicksEmptyObject(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	empty := engine.NewGameObject("Empty")
+	empty.Transform.Position = rl.Vector3{X: 0, Y: 0, Z: -5}
+	light := engine.NewGameObject("Light")
+	light.Transform.Position = rl.Vector3{X: 3, Y: 0, Z: -5}
+	light.AddComponent(components.NewPointLight())
+	objects := []*engine.GameObject{empty, light}
+
+	hit, ok := p.EditorRaycast(rl.Vector3{}, rl.Vector3{Z: -1}, 100, objects)
+	if !ok || hit.GameObject != empty {
+		t.Fatalf("ray through the marker should pick the empty object, got %v", hit.GameObject)
+	}
+	if _, ok := p.EditorRaycast(rl.Vector3{X: 3}, rl.Vector3{Z: -1}, 100, objects); ok {
+		t.Errorf("objects with a light gizmo should not get a marker")
+	}
+}
+
+// impactRecorder is an ImpactHandler that keeps the last impulse it was given
+type impactRecorder struct {
+	engine.BaseComponent
+	impulse float32
+	hits    int
+}
+
+func (r *impactRecorder) OnCollisionImpact(other *engine.GameObject, impulse float32) {
+	r.impulse = impulse
+	r.hits++
+}
+
+func TestImpactImpulseUsesPreResolveVelocity(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	// A 2kg box already touching the floor, falling at 5 m/s
+	box := newBody("Box", rl.Vector3{X: 0, Y: 0.95, Z: 0}, 2, false)
+	engine.GetComponent[*components.Rigidbody](box).Velocity = rl.Vector3{Y: -5}
+	rec := &impactRecorder{}
+	box.AddComponent(rec)
+	p.AddObject(box)
+
+	p.Update(1.0 / 60.0)
+
+	if rec.hits != 1 {
+		t.Fatalf("got %d impacts, want 1", rec.hits)
+	}
+	// Against an immovable floor the impulse is the box's momentum, plus one
+	// frame of gravity
+	if rec.impulse < 10 || rec.impulse > 11 {
+		t.Errorf("impulse = %v, want about 10", rec.impulse)
+	}
+}
+
+type collisionCounter struct {
+	engine.BaseComponent
+	enters, exits int
+}
+
+func (c *collisionCounter) OnCollisionEnter(other *engine.GameObject) { c.enters++ }
+func (c *collisionCounter) OnCollisionExit(other *engine.GameObject)  { c.exits++ }
+
+func TestCollisionCooldownThrottlesReentry(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.CollisionCooldown = 0.5
+
+	ball := engine.NewGameObject("Ball")
+	counter := &collisionCounter{}
+	ball.AddComponent(counter)
+	floor := engine.NewGameObject("Floor")
+	pair := makePair(ball, floor)
+
+	// Bounce: touch, separate, touch again 0.1s later, then once more after the cooldown
+	frame := func(touching bool, dt float32) {
+		p.currentCollisions = make(map[CollisionPair]bool)
+		p.elapsed += dt
+		if touching {
+			p.currentCollisions[pair] = true
+		}
+		p.dispatchCollisionCallbacks()
+	}
+	frame(true, 0.1)
+	frame(false, 0.05)
+	frame(true, 0.05)
+	frame(false, 0.05)
+	if counter.enters != 1 || counter.exits != 1 {
+		t.Fatalf("within cooldown: enters=%d exits=%d; want 1, 1", counter.enters, counter.exits)
+	}
+
+	frame(true, 0.5)
+	if counter.enters != 2 {
+		t.Errorf("after cooldown: enters=%d; want 2", counter.enters)
+	}
+	frame(false, 0.05)
+	if len(p.lastEnter) != 1 || len(p.muted) != 0 {
+		t.Errorf("bookkeeping leaked: lastEnter=%d muted=%d", len(p.lastEnter), len(p.muted))
+	}
+}
+
+func TestScaledSphereRestsOnItsScaledRadius(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	// Radius 0.5 stretched to (1, 2, 1) collides with radius 1
+	ball := newBody("Ball", rl.Vector3{X: 0, Y: 3, Z: 0}, 1, true)
+	ball.Transform.Scale = rl.Vector3{X: 1, Y: 2, Z: 1}
+	p.AddObject(ball)
+
+	for i := 0; i < 180; i++ {
+		p.Update(1.0 / 60.0)
+	}
+
+	if y := ball.Transform.Position.Y; y < 1.4 || y > 1.6 {
+		t.Errorf("scaled sphere rests at y=%v; want about 1.5 (floor top 0.5 + radius 1)", y)
+	}
+}
+
+func TestFindStaticOverlaps(t *testing.T) {
+	newWall := func(name string, pos, rot rl.Vector3) *engine.GameObject {
+		obj := engine.NewGameObject(name)
+		obj.Transform.Position = pos
+		obj.Transform.Rotation = rot
+		obj.AddComponent(components.NewBoxCollider(rl.Vector3{X: 4, Y: 2, Z: 0.5}))
+		return obj
+	}
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+
+	standing := newWall("Standing", rl.Vector3{X: 0, Y: 1.5, Z: 0}, rl.Vector3{})      // rests on the floor
+	crossing := newWall("Crossing", rl.Vector3{X: 0, Y: 1.5, Z: 0}, rl.Vector3{Y: 90}) // clips through Standing
+	apart := newWall("Apart", rl.Vector3{X: 8, Y: 1.5, Z: 0}, rl.Vector3{})            // touches only the floor
+	sunk := newWall("Sunk", rl.Vector3{X: -8, Y: 1.0, Z: 0}, rl.Vector3{Z: 10})        // half a unit into the floor
+
+	overlaps := FindStaticOverlaps([]*engine.GameObject{floor, standing, crossing, apart, sunk})
+
+	got := map[string]bool{}
+	for _, o := range overlaps {
+		got[o.A.Name+"/"+o.B.Name] = true
+	}
+	want := map[string]bool{"Standing/Crossing": true, "Floor/Sunk": true}
+	if len(got) != len(want) {
+		t.Fatalf("overlaps = %v; want %v", got, want)
+	}
+	for pair := range want {
+		if !got[pair] {
+			t.Errorf("missing overlap %s (got %v)", pair, got)
+		}
+	}
+}
+
+func TestInitGPUFallsBackToCPU(t *testing.T) {
+	// Simulate a machine without a usable GPU adapter
+	orig := newGPUBroadPhase
+	newGPUBroadPhase = func() (*compute.BroadPhase, error) {
+		return nil, &compute.InitError{Stage: "adapter", Err: errors.New("no adapter found")}
+	}
+	defer func() { newGPUBroadPhase = orig }()
+
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{}
+	err := p.InitGPU()
+	if !errors.Is(err, compute.ErrUnavailable) {
+		t.Fatalf("InitGPU error = %v, want one matching compute.ErrUnavailable", err)
+	}
+	if p.GPUAvailable() {
+		t.Fatal("GPUAvailable should be false after a failed InitGPU")
+	}
+
+	// Forcing the GPU must still resolve collisions, on the CPU
+	p.BroadPhaseMode = BroadPhaseForceGPU
+	a := newBody("A", rl.Vector3{}, 1, false)
+	b := newBody("B", rl.Vector3{X: 0.8}, 1, false)
+	p.AddObject(a)
+	p.AddObject(b)
+	p.Update(1.0 / 60.0)
+
+	if p.UsingGPU() {
+		t.Error("broad-phase ran on the GPU without one")
+	}
+	if !p.activeCollisions[makePair(a, b)] {
+		t.Error("overlapping bodies did not collide on the CPU fallback")
+	}
+}
+
+func TestEditorRaycastAllSortsByDistance(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	far := newBody("Far", rl.Vector3{Z: -10}, 1, false)
+	near := newBody("Near", rl.Vector3{Z: -3}, 1, true)
+	middle := newBody("Middle", rl.Vector3{Z: -6}, 1, false)
+	aside := newBody("Aside", rl.Vector3{X: 5, Z: -6}, 1, false)
+	objects := []*engine.GameObject{far, near, middle, aside}
+
+	hits := p.EditorRaycastAll(rl.Vector3{}, rl.Vector3{Z: -1}, 100, objects)
+	if len(hits) != 3 {
+		t.Fatalf("got %d hits, want 3", len(hits))
+	}
+	for i, want := range []*engine.GameObject{near, middle, far} {
+		if hits[i].GameObject != want {
+			t.Errorf("hit %d = %s, want %s", i, hits[i].GameObject.Name, want.Name)
+		}
+	}
+
+	closest, ok := p.EditorRaycast(rl.Vector3{}, rl.Vector3{Z: -1}, 100, objects)
+	if !ok || closest.GameObject != near {
+		t.Errorf("EditorRaycast should still return the nearest hit")
+	}
+}
+
+func TestSphereCastDistances(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 10, Y: 1, Z: 10}))
+	// Rotated 45 degrees so a corner faces the cast
+	diamond := engine.NewGameObject("Diamond")
+	diamond.Transform.Position = rl.Vector3{X: 20, Y: 0, Z: -5}
+	diamond.Transform.Rotation = rl.Vector3{Y: 45}
+	diamond.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1}))
+	pole := engine.NewGameObject("Pole")
+	pole.Transform.Position = rl.Vector3{X: -20, Y: 0, Z: -5}
+	pole.AddComponent(components.NewSphereCollider(0.1))
+	p.AddObject(floor)
+	p.AddObject(diamond)
+	p.AddObject(pole)
+
+	near := func(a, b float32) bool { return absf(a-b) < 0.01 }
+
+	hit, ok := p.SphereCast(rl.Vector3{Y: 5}, rl.Vector3{Y: -1}, 0.5, 100)
+	if !ok || hit.GameObject != floor || !near(hit.Distance, 4) || !near(hit.Normal.Y, 1) {
+		t.Fatalf("sphere should land on the floor after 4 units, got %v ok=%v", hit, ok)
+	}
+
+	hit, ok = p.SphereCast(rl.Vector3{X: 20}, rl.Vector3{Z: -1}, 0.5, 100)
+	want := 5 - float32(math.Sqrt2)/2 - 0.5
+	if !ok || hit.GameObject != diamond || !near(hit.Distance, want) {
+		t.Fatalf("sphere should touch the rotated box's corner at %.3f, got %v ok=%v", want, hit, ok)
+	}
+
+	// A ray passing beside the thin pole misses it, a fat enough sphere doesn't
+	origin := rl.Vector3{X: -19.7}
+	if _, ok := p.Raycast(origin, rl.Vector3{Z: -1}, 100); ok {
+		t.Fatalf("ray should pass beside the pole")
+	}
+	if hit, ok := p.SphereCast(origin, rl.Vector3{Z: -1}, 0.3, 100); !ok || hit.GameObject != pole {
+		t.Errorf("sphere cast should clip the pole, got %v ok=%v", hit, ok)
+	}
+	if _, ok := p.SphereCast(origin, rl.Vector3{Z: -1}, 0.3, 4); ok {
+		t.Errorf("sphere cast should stop at maxDistance")
+	}
+}
+
+func TestBoxCastDistances(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	box := engine.NewGameObject("Box")
+	box.Transform.Position = rl.Vector3{Z: -5}
+	box.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1}))
+	ball := engine.NewGameObject("Ball")
+	ball.Transform.Position = rl.Vector3{X: 10, Z: -5}
+	ball.AddComponent(components.NewSphereCollider(0.5))
+	p.AddObject(box)
+	p.AddObject(ball)
+
+	half := rl.Vector3{X: 0.5, Y: 0.5, Z: 0.5}
+	near := func(a, b float32) bool { return absf(a-b) < 0.01 }
+
+	hit, ok := p.BoxCast(rl.Vector3{}, rl.Vector3{Z: -1}, half, 100)
+	if !ok || hit.GameObject != box || !near(hit.Distance, 4) || !near(hit.Normal.Z, 1) {
+		t.Fatalf("box cast should meet the box face after 4 units, got %v ok=%v", hit, ok)
+	}
+	hit, ok = p.BoxCast(rl.Vector3{X: 10}, rl.Vector3{Z: -1}, half, 100)
+	if !ok || hit.GameObject != ball || !near(hit.Distance, 4) {
+		t.Fatalf("box cast should meet the ball after 4 units, got %v ok=%v", hit, ok)
+	}
+	if hit, ok := p.BoxCast(rl.Vector3{Z: -5}, rl.Vector3{Z: -1}, half, 100); !ok || hit.Distance != 0 {
+		t.Errorf("box cast starting inside a collider should hit at 0, got %v ok=%v", hit, ok)
+	}
+}
+
+func TestShapeCastsHitMeshes(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	// A 10x10 quad at y=0 built as a mesh collider
+	verts := []float32{
+		-5, 0, -5, -5, 0, 5, 5, 0, 5,
+		-5, 0, -5, 5, 0, 5, 5, 0, -5,
+	}
+	mesh := rl.Mesh{VertexCount: 6, TriangleCount: 2, Vertices: &verts[0]}
+	ground := engine.NewGameObject("Ground")
+	mc := components.NewMeshCollider()
+	ground.AddComponent(mc)
+	mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+	p.AddObject(ground)
+
+	near := func(a, b float32) bool { return absf(a-b) < 0.01 }
+	down := rl.Vector3{Y: -1}
+
+	hit, ok := p.SphereCast(rl.Vector3{X: 1, Y: 5, Z: 2}, down, 0.5, 100)
+	if !ok || hit.GameObject != ground || !near(hit.Distance, 4.5) || !near(hit.Normal.Y, 1) {
+		t.Fatalf("sphere should land on the mesh after 4.5 units, got %v ok=%v", hit, ok)
+	}
+	hit, ok = p.BoxCast(rl.Vector3{X: -2, Y: 5, Z: 1}, down, rl.Vector3{X: 0.5, Y: 0.5, Z: 0.5}, 100)
+	if !ok || hit.GameObject != ground || !near(hit.Distance, 4.5) || !near(hit.Normal.Y, 1) {
+		t.Fatalf("box should land on the mesh after 4.5 units, got %v ok=%v", hit, ok)
+	}
+	// Sweeping sideways past the quad's edge misses it
+	if hit, ok := p.SphereCast(rl.Vector3{X: 7, Y: 1}, rl.Vector3{Z: -1}, 0.5, 100); ok {
+		t.Errorf("sphere beside the mesh should miss it, got %v", hit)
+	}
+}
+
+func TestRaycastAllSortsAndHitsMeshes(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	// A 10x10 quad at y=0 built as a mesh collider
+	verts := []float32{
+		-5, 0, -5, -5, 0, 5, 5, 0, 5,
+		-5, 0, -5, 5, 0, 5, 5, 0, -5,
+	}
+	mesh := rl.Mesh{VertexCount: 6, TriangleCount: 2, Vertices: &verts[0]}
+	ground := engine.NewGameObject("Ground")
+	mc := components.NewMeshCollider()
+	ground.AddComponent(mc)
+	mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+
+	crate := engine.NewGameObject("Crate")
+	crate.Transform.Position = rl.Vector3{Y: 2}
+	crate.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1}))
+	ball := newBody("Ball", rl.Vector3{Y: 4}, 1, true)
+	p.AddObject(ground)
+	p.AddObject(crate)
+	p.AddObject(ball)
+
+	hits := p.RaycastAll(rl.Vector3{Y: 10}, rl.Vector3{Y: -1}, 100)
+	want := []*engine.GameObject{ball, crate, ground}
+	if len(hits) != len(want) {
+		t.Fatalf("expected %d hits, got %d", len(want), len(hits))
+	}
+	for i, h := range hits {
+		if h.GameObject != want[i] {
+			t.Errorf("hit %d: expected %s, got %s", i, want[i].Name, h.GameObject.Name)
+		}
+	}
+	if g := hits[2]; absf(g.Distance-10) > 0.001 || absf(g.Normal.Y-1) > 0.001 {
+		t.Errorf("mesh hit should be 10 units down facing up, got %v", g)
+	}
+
+	if hit, ok := p.Raycast(rl.Vector3{X: 3, Y: 1}, rl.Vector3{Y: -1}, 5); !ok || hit.GameObject != ground {
+		t.Errorf("Raycast should hit the mesh collider too, got %v ok=%v", hit, ok)
+	}
+	if hits := p.RaycastAll(rl.Vector3{Y: 10}, rl.Vector3{Y: -1}, 8); len(hits) != 2 {
+		t.Errorf("maxDistance should cut off the ground, got %d hits", len(hits))
+	}
+}
+
+func TestCapsuleRestsOnFloorAndIsHitByRays(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	p.AddObject(floor)
+
+	capsule := engine.NewGameObject("Capsule")
+	capsule.Transform.Position = rl.Vector3{Y: 3}
+	rb := components.NewRigidbody()
+	rb.CanSleep = false
+	capsule.AddComponent(rb)
+	capsule.AddComponent(components.NewCapsuleCollider(0.5, 2))
+	p.AddObject(capsule)
+
+	for range 240 {
+		p.Update(1.0 / 60.0)
+	}
+	// Floor top at 0.5, capsule center a half-height above it
+	if y := capsule.Transform.Position.Y; absf(y-1.5) > 0.05 {
+		t.Errorf("capsule should rest with its center at 1.5, got %.3f", y)
+	}
+
+	hit, ok := p.Raycast(rl.Vector3{X: -5, Y: 1.5}, rl.Vector3{X: 1}, 100)
+	if !ok || hit.GameObject != capsule || absf(hit.Distance-4.5) > 0.01 || absf(hit.Normal.X+1) > 0.01 {
+		t.Errorf("ray from the side should hit the capsule after 4.5 units, got %v ok=%v", hit, ok)
+	}
+}
+
+func TestKinematicCapsuleIsPushedOutOfWallsAndMeshes(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	wall := engine.NewGameObject("Wall")
+	wall.Transform.Position = rl.Vector3{X: 1, Y: 1}
+	wall.AddComponent(components.NewBoxCollider(rl.Vector3{X: 1, Y: 4, Z: 4}))
+
+	verts := []float32{
+		-5, 0, -5, -5, 0, 5, 5, 0, 5,
+		-5, 0, -5, 5, 0, 5, 5, 0, -5,
+	}
+	mesh := rl.Mesh{VertexCount: 6, TriangleCount: 2, Vertices: &verts[0]}
+	ground := engine.NewGameObject("Ground")
+	ground.Transform.Position = rl.Vector3{X: 10}
+	mc := components.NewMeshCollider()
+	ground.AddComponent(mc)
+	mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+
+	// One character walked into the wall, the other sunk into the ground
+	newCharacter := func(pos rl.Vector3) *engine.GameObject {
+		g := engine.NewGameObject("Character")
+		g.Transform.Position = pos
+		rb := components.NewRigidbody()
+		rb.IsKinematic = true
+		g.AddComponent(rb)
+		g.AddComponent(components.NewCapsuleCollider(0.4, 1.8))
+		return g
+	}
+	walker := newCharacter(rl.Vector3{X: 0.3, Y: 1})
+	sinker := newCharacter(rl.Vector3{X: 10, Y: 0.7})
+	p.AddObject(wall)
+	p.AddObject(ground)
+	p.AddObject(walker)
+	p.AddObject(sinker)
+
+	p.Update(1.0 / 60.0)
+
+	// Wall face at x=0.5, capsule radius 0.4
+	if x := walker.Transform.Position.X; absf(x-0.1) > 0.01 {
+		t.Errorf("capsule should be pushed back to x=0.1, got %.3f", x)
+	}
+	// Capsule bottom at y-0.9 should come up to the ground
+	if y := sinker.Transform.Position.Y; y < 0.89 {
+		t.Errorf("capsule should be pushed out of the mesh to y>=0.9, got %.3f", y)
+	}
+}
+
+func TestCCDStopsFastBodyAtThinWall(t *testing.T) {
+	fire := func(useCCD bool) (float32, *collisionCounter) {
+		p := NewPhysicsWorld()
+
+		wall := engine.NewGameObject("Wall")
+		wall.Transform.Position = rl.Vector3{X: 5}
+		wall.AddComponent(components.NewBoxCollider(rl.Vector3{X: 0.1, Y: 4, Z: 4}))
+		p.AddObject(wall)
+
+		bullet := newBody("Bullet", rl.Vector3{}, 1, true)
+		rb := engine.GetComponent[*components.Rigidbody](bullet)
+		rb.UseGravity = false
+		rb.Bounciness = 0
+		rb.UseCCD = useCCD
+		rb.Velocity = rl.Vector3{X: 300} // 5 units per step, well past the wall
+		counter := &collisionCounter{}
+		bullet.AddComponent(counter)
+		p.AddObject(bullet)
+
+		p.Update(1.0 / 60.0)
+		p.Update(1.0 / 60.0)
+		return bullet.Transform.Position.X, counter
+	}
+
+	if x, _ := fire(false); x < 5 {
+		t.Fatalf("without CCD the bullet should tunnel through the wall, stopped at x=%.3f", x)
+	}
+	x, counter := fire(true)
+	// Wall face at 4.95, sphere radius 0.5
+	if x > 4.95 || x < 4.4 {
+		t.Errorf("with CCD the bullet should stop at the wall (x~4.45), got x=%.3f", x)
+	}
+	if counter.enters != 1 {
+		t.Errorf("swept hit should fire OnCollisionEnter once, got %d", counter.enters)
+	}
+}
+
+func TestCCDStopsFastBodyAtMeshAndKinematicWalls(t *testing.T) {
+	// A 4x4 quad standing at x=5, facing -X
+	verts := []float32{
+		5, -2, -2, 5, 2, 2, 5, -2, 2,
+		5, -2, -2, 5, 2, -2, 5, 2, 2,
+	}
+	mesh := rl.Mesh{VertexCount: 6, TriangleCount: 2, Vertices: &verts[0]}
+
+	walls := map[string]func() *engine.GameObject{
+		"mesh": func() *engine.GameObject {
+			wall := engine.NewGameObject("Wall")
+			mc := components.NewMeshCollider()
+			wall.AddComponent(mc)
+			mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+			return wall
+		},
+		"kinematic": func() *engine.GameObject {
+			wall := engine.NewGameObject("Wall")
+			wall.Transform.Position = rl.Vector3{X: 5}
+			rb := components.NewRigidbody()
+			rb.IsKinematic = true
+			rb.UseGravity = false
+			wall.AddComponent(rb)
+			wall.AddComponent(components.NewBoxCollider(rl.Vector3{X: 0.1, Y: 4, Z: 4}))
+			return wall
+		},
+	}
+	for name, newWall := range walls {
+		p := NewPhysicsWorld()
+		p.AddObject(newWall())
+
+		bullet := newBody("Bullet", rl.Vector3{}, 1, true)
+		rb := engine.GetComponent[*components.Rigidbody](bullet)
+		rb.UseGravity = false
+		rb.Bounciness = 0
+		rb.UseCCD = true
+		rb.Velocity = rl.Vector3{X: 300}
+		p.AddObject(bullet)
+
+		p.Update(1.0 / 60.0)
+		p.Update(1.0 / 60.0)
+		if x := bullet.Transform.Position.X; x > 5 || x < 4.4 {
+			t.Errorf("%s wall: CCD should stop the bullet in front of it, got x=%.3f", name, x)
+		}
+	}
+}
+
+func TestAddedForcesApplyOnceAndWakeSleepers(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.FixedDeltaTime = 0 // one step per frame
+	body := newBody("Body", rl.Vector3{Y: 10}, 2, true)
+	rb := engine.GetComponent[*components.Rigidbody](body)
+	rb.UseGravity = false
+	rb.AngularDamping = 1
+	rb.Sleep()
+	p.AddObject(body)
+
+	dt := float32(0.5)
+	rb.AddForce(rl.Vector3{X: 4})   // 4 / 2 * 0.5 = 1
+	rb.AddImpulse(rl.Vector3{Y: 2}) // 2 / 2 = 1
+	rb.AddForceAtPoint(rl.Vector3{Z: 2}, rl.Vector3{X: 1, Y: 10})
+	p.FixedUpdate(dt)
+
+	if rb.IsSleeping {
+		t.Fatal("added forces should wake a sleeping body")
+	}
+	// Z: 2 / 2 * 0.5 = 0.5 from the force at the point
+	if v := rb.Velocity; absf(v.X-1) > 1e-4 || absf(v.Y-1) > 1e-4 || absf(v.Z-0.5) > 1e-4 {
+		t.Errorf("velocity = %v, want (1, 1, 0.5)", v)
+	}
+	// Arm (1,0,0) x force (0,0,2) = torque (0,-2,0): 2 / 2 * 0.5 rad/s
+	if w := rb.AngularVelocity.Y; absf(w+0.5*rl.Rad2deg) > 1e-3 {
+		t.Errorf("angular velocity Y = %.3f, want about %.3f", w, -0.5*rl.Rad2deg)
+	}
+
+	before := rb.Velocity
+	p.FixedUpdate(dt)
+	if rb.Velocity != before {
+		t.Errorf("forces should be cleared after the frame, velocity went %v -> %v", before, rb.Velocity)
+	}
+}
+
+func TestAddedForcesAreFrameRateIndependent(t *testing.T) {
+	push := func(frame float32, frames int) (rl.Vector3, rl.Vector3) {
+		p := NewPhysicsWorld()
+		p.FixedDeltaTime = 1.0 / 64
+		p.MaxSubsteps = 8
+		body := newBody("Body", rl.Vector3{}, 2, true)
+		rb := engine.GetComponent[*components.Rigidbody](body)
+		rb.UseGravity = false
+		rb.AngularDamping = 1
+		rb.CanSleep = false
+		p.AddObject(body)
+		for range frames {
+			rb.AddForce(rl.Vector3{X: 4})
+			rb.AddTorque(rl.Vector3{Y: 1})
+			p.FixedUpdate(frame)
+		}
+		return rb.Velocity, rb.AngularVelocity
+	}
+
+	// One second of a steady push at 8, 64 and 256 frames per second: 4 / 2 * 1
+	for _, fps := range []int{8, 64, 256} {
+		v, w := push(1/float32(fps), fps)
+		if absf(v.X-2) > 1e-3 {
+			t.Errorf("%d fps: velocity X = %.4f, want 2", fps, v.X)
+		}
+		if want := float32(0.5 * rl.Rad2deg); absf(w.Y-want) > 1e-2 {
+			t.Errorf("%d fps: angular velocity Y = %.3f, want %.3f", fps, w.Y, want)
+		}
+	}
+}
+
+type triggerCounter struct {
+	engine.BaseComponent
+	enters, exits int
+}
+
+func (c *triggerCounter) OnTriggerEnter(other *engine.GameObject) { c.enters++ }
+func (c *triggerCounter) OnTriggerExit(other *engine.GameObject)  { c.exits++ }
+
+func TestTriggerReportsOverlapWithoutBlocking(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	zone := engine.NewGameObject("Zone")
+	zone.Transform.Position = rl.Vector3{X: 3}
+	box := components.NewBoxCollider(rl.Vector3{X: 1, Y: 4, Z: 4})
+	box.IsTrigger = true
+	zone.AddComponent(box)
+	zoneCounter := &triggerCounter{}
+	zone.AddComponent(zoneCounter)
+	p.AddObject(zone)
+
+	ball := newBody("Ball", rl.Vector3{}, 1, true)
+	rb := engine.GetComponent[*components.Rigidbody](ball)
+	rb.UseGravity = false
+	rb.Velocity = rl.Vector3{X: 6}
+	ballTriggers := &triggerCounter{}
+	ballCollisions := &collisionCounter{}
+	ball.AddComponent(ballTriggers)
+	ball.AddComponent(ballCollisions)
+	p.AddObject(ball)
+
+	if _, ok := p.RaycastIgnoring(rl.Vector3{}, rl.Vector3{X: 1}, 10, ball); ok {
+		t.Error("raycasts should pass through triggers")
+	}
+
+	// 1.5s at 6 units/s carries the ball from x=0 through the zone to x=9
+	for range 90 {
+		p.Update(1.0 / 60.0)
+	}
+
+	if x := ball.Transform.Position.X; x < 8.9 || rb.Velocity.X != 6 {
+		t.Errorf("trigger should not block: x=%.3f vx=%.3f; want x~9, vx=6", x, rb.Velocity.X)
+	}
+	if ballTriggers.enters != 1 || ballTriggers.exits != 1 {
+		t.Errorf("ball: trigger enters=%d exits=%d; want 1, 1", ballTriggers.enters, ballTriggers.exits)
+	}
+	if zoneCounter.enters != 1 || zoneCounter.exits != 1 {
+		t.Errorf("zone: trigger enters=%d exits=%d; want 1, 1", zoneCounter.enters, zoneCounter.exits)
+	}
+	if ballCollisions.enters != 0 {
+		t.Errorf("a trigger overlap should not fire OnCollisionEnter, got %d", ballCollisions.enters)
+	}
+}
+
+func TestAngularVelocityTurnsAboutItsWorldAxis(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{}
+
+	ball := newBody("Ball", rl.Vector3{}, 1, true)
+	rb := engine.GetComponent[*components.Rigidbody](ball)
+	rb.AngularDamping = 1
+	axis := rl.Vector3Normalize(rl.Vector3{X: 1, Y: 1, Z: 0})
+	rb.AngularVelocity = rl.Vector3Scale(axis, 90)
+	p.AddObject(ball)
+
+	// 90 degrees per second for four seconds is one full turn
+	for range 240 {
+		p.Update(1.0 / 60.0)
+		if got := rl.Vector3Transform(axis, ball.WorldRotationMatrix()); rl.Vector3Distance(got, axis) > 1e-3 {
+			t.Fatalf("spin axis moved to %v, want %v", got, axis)
+		}
+	}
+	forward := rl.Vector3{Z: 1}
+	if got := rl.Vector3Transform(forward, ball.WorldRotationMatrix()); rl.Vector3Distance(got, forward) > 1e-2 {
+		t.Errorf("after a full turn forward = %v, want %v", got, forward)
+	}
+}
+
+func TestFixedUpdateCarriesLeftoverTime(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.FixedDeltaTime = 0.25
+
+	if steps := p.FixedUpdate(0.625); steps != 2 {
+		t.Errorf("0.625s frame ran %d steps, want 2", steps)
+	}
+	// The 0.125s left over makes up a full step with the next frame's
+	if steps := p.FixedUpdate(0.125); steps != 1 {
+		t.Errorf("leftover plus 0.125s ran %d steps, want 1", steps)
+	}
+	if steps := p.FixedUpdate(0.125); steps != 0 {
+		t.Errorf("0.125s frame ran %d steps, want 0", steps)
+	}
+	if got := p.Stats().Steps; got != 0 {
+		t.Errorf("Stats().Steps = %d, want 0", got)
+	}
+}
+
+func TestFixedUpdateCapsSubstepsAfterStall(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.FixedDeltaTime = 0.25
+	p.MaxSubsteps = 3
+
+	if steps := p.FixedUpdate(10); steps != 3 {
+		t.Errorf("10s stall ran %d steps, want the cap of 3", steps)
+	}
+	// The rest of the backlog is dropped, not caught up on later
+	if steps := p.FixedUpdate(0.125); steps != 0 {
+		t.Errorf("frame after the stall ran %d steps, want 0", steps)
+	}
+}
+
+func TestFixedUpdateIsFrameRateIndependent(t *testing.T) {
+	drop := func(frame float32, frames int) rl.Vector3 {
+		p := NewPhysicsWorld()
+		p.FixedDeltaTime = 1.0 / 64
+		p.MaxSubsteps = 8
+		floor := engine.NewGameObject("Floor")
+		floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+		p.AddObject(floor)
+		ball := newBody("Ball", rl.Vector3{X: 0, Y: 3, Z: 0}, 1, true)
+		engine.GetComponent[*components.Rigidbody](ball).Bounciness = 0.5
+		p.AddObject(ball)
+		for range frames {
+			p.FixedUpdate(frame)
+		}
+		return ball.Transform.Position
+	}
+
+	// Two seconds of bouncing at 16 and at 128 frames per second
+	slow := drop(1.0/16, 32)
+	fast := drop(1.0/128, 256)
+	if slow != fast {
+		t.Errorf("ball ended at %v at 16 fps but %v at 128 fps", slow, fast)
+	}
+}
+
+func TestOverlapSphereAndBoxFindOverlappingColliders(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	floor := engine.NewGameObject("Floor")
+	floor.AddComponent(components.NewBoxCollider(rl.Vector3{X: 20, Y: 1, Z: 20}))
+	ball := newBody("Ball", rl.Vector3{X: 3, Y: 2}, 1, true)
+	far := newBody("Far", rl.Vector3{X: 3, Y: 2, Z: 20}, 1, true)
+	character := engine.NewGameObject("Character")
+	character.Transform.Position = rl.Vector3{X: -3, Y: 2}
+	rb := components.NewRigidbody()
+	rb.IsKinematic = true
+	character.AddComponent(rb)
+	character.AddComponent(components.NewCapsuleCollider(0.4, 1.8))
+	zone := engine.NewGameObject("Zone")
+	zone.Transform.Position = rl.Vector3{Y: 2, Z: 6}
+	box := components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1})
+	box.IsTrigger = true
+	zone.AddComponent(box)
+
+	verts := []float32{
+		-5, 0, -5, -5, 0, 5, 5, 0, 5,
+		-5, 0, -5, 5, 0, 5, 5, 0, -5,
+	}
+	mesh := rl.Mesh{VertexCount: 6, TriangleCount: 2, Vertices: &verts[0]}
+	ground := engine.NewGameObject("Ground")
+	ground.Transform.Position = rl.Vector3{X: 30}
+	mc := components.NewMeshCollider()
+	ground.AddComponent(mc)
+	mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+
+	for _, obj := range []*engine.GameObject{floor, ball, far, character, zone, ground} {
+		p.AddObject(obj)
+	}
+
+	same := func(got []*engine.GameObject, want ...*engine.GameObject) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for _, obj := range want {
+			if !slices.Contains(got, obj) {
+				return false
+			}
+		}
+		return true
+	}
+	names := func(objs []*engine.GameObject) []string {
+		var s []string
+		for _, obj := range objs {
+			s = append(s, obj.Name)
+		}
+		return s
+	}
+
+	// Floor top 1.5 below, ball 3 away with radius 0.5, capsule axis 3 away with radius 0.4
+	if got := p.OverlapSphere(rl.Vector3{Y: 2}, 2.7); !same(got, floor, ball, character) {
+		t.Errorf("OverlapSphere should find the floor, ball and character, got %v", names(got))
+	}
+	if got := p.OverlapSphere(rl.Vector3{Y: 2}, 1); len(got) != 0 {
+		t.Errorf("a small sphere in the air should overlap nothing, got %v", names(got))
+	}
+	if got := p.OverlapSphere(rl.Vector3{X: 30, Y: 0.2}, 0.5); !same(got, ground) {
+		t.Errorf("OverlapSphere should find the mesh collider, got %v", names(got))
+	}
+
+	// Turned 45 degrees the box's corner reaches the trigger zone, square it doesn't
+	half := rl.Vector3{X: 0.5, Y: 0.5, Z: 0.5}
+	if got := p.OverlapBox(rl.Vector3{X: 1.1, Y: 2, Z: 6}, half, rl.Vector3{}); len(got) != 0 {
+		t.Errorf("square box should miss the zone, got %v", names(got))
+	}
+	if got := p.OverlapBox(rl.Vector3{X: 1.1, Y: 2, Z: 6}, half, rl.Vector3{Y: 45}); !same(got, zone) {
+		t.Errorf("rotated box should reach the trigger zone, got %v", names(got))
+	}
+	if got := p.OverlapBox(rl.Vector3{X: 30, Y: 0.3}, half, rl.Vector3{}); !same(got, ground) {
+		t.Errorf("box crossing the mesh should find it, got %v", names(got))
+	}
+	if got := p.OverlapBox(rl.Vector3{X: 30, Y: 0.6}, half, rl.Vector3{}); len(got) != 0 {
+		t.Errorf("box above the mesh should miss it, got %v", names(got))
+	}
+}
+
+func TestOverlapQueriesReuseGridUntilBodiesMove(t *testing.T) {
+	p := NewPhysicsWorld()
+	p.Gravity = rl.Vector3{}
+	ball := newBody("Ball", rl.Vector3{}, 1, true)
+	engine.GetComponent[*components.Rigidbody](ball).Velocity = rl.Vector3{X: 360}
+	p.AddObject(ball)
+
+	if got := p.OverlapSphere(rl.Vector3{}, 1); len(got) != 1 || !p.gridFresh {
+		t.Fatalf("query should find the ball and leave the grid built, got %d fresh=%v", len(got), p.gridFresh)
+	}
+
+	// An added body is found without a step in between
+	other := newBody("Other", rl.Vector3{X: 20}, 1, true)
+	p.AddObject(other)
+	if got := p.OverlapSphere(rl.Vector3{X: 20}, 1); len(got) != 1 || got[0] != other {
+		t.Fatalf("added body should be found right away, got %d", len(got))
+	}
+
+	// One step carries the ball 6 units, into the next grid cell
+	p.Update(1.0 / 60.0)
+	if p.gridFresh {
+		t.Fatal("a step should mark the grid stale")
+	}
+	if got := p.OverlapSphere(rl.Vector3{X: 6}, 0.6); len(got) != 1 || got[0] != ball {
+		t.Errorf("query after a step should find the ball where it moved, got %d", len(got))
+	}
+}
+
+// gridMesh returns a flat mesh at y=0 of n x n quads, one unit deep along Z
+// and running from x=0 to x=n along X, narrowest at the origin, so the BVH
+// is lopsided like a level with one detailed area
+func gridMesh(n int) (rl.Mesh, func(i int) float32) {
+	xAt := func(i int) float32 {
+		f := float32(i) / float32(n)
+		return f * f * f * float32(n)
+	}
+	verts := make([]float32, 0, n*n*18)
+	for x := range n {
+		for z := range n {
+			x0, z0, x1, z1 := xAt(x), float32(z), xAt(x+1), float32(z+1)
+			verts = append(verts,
+				x0, 0, z0, x0, 0, z1, x1, 0, z1,
+				x0, 0, z0, x1, 0, z1, x1, 0, z0,
+			)
+		}
+	}
+	return rl.Mesh{VertexCount: int32(len(verts) / 3), TriangleCount: int32(n * n * 2), Vertices: &verts[0]}, xAt
+}
+
+func TestLargeMeshColliderQueriesOnlyNearbyTriangles(t *testing.T) {
+	p := NewPhysicsWorld()
+
+	// 224 x 224 quads is just over 100k triangles
+	mesh, xAt := gridMesh(224)
+	ground := engine.NewGameObject("Terrain")
+	mc := components.NewMeshCollider()
+	ground.AddComponent(mc)
+	mc.BuildFromModel(rl.Model{MeshCount: 1, Meshes: &mesh})
+	p.AddObject(ground)
+
+	if mc.TriangleCount() != 224*224*2 {
+		t.Fatalf("expected %d triangles, got %d", 224*224*2, mc.TriangleCount())
+	}
+	if n := mc.NodeCount(); n == 0 || n >= 2*mc.TriangleCount() {
+		t.Errorf("BVH node count %d out of range", n)
+	}
+
+	// A query inside one quad only reaches a few leaves
+	for _, i := range []int{120, 200, 160} {
+		query := components.AABB{
+			Min: rl.Vector3{X: xAt(i) + 0.01, Y: -1, Z: float32(i) - 99.8},
+			Max: rl.Vector3{X: xAt(i+1) - 0.01, Y: 1, Z: float32(i) - 99.2},
+		}
+		if got := mc.TrianglesInBounds(query); len(got) < 2 || len(got) > 32 {
+			t.Errorf("query in quad %d returned %d triangles", i, len(got))
+		}
+	}
+	// A strip across the whole terrain collects leaves from all over the tree
+	strip := components.AABB{Min: rl.Vector3{X: 0, Y: -1, Z: 100.2}, Max: rl.Vector3{X: 224, Y: 1, Z: 100.8}}
+	if got := mc.TrianglesInBounds(strip); len(got) < 224*2 {
+		t.Errorf("strip across the terrain should reach its %d triangles, got %d", 224*2, len(got))
+	}
+	// ...and the queries left every triangle in exactly one leaf
+	all := mc.TrianglesInBounds(mc.GetBounds())
+	seen := make(map[int]bool, len(all))
+	for _, idx := range all {
+		seen[idx] = true
+	}
+	if len(all) != mc.TriangleCount() || len(seen) != mc.TriangleCount() {
+		t.Errorf("BVH should hold each triangle once, got %d entries for %d distinct", len(all), len(seen))
+	}
+
+	// A dozen balls dropped across the terrain come to rest on it
+	var balls []*engine.GameObject
+	for i := range 12 {
+		ball := newBody("Ball", rl.Vector3{X: float32(10 + i*17), Y: 2, Z: float32(200 - i*15)}, 1, true)
+		p.AddObject(ball)
+		balls = append(balls, ball)
+	}
+	for range 180 {
+		p.Update(1.0 / 60.0)
+	}
+	for i, ball := range balls {
+		if y := ball.Transform.Position.Y; absf(y-0.5) > 0.05 {
+			t.Errorf("ball %d should rest with its center at 0.5, got %.3f", i, y)
+		}
+	}
+}
+
+func TestGPUPairContactsMatchCPUNarrowPhase(t *testing.T) {
+	// Overlapping spheres, then a sphere resting against a box
+	newWorld := func() *PhysicsWorld {
+		p := NewPhysicsWorld()
+		p.Gravity = rl.Vector3{}
+		bodies := []*engine.GameObject{
+			newBody("A", rl.Vector3{}, 1, true),
+			newBody("B", rl.Vector3{X: 0.7, Y: 0.2}, 2, true),
+			newBody("C", rl.Vector3{X: 10}, 1, true),
+			newBody("D", rl.Vector3{X: 10.8}, 1, false),
+		}
+		engine.GetComponent[*components.Rigidbody](bodies[0]).Velocity = rl.Vector3{X: 3}
+		engine.GetComponent[*components.Rigidbody](bodies[2]).Velocity = rl.Vector3{X: 2}
+		for _, obj := range bodies {
+			p.AddObject(obj)
+		}
+		return p
+	}
+
+	cpu := newWorld()
+	cpu.resolveCollision(cpu.Objects[0], cpu.Objects[1])
+	cpu.resolveCollision(cpu.Objects[2], cpu.Objects[3])
+
+	// A broad-phase without a GPU can't run the shader, so the contacts come
+	// from the CPU fallback, which computes them the way the shader does
+	gpu := newWorld()
+	gpu.gpuBroadPhase = &compute.BroadPhase{}
+	pairs := []compute.CollisionPair{{A: 0, B: 1}, {A: 2, B: 3}}
+	if n := gpu.resolveGPUPairs(gpu.buildBoundingSpheres(), pairs); n != 0 {
+		t.Errorf("no contacts should be counted as GPU ones without a GPU, got %d", n)
+	}
+
+	near := func(a, b rl.Vector3) bool { return rl.Vector3Distance(a, b) < 1e-5 }
+	for i := range cpu.Objects {
+		c, g := cpu.Objects[i], gpu.Objects[i]
+		rbC := engine.GetComponent[*components.Rigidbody](c)
+		rbG := engine.GetComponent[*components.Rigidbody](g)
+		if !near(c.Transform.Position, g.Transform.Position) || !near(rbC.Velocity, rbG.Velocity) {
+			t.Errorf("%s: contact path gave pos %v vel %v; resolveCollision gave pos %v vel %v",
+				c.Name, g.Transform.Position, rbG.Velocity, c.Transform.Position, rbC.Velocity)
+		}
+	}
+	if !gpu.currentCollisions[makePair(gpu.Objects[0], gpu.Objects[1])] {
+		t.Error("sphere contact should be recorded for callbacks")
+	}
+}
@@ -0,0 +1,102 @@
+// Package project holds the per-project settings stored in project.json:
+// which scene the editor opens, how the game is built, and how assets import.
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// File is the settings file, relative to the project root
+const File = "project.json"
+
+// DefaultMaxTextureSize is the largest texture dimension kept on load unless
+// project.json sets maxTextureSize
+const DefaultMaxTextureSize = 2048
+
+type Project struct {
+	Name         string   `json:"name"`              // game name, used for the built binary
+	DefaultScene string   `json:"defaultScene"`      // scene opened at startup
+	BuildDir     string   `json:"buildDir"`          // where builds are written
+	Targets      []string `json:"targets,omitempty"` // GOOS/GOARCH pairs, e.g. "linux/amd64" (empty = this machine)
+
+	// MaxTextureSize downsizes material textures whose width or height is
+	// larger when they load (0 = default, negative = keep full size)
+	MaxTextureSize int `json:"maxTextureSize,omitempty"`
+
+	// CollisionCooldownMs keeps a pair that touches again within this many
+	// milliseconds of its last OnCollisionEnter from re-firing it (0 = off)
+	CollisionCooldownMs int `json:"collisionCooldownMs,omitempty"`
+
+	// Audio holds the starting volume of each audio channel
+	Audio AudioSettings `json:"audio"`
+
+	// CurrentScene is the scene being edited or played. It starts as
+	// DefaultScene and is never written back to project.json.
+	CurrentScene string `json:"-"`
+}
+
+// AudioSettings are volumes from 0 to 1. Master scales all the others.
+type AudioSettings struct {
+	Master float32 `json:"master"`
+	SFX    float32 `json:"sfx"`
+	UI     float32 `json:"ui"`
+	Music  float32 `json:"music"`
+}
+
+// Default returns the settings used when project.json is missing
+func Default() *Project {
+	return &Project{
+		Name:           "game",
+		DefaultScene:   "assets/scenes/main.json",
+		BuildDir:       "build",
+		MaxTextureSize: DefaultMaxTextureSize,
+		Audio:          AudioSettings{Master: 1, SFX: 1, UI: 1, Music: 1},
+		CurrentScene:   "assets/scenes/main.json",
+	}
+}
+
+// Current is the loaded project (defaults until Load is called)
+var Current = Default()
+
+// Load reads the project file. A missing file is not an error; the defaults
+// are returned. Empty fields fall back to their defaults.
+func Load(path string) (*Project, error) {
+	p := Default()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return Default(), fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	defaults := Default()
+	if p.Name == "" {
+		p.Name = defaults.Name
+	}
+	if p.DefaultScene == "" {
+		p.DefaultScene = defaults.DefaultScene
+	}
+	if p.BuildDir == "" {
+		p.BuildDir = defaults.BuildDir
+	}
+	if p.MaxTextureSize == 0 {
+		p.MaxTextureSize = defaults.MaxTextureSize
+	}
+	p.CurrentScene = p.DefaultScene
+	return p, nil
+}
+
+// BuildArgs returns the mirgo-utils arguments that build this project
+func (p *Project) BuildArgs() []string {
+	args := []string{"build", p.Name, "--out", p.BuildDir}
+	for _, t := range p.Targets {
+		args = append(args, "--target", t)
+	}
+	return args
+}
@@ -0,0 +1,132 @@
+package world
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// bloom holds the shaders and render targets of the bloom post-process. They
+// are created the first time bloom is used and resized with the window.
+type bloom struct {
+	loaded     bool
+	bright     rl.Shader
+	blur       rl.Shader
+	composite  rl.Shader
+	scene      rl.RenderTexture2D // full resolution scene
+	ping, pong rl.RenderTexture2D // half resolution bright pass / blur targets
+
+	thresholdLoc, directionLoc, intensityLoc, bloomTextureLoc int32
+}
+
+func (b *bloom) load() {
+	b.bright = rl.LoadShader("", "assets/shaders/bloom_bright.fs")
+	b.blur = rl.LoadShader("", "assets/shaders/bloom_blur.fs")
+	b.composite = rl.LoadShader("", "assets/shaders/bloom_composite.fs")
+	b.thresholdLoc = rl.GetShaderLocation(b.bright, "threshold")
+	b.directionLoc = rl.GetShaderLocation(b.blur, "direction")
+	b.intensityLoc = rl.GetShaderLocation(b.composite, "intensity")
+	b.bloomTextureLoc = rl.GetShaderLocation(b.composite, "bloomTexture")
+	b.loaded = true
+}
+
+// resize (re)creates the render targets when the window size changed
+func (b *bloom) resize(width, height int32) {
+	if b.scene.ID != 0 && b.scene.Texture.Width == width && b.scene.Texture.Height == height {
+		return
+	}
+	b.unloadTargets()
+	b.scene = rl.LoadRenderTexture(width, height)
+	b.ping = rl.LoadRenderTexture(max(width/2, 1), max(height/2, 1))
+	b.pong = rl.LoadRenderTexture(max(width/2, 1), max(height/2, 1))
+	for _, t := range []rl.RenderTexture2D{b.scene, b.ping, b.pong} {
+		rl.SetTextureFilter(t.Texture, rl.FilterBilinear)
+	}
+}
+
+func (b *bloom) unloadTargets() {
+	if b.scene.ID == 0 {
+		return
+	}
+	rl.UnloadRenderTexture(b.scene)
+	rl.UnloadRenderTexture(b.ping)
+	rl.UnloadRenderTexture(b.pong)
+	b.scene, b.ping, b.pong = rl.RenderTexture2D{}, rl.RenderTexture2D{}, rl.RenderTexture2D{}
+}
+
+func (b *bloom) unload() {
+	if !b.loaded {
+		return
+	}
+	b.unloadTargets()
+	rl.UnloadShader(b.bright)
+	rl.UnloadShader(b.blur)
+	rl.UnloadShader(b.composite)
+	b.loaded = false
+}
+
+// BeginBloom redirects 3D drawing into an offscreen target when bloom is
+// enabled, clearing it to clear. Returns false (and does nothing) when bloom
+// is off; otherwise EndBloom must be called after the scene is drawn.
+func (r *Renderer) BeginBloom(settings BloomSettings, clear rl.Color) bool {
+	if !settings.Enabled {
+		return false
+	}
+	if !r.bloom.loaded {
+		r.bloom.load()
+	}
+	r.bloom.resize(int32(rl.GetRenderWidth()), int32(rl.GetRenderHeight()))
+
+	rl.BeginTextureMode(r.bloom.scene)
+	rl.ClearBackground(clear)
+	return true
+}
+
+// EndBloom extracts the bright parts of the scene, blurs them at half
+// resolution and draws the scene plus the glow to the screen
+func (r *Renderer) EndBloom(settings BloomSettings) {
+	b := &r.bloom
+	rl.EndTextureMode()
+
+	halfW := float32(b.ping.Texture.Width)
+	halfH := float32(b.ping.Texture.Height)
+
+	// Bright pass, downsampled to half resolution
+	rl.BeginTextureMode(b.ping)
+	rl.ClearBackground(rl.Black)
+	rl.SetShaderValue(b.bright, b.thresholdLoc, []float32{settings.Threshold}, rl.ShaderUniformFloat)
+	rl.BeginShaderMode(b.bright)
+	drawTargetTo(b.scene, halfW, halfH)
+	rl.EndShaderMode()
+	rl.EndTextureMode()
+
+	// Separable blur: horizontal into pong, vertical back into ping
+	for _, pass := range []struct {
+		src, dst  rl.RenderTexture2D
+		direction []float32
+	}{
+		{b.ping, b.pong, []float32{1 / halfW, 0}},
+		{b.pong, b.ping, []float32{0, 1 / halfH}},
+	} {
+		rl.BeginTextureMode(pass.dst)
+		rl.ClearBackground(rl.Black)
+		rl.SetShaderValue(b.blur, b.directionLoc, pass.direction, rl.ShaderUniformVec2)
+		rl.BeginShaderMode(b.blur)
+		drawTargetTo(pass.src, halfW, halfH)
+		rl.EndShaderMode()
+		rl.EndTextureMode()
+	}
+
+	// Composite onto the screen
+	rl.BeginShaderMode(b.composite)
+	rl.SetShaderValue(b.composite, b.intensityLoc, []float32{settings.Intensity}, rl.ShaderUniformFloat)
+	rl.SetShaderValueTexture(b.composite, b.bloomTextureLoc, b.ping.Texture)
+	drawTargetTo(b.scene, float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()))
+	rl.EndShaderMode()
+}
+
+// drawTargetTo draws a render texture stretched over (0,0)-(width,height),
+// flipped since render textures are stored upside down
+func drawTargetTo(target rl.RenderTexture2D, width, height float32) {
+	src := rl.Rectangle{Width: float32(target.Texture.Width), Height: -float32(target.Texture.Height)}
+	dst := rl.Rectangle{Width: width, Height: height}
+	rl.DrawTexturePro(target.Texture, src, dst, rl.Vector2{}, 0, rl.White)
+}
@@ -0,0 +1,84 @@
+package world
+
+import (
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// DrawColliders draws a wireframe for every active collider: boxes, spheres
+// and capsules as they are, character controllers as their box and mesh colliders as their
+// bounds. Call inside BeginMode3D/EndMode3D.
+func DrawColliders(gameObjects []*engine.GameObject) {
+	color := rl.Fade(rl.Green, 0.7)
+	for _, g := range gameObjects {
+		if !g.Active {
+			continue
+		}
+		if box := engine.GetComponent[*components.BoxCollider](g); box != nil {
+			DrawRotatedBoxWires(box.GetCenter(), box.GetWorldSize(), g.WorldRotation(), color)
+		}
+		if sphere := engine.GetComponent[*components.SphereCollider](g); sphere != nil {
+			rl.DrawSphereWires(sphere.GetCenter(), sphere.GetWorldRadius(), 8, 8, color)
+		}
+		if capsule := engine.GetComponent[*components.CapsuleCollider](g); capsule != nil {
+			a, b := capsule.Segment()
+			rl.DrawCapsuleWires(a, b, capsule.GetWorldRadius(), 8, 4, color)
+		}
+		if cc := engine.GetComponent[*components.CharacterController](g); cc != nil {
+			size := rl.Vector3{X: cc.Radius * 2, Y: cc.Height, Z: cc.Radius * 2}
+			rl.DrawCubeWiresV(g.WorldPosition(), size, rl.Lime)
+		}
+		if mc := engine.GetComponent[*components.MeshCollider](g); mc != nil && mc.IsBuilt() {
+			b := mc.GetBounds()
+			rl.DrawBoundingBox(rl.BoundingBox{Min: b.Min, Max: b.Max}, rl.Fade(rl.SkyBlue, 0.7))
+		}
+	}
+}
+
+// DrawRotatedBoxWires draws a wireframe box with rotation (Euler degrees) applied
+func DrawRotatedBoxWires(center, size, rotation rl.Vector3, color rl.Color) {
+	rotX := rl.MatrixRotateX(rotation.X * rl.Deg2rad)
+	rotY := rl.MatrixRotateY(rotation.Y * rl.Deg2rad)
+	rotZ := rl.MatrixRotateZ(rotation.Z * rl.Deg2rad)
+	rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+
+	// Half extents
+	hx, hy, hz := size.X/2, size.Y/2, size.Z/2
+
+	// 8 corners in local space
+	corners := [8]rl.Vector3{
+		{X: -hx, Y: -hy, Z: -hz},
+		{X: hx, Y: -hy, Z: -hz},
+		{X: hx, Y: hy, Z: -hz},
+		{X: -hx, Y: hy, Z: -hz},
+		{X: -hx, Y: -hy, Z: hz},
+		{X: hx, Y: -hy, Z: hz},
+		{X: hx, Y: hy, Z: hz},
+		{X: -hx, Y: hy, Z: hz},
+	}
+
+	// Transform corners to world space
+	for i := range corners {
+		corners[i] = rl.Vector3Transform(corners[i], rotMatrix)
+		corners[i] = rl.Vector3Add(corners[i], center)
+	}
+
+	// Draw 12 edges
+	// Bottom face
+	rl.DrawLine3D(corners[0], corners[1], color)
+	rl.DrawLine3D(corners[1], corners[2], color)
+	rl.DrawLine3D(corners[2], corners[3], color)
+	rl.DrawLine3D(corners[3], corners[0], color)
+	// Top face
+	rl.DrawLine3D(corners[4], corners[5], color)
+	rl.DrawLine3D(corners[5], corners[6], color)
+	rl.DrawLine3D(corners[6], corners[7], color)
+	rl.DrawLine3D(corners[7], corners[4], color)
+	// Vertical edges
+	rl.DrawLine3D(corners[0], corners[4], color)
+	rl.DrawLine3D(corners[1], corners[5], color)
+	rl.DrawLine3D(corners[2], corners[6], color)
+	rl.DrawLine3D(corners[3], corners[7], color)
+}
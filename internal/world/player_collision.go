@@ -60,12 +60,12 @@ func (p *PlayerCollision) Update(deltaTime float32) {
 		}
 
 		objCollider := engine.GetComponent[*components.BoxCollider](obj)
-		if objCollider == nil {
+		if objCollider == nil || objCollider.IsTrigger {
 			continue
 		}
 
 		// Use OBB for rotated box collision
-		objOBB := physics.NewOBBFromBox(objCollider.GetCenter(), objCollider.Size, obj.WorldRotation(), obj.WorldScale())
+		objOBB := physics.NewOBBFromBox(objCollider.GetCenter(), objCollider.Size, obj.WorldQuaternion(), obj.WorldScale())
 		pushOut := playerOBB.ResolveOBB(objOBB)
 
 		if pushOut.X != 0 || pushOut.Y != 0 || pushOut.Z != 0 {
@@ -0,0 +1,372 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"test3d/internal/components"
+	"test3d/internal/engine"
+	"time"
+)
+
+// PrefabExt is the file extension of prefab files
+const PrefabExt = ".prefab.json"
+
+// --- Prefab files ---
+
+// cachedPrefab is a parsed prefab file and the modification time it was read at
+type cachedPrefab struct {
+	def     ObjectDef
+	modTime time.Time
+}
+
+// loadPrefabDef reads a prefab file, caching the parsed definition until the
+// file changes on disk.
+func (w *World) loadPrefabDef(path string) (ObjectDef, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ObjectDef{}, fmt.Errorf("read prefab: %w", err)
+	}
+	if cached, ok := w.prefabDefs[path]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.def, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ObjectDef{}, fmt.Errorf("read prefab: %w", err)
+	}
+
+	def, err := parsePrefabFile(data)
+	if err != nil {
+		return ObjectDef{}, fmt.Errorf("parse prefab: %w", err)
+	}
+
+	w.cachePrefabDef(path, def, info.ModTime())
+	return def, nil
+}
+
+// writePrefabDef writes a prefab definition to disk and refreshes the cache.
+func (w *World) writePrefabDef(def ObjectDef, path string) error {
+	data, err := json.MarshalIndent(prefabFile{Version: SceneVersion, ObjectDef: def}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal prefab: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write prefab: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		w.cachePrefabDef(path, def, info.ModTime())
+	}
+	return nil
+}
+
+func (w *World) cachePrefabDef(path string, def ObjectDef, modTime time.Time) {
+	if w.prefabDefs == nil {
+		w.prefabDefs = make(map[string]cachedPrefab)
+	}
+	w.prefabDefs[path] = cachedPrefab{def: def, modTime: modTime}
+}
+
+// prefabDefFromObject serializes an object subtree as a prefab definition.
+// The root transform is reset, since it belongs to each instance. UIDs are
+// kept so references between the prefab's objects can be remapped when it is
+// instantiated.
+func prefabDefFromObject(g *engine.GameObject) ObjectDef {
+	def := serializeObject(g)
+	def.Prefab = ""
+	def.Position = [3]float32{}
+	def.Rotation = [3]float32{}
+	def.Scale = [3]float32{1, 1, 1}
+	return def
+}
+
+// SavePrefab writes g and its children to a prefab file and makes g an
+// instance of it, so later edits can be applied back with ApplyPrefab.
+func (w *World) SavePrefab(g *engine.GameObject, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create prefab dir: %w", err)
+	}
+	if err := w.writePrefabDef(prefabDefFromObject(g), path); err != nil {
+		return err
+	}
+	g.PrefabPath = path
+	return nil
+}
+
+// InstantiatePrefab adds a fresh copy of a prefab file to the scene and physics
+// world and starts it. Every object in the copy gets a new UID, and
+// references between its objects are pointed at the copies. Returns nil if
+// the file can't be read.
+func (w *World) InstantiatePrefab(path string) *engine.GameObject {
+	def, err := w.loadPrefabDef(path)
+	if err != nil {
+		log.Printf("Prefab: %v", err)
+		return nil
+	}
+	def.Prefab = path
+
+	g := w.loadFresh(def, nil)
+	startSubtree(g)
+	return g
+}
+
+// startSubtree starts an object and all its descendants
+func startSubtree(g *engine.GameObject) {
+	g.Start()
+	for _, child := range g.Children {
+		startSubtree(child)
+	}
+}
+
+// --- Applying ---
+
+// ApplyPrefab writes a prefab instance back to its prefab file and
+// rebuilds every other instance of the same prefab in the scene, including
+// instances nested inside other objects.
+func (w *World) ApplyPrefab(g *engine.GameObject) error {
+	if g.PrefabPath == "" {
+		return fmt.Errorf("%s is not a prefab instance", g.Name)
+	}
+
+	def := prefabDefFromObject(g)
+	if err := w.writePrefabDef(def, g.PrefabPath); err != nil {
+		return err
+	}
+
+	var roots []*engine.GameObject
+	for _, obj := range w.Scene.GameObjects {
+		if obj.Parent == nil {
+			roots = append(roots, obj)
+		}
+	}
+	w.refreshInstances(roots, g, def)
+	return nil
+}
+
+// refreshInstances rebuilds the instances of applied's prefab among objs and
+// their descendants. applied's own subtree is left alone, and a rebuilt
+// instance's old subtree isn't searched since it has been replaced.
+func (w *World) refreshInstances(objs []*engine.GameObject, applied *engine.GameObject, def ObjectDef) {
+	// Copy the list since refreshing modifies it
+	for _, obj := range slices.Clone(objs) {
+		switch {
+		case obj == applied:
+		case obj.PrefabPath == applied.PrefabPath:
+			w.refreshPrefabInstance(obj, def)
+		default:
+			w.refreshInstances(obj.Children, applied, def)
+		}
+	}
+}
+
+// refreshPrefabInstance replaces an instance with a fresh copy of the prefab,
+// keeping the instance's name, parent, root transform and its place among its
+// siblings and in the scene. Objects still at the same place in the prefab's
+// hierarchy keep their UIDs, so references to them from elsewhere survive.
+func (w *World) refreshPrefabInstance(old *engine.GameObject, def ObjectDef) *engine.GameObject {
+	prefabRoot := def.UID
+	keep := make(map[uint64]uint64)
+	matchUIDs(def, serializeObject(old), keep)
+	remap := make(map[uint64]uint64)
+	def = withKeptUIDs(def, keep, remap)
+	if prefabRoot > 0 {
+		remap[prefabRoot] = old.UID
+	}
+	def.UID = old.UID
+	def.Name = old.Name
+	def.Prefab = old.PrefabPath
+	def.Position = [3]float32{old.Transform.Position.X, old.Transform.Position.Y, old.Transform.Position.Z}
+	def.Rotation = [3]float32{old.Transform.Rotation.X, old.Transform.Rotation.Y, old.Transform.Rotation.Z}
+	def.Scale = [3]float32{old.Transform.Scale.X, old.Transform.Scale.Y, old.Transform.Scale.Z}
+
+	parent := old.Parent
+	childIndex := -1
+	if parent != nil {
+		childIndex = parent.ChildIndex(old)
+	}
+	sceneIndex := 0
+	for _, obj := range w.Scene.GameObjects[:max(w.Scene.IndexOf(old), 0)] {
+		if !obj.IsDescendantOf(old) {
+			sceneIndex++
+		}
+	}
+	unloadSubtree(old)
+	w.EditorDestroy(old)
+
+	g := w.loadObject(def, parent)
+	remapObjectRefs(g, remap)
+	if parent != nil {
+		parent.RemoveChild(g)
+		parent.InsertChild(g, childIndex)
+	}
+	w.Scene.MoveGameObject(g, sceneIndex)
+	return g
+}
+
+// withKeptUIDs is withFreshUIDs, except that objects whose UID is in keep
+// get the UID it maps to instead of a new one
+func withKeptUIDs(def ObjectDef, keep, remap map[uint64]uint64) ObjectDef {
+	uid, ok := keep[def.UID]
+	if !ok || def.UID == 0 {
+		uid = engine.NewUID()
+	}
+	if def.UID > 0 {
+		remap[def.UID] = uid
+	}
+	def.UID = uid
+	children := make([]ObjectDef, len(def.Children))
+	for i, child := range def.Children {
+		children[i] = withKeptUIDs(child, keep, remap)
+	}
+	def.Children = children
+	return def
+}
+
+// unloadSubtree unloads the models of an object and all its descendants.
+func unloadSubtree(g *engine.GameObject) {
+	if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
+		renderer.Unload()
+	}
+	for _, child := range g.Children {
+		unloadSubtree(child)
+	}
+}
+
+// --- Overrides ---
+
+// PrefabOverrides lists the fields where a prefab instance differs from its prefab file,
+// e.g. "Rigidbody.mass" or "Wheel/BoxCollider.size". The root transform is not an override.
+func (w *World) PrefabOverrides(g *engine.GameObject) ([]string, error) {
+	if g.PrefabPath == "" {
+		return nil, nil
+	}
+
+	prefab, err := w.loadPrefabDef(g.PrefabPath)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := prefabDefFromObject(g)
+	instance.Name = prefab.Name // renaming an instance isn't an override
+
+	// References between the instance's own objects use its UIDs, not the file's
+	toPrefab := make(map[uint64]uint64)
+	matchUIDs(instance, prefab, toPrefab)
+	remapDefRefs(&instance, toPrefab)
+
+	var overrides []string
+	if err := diffObjectDefs("", instance, prefab, &overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// matchUIDs maps the UIDs in from to the UIDs of the objects at the same
+// place in to, as far as the two hierarchies have the same shape
+func matchUIDs(from, to ObjectDef, out map[uint64]uint64) {
+	if from.UID > 0 && to.UID > 0 {
+		out[from.UID] = to.UID
+	}
+	if len(from.Children) != len(to.Children) {
+		return
+	}
+	for i := range from.Children {
+		matchUIDs(from.Children[i], to.Children[i], out)
+	}
+}
+
+// diffObjectDefs appends the differing fields of two object definitions to out.
+func diffObjectDefs(prefix string, a, b ObjectDef, out *[]string) error {
+	if a.Name != b.Name {
+		*out = append(*out, prefix+"name")
+	}
+	if !reflect.DeepEqual(a.Tags, b.Tags) {
+		*out = append(*out, prefix+"tags")
+	}
+	if a.Position != b.Position {
+		*out = append(*out, prefix+"position")
+	}
+	if a.Rotation != b.Rotation {
+		*out = append(*out, prefix+"rotation")
+	}
+	if a.Scale != b.Scale {
+		*out = append(*out, prefix+"scale")
+	}
+
+	if len(a.Components) != len(b.Components) {
+		*out = append(*out, prefix+"components")
+	} else {
+		for i := range a.Components {
+			if err := diffComponents(prefix, a.Components[i], b.Components[i], out); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(a.Children) != len(b.Children) {
+		*out = append(*out, prefix+"children")
+		return nil
+	}
+	for i := range a.Children {
+		if err := diffObjectDefs(prefix+b.Children[i].Name+"/", a.Children[i], b.Children[i], out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffComponents compares two serialized components field by field.
+func diffComponents(prefix string, rawA, rawB json.RawMessage, out *[]string) error {
+	var a, b map[string]any
+	if err := json.Unmarshal(rawA, &a); err != nil {
+		return fmt.Errorf("parse %scomponent: %w", prefix, err)
+	}
+	if err := json.Unmarshal(rawB, &b); err != nil {
+		return fmt.Errorf("parse prefab %scomponent: %w", prefix, err)
+	}
+
+	typeName, _ := b["type"].(string)
+	if typeA, _ := a["type"].(string); typeA != typeName {
+		*out = append(*out, prefix+typeName)
+		return nil
+	}
+	// Scripts are all "Script" - use the script name and compare its props
+	if typeName == "Script" {
+		if name, ok := b["name"].(string); ok {
+			typeName = name
+		}
+		a, _ = a["props"].(map[string]any)
+		b, _ = b["props"].(map[string]any)
+	}
+
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var fields []string
+	for k := range keys {
+		if k == "type" {
+			continue
+		}
+		if !reflect.DeepEqual(a[k], b[k]) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	for _, f := range fields {
+		*out = append(*out, fmt.Sprintf("%s%s.%s", prefix, typeName, f))
+	}
+	return nil
+}
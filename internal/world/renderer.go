@@ -1,6 +1,7 @@
 package world
 
 import (
+	"test3d/internal/assets"
 	"test3d/internal/components"
 	"test3d/internal/engine"
 	"unsafe"
@@ -10,6 +11,8 @@ import (
 
 const ShadowMapResolution = 2048
 const MaxPointLights = 4
+const MaxDirectionalLights = 4
+const MaxSpotLights = 4
 
 const (
 	ShadowNear float32 = 1.0
@@ -20,12 +23,18 @@ type Renderer struct {
 	Shader         rl.Shader
 	InstanceShader rl.Shader
 	ShadowMap      rl.RenderTexture2D
-	Light          *components.DirectionalLight
+	Light          *components.DirectionalLight   // shadow-casting light
+	Lights         []*components.DirectionalLight // active directional lights this frame, Light first
 	LightCamera    rl.Camera3D
 	MatLightVP     rl.Matrix
 	floorSize      float32
-	frustum        Frustum // current frame's view frustum for culling
-	CullEnabled    bool    // frustum culling toggle (default true)
+	frustum        Frustum         // current frame's view frustum for culling
+	CullEnabled    bool            // frustum culling toggle (default true)
+	shadowPass     bool            // drawing the shadow map (backface culling is already off)
+	bloom          bloom           // bloom post-process, loaded on first use
+	viewPos        rl.Vector3      // camera position of the current pass, for LOD selection
+	Fog            FogSettings     // distance fog for the main view (set from the scene settings)
+	Ambient        AmbientSettings // light everywhere, even in shadow (set from the scene settings)
 
 	// Stats for debug display
 	DrawnObjects  int // objects rendered this frame
@@ -57,6 +66,8 @@ func (r *Renderer) Initialize(floorSize float32) {
 	r.ShadowMap = loadShadowmapRenderTexture(ShadowMapResolution, ShadowMapResolution)
 }
 
+// SetLight makes light the directional light that casts shadows. Every other
+// active DirectionalLight still lights the scene.
 func (r *Renderer) SetLight(light *components.DirectionalLight) {
 	r.Light = light
 	r.updateLightCamera()
@@ -71,27 +82,73 @@ func (r *Renderer) updateLightCamera() {
 }
 
 func (r *Renderer) updateShaderUniforms() {
-	if r.Light == nil {
-		return
+	directions := make([]float32, 0, 3*MaxDirectionalLights)
+	colors := make([]float32, 0, 3*MaxDirectionalLights)
+	for _, light := range r.Lights {
+		directions = append(directions, light.Direction.X, light.Direction.Y, light.Direction.Z)
+		colors = append(colors, light.GetColorFloat()[:3]...)
 	}
-
-	lightDir := []float32{r.Light.Direction.X, r.Light.Direction.Y, r.Light.Direction.Z}
-	lightColor := r.Light.GetColorFloat()
-	ambient := r.Light.GetAmbientFloat()
+	// Pad arrays to MaxDirectionalLights size (shader expects fixed-size arrays)
+	for i := len(r.Lights); i < MaxDirectionalLights; i++ {
+		directions = append(directions, 0, -1, 0)
+		colors = append(colors, 0, 0, 0)
+	}
+	ambient := r.Ambient.Float()
 
 	// Update both shaders
 	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
-		lightDirLoc := rl.GetShaderLocation(shader, "lightDir")
-		rl.SetShaderValue(shader, lightDirLoc, lightDir, rl.ShaderUniformVec3)
+		countLoc := rl.GetShaderLocation(shader, "dirLightCount")
+		rl.EnableShader(shader.ID)
+		rl.SetUniform(countLoc, []int32{int32(len(r.Lights))}, int32(rl.ShaderUniformInt), 1)
 
-		lightColorLoc := rl.GetShaderLocation(shader, "lightColor")
-		rl.SetShaderValue(shader, lightColorLoc, lightColor, rl.ShaderUniformVec4)
+		dirLoc := rl.GetShaderLocation(shader, "dirLightDir")
+		rl.SetShaderValueV(shader, dirLoc, directions, rl.ShaderUniformVec3, MaxDirectionalLights)
+
+		colorLoc := rl.GetShaderLocation(shader, "dirLightColor")
+		rl.SetShaderValueV(shader, colorLoc, colors, rl.ShaderUniformVec3, MaxDirectionalLights)
 
 		ambientLoc := rl.GetShaderLocation(shader, "ambient")
 		rl.SetShaderValue(shader, ambientLoc, ambient, rl.ShaderUniformVec4)
 	}
 }
 
+// updateDirectionalLights collects the directional lights on active objects,
+// up to MaxDirectionalLights. The shadow-casting Light goes first; if it is
+// gone, the first light found takes over the shadows.
+func (r *Renderer) updateDirectionalLights(gameObjects []*engine.GameObject) {
+	r.Lights = r.Lights[:0]
+	found := false
+	for _, g := range gameObjects {
+		if !g.Active {
+			continue
+		}
+		light := engine.GetComponent[*components.DirectionalLight](g)
+		if light == nil {
+			continue
+		}
+		if light == r.Light {
+			found = true
+		}
+		r.Lights = append(r.Lights, light)
+	}
+
+	if !found {
+		r.Light = nil
+		if len(r.Lights) > 0 {
+			r.Light = r.Lights[0]
+		}
+	}
+	for i, light := range r.Lights {
+		if light == r.Light {
+			r.Lights[0], r.Lights[i] = r.Lights[i], r.Lights[0]
+			break
+		}
+	}
+	if len(r.Lights) > MaxDirectionalLights {
+		r.Lights = r.Lights[:MaxDirectionalLights]
+	}
+}
+
 func (r *Renderer) DrawShadowMap(gameObjects []*engine.GameObject) {
 	rl.BeginTextureMode(r.ShadowMap)
 	rl.ClearBackground(rl.White)
@@ -111,7 +168,9 @@ func (r *Renderer) DrawShadowMap(gameObjects []*engine.GameObject) {
 
 	// Disable face culling during shadow pass for better shadow coverage
 	rl.DisableBackfaceCulling()
+	r.shadowPass = true
 	r.drawScene(gameObjects)
+	r.shadowPass = false
 	rl.EnableBackfaceCulling()
 
 	rl.EndMode3D()
@@ -124,6 +183,7 @@ func (r *Renderer) DrawShadowMap(gameObjects []*engine.GameObject) {
 
 func (r *Renderer) DrawWithShadows(camera rl.Camera3D, gameObjects []*engine.GameObject) {
 	// Sync light uniforms and camera every frame (in case editor changed them)
+	r.updateDirectionalLights(gameObjects)
 	r.updateLightCamera()
 	r.updateShaderUniforms()
 
@@ -132,19 +192,12 @@ func (r *Renderer) DrawWithShadows(camera rl.Camera3D, gameObjects []*engine.Gam
 		r.frustum = ExtractFrustum(camera)
 	}
 
-	viewPos := []float32{camera.Position.X, camera.Position.Y, camera.Position.Z}
-
-	// Update both shaders with view position and light VP matrix
-	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
-		viewPosLoc := rl.GetShaderLocation(shader, "viewPos")
-		rl.SetShaderValue(shader, viewPosLoc, viewPos, rl.ShaderUniformVec3)
-
-		lightVPLoc := rl.GetShaderLocation(shader, "matLightVP")
-		rl.SetShaderValueMatrix(shader, lightVPLoc, r.MatLightVP)
-	}
+	r.setViewUniforms(camera)
+	r.setFogUniforms(r.Fog)
 
-	// Collect and set point lights
+	// Collect and set point and spot lights
 	r.updatePointLights(gameObjects)
+	r.updateSpotLights(gameObjects)
 
 	// Bind shadow map for both shaders
 	textureSlot := int32(10)
@@ -159,14 +212,88 @@ func (r *Renderer) DrawWithShadows(camera rl.Camera3D, gameObjects []*engine.Gam
 
 	r.drawScene(gameObjects)
 
-	// Draw light indicator
-	if r.Light != nil {
-		lightIndicatorPos := rl.Vector3Scale(r.Light.Direction, -r.Light.ShadowDistance)
+	// Draw light indicators
+	for _, light := range r.Lights {
+		lightIndicatorPos := rl.Vector3Scale(light.Direction, -light.ShadowDistance)
 		rl.DrawSphere(lightIndicatorPos, 0.5, rl.Yellow)
 		rl.DrawLine3D(lightIndicatorPos, rl.Vector3Zero(), rl.Yellow)
 	}
 }
 
+// setViewUniforms updates both shaders with the view position and light VP matrix.
+func (r *Renderer) setViewUniforms(camera rl.Camera3D) {
+	r.viewPos = camera.Position
+	viewPos := []float32{camera.Position.X, camera.Position.Y, camera.Position.Z}
+
+	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
+		viewPosLoc := rl.GetShaderLocation(shader, "viewPos")
+		rl.SetShaderValue(shader, viewPosLoc, viewPos, rl.ShaderUniformVec3)
+
+		lightVPLoc := rl.GetShaderLocation(shader, "matLightVP")
+		rl.SetShaderValueMatrix(shader, lightVPLoc, r.MatLightVP)
+	}
+}
+
+// setFogUniforms updates both shaders with the fog settings (fogMode 0 = off)
+func (r *Renderer) setFogUniforms(fog FogSettings) {
+	mode := int32(0)
+	if fog.Enabled {
+		mode = 1
+		if fog.Mode == FogExponential {
+			mode = 2
+		}
+	}
+	color := []float32{float32(fog.Color[0]) / 255, float32(fog.Color[1]) / 255, float32(fog.Color[2]) / 255}
+
+	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "fogColor"), color, rl.ShaderUniformVec3)
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "fogStart"), []float32{fog.Start}, rl.ShaderUniformFloat)
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "fogEnd"), []float32{fog.End}, rl.ShaderUniformFloat)
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "fogDensity"), []float32{fog.Density}, rl.ShaderUniformFloat)
+		rl.EnableShader(shader.ID)
+		rl.SetUniform(rl.GetShaderLocation(shader, "fogMode"), []int32{mode}, int32(rl.ShaderUniformInt), 1)
+	}
+}
+
+// DrawMinimaps renders every active MinimapCamera's top-down view into its render texture.
+// Call after DrawShadowMap and before BeginDrawing.
+func (r *Renderer) DrawMinimaps(gameObjects []*engine.GameObject) {
+	cull := r.CullEnabled
+	r.CullEnabled = false           // frustum extraction assumes perspective
+	r.setFogUniforms(FogSettings{}) // fog by distance from a top-down camera only hides the map
+
+	for _, g := range gameObjects {
+		if !g.Active {
+			continue
+		}
+		mc := engine.GetComponent[*components.MinimapCamera](g)
+		if mc == nil {
+			continue
+		}
+
+		visible := gameObjects
+		if len(mc.Layers) > 0 {
+			visible = make([]*engine.GameObject, 0, len(gameObjects))
+			for _, obj := range gameObjects {
+				if mc.Includes(obj) {
+					visible = append(visible, obj)
+				}
+			}
+		}
+
+		camera := mc.GetRaylibCamera()
+		rl.BeginTextureMode(mc.Target())
+		rl.ClearBackground(rl.NewColor(20, 20, 30, 255))
+		rl.BeginMode3D(camera)
+		r.setViewUniforms(camera)
+		r.drawScene(visible)
+		rl.EndMode3D()
+		rl.EndTextureMode()
+	}
+
+	r.CullEnabled = cull
+}
+
 // instanceBatch groups objects by mesh type for instanced rendering
 type instanceBatch struct {
 	mesh       rl.Mesh
@@ -187,11 +314,31 @@ func (r *Renderer) drawScene(gameObjects []*engine.GameObject) {
 		if !g.Active {
 			continue
 		}
+		if terrain := engine.GetComponent[*components.Terrain](g); terrain != nil {
+			// Terrain is large and usually on screen - skip culling
+			terrain.Draw()
+			r.DrawnObjects++
+		}
+
 		mr := engine.GetComponent[*components.ModelRenderer](g)
 		if mr == nil {
 			continue
 		}
 
+		// LOD: pick a model from the distance to the camera (the shadow pass
+		// reuses the last camera so shadows match what is on screen)
+		model, lodSwap := mr.Model, false
+		if lod := engine.GetComponent[*components.LODGroup](g); lod != nil {
+			level := lod.Select(rl.Vector3Distance(r.viewPos, g.WorldPosition()))
+			if level == components.LODCulled {
+				r.CulledObjects++
+				continue
+			}
+			if m, ok := lod.Model(level); ok {
+				model, lodSwap = m, true
+			}
+		}
+
 		// Frustum culling: skip objects outside the view frustum
 		if r.CullEnabled {
 			pos := g.WorldPosition()
@@ -203,6 +350,13 @@ func (r *Renderer) drawScene(gameObjects []*engine.GameObject) {
 		}
 		r.DrawnObjects++
 
+		// Double-sided and alpha-tested materials and swapped-in LOD models draw
+		// on their own; the instancing shader has no per-instance cutoff
+		if mr.DoubleSided() || mr.AlphaTested() || lodSwap {
+			r.drawSingle(mr, model)
+			continue
+		}
+
 		// Only batch generated meshes (sphere, cube, plane) - file models render individually
 		// Also skip batching if mesh has custom size (like the floor) since mesh geometry differs
 		if mr.MeshType == "" || len(mr.MeshSize) > 0 {
@@ -232,11 +386,7 @@ func (r *Renderer) drawScene(gameObjects []*engine.GameObject) {
 		scale := g.WorldScale()
 		scaleMatrix := rl.MatrixScale(scale.X, scale.Y, scale.Z)
 
-		rot := g.WorldRotation()
-		rotX := rl.MatrixRotateX(rot.X * rl.Deg2rad)
-		rotY := rl.MatrixRotateY(rot.Y * rl.Deg2rad)
-		rotZ := rl.MatrixRotateZ(rot.Z * rl.Deg2rad)
-		rotMatrix := rl.MatrixMultiply(rl.MatrixMultiply(rotX, rotY), rotZ)
+		rotMatrix := g.WorldRotationMatrix()
 
 		pos := g.WorldPosition()
 		transMatrix := rl.MatrixTranslate(pos.X, pos.Y, pos.Z)
@@ -261,14 +411,29 @@ func (r *Renderer) drawScene(gameObjects []*engine.GameObject) {
 		metallicLoc := rl.GetShaderLocation(r.InstanceShader, "metallic")
 		roughnessLoc := rl.GetShaderLocation(r.InstanceShader, "roughness")
 		emissiveLoc := rl.GetShaderLocation(r.InstanceShader, "emissive")
+		alphaCutoffLoc := rl.GetShaderLocation(r.InstanceShader, "alphaCutoff")
 		rl.SetShaderValue(r.InstanceShader, metallicLoc, []float32{0.0}, rl.ShaderUniformFloat)
 		rl.SetShaderValue(r.InstanceShader, roughnessLoc, []float32{0.5}, rl.ShaderUniformFloat)
 		rl.SetShaderValue(r.InstanceShader, emissiveLoc, []float32{0.0}, rl.ShaderUniformFloat)
+		rl.SetShaderValue(r.InstanceShader, alphaCutoffLoc, []float32{0.0}, rl.ShaderUniformFloat)
 
 		rl.DrawMeshInstanced(batch.mesh, batch.material, batch.transforms, len(batch.transforms))
 	}
 }
 
+// drawSingle draws one renderer with model outside of any batch, turning
+// backface culling off for double-sided materials
+func (r *Renderer) drawSingle(mr *components.ModelRenderer, model rl.Model) {
+	doubleSided := mr.DoubleSided() && !r.shadowPass
+	if doubleSided {
+		rl.DisableBackfaceCulling()
+	}
+	mr.DrawModel(model)
+	if doubleSided {
+		rl.EnableBackfaceCulling()
+	}
+}
+
 // colorKey returns a string key for a color (for batching by color)
 func colorKey(c rl.Color) string {
 	return string([]byte{c.R, c.G, c.B, c.A})
@@ -287,16 +452,8 @@ func (r *Renderer) getBoundingRadius(g *engine.GameObject, mr *components.ModelR
 	}
 
 	// Check for custom mesh size (e.g., floor plane with meshSize [60, 60])
-	if len(mr.MeshSize) >= 2 {
-		// MeshSize defines the actual mesh dimensions
-		meshMax := mr.MeshSize[0]
-		for _, s := range mr.MeshSize[1:] {
-			if s > meshMax {
-				meshMax = s
-			}
-		}
-		// Diagonal of the mesh (sqrt(2) for 2D, sqrt(3) for 3D)
-		return meshMax * 0.707 * maxScale // half-diagonal for radius
+	if len(mr.MeshSize) > 0 {
+		return assets.PrimitiveRadius(mr.MeshType, mr.MeshSize) * maxScale
 	}
 
 	// Base radius depends on mesh type (unit primitives)
@@ -359,29 +516,81 @@ func (r *Renderer) updatePointLights(gameObjects []*engine.GameObject) {
 	}
 }
 
+func (r *Renderer) updateSpotLights(gameObjects []*engine.GameObject) {
+	var positions, directions, colors []float32
+	var ranges, cosInner, cosOuter []float32
+	count := 0
+
+	for _, g := range gameObjects {
+		if count >= MaxSpotLights {
+			break
+		}
+		if !g.Active {
+			continue
+		}
+		if sl := engine.GetComponent[*components.SpotLight](g); sl != nil {
+			pos, dir := sl.GetPosition(), sl.GetDirection()
+			inner, outer := sl.ConeCos()
+			positions = append(positions, pos.X, pos.Y, pos.Z)
+			directions = append(directions, dir.X, dir.Y, dir.Z)
+			colors = append(colors, sl.GetColorFloat()...)
+			ranges = append(ranges, sl.Range)
+			cosInner = append(cosInner, inner)
+			cosOuter = append(cosOuter, outer)
+			count++
+		}
+	}
+
+	// Pad arrays to MaxSpotLights size (shader expects fixed-size arrays)
+	for i := count; i < MaxSpotLights; i++ {
+		positions = append(positions, 0, 0, 0)
+		directions = append(directions, 0, 0, -1)
+		colors = append(colors, 0, 0, 0)
+		ranges = append(ranges, 0)
+		cosInner = append(cosInner, 1)
+		cosOuter = append(cosOuter, 1)
+	}
+
+	// Update both shaders
+	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
+		countLoc := rl.GetShaderLocation(shader, "spotLightCount")
+		rl.EnableShader(shader.ID)
+		rl.SetUniform(countLoc, []int32{int32(count)}, int32(rl.ShaderUniformInt), 1)
+
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightPos"), positions, rl.ShaderUniformVec3, MaxSpotLights)
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightDir"), directions, rl.ShaderUniformVec3, MaxSpotLights)
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightColor"), colors, rl.ShaderUniformVec3, MaxSpotLights)
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightRange"), ranges, rl.ShaderUniformFloat, MaxSpotLights)
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightCosInner"), cosInner, rl.ShaderUniformFloat, MaxSpotLights)
+		rl.SetShaderValueV(shader, rl.GetShaderLocation(shader, "spotLightCosOuter"), cosOuter, rl.ShaderUniformFloat, MaxSpotLights)
+	}
+}
+
 func (r *Renderer) MoveLightDir(dx, dy, dz float32) {
 	if r.Light == nil {
 		return
 	}
 	r.Light.MoveLightDir(dx, dy, dz)
 	r.updateLightCamera()
-
-	lightDir := []float32{r.Light.Direction.X, r.Light.Direction.Y, r.Light.Direction.Z}
-	for _, shader := range []rl.Shader{r.Shader, r.InstanceShader} {
-		lightDirLoc := rl.GetShaderLocation(shader, "lightDir")
-		rl.SetShaderValue(shader, lightDirLoc, lightDir, rl.ShaderUniformVec3)
-	}
+	r.updateShaderUniforms()
 }
 
 func (r *Renderer) Unload(gameObjects []*engine.GameObject) {
 	rl.UnloadShader(r.Shader)
 	rl.UnloadShader(r.InstanceShader)
 	rl.UnloadRenderTexture(r.ShadowMap)
+	r.bloom.unload()
 
 	for _, g := range gameObjects {
 		if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
 			renderer.Unload()
 		}
+		if mc := engine.GetComponent[*components.MinimapCamera](g); mc != nil {
+			mc.Unload()
+		}
+		if terrain := engine.GetComponent[*components.Terrain](g); terrain != nil {
+			terrain.Unload()
+		}
 	}
 }
 
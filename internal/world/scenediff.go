@@ -0,0 +1,224 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// --- Scene diffing ---
+
+// ChangeKind says how an object differs from the saved scene
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+// SceneChange is one difference between the scene in memory and the file on disk
+type SceneChange struct {
+	Kind   ChangeKind
+	UID    uint64 // object UID in memory (0 for removed objects)
+	Name   string
+	Detail string // e.g. "position (0, 1, 0) -> (0, 2, 0)"
+}
+
+// diffEntry is an object flattened out of the hierarchy
+type diffEntry struct {
+	def    ObjectDef
+	parent string // parent name, "" for root objects
+}
+
+// DiffScene compares the current scene against the scene file at path,
+// matching objects by UID. Objects without a UID on disk are matched by name.
+func (w *World) DiffScene(path string) ([]SceneChange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scene: %w", err)
+	}
+	saved, err := parseSceneFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse scene: %w", err)
+	}
+	// Loading gave repeated UIDs new ones that can't be predicted here, so the
+	// repeats are matched by name instead
+	dedupeUIDs(saved.Objects, nil, func() uint64 { return 0 })
+
+	var current SceneFile
+	for _, g := range w.Scene.GameObjects {
+		if g.Parent == nil {
+			current.Objects = append(current.Objects, serializeObject(g))
+		}
+	}
+
+	changes := DiffSceneFiles(saved, current)
+	if saved.Settings == nil || *saved.Settings != w.Settings {
+		changes = append(changes, SceneChange{Kind: ChangeModified, Name: "Scene", Detail: "settings changed"})
+	}
+	return changes, nil
+}
+
+// DiffSceneFiles lists what changed going from old to new, in new's object order
+func DiffSceneFiles(old, new SceneFile) []SceneChange {
+	oldList := flattenDefs(old.Objects, "", nil)
+	newList := flattenDefs(new.Objects, "", nil)
+
+	oldByUID := make(map[uint64]int)
+	for i, e := range oldList {
+		if e.def.UID > 0 {
+			oldByUID[e.def.UID] = i
+		}
+	}
+
+	matched := make([]bool, len(oldList))
+	pairs := make([]int, len(newList)) // index into oldList, -1 if new
+	for i, e := range newList {
+		pairs[i] = -1
+		if j, ok := oldByUID[e.def.UID]; ok && e.def.UID > 0 {
+			pairs[i] = j
+			matched[j] = true
+		}
+	}
+	// Hand-written scenes can leave UIDs out; those get fresh ones on load
+	for i, e := range newList {
+		if pairs[i] >= 0 {
+			continue
+		}
+		for j, o := range oldList {
+			if !matched[j] && o.def.UID == 0 && o.def.Name == e.def.Name {
+				pairs[i] = j
+				matched[j] = true
+				break
+			}
+		}
+	}
+
+	var changes []SceneChange
+	for i, e := range newList {
+		if pairs[i] < 0 {
+			changes = append(changes, SceneChange{Kind: ChangeAdded, UID: e.def.UID, Name: e.def.Name, Detail: "added"})
+			continue
+		}
+		for _, detail := range diffObject(oldList[pairs[i]], e) {
+			changes = append(changes, SceneChange{Kind: ChangeModified, UID: e.def.UID, Name: e.def.Name, Detail: detail})
+		}
+	}
+	for j, o := range oldList {
+		if !matched[j] {
+			changes = append(changes, SceneChange{Kind: ChangeRemoved, Name: o.def.Name, Detail: "removed"})
+		}
+	}
+	return changes
+}
+
+// flattenDefs lists every object in the hierarchy, parents before children
+func flattenDefs(defs []ObjectDef, parent string, out []diffEntry) []diffEntry {
+	for _, def := range defs {
+		out = append(out, diffEntry{def: def, parent: parent})
+		out = flattenDefs(def.Children, def.Name, out)
+	}
+	return out
+}
+
+// diffObject describes the differences between two versions of the same object
+func diffObject(old, new diffEntry) []string {
+	var details []string
+	if old.def.Name != new.def.Name {
+		details = append(details, fmt.Sprintf("renamed from %q", old.def.Name))
+	}
+	if old.parent != new.parent {
+		if new.parent == "" {
+			details = append(details, "moved to root")
+		} else {
+			details = append(details, fmt.Sprintf("moved under %s", new.parent))
+		}
+	}
+	if !slices.Equal(old.def.Tags, new.def.Tags) {
+		details = append(details, fmt.Sprintf("tags [%s] -> [%s]", strings.Join(old.def.Tags, ", "), strings.Join(new.def.Tags, ", ")))
+	}
+	if old.def.Prefab != new.def.Prefab {
+		details = append(details, fmt.Sprintf("prefab %q -> %q", old.def.Prefab, new.def.Prefab))
+	}
+
+	// A missing scale on disk loads as 1
+	oldScale := old.def.Scale
+	if oldScale == [3]float32{} {
+		oldScale = [3]float32{1, 1, 1}
+	}
+	for _, f := range []struct {
+		name     string
+		old, new [3]float32
+	}{
+		{"position", old.def.Position, new.def.Position},
+		{"rotation", old.def.Rotation, new.def.Rotation},
+		{"scale", oldScale, new.def.Scale},
+	} {
+		if f.old != f.new {
+			details = append(details, fmt.Sprintf("%s %s -> %s", f.name, formatVec(f.old), formatVec(f.new)))
+		}
+	}
+
+	return append(details, diffSceneComponents(old.def.Components, new.def.Components)...)
+}
+
+// diffSceneComponents pairs components by type (and script name), in order,
+// and reports added, removed and modified ones with the fields that changed
+func diffSceneComponents(old, new []json.RawMessage) []string {
+	group := func(raws []json.RawMessage) map[string][]json.RawMessage {
+		byKey := make(map[string][]json.RawMessage)
+		for _, raw := range raws {
+			var header scriptDef
+			if err := json.Unmarshal(raw, &header); err != nil {
+				continue
+			}
+			key := header.Type
+			if key == "Script" {
+				key = header.Name
+			}
+			byKey[key] = append(byKey[key], raw)
+		}
+		return byKey
+	}
+	oldByKey := group(old)
+	newByKey := group(new)
+
+	var keys []string
+	for k := range oldByKey {
+		keys = append(keys, k)
+	}
+	for k := range newByKey {
+		if _, ok := oldByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var details []string
+	for _, key := range keys {
+		o, n := oldByKey[key], newByKey[key]
+		for i := 0; i < max(len(o), len(n)); i++ {
+			switch {
+			case i >= len(o):
+				details = append(details, key+" added")
+			case i >= len(n):
+				details = append(details, key+" removed")
+			default:
+				var fields []string
+				diffComponents("", n[i], o[i], &fields)
+				if len(fields) > 0 {
+					details = append(details, strings.Join(fields, ", ")+" changed")
+				}
+			}
+		}
+	}
+	return details
+}
+
+func formatVec(v [3]float32) string {
+	return fmt.Sprintf("(%g, %g, %g)", v[0], v[1], v[2])
+}
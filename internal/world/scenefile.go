@@ -3,7 +3,11 @@ package world
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"test3d/internal/assets"
 	"test3d/internal/components"
 	"test3d/internal/engine"
@@ -14,7 +18,9 @@ import (
 // --- JSON types ---
 
 type SceneFile struct {
-	Objects []ObjectDef `json:"objects"`
+	Version  int            `json:"version"` // format version, see SceneVersion
+	Settings *SceneSettings `json:"settings,omitempty"`
+	Objects  []ObjectDef    `json:"objects"`
 }
 
 type ObjectDef struct {
@@ -26,6 +32,7 @@ type ObjectDef struct {
 	Scale      [3]float32        `json:"scale"`
 	Components []json.RawMessage `json:"components"`
 	Children   []ObjectDef       `json:"children,omitempty"`
+	Prefab     string            `json:"prefab,omitempty"` // source prefab path for prefab instances
 }
 
 type componentHeader struct {
@@ -138,20 +145,216 @@ func (w *World) LoadScene(path string) error {
 	if err != nil {
 		return fmt.Errorf("read scene: %w", err)
 	}
+	return w.loadSceneData(data)
+}
 
-	var sf SceneFile
-	if err := json.Unmarshal(data, &sf); err != nil {
+// loadSceneData adds the objects of a scene file's contents to the world
+func (w *World) loadSceneData(data []byte) error {
+	// Older files are upgraded to the current format before anything is created
+	sf, err := parseSceneFile(data)
+	if err != nil {
 		return fmt.Errorf("parse scene: %w", err)
 	}
+	w.Settings = *sf.Settings
+	w.PhysicsWorld.Gravity = w.Settings.Physics.GravityVector()
+
+	// Hand-merged or copy-pasted scenes can repeat UIDs, which breaks FindByUID.
+	// UIDs already in the scene count as taken too, for additive loads.
+	taken := make(map[uint64]bool, len(w.Scene.GameObjects))
+	for _, g := range w.Scene.GameObjects {
+		taken[g.UID] = true
+	}
+	remaps := dedupeUIDs(sf.Objects, taken, engine.NewUID)
 
-	for _, objDef := range sf.Objects {
-		w.loadObject(objDef, nil)
+	for i, objDef := range sf.Objects {
+		g := w.loadObject(objDef, nil)
+		if remap := remaps[i]; len(remap) > 0 {
+			remapObjectRefs(g, remap)
+		}
 	}
 
 	return nil
 }
 
-func (w *World) loadObject(objDef ObjectDef, parent *engine.GameObject) {
+// dedupeUIDs assigns a UID from fresh to every object whose UID is taken or
+// already appeared earlier in the file. Returns the old->new mappings per root
+// object index, so references inside a duplicated subtree can be pointed at
+// the copy instead of the original.
+func dedupeUIDs(defs []ObjectDef, taken map[uint64]bool, fresh func() uint64) map[int]map[uint64]uint64 {
+	// Keep fresh UIDs clear of the ones the file holds
+	var reserve func(def *ObjectDef)
+	reserve = func(def *ObjectDef) {
+		engine.ReserveUID(def.UID)
+		for i := range def.Children {
+			reserve(&def.Children[i])
+		}
+	}
+	for i := range defs {
+		reserve(&defs[i])
+	}
+
+	seen := maps.Clone(taken)
+	if seen == nil {
+		seen = make(map[uint64]bool)
+	}
+	remaps := make(map[int]map[uint64]uint64)
+
+	var visit func(def *ObjectDef, root int)
+	visit = func(def *ObjectDef, root int) {
+		if def.UID > 0 {
+			if seen[def.UID] {
+				uid := fresh()
+				log.Printf("Scene: duplicate UID %d on %q, reassigned to %d", def.UID, def.Name, uid)
+				if remaps[root] == nil {
+					remaps[root] = make(map[uint64]uint64)
+				}
+				remaps[root][def.UID] = uid
+				def.UID = uid
+			}
+			seen[def.UID] = true
+		}
+		for i := range def.Children {
+			visit(&def.Children[i], root)
+		}
+	}
+	for i := range defs {
+		visit(&defs[i], i)
+	}
+
+	return remaps
+}
+
+// withFreshUIDs returns a copy of def in which every object has a new UID,
+// recording each old->new pair in remap. def itself is left untouched, so a
+// clipboard or prefab definition can be copied again.
+func withFreshUIDs(def ObjectDef, remap map[uint64]uint64) ObjectDef {
+	uid := engine.NewUID()
+	if def.UID > 0 {
+		remap[def.UID] = uid
+	}
+	def.UID = uid
+	children := make([]ObjectDef, len(def.Children))
+	for i, child := range def.Children {
+		children[i] = withFreshUIDs(child, remap)
+	}
+	def.Children = children
+	return def
+}
+
+// loadFresh loads a copy of def under parent with new UIDs throughout.
+// References between the copied objects are pointed at the copies;
+// references to anything else are kept.
+func (w *World) loadFresh(def ObjectDef, parent *engine.GameObject) *engine.GameObject {
+	remap := make(map[uint64]uint64)
+	g := w.loadObject(withFreshUIDs(def, remap), parent)
+	remapObjectRefs(g, remap)
+	return g
+}
+
+// remapObjectRefs repoints the references in g's subtree that point at a
+// remapped UID
+func remapObjectRefs(g *engine.GameObject, remap map[uint64]uint64) {
+	for _, c := range g.Components() {
+		remapComponentRefs(c, remap)
+	}
+	for _, child := range g.Children {
+		remapObjectRefs(child, remap)
+	}
+}
+
+// remapDefRefs is remapObjectRefs for serialized objects: components holding
+// a remapped reference are rebuilt, repointed and serialized again
+func remapDefRefs(def *ObjectDef, remap map[uint64]uint64) {
+	comps := make([]json.RawMessage, len(def.Components))
+	for i, raw := range def.Components {
+		comps[i] = raw
+		if c := refComponent(raw); c != nil && remapComponentRefs(c, remap) {
+			if data := serializeComponent(c); data != nil {
+				comps[i] = data
+			}
+		}
+	}
+	def.Components = comps
+	children := make([]ObjectDef, len(def.Children))
+	for i, child := range def.Children {
+		remapDefRefs(&child, remap)
+		children[i] = child
+	}
+	def.Children = children
+}
+
+// refComponent builds the component raw describes if it can hold object
+// references (a script or an engine.ObjectRefHolder), or returns nil
+func refComponent(raw json.RawMessage) engine.Component {
+	var def scriptDef
+	if err := json.Unmarshal(raw, &def); err != nil {
+		return nil
+	}
+	if def.Type == "Script" {
+		return engine.CreateScript(def.Name, def.Props)
+	}
+	comp := engine.CreateComponent(def.Type)
+	if _, ok := comp.(engine.ObjectRefHolder); !ok {
+		return nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil
+	}
+	comp.Deserialize(data)
+	return comp.(engine.Component)
+}
+
+// remapComponentRefs repoints c's GameObjectRef fields, or GameObjectRef
+// script fields (and lists of them), that point at a remapped UID. Reports
+// whether any changed.
+func remapComponentRefs(c engine.Component, remap map[uint64]uint64) bool {
+	changed := false
+	if holder, ok := c.(engine.ObjectRefHolder); ok {
+		for _, ref := range holder.ObjectRefs() {
+			if newUID, ok := remap[ref.UID]; ok {
+				ref.UID = newUID
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	_, props, ok := engine.SerializeScript(c)
+	if !ok {
+		return false
+	}
+	for k, v := range props {
+		switch engine.GetScriptFieldType(c, k) {
+		case "GameObjectRef":
+			uid, ok := v.(float64)
+			if !ok {
+				continue
+			}
+			if newUID, ok := remap[uint64(uid)]; ok {
+				engine.ApplyScriptProperty(c, k, float64(newUID))
+				changed = true
+			}
+		case "[]GameObjectRef":
+			uids, ok := v.([]float64)
+			if !ok {
+				continue
+			}
+			list := make([]any, len(uids))
+			for i, uid := range uids {
+				list[i] = uid
+				if newUID, ok := remap[uint64(uid)]; ok {
+					list[i] = float64(newUID)
+					changed = true
+				}
+			}
+			engine.ApplyScriptProperty(c, k, list)
+		}
+	}
+	return changed
+}
+
+func (w *World) loadObject(objDef ObjectDef, parent *engine.GameObject) *engine.GameObject {
 	var g *engine.GameObject
 	if objDef.UID > 0 {
 		g = engine.NewGameObjectWithUID(objDef.Name, objDef.UID)
@@ -159,6 +362,7 @@ func (w *World) loadObject(objDef ObjectDef, parent *engine.GameObject) {
 		g = engine.NewGameObject(objDef.Name)
 	}
 	g.Tags = objDef.Tags
+	g.PrefabPath = objDef.Prefab
 	g.Transform.Position = rl.Vector3{X: objDef.Position[0], Y: objDef.Position[1], Z: objDef.Position[2]}
 	g.Transform.Rotation = rl.Vector3{X: objDef.Rotation[0], Y: objDef.Rotation[1], Z: objDef.Rotation[2]}
 
@@ -170,41 +374,7 @@ func (w *World) loadObject(objDef ObjectDef, parent *engine.GameObject) {
 	}
 
 	for _, raw := range objDef.Components {
-		var header componentHeader
-		if err := json.Unmarshal(raw, &header); err != nil {
-			continue
-		}
-
-		// Try component registry first (for Serializable components)
-		if comp := engine.CreateComponent(header.Type); comp != nil {
-			var data map[string]any
-			json.Unmarshal(raw, &data)
-			comp.Deserialize(data)
-			g.AddComponent(comp.(engine.Component))
-
-			// Post-load hooks for components that need extra setup
-			switch header.Type {
-			case "MeshCollider":
-				if mc, ok := comp.(*components.MeshCollider); ok {
-					if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
-						mc.BuildFromModel(renderer.Model)
-					}
-				}
-			case "DirectionalLight":
-				if light, ok := comp.(*components.DirectionalLight); ok {
-					w.Light = g
-					w.Renderer.SetLight(light)
-				}
-			}
-			continue
-		}
-
-		switch header.Type {
-		case "ModelRenderer":
-			w.loadModelRenderer(g, raw)
-		case "Script":
-			loadScript(g, raw)
-		}
+		w.loadComponent(g, raw)
 	}
 
 	if parent != nil {
@@ -223,6 +393,50 @@ func (w *World) loadObject(objDef ObjectDef, parent *engine.GameObject) {
 	for _, childDef := range objDef.Children {
 		w.loadObject(childDef, g)
 	}
+
+	return g
+}
+
+// loadComponent creates the component described by raw and adds it to g
+func (w *World) loadComponent(g *engine.GameObject, raw json.RawMessage) {
+	var header componentHeader
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return
+	}
+
+	// Try component registry first (for Serializable components)
+	if comp := engine.CreateComponent(header.Type); comp != nil {
+		var data map[string]any
+		json.Unmarshal(raw, &data)
+		comp.Deserialize(data)
+		g.AddComponent(comp.(engine.Component))
+
+		// Post-load hooks for components that need extra setup
+		switch header.Type {
+		case "MeshCollider":
+			if mc, ok := comp.(*components.MeshCollider); ok {
+				mc.Rebuild()
+			}
+		case "Terrain":
+			if terrain, ok := comp.(*components.Terrain); ok {
+				terrain.SetShader(w.Renderer.Shader)
+				terrain.BuildCollider()
+			}
+		case "DirectionalLight":
+			if light, ok := comp.(*components.DirectionalLight); ok {
+				w.Light = g
+				w.Renderer.SetLight(light)
+			}
+		}
+		return
+	}
+
+	switch header.Type {
+	case "ModelRenderer":
+		w.loadModelRenderer(g, raw)
+	case "Script":
+		loadScript(g, raw)
+	}
 }
 
 func (w *World) loadModelRenderer(g *engine.GameObject, raw json.RawMessage) {
@@ -237,23 +451,11 @@ func (w *World) loadModelRenderer(g *engine.GameObject, raw json.RawMessage) {
 	if def.Model != "" {
 		renderer = components.NewModelRendererFromFile(def.Model, color)
 	} else {
-		var model rl.Model
-		switch def.Mesh {
-		case "cube":
-			if len(def.MeshSize) >= 3 {
-				model = rl.LoadModelFromMesh(rl.GenMeshCube(def.MeshSize[0], def.MeshSize[1], def.MeshSize[2]))
-			}
-		case "plane":
-			if len(def.MeshSize) >= 2 {
-				model = rl.LoadModelFromMesh(rl.GenMeshPlane(def.MeshSize[0], def.MeshSize[1], 1, 1))
-			}
-		case "sphere":
-			if len(def.MeshSize) >= 1 {
-				model = rl.LoadModelFromMesh(rl.GenMeshSphere(def.MeshSize[0], 16, 16))
-			}
-		default:
+		mesh, ok := assets.GenPrimitiveMesh(def.Mesh, def.MeshSize)
+		if !ok {
 			return
 		}
+		model := rl.LoadModelFromMesh(mesh)
 		renderer = components.NewModelRenderer(model, color)
 		renderer.MeshType = def.Mesh
 		renderer.MeshSize = def.MeshSize
@@ -288,104 +490,180 @@ func loadScript(g *engine.GameObject, raw json.RawMessage) {
 // --- Duplicating ---
 
 // DuplicateObject creates a deep copy of a GameObject and adds it to the scene.
-// Returns the new root object.
+// References between the copied objects point at the copies. Returns the new
+// root object.
 func (w *World) DuplicateObject(original *engine.GameObject) *engine.GameObject {
 	// Serialize the object (including children)
 	objDef := serializeObject(original)
 
-	// Clear UIDs so new ones are generated
-	clearUIDs(&objDef)
-
 	// Rename to indicate copy
 	objDef.Name = objDef.Name + "_copy"
 
 	// Offset position slightly so it's visible
 	objDef.Position[0] += 1.0
 
-	// Load as new object with same parent
-	return w.loadObjectAndReturn(objDef, original.Parent)
+	// Load as new object with same parent, new UIDs are generated
+	return w.loadFresh(objDef, original.Parent)
 }
 
-func clearUIDs(def *ObjectDef) {
-	def.UID = 0
-	for i := range def.Children {
-		clearUIDs(&def.Children[i])
-	}
+// --- Copying ---
+
+// CopyObject serializes g and its children for the editor clipboard. The copy
+// is a snapshot that can be pasted any number of times.
+func CopyObject(g *engine.GameObject) ObjectDef {
+	return serializeObject(g)
 }
 
-// loadObjectAndReturn is like loadObject but returns the created object
-func (w *World) loadObjectAndReturn(objDef ObjectDef, parent *engine.GameObject) *engine.GameObject {
-	g := engine.NewGameObject(objDef.Name)
-	g.Tags = objDef.Tags
-	g.Transform.Position = rl.Vector3{X: objDef.Position[0], Y: objDef.Position[1], Z: objDef.Position[2]}
-	g.Transform.Rotation = rl.Vector3{X: objDef.Rotation[0], Y: objDef.Rotation[1], Z: objDef.Rotation[2]}
+// PasteObject adds a fresh copy of an object from CopyObject to the scene
+// root. Each paste gets new UIDs, with references between the copied objects
+// pointed at the pasted ones.
+func (w *World) PasteObject(def ObjectDef) *engine.GameObject {
+	return w.loadFresh(def, nil)
+}
 
-	if objDef.Scale == [3]float32{} {
-		g.Transform.Scale = rl.Vector3{X: 1, Y: 1, Z: 1}
-	} else {
-		g.Transform.Scale = rl.Vector3{X: objDef.Scale[0], Y: objDef.Scale[1], Z: objDef.Scale[2]}
+// CopyComponent serializes a single component the way the scene file stores
+// it. Returns nil for components that aren't saved.
+func CopyComponent(c engine.Component) json.RawMessage {
+	return serializeComponent(c)
+}
+
+// CopiedComponentName returns the type of a component from CopyComponent, or
+// the script name for scripts
+func CopiedComponentName(raw json.RawMessage) string {
+	var def scriptDef
+	if err := json.Unmarshal(raw, &def); err != nil {
+		return ""
+	}
+	if def.Type == "Script" {
+		return def.Name
 	}
+	return def.Type
+}
 
-	for _, raw := range objDef.Components {
-		var header componentHeader
-		if err := json.Unmarshal(raw, &header); err != nil {
-			continue
-		}
+// colliderTypes are the components physics treats as an object's collider. It
+// only ever uses one, so an object can't have two of them.
+var colliderTypes = map[string]bool{
+	"BoxCollider":     true,
+	"SphereCollider":  true,
+	"CapsuleCollider": true,
+	"MeshCollider":    true,
+}
 
-		// Try component registry first (for Serializable components)
-		if comp := engine.CreateComponent(header.Type); comp != nil {
-			var data map[string]any
-			json.Unmarshal(raw, &data)
-			comp.Deserialize(data)
-			g.AddComponent(comp.(engine.Component))
-
-			// Post-load hooks for components that need extra setup
-			switch header.Type {
-			case "MeshCollider":
-				if mc, ok := comp.(*components.MeshCollider); ok {
-					if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
-						mc.BuildFromModel(renderer.Model)
-					}
-				}
-			case "DirectionalLight":
-				if light, ok := comp.(*components.DirectionalLight); ok {
-					w.Light = g
-					w.Renderer.SetLight(light)
-				}
-			}
-			continue
+// PasteComponent adds a fresh component built from CopyComponent's output to
+// g. Objects hold one component of each type (Joints aside) and one collider,
+// so pasting a second Rigidbody or collider fails instead of being ignored.
+func (w *World) PasteComponent(g *engine.GameObject, raw json.RawMessage) (engine.Component, error) {
+	name := CopiedComponentName(raw)
+	if name == "" {
+		return nil, fmt.Errorf("clipboard holds no component")
+	}
+	for _, c := range g.Components() {
+		existing := CopiedComponentName(serializeComponent(c))
+		if existing == name && name != "Joint" || colliderTypes[existing] && colliderTypes[name] {
+			return nil, fmt.Errorf("%s already has a %s", g.Name, existing)
 		}
+	}
 
-		switch header.Type {
-		case "ModelRenderer":
-			w.loadModelRenderer(g, raw)
-		case "Script":
-			loadScript(g, raw)
-		}
+	count := len(g.Components())
+	w.loadComponent(g, raw)
+	comps := g.Components()
+	if len(comps) == count {
+		return nil, fmt.Errorf("can't create %s", name)
 	}
+	return comps[len(comps)-1], nil
+}
 
-	if parent != nil {
-		parent.AddChild(g)
+// RestoreComponent sets c's properties back to a CopyComponent snapshot of
+// the same component, for editor undo. Returns false if raw describes a
+// different type of component.
+func (w *World) RestoreComponent(c engine.Component, raw json.RawMessage) bool {
+	if renderer, ok := c.(*components.ModelRenderer); ok {
+		var def modelRendererDef
+		if err := json.Unmarshal(raw, &def); err != nil || def.Type != "ModelRenderer" {
+			return false
+		}
+		w.restoreModelRenderer(renderer, def)
+		return true
 	}
 
-	w.Scene.AddGameObject(g)
+	if s, ok := c.(engine.Serializable); ok {
+		var data map[string]any
+		if err := json.Unmarshal(raw, &data); err != nil || data["type"] != s.TypeName() {
+			return false
+		}
+		s.Deserialize(data)
+		switch comp := c.(type) {
+		case *components.MeshCollider:
+			comp.Rebuild()
+		case *components.Terrain:
+			comp.BuildCollider()
+		}
+		return true
+	}
 
-	if parent == nil {
-		w.PhysicsWorld.AddObject(g)
+	var def scriptDef
+	if err := json.Unmarshal(raw, &def); err != nil || def.Type != "Script" {
+		return false
 	}
+	for name, value := range def.Props {
+		engine.ApplyScriptProperty(c, name, value)
+	}
+	return true
+}
 
-	// Recursively load children
-	for _, childDef := range objDef.Children {
-		w.loadObjectAndReturn(childDef, g)
+// restoreModelRenderer applies a saved mesh and material to an existing
+// renderer, regenerating the mesh only if it changed
+func (w *World) restoreModelRenderer(renderer *components.ModelRenderer, def modelRendererDef) {
+	if def.Model == "" && (def.Mesh != renderer.MeshType || !slices.Equal(def.MeshSize, renderer.MeshSize)) {
+		if mesh, ok := assets.GenPrimitiveMesh(def.Mesh, def.MeshSize); ok {
+			old := renderer.Model
+			renderer.Model = rl.LoadModelFromMesh(mesh)
+			renderer.SetShader(w.Renderer.Shader)
+			if old.MeshCount > 0 && !assets.IsCachedModel(old) {
+				rl.UnloadModel(old)
+			}
+			renderer.MeshType = def.Mesh
+			renderer.MeshSize = def.MeshSize
+		}
 	}
 
-	return g
+	if def.Material != renderer.MaterialPath {
+		renderer.Material = nil
+		if def.Material != "" {
+			renderer.Material = assets.LoadMaterial(def.Material)
+		}
+		renderer.MaterialPath = def.Material
+	}
+	if def.Material == "" {
+		// Colors outside the named palette can't be looked up, so leave those alone
+		if color, ok := colorByName[def.Color]; ok {
+			renderer.Color = color
+		}
+		renderer.Metallic = def.Metallic
+		renderer.Roughness = def.Roughness
+		renderer.Emissive = def.Emissive
+	}
 }
 
 // --- Saving ---
 
 func (w *World) SaveScene(path string) error {
-	var sf SceneFile
+	data, err := w.SnapshotScene()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write scene: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotScene returns the scene as SaveScene would write it, UIDs included,
+// for ResetSceneTo to restore later
+func (w *World) SnapshotScene() ([]byte, error) {
+	sf := SceneFile{Version: SceneVersion, Settings: w.savedSettings()}
 
 	for _, g := range w.Scene.GameObjects {
 		// Skip children (saved recursively under their parent)
@@ -398,13 +676,56 @@ func (w *World) SaveScene(path string) error {
 
 	data, err := json.MarshalIndent(sf, "", "  ")
 	if err != nil {
-		return fmt.Errorf("marshal scene: %w", err)
+		return nil, fmt.Errorf("marshal scene: %w", err)
+	}
+	return data, nil
+}
+
+// ExportScene writes objects (with their children) to a new scene file without
+// touching the current scene. Each object becomes a root, keeping its world
+// transform. With keepUIDs false every object is written with a new UID and
+// references between the exported objects are pointed at the new UIDs.
+func (w *World) ExportScene(objs []*engine.GameObject, path string, keepUIDs bool) error {
+	sf := SceneFile{Version: SceneVersion, Settings: w.savedSettings()}
+	remap := make(map[uint64]uint64)
+	for _, g := range objs {
+		// Descendants of another exported object are written under it
+		covered := false
+		for _, other := range objs {
+			if other != g && g.IsDescendantOf(other) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		def := serializeObject(g)
+		pos, rot, scale := g.WorldPosition(), g.WorldRotation(), g.WorldScale()
+		def.Position = [3]float32{pos.X, pos.Y, pos.Z}
+		def.Rotation = [3]float32{rot.X, rot.Y, rot.Z}
+		def.Scale = [3]float32{scale.X, scale.Y, scale.Z}
+		if !keepUIDs {
+			def = withFreshUIDs(def, remap)
+		}
+		sf.Objects = append(sf.Objects, def)
+	}
+	// Remap once every object has its new UID, so references across roots follow too
+	for i := range sf.Objects {
+		remapDefRefs(&sf.Objects[i], remap)
 	}
 
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal scene: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create scene dir: %w", err)
+	}
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("write scene: %w", err)
 	}
-
 	return nil
 }
 
@@ -416,6 +737,7 @@ func serializeObject(g *engine.GameObject) ObjectDef {
 		Position: [3]float32{g.Transform.Position.X, g.Transform.Position.Y, g.Transform.Position.Z},
 		Rotation: [3]float32{g.Transform.Rotation.X, g.Transform.Rotation.Y, g.Transform.Rotation.Z},
 		Scale:    [3]float32{g.Transform.Scale.X, g.Transform.Scale.Y, g.Transform.Scale.Z},
+		Prefab:   g.PrefabPath,
 	}
 
 	for _, c := range g.Components() {
@@ -0,0 +1,213 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// SceneVersion is the scene file format version written on save. Bump it and
+// register a migration from the previous version whenever a change would make
+// older scenes load differently (a renamed field, a new field whose zero value
+// isn't the right default).
+const SceneVersion = 2
+
+// SceneMigration upgrades a decoded scene file by one version, editing it in
+// place. It runs on the raw JSON (objects, maps and slices as decoded by
+// encoding/json) before any object is created.
+type SceneMigration func(scene map[string]any) error
+
+// sceneMigrations maps a version to the migration that upgrades it to the next.
+// Files written before versioning have no "version" field and count as 0.
+var sceneMigrations = map[int]SceneMigration{
+	// Version 1 only started writing the version; nothing else changed
+	0: func(scene map[string]any) error { return nil },
+	// Version 2 replaced the Rotator sample script with the Rotator component
+	1: migrateRotatorScripts,
+}
+
+// migrateRotatorScripts turns Rotator scripts (a speed around one axis) into
+// Rotator components (a speed per axis)
+func migrateRotatorScripts(scene map[string]any) error {
+	MigrateObjects(scene, func(obj map[string]any) {
+		comps, _ := obj["components"].([]any)
+		for i, c := range comps {
+			comp, ok := c.(map[string]any)
+			if !ok || comp["type"] != "Script" || comp["name"] != "Rotator" {
+				continue
+			}
+			props, _ := comp["props"].(map[string]any)
+			speed, _ := props["speed"].(float64)
+			axes := []any{0.0, 0.0, 0.0}
+			switch props["axis"] {
+			case "x":
+				axes[0] = speed
+			case "z":
+				axes[2] = speed
+			default:
+				axes[1] = speed
+			}
+			comps[i] = map[string]any{"type": "Rotator", "speed": axes}
+		}
+	})
+	return nil
+}
+
+// RegisterSceneMigration sets the migration that upgrades scene files from
+// version from to from+1
+func RegisterSceneMigration(from int, m SceneMigration) {
+	sceneMigrations[from] = m
+}
+
+// parseSceneFile decodes a scene file, first upgrading it to SceneVersion if it
+// was saved by an older version. Settings missing from the file keep their
+// defaults.
+func parseSceneFile(data []byte) (SceneFile, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return SceneFile{}, err
+	}
+
+	if header.Version > SceneVersion {
+		log.Printf("Scene: file version %d is newer than supported version %d, loading anyway", header.Version, SceneVersion)
+	} else if header.Version < SceneVersion {
+		migrated, err := migrateScene(data, header.Version)
+		if err != nil {
+			return SceneFile{}, err
+		}
+		data = migrated
+	}
+
+	sf := SceneFile{Settings: DefaultSceneSettings()}
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return SceneFile{}, err
+	}
+	if sf.Settings == nil {
+		sf.Settings = DefaultSceneSettings()
+	}
+	return sf, nil
+}
+
+// migrateScene runs the migrations from version up to SceneVersion and
+// returns the upgraded file
+func migrateScene(data []byte, version int) ([]byte, error) {
+	var scene map[string]any
+	if err := json.Unmarshal(data, &scene); err != nil {
+		return nil, err
+	}
+	if err := runSceneMigrations(scene, version); err != nil {
+		return nil, err
+	}
+	return json.Marshal(scene)
+}
+
+// runSceneMigrations upgrades a decoded scene from version to SceneVersion in place
+func runSceneMigrations(scene map[string]any, version int) error {
+	for v := version; v < SceneVersion; v++ {
+		m, ok := sceneMigrations[v]
+		if !ok {
+			return fmt.Errorf("no migration from scene version %d", v)
+		}
+		if err := m(scene); err != nil {
+			return fmt.Errorf("migrate scene from version %d: %w", v, err)
+		}
+	}
+	scene["version"] = SceneVersion
+	return nil
+}
+
+// prefabFile is the on-disk form of a prefab: the object tree with the scene
+// format version it was written in alongside its fields
+type prefabFile struct {
+	Version int `json:"version"`
+	ObjectDef
+}
+
+// parsePrefabFile decodes a prefab file, first upgrading it to SceneVersion
+// if it was saved by an older version. The object is wrapped in a one-object
+// scene so the scene migrations apply to it unchanged.
+func parsePrefabFile(data []byte) (ObjectDef, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return ObjectDef{}, err
+	}
+	version, _ := obj["version"].(float64)
+	delete(obj, "version")
+
+	if int(version) > SceneVersion {
+		log.Printf("Prefab: file version %d is newer than supported version %d, loading anyway", int(version), SceneVersion)
+	} else if int(version) < SceneVersion {
+		scene := map[string]any{"objects": []any{obj}}
+		if err := runSceneMigrations(scene, int(version)); err != nil {
+			return ObjectDef{}, err
+		}
+		objs, _ := scene["objects"].([]any)
+		if len(objs) != 1 {
+			return ObjectDef{}, fmt.Errorf("migration left %d prefab objects, want 1", len(objs))
+		}
+		migrated, err := json.Marshal(objs[0])
+		if err != nil {
+			return ObjectDef{}, err
+		}
+		data = migrated
+	}
+
+	var def ObjectDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		return ObjectDef{}, err
+	}
+	return def, nil
+}
+
+// MigrateObjects calls fn for every object in a decoded scene, children
+// included, for migrations that change object fields
+func MigrateObjects(scene map[string]any, fn func(obj map[string]any)) {
+	var visit func(list any)
+	visit = func(list any) {
+		objs, _ := list.([]any)
+		for _, o := range objs {
+			obj, ok := o.(map[string]any)
+			if !ok {
+				continue
+			}
+			fn(obj)
+			visit(obj["children"])
+		}
+	}
+	visit(scene["objects"])
+}
+
+// MigrateComponents calls fn for every component of type typeName in a decoded
+// scene, for migrations that change a component's fields
+func MigrateComponents(scene map[string]any, typeName string, fn func(comp map[string]any)) {
+	MigrateObjects(scene, func(obj map[string]any) {
+		comps, _ := obj["components"].([]any)
+		for _, c := range comps {
+			comp, ok := c.(map[string]any)
+			if ok && comp["type"] == typeName {
+				fn(comp)
+			}
+		}
+	})
+}
+
+// RenameField moves m[from] to m[to], unless from is missing or to is already set
+func RenameField(m map[string]any, from, to string) {
+	v, ok := m[from]
+	if !ok {
+		return
+	}
+	if _, taken := m[to]; !taken {
+		m[to] = v
+	}
+	delete(m, from)
+}
+
+// DefaultField sets m[key] to value if the key is missing
+func DefaultField(m map[string]any, key string, value any) {
+	if _, ok := m[key]; !ok {
+		m[key] = value
+	}
+}
@@ -0,0 +1,110 @@
+package world
+
+import (
+	"test3d/internal/physics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// SceneSettings are per-scene options saved in the scene file's "settings"
+// block. Missing fields keep their defaults, and a scene using only defaults
+// doesn't write the block at all.
+type SceneSettings struct {
+	Bloom   BloomSettings   `json:"bloom"`
+	Fog     FogSettings     `json:"fog"`
+	Ambient AmbientSettings `json:"ambient"`
+	Physics PhysicsSettings `json:"physics"`
+}
+
+// BloomSettings control the bloom post-process that makes bright and emissive
+// surfaces glow
+type BloomSettings struct {
+	Enabled   bool    `json:"enabled"`
+	Threshold float32 `json:"threshold"` // brightness (0-1) above which pixels glow
+	Intensity float32 `json:"intensity"` // strength of the glow added back onto the scene
+}
+
+// Fog modes
+const (
+	FogLinear      = "linear"      // fades in between Start and End
+	FogExponential = "exponential" // thickens with distance at Density
+)
+
+// FogSettings blend distant surfaces toward a fog color by their distance from
+// the camera. The background is cleared to the fog color while fog is on.
+type FogSettings struct {
+	Enabled bool     `json:"enabled"`
+	Mode    string   `json:"mode"`    // FogLinear or FogExponential
+	Color   [3]uint8 `json:"color"`   // RGB
+	Start   float32  `json:"start"`   // linear: distance where fog begins
+	End     float32  `json:"end"`     // linear: distance where fog is solid
+	Density float32  `json:"density"` // exponential: fog per unit of distance
+}
+
+// RGBA returns the fog color as an opaque rl.Color
+func (f FogSettings) RGBA() rl.Color {
+	return rl.NewColor(f.Color[0], f.Color[1], f.Color[2], 255)
+}
+
+// AmbientSettings light every surface evenly, shadows included, so a scene
+// without a directional light isn't pitch black
+type AmbientSettings struct {
+	Color     [3]uint8 `json:"color"`     // RGB
+	Intensity float32  `json:"intensity"` // multiplies Color
+}
+
+// Float returns the ambient light as RGBA floats for the lighting shader
+func (a AmbientSettings) Float() []float32 {
+	return []float32{
+		float32(a.Color[0]) / 255 * a.Intensity,
+		float32(a.Color[1]) / 255 * a.Intensity,
+		float32(a.Color[2]) / 255 * a.Intensity,
+		1,
+	}
+}
+
+// PhysicsSettings configure the physics world while the scene is loaded
+type PhysicsSettings struct {
+	Gravity [3]float32 `json:"gravity"` // acceleration of rigidbodies using gravity, units/sec²
+}
+
+// GravityVector returns Gravity as an rl.Vector3
+func (p PhysicsSettings) GravityVector() rl.Vector3 {
+	return rl.Vector3{X: p.Gravity[0], Y: p.Gravity[1], Z: p.Gravity[2]}
+}
+
+// DefaultSceneSettings returns the settings of a scene without a settings block
+func DefaultSceneSettings() *SceneSettings {
+	return &SceneSettings{
+		Bloom: BloomSettings{
+			Enabled:   false,
+			Threshold: 0.8,
+			Intensity: 1.0,
+		},
+		Fog: FogSettings{
+			Enabled: false,
+			Mode:    FogLinear,
+			Color:   [3]uint8{150, 160, 175},
+			Start:   20,
+			End:     120,
+			Density: 0.02,
+		},
+		Ambient: AmbientSettings{
+			Color:     [3]uint8{25, 25, 25},
+			Intensity: 1,
+		},
+		Physics: PhysicsSettings{
+			Gravity: [3]float32{0, physics.DefaultGravity, 0},
+		},
+	}
+}
+
+// savedSettings returns the settings to write to a scene file, or nil if they
+// are all defaults
+func (w *World) savedSettings() *SceneSettings {
+	if w.Settings == *DefaultSceneSettings() {
+		return nil
+	}
+	settings := w.Settings
+	return &settings
+}
@@ -8,13 +8,12 @@ import (
 	"test3d/internal/compute"
 	"test3d/internal/engine"
 	"test3d/internal/physics"
+	"test3d/internal/project"
 	_ "test3d/internal/scripts"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-var ScenePath = "assets/scenes/main.json"
-
 const FloorSize = 60.0
 
 type World struct {
@@ -22,6 +21,13 @@ type World struct {
 	PhysicsWorld *physics.PhysicsWorld
 	Renderer     *Renderer
 	Light        *engine.GameObject
+	Settings     SceneSettings // loaded from and saved with the scene file
+
+	// Solo, when set, limits play mode to this object and its children:
+	// everything else skips Update and physics (set by the editor)
+	Solo *engine.GameObject
+
+	prefabDefs map[string]cachedPrefab // parsed prefab files, keyed by path
 }
 
 func New() *World {
@@ -29,6 +35,7 @@ func New() *World {
 		Scene:        engine.NewScene("Main"),
 		PhysicsWorld: physics.NewPhysicsWorld(),
 		Renderer:     NewRenderer(),
+		Settings:     *DefaultSceneSettings(),
 	}
 	w.Scene.World = w
 	return w
@@ -43,9 +50,12 @@ func (w *World) Initialize() {
 	w.initializeCompute()
 
 	// Load scene objects from JSON
-	if err := w.LoadScene(ScenePath); err != nil {
+	if err := w.LoadScene(project.Current.CurrentScene); err != nil {
 		log.Fatalf("failed to load scene: %v", err)
 	}
+	if warning := components.AudioListenerWarning(w.Scene); warning != "" {
+		log.Printf("Audio: %s", warning)
+	}
 
 	// Don't call Start() here - it will be called when entering play mode from editor
 	// or when the game loop starts if running without editor
@@ -54,11 +64,25 @@ func (w *World) Initialize() {
 // ResetScene reloads the scene from disk, removing all dynamically spawned
 // objects and restoring scene objects to their saved state.
 func (w *World) ResetScene() {
+	w.resetScene(func() error { return w.LoadScene(project.Current.CurrentScene) })
+}
+
+// ResetSceneTo is ResetScene from a SnapshotScene taken earlier instead of the
+// file on disk, so unsaved edits made before the snapshot survive
+func (w *World) ResetSceneTo(snapshot []byte) {
+	w.resetScene(func() error { return w.loadSceneData(snapshot) })
+}
+
+// resetScene unloads every object and rebuilds the scene with load
+func (w *World) resetScene(load func() error) {
 	// Unload all models
 	for _, g := range w.Scene.GameObjects {
 		if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
 			renderer.Unload()
 		}
+		if terrain := engine.GetComponent[*components.Terrain](g); terrain != nil {
+			terrain.Unload()
+		}
 	}
 
 	// Clear scene and physics
@@ -67,8 +91,8 @@ func (w *World) ResetScene() {
 	w.PhysicsWorld.Statics = w.PhysicsWorld.Statics[:0]
 	w.PhysicsWorld.Kinematics = w.PhysicsWorld.Kinematics[:0]
 
-	// Reload scene from disk (includes Player now)
-	if err := w.LoadScene(ScenePath); err != nil {
+	// Reload scene (includes Player now)
+	if err := load(); err != nil {
 		log.Printf("failed to reload scene: %v", err)
 		return
 	}
@@ -76,8 +100,21 @@ func (w *World) ResetScene() {
 }
 
 func (w *World) Update(deltaTime float32) {
-	w.PhysicsWorld.Update(deltaTime)
-	w.Scene.Update(deltaTime)
+	// A soloed object destroyed at runtime ends the solo
+	if w.Solo != nil && w.Scene.FindByUID(w.Solo.UID) != w.Solo {
+		w.Solo = nil
+	}
+	w.PhysicsWorld.Solo = w.Solo
+	w.PhysicsWorld.FixedUpdate(deltaTime)
+	if w.Solo == nil {
+		w.Scene.Update(deltaTime)
+	} else {
+		for _, g := range w.Scene.GameObjects {
+			if g == w.Solo || g.IsDescendantOf(w.Solo) {
+				g.Update(deltaTime)
+			}
+		}
+	}
 	audio.Update()
 }
 
@@ -107,6 +144,9 @@ func (w *World) Destroy(g *engine.GameObject) {
 	if renderer := engine.GetComponent[*components.ModelRenderer](g); renderer != nil {
 		renderer.Unload()
 	}
+	if terrain := engine.GetComponent[*components.Terrain](g); terrain != nil {
+		terrain.Unload()
+	}
 }
 
 // EditorDestroy removes a GameObject but keeps resources loaded (for undo support).
@@ -129,6 +169,59 @@ func (w *World) Raycast(origin, direction rl.Vector3, maxDistance float32) (engi
 	}, true
 }
 
+// RaycastAll returns every collider along the ray, nearest first
+func (w *World) RaycastAll(origin, direction rl.Vector3, maxDistance float32) []engine.RaycastResult {
+	hits := w.PhysicsWorld.RaycastAll(origin, direction, maxDistance)
+	results := make([]engine.RaycastResult, len(hits))
+	for i, hit := range hits {
+		results[i] = engine.RaycastResult{
+			GameObject: hit.GameObject,
+			Point:      hit.Point,
+			Normal:     hit.Normal,
+			Distance:   hit.Distance,
+		}
+	}
+	return results
+}
+
+// SphereCast sweeps a sphere along a ray and returns the first collider it touches
+func (w *World) SphereCast(origin, direction rl.Vector3, radius, maxDistance float32) (engine.RaycastResult, bool) {
+	hit, ok := w.PhysicsWorld.SphereCast(origin, direction, radius, maxDistance)
+	if !ok {
+		return engine.RaycastResult{}, false
+	}
+	return engine.RaycastResult{
+		GameObject: hit.GameObject,
+		Point:      hit.Point,
+		Normal:     hit.Normal,
+		Distance:   hit.Distance,
+	}, true
+}
+
+// BoxCast sweeps an axis-aligned box along a ray and returns the first collider it touches
+func (w *World) BoxCast(origin, direction, halfExtents rl.Vector3, maxDistance float32) (engine.RaycastResult, bool) {
+	hit, ok := w.PhysicsWorld.BoxCast(origin, direction, halfExtents, maxDistance)
+	if !ok {
+		return engine.RaycastResult{}, false
+	}
+	return engine.RaycastResult{
+		GameObject: hit.GameObject,
+		Point:      hit.Point,
+		Normal:     hit.Normal,
+		Distance:   hit.Distance,
+	}, true
+}
+
+// OverlapSphere returns every object whose collider overlaps the sphere
+func (w *World) OverlapSphere(center rl.Vector3, radius float32) []*engine.GameObject {
+	return w.PhysicsWorld.OverlapSphere(center, radius)
+}
+
+// OverlapBox returns every object whose collider overlaps the rotated box
+func (w *World) OverlapBox(center, halfExtents, rotation rl.Vector3) []*engine.GameObject {
+	return w.PhysicsWorld.OverlapBox(center, halfExtents, rotation)
+}
+
 // EditorRaycast performs raycast that also hits objects without colliders (using model bounds)
 func (w *World) EditorRaycast(origin, direction rl.Vector3, maxDistance float32) (engine.RaycastResult, bool) {
 	hit, ok := w.PhysicsWorld.EditorRaycast(origin, direction, maxDistance, w.Scene.GameObjects)
@@ -143,6 +236,21 @@ func (w *World) EditorRaycast(origin, direction rl.Vector3, maxDistance float32)
 	}, true
 }
 
+// EditorRaycastAll is EditorRaycast returning every object hit, nearest first
+func (w *World) EditorRaycastAll(origin, direction rl.Vector3, maxDistance float32) []engine.RaycastResult {
+	hits := w.PhysicsWorld.EditorRaycastAll(origin, direction, maxDistance, w.Scene.GameObjects)
+	results := make([]engine.RaycastResult, len(hits))
+	for i, hit := range hits {
+		results[i] = engine.RaycastResult{
+			GameObject: hit.GameObject,
+			Point:      hit.Point,
+			Normal:     hit.Normal,
+			Distance:   hit.Distance,
+		}
+	}
+	return results
+}
+
 func (w *World) Unload() {
 	w.Renderer.Unload(w.Scene.GameObjects)
 	w.PhysicsWorld.Release()
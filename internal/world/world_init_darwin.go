@@ -8,11 +8,10 @@ import (
 )
 
 func (w *World) initializeCompute() {
-	// Initialize GPU compute (Metal on Mac works fine)
-	if info, err := compute.Initialize(); err != nil {
-		log.Printf("Compute shaders unavailable: %v", err)
-	} else {
+	// Initialize GPU compute (Metal on Mac works fine). A failure is logged
+	// once by InitGPU, and physics stays on the CPU.
+	if info, err := compute.Initialize(); err == nil {
 		log.Printf("Compute: %s | %s | %s | %s", info.Backend, info.Vendor, info.Name, info.DeviceType)
-		w.PhysicsWorld.InitGPU()
 	}
+	w.PhysicsWorld.InitGPU()
 }
@@ -0,0 +1,426 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"test3d/internal/components"
+	"test3d/internal/engine"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type triggerCounter struct {
+	engine.BaseComponent
+	enters, exits int
+}
+
+func (c *triggerCounter) OnTriggerEnter(other *engine.GameObject) { c.enters++ }
+func (c *triggerCounter) OnTriggerExit(other *engine.GameObject)  { c.exits++ }
+
+func TestCharacterControllerPassesThroughTriggers(t *testing.T) {
+	w := New()
+
+	zone := engine.NewGameObject("Checkpoint")
+	zone.Transform.Position = rl.Vector3{X: 3}
+	box := components.NewBoxCollider(rl.Vector3{X: 1, Y: 4, Z: 4})
+	box.IsTrigger = true
+	zone.AddComponent(box)
+	zoneCounter := &triggerCounter{}
+	zone.AddComponent(zoneCounter)
+	w.SpawnObject(zone)
+
+	player := engine.NewGameObject("Player")
+	cc := components.NewCharacterController()
+	cc.UseGravity = false
+	player.AddComponent(cc)
+	playerCounter := &triggerCounter{}
+	player.AddComponent(playerCounter)
+	w.SpawnObject(player)
+
+	// 90 frames of 0.1 carry the character from x=0 through the zone to x=9
+	for range 90 {
+		cc.Move(rl.Vector3{X: 0.1})
+		w.PhysicsWorld.FixedUpdate(1.0 / 60.0)
+	}
+
+	if x := player.Transform.Position.X; x < 8.9 {
+		t.Errorf("trigger should not block the character: x=%.3f, want ~9", x)
+	}
+	if playerCounter.enters != 1 || playerCounter.exits != 1 {
+		t.Errorf("character: trigger enters=%d exits=%d; want 1, 1", playerCounter.enters, playerCounter.exits)
+	}
+	if zoneCounter.enters != 1 || zoneCounter.exits != 1 {
+		t.Errorf("zone: trigger enters=%d exits=%d; want 1, 1", zoneCounter.enters, zoneCounter.exits)
+	}
+}
+
+// rigJSON is an object tree whose Joint and UIButton reference its own Anchor
+func rigJSON(name string) string {
+	return fmt.Sprintf(`{"uid": 10, "name": %q, "components": [], "children": [
+		{"uid": 11, "name": "Anchor", "components": []},
+		{"uid": 12, "name": "Body", "components": [{"type": "Joint", "connected": 11}]},
+		{"uid": 13, "name": "Button", "components": [{"type": "UIButton", "navigateDown": 11}]}
+	]}`, name)
+}
+
+func TestLoadSceneRemapsDuplicateUIDs(t *testing.T) {
+	w := New()
+	// Already in the scene, so the file's UID 14 is taken (additive load)
+	w.SpawnObject(engine.NewGameObjectWithUID("Existing", 14))
+
+	data := fmt.Sprintf(`{"version": %d, "objects": [%s, %s, {"uid": 14, "name": "Other", "components": []}]}`,
+		SceneVersion, rigJSON("Rig"), rigJSON("RigCopy"))
+	if err := w.loadSceneData([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[uint64]string)
+	for _, g := range w.Scene.GameObjects {
+		if other, ok := seen[g.UID]; ok {
+			t.Errorf("%s and %s share UID %d", other, g.Name, g.UID)
+		}
+		seen[g.UID] = g.Name
+	}
+
+	for _, name := range []string{"Rig", "RigCopy"} {
+		rig := w.Scene.FindByName(name)
+		if rig == nil || len(rig.Children) != 3 {
+			t.Fatalf("%s not loaded with its children", name)
+		}
+		anchor, body, button := rig.Children[0], rig.Children[1], rig.Children[2]
+		if got := engine.GetComponent[*components.Joint](body).Connected.Get(w.Scene); got != anchor {
+			t.Errorf("%s: joint connected to %v, want its own anchor", name, got)
+		}
+		if got := engine.GetComponent[*components.UIButton](button).NavigateDown.Get(w.Scene); got != anchor {
+			t.Errorf("%s: button navigates to %v, want its own anchor", name, got)
+		}
+	}
+	if other := w.Scene.FindByName("Other"); other == nil || other.UID == 14 {
+		t.Error("an object reusing a UID already in the scene should get a new one")
+	}
+}
+
+func TestInstantiatePrefabRemapsInternalReferences(t *testing.T) {
+	w := New()
+	rig := engine.NewGameObject("Rig")
+	anchor := engine.NewGameObject("Anchor")
+	body := engine.NewGameObject("Body")
+	joint := components.NewJoint()
+	joint.Connected.Set(anchor)
+	body.AddComponent(joint)
+	rig.AddChild(anchor)
+	rig.AddChild(body)
+	w.SpawnObject(rig)
+
+	path := filepath.Join(t.TempDir(), "rig"+PrefabExt)
+	if err := w.SavePrefab(rig, path); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		g := w.InstantiatePrefab(path)
+		if g == nil || len(g.Children) != 2 {
+			t.Fatal("prefab not instantiated with its children")
+		}
+		if g.UID == rig.UID || g.Children[0].UID == anchor.UID {
+			t.Error("instances should get fresh UIDs")
+		}
+		if got := engine.GetComponent[*components.Joint](g.Children[1]).Connected.UID; got != g.Children[0].UID {
+			t.Errorf("instance joint connected to UID %d, want its own anchor %d", got, g.Children[0].UID)
+		}
+		if overrides, err := w.PrefabOverrides(g); err != nil || len(overrides) != 0 {
+			t.Errorf("fresh instance overrides = %v, %v; want none", overrides, err)
+		}
+	}
+
+	overrides, err := w.PrefabOverrides(rig)
+	if err != nil || len(overrides) != 0 {
+		t.Errorf("unchanged instance overrides = %v, %v; want none", overrides, err)
+	}
+}
+
+func TestPasteAndDuplicateRemapInternalReferences(t *testing.T) {
+	w := New()
+	rig := engine.NewGameObject("Rig")
+	anchor := engine.NewGameObject("Anchor")
+	button := engine.NewGameObject("Button")
+	b := components.NewUIButton()
+	b.NavigateDown.Set(anchor)
+	button.AddComponent(b)
+	rig.AddChild(anchor)
+	rig.AddChild(button)
+	w.SpawnObject(rig)
+
+	clipboard := CopyObject(rig)
+	copies := []*engine.GameObject{w.PasteObject(clipboard), w.PasteObject(clipboard), w.DuplicateObject(rig)}
+	for i, g := range copies {
+		if len(g.Children) != 2 || g.Children[0].UID == anchor.UID {
+			t.Fatalf("copy %d: children not copied with fresh UIDs", i)
+		}
+		if got := engine.GetComponent[*components.UIButton](g.Children[1]).NavigateDown.UID; got != g.Children[0].UID {
+			t.Errorf("copy %d: button navigates to UID %d, want its own anchor %d", i, got, g.Children[0].UID)
+		}
+	}
+	if b.NavigateDown.UID != anchor.UID {
+		t.Error("copying should leave the original's references alone")
+	}
+}
+
+func TestExportSceneWithNewUIDsRemapsReferences(t *testing.T) {
+	w := New()
+	anchor := engine.NewGameObject("Anchor")
+	w.SpawnObject(anchor)
+	body := engine.NewGameObject("Body")
+	joint := components.NewJoint()
+	joint.Connected.Set(anchor)
+	body.AddComponent(joint)
+	w.SpawnObject(body)
+
+	path := filepath.Join(t.TempDir(), "export.json")
+	if err := w.ExportScene([]*engine.GameObject{anchor, body}, path, false); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := New()
+	if err := loaded.loadSceneData(data); err != nil {
+		t.Fatal(err)
+	}
+	newAnchor, newBody := loaded.Scene.FindByName("Anchor"), loaded.Scene.FindByName("Body")
+	if newAnchor == nil || newBody == nil || newAnchor.UID == anchor.UID {
+		t.Fatal("exported objects should load with new UIDs")
+	}
+	if got := engine.GetComponent[*components.Joint](newBody).Connected.Get(loaded.Scene); got != newAnchor {
+		t.Errorf("exported joint connected to %v, want the exported anchor", got)
+	}
+}
+
+func TestSaveLoadKeepsEditableComponentFields(t *testing.T) {
+	w := New()
+	g := engine.NewGameObject("Everything")
+
+	sphere := components.NewSphereCollider(0.75)
+	sphere.Offset = rl.Vector3{X: 1, Y: 2, Z: 3}
+	sphere.IsTrigger = true
+	box := components.NewBoxCollider(rl.Vector3{X: 2, Y: 3, Z: 4})
+	box.Offset = rl.Vector3{Y: -1}
+	mesh := components.NewMeshCollider()
+	mesh.ModelPath = "assets/models/missing.gltf" // not built, the path is still kept
+	cc := components.NewCharacterController()
+	cc.Height, cc.Radius, cc.StepHeight, cc.SlopeLimit = 2.2, 0.6, 0.3, 35
+	cc.UseGravity, cc.Gravity = false, 12
+	light := components.NewPointLight()
+	light.Color, light.Intensity, light.Radius = rl.NewColor(255, 120, 10, 255), 2.5, 7
+	camera := components.NewCamera()
+	camera.FOV, camera.Near, camera.Far = 70, 0.5, 300
+	camera.Projection, camera.IsMain = rl.CameraOrthographic, true
+	rb := components.NewRigidbody()
+	rb.Mass, rb.Bounciness, rb.Friction, rb.AngularDamping = 3, 0.2, 0.7, 0.5
+	rb.UseGravity, rb.UseCCD, rb.CanSleep = false, true, false
+	for _, c := range []engine.Component{sphere, box, mesh, cc, light, camera, rb} {
+		g.AddComponent(c)
+	}
+	w.SpawnObject(g)
+
+	data, err := w.SnapshotScene()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := New()
+	if err := loaded.loadSceneData(data); err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Scene.FindByName("Everything")
+	if got == nil || len(got.Components()) != len(g.Components()) {
+		t.Fatal("object not loaded with all its components")
+	}
+
+	// Every exported field set above must survive; runtime state is zero on both
+	for i, want := range g.Components() {
+		wv, gv := reflect.ValueOf(want).Elem(), reflect.ValueOf(got.Components()[i]).Elem()
+		if wv.Type() != gv.Type() {
+			t.Fatalf("component %d loaded as %s, want %s", i, gv.Type(), wv.Type())
+		}
+		for f := range wv.NumField() {
+			field := wv.Type().Field(f)
+			if field.Anonymous || !field.IsExported() {
+				continue
+			}
+			if !reflect.DeepEqual(wv.Field(f).Interface(), gv.Field(f).Interface()) {
+				t.Errorf("%s.%s = %v after loading, want %v", wv.Type().Name(), field.Name, gv.Field(f).Interface(), wv.Field(f).Interface())
+			}
+		}
+	}
+}
+
+func TestApplyPrefabRefreshesNestedInstances(t *testing.T) {
+	w := New()
+	crate := engine.NewGameObject("Crate")
+	box := components.NewBoxCollider(rl.Vector3{X: 1, Y: 1, Z: 1})
+	crate.AddComponent(box)
+	w.SpawnObject(crate)
+
+	path := filepath.Join(t.TempDir(), "crate"+PrefabExt)
+	if err := w.SavePrefab(crate, path); err != nil {
+		t.Fatal(err)
+	}
+
+	shelf := engine.NewGameObject("Shelf")
+	w.SpawnObject(shelf)
+	nested := w.InstantiatePrefab(path)
+	if nested == nil {
+		t.Fatal("prefab not instantiated")
+	}
+	shelf.AddChild(nested)
+
+	box.Size = rl.Vector3{X: 2, Y: 2, Z: 2}
+	if overrides, err := w.PrefabOverrides(crate); err != nil || len(overrides) != 1 {
+		t.Fatalf("edited instance overrides = %v, %v; want one", overrides, err)
+	}
+	if err := w.ApplyPrefab(crate); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(shelf.Children) != 1 || shelf.Children[0].UID != nested.UID {
+		t.Fatal("nested instance should be rebuilt in place under its parent")
+	}
+	if got := engine.GetComponent[*components.BoxCollider](shelf.Children[0]).Size; got != box.Size {
+		t.Errorf("nested instance box size = %v after apply, want %v", got, box.Size)
+	}
+}
+
+func TestSceneMigrationsUpgradeOldScenesAndPrefabs(t *testing.T) {
+	// Pretend version 1 renamed Rigidbody's "weight" to "mass"
+	saved := sceneMigrations[0]
+	t.Cleanup(func() { sceneMigrations[0] = saved })
+	RegisterSceneMigration(0, func(scene map[string]any) error {
+		MigrateComponents(scene, "Rigidbody", func(c map[string]any) {
+			RenameField(c, "weight", "mass")
+		})
+		return nil
+	})
+
+	crate := `{"name": "Crate", "components": [{"type": "Rigidbody", "weight": 4}], "children": [
+		{"name": "Lid", "components": [{"type": "Rigidbody", "weight": 1}]}
+	]}`
+	wantMass := func(what string, def ObjectDef, mass float64) {
+		t.Helper()
+		var comp map[string]any
+		if err := json.Unmarshal(def.Components[0], &comp); err != nil {
+			t.Fatal(err)
+		}
+		if comp["mass"] != mass || comp["weight"] != nil {
+			t.Errorf("%s Rigidbody = %v after migrating, want mass %v", what, comp, mass)
+		}
+	}
+
+	sf, err := parseSceneFile([]byte(`{"objects": [` + crate + `]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sf.Objects) != 1 || len(sf.Objects[0].Children) != 1 {
+		t.Fatal("scene objects not decoded")
+	}
+	wantMass("scene", sf.Objects[0], 4)
+	wantMass("scene child", sf.Objects[0].Children[0], 1)
+
+	path := filepath.Join(t.TempDir(), "crate"+PrefabExt)
+	if err := os.WriteFile(path, []byte(crate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	def, err := New().loadPrefabDef(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Name != "Crate" || len(def.Children) != 1 {
+		t.Fatal("prefab object not decoded")
+	}
+	wantMass("prefab", def, 4)
+	wantMass("prefab child", def.Children[0], 1)
+}
+
+func TestSceneMigrationReplacesRotatorScripts(t *testing.T) {
+	sf, err := parseSceneFile([]byte(`{"version": 1, "objects": [{"name": "Fan", "components": [
+		{"type": "Script", "name": "Rotator", "props": {"speed": 90, "axis": "x"}},
+		{"type": "Script", "name": "Mover", "props": {"speed": 2}}
+	]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rot, mover map[string]any
+	if err := json.Unmarshal(sf.Objects[0].Components[0], &rot); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(sf.Objects[0].Components[1], &mover); err != nil {
+		t.Fatal(err)
+	}
+	if rot["type"] != "Rotator" || !slices.Equal(rot["speed"].([]any), []any{90.0, 0.0, 0.0}) {
+		t.Errorf("Rotator script migrated to %v, want a Rotator component spinning 90 around x", rot)
+	}
+	if mover["type"] != "Script" || mover["name"] != "Mover" {
+		t.Errorf("other scripts should be left alone, got %v", mover)
+	}
+}
+
+func TestApplyPrefabKeepsOutsideReferencesAndOrder(t *testing.T) {
+	w := New()
+	cart := engine.NewGameObject("Cart")
+	cart.AddChild(engine.NewGameObject("Hitch"))
+	w.SpawnObject(cart)
+
+	path := filepath.Join(t.TempDir(), "cart"+PrefabExt)
+	if err := w.SavePrefab(cart, path); err != nil {
+		t.Fatal(err)
+	}
+
+	train := engine.NewGameObject("Train")
+	w.SpawnObject(train)
+	first := w.InstantiatePrefab(path)
+	train.AddChild(first)
+	train.AddChild(engine.NewGameObject("Caboose"))
+	w.SpawnObject(engine.NewGameObject("Last"))
+
+	// An object outside the instance hangs off its child
+	horse := engine.NewGameObject("Horse")
+	joint := components.NewJoint()
+	joint.Connected.Set(first.Children[0])
+	horse.AddComponent(joint)
+	w.SpawnObject(horse)
+
+	siblingsBefore := slices.Clone(train.Children)
+	sceneIndex := w.Scene.IndexOf(first)
+
+	cart.Children[0].Transform.Position = rl.Vector3{Y: 1}
+	if err := w.ApplyPrefab(cart); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed := train.Children[0]
+	if refreshed == first || refreshed.UID != first.UID || train.Children[1] != siblingsBefore[1] {
+		t.Fatal("refreshed instance should replace the old one at its sibling position")
+	}
+	if got := w.Scene.IndexOf(refreshed); got != sceneIndex {
+		t.Errorf("refreshed instance at scene index %d, want %d", got, sceneIndex)
+	}
+	hitch := refreshed.Children[0]
+	if hitch.Transform.Position.Y != 1 {
+		t.Error("refreshed instance should have the applied child position")
+	}
+	if got := joint.Connected.Get(w.Scene); got != hitch {
+		t.Errorf("joint connected to %v after apply, want the refreshed Hitch", got)
+	}
+	for _, g := range w.Scene.GameObjects {
+		if g == first || g.Parent == first {
+			t.Errorf("%s from the old instance is still in the scene", g.Name)
+		}
+	}
+}
@@ -1,10 +1,31 @@
+use serde_json::Value;
 use std::fs;
 use std::path::Path;
 use std::process::{self, Command};
 
-pub fn run(output_name: Option<&str>) {
-    let name = output_name.unwrap_or("game");
-    let build_dir = Path::new("build");
+/// Settings from project.json, overridable on the command line
+pub struct BuildOptions {
+    pub name: Option<String>,
+    pub out: Option<String>,
+    pub targets: Vec<String>,
+}
+
+pub fn run(opts: BuildOptions) {
+    let project = load_project();
+    let name = opts
+        .name
+        .or_else(|| project_str(&project, "name"))
+        .unwrap_or_else(|| "game".to_string());
+    let out = opts
+        .out
+        .or_else(|| project_str(&project, "buildDir"))
+        .unwrap_or_else(|| "build".to_string());
+    let targets = if opts.targets.is_empty() {
+        project_targets(&project)
+    } else {
+        opts.targets
+    };
+    let build_dir = Path::new(&out);
 
     println!("Building game (without editor)...");
 
@@ -16,21 +37,62 @@ pub fn run(output_name: Option<&str>) {
         }
     }
 
-    // On macOS, create an .app bundle
-    #[cfg(target_os = "macos")]
-    {
-        build_macos_app(name, build_dir);
+    // No targets: build for this machine straight into the build directory
+    if targets.is_empty() {
+        if cfg!(target_os = "macos") {
+            build_macos_app(&name, build_dir, None);
+        } else {
+            build_binary(&name, build_dir, None);
+        }
+        return;
     }
 
-    // Non-macOS: just build the binary
-    #[cfg(not(target_os = "macos"))]
-    {
-        build_binary(name, build_dir);
+    // One subdirectory per GOOS/GOARCH target
+    for target in &targets {
+        let Some((goos, goarch)) = target.split_once('/') else {
+            eprintln!("Invalid target \"{target}\" (expected GOOS/GOARCH, e.g. linux/amd64)");
+            process::exit(1);
+        };
+        let target_dir = build_dir.join(format!("{goos}-{goarch}"));
+        println!("\n== {target} ==");
+        if goos == "darwin" {
+            build_macos_app(&name, &target_dir, Some((goos, goarch)));
+        } else {
+            build_binary(&name, &target_dir, Some((goos, goarch)));
+        }
     }
 }
 
-#[cfg(target_os = "macos")]
-fn build_macos_app(name: &str, build_dir: &Path) {
+/// Reads project.json from the project root (Null if missing or invalid)
+fn load_project() -> Value {
+    let Ok(content) = fs::read_to_string("project.json") else {
+        return Value::Null;
+    };
+    serde_json::from_str(&content).unwrap_or_else(|e| {
+        eprintln!("Warning: failed to parse project.json: {e}");
+        Value::Null
+    })
+}
+
+fn project_str(project: &Value, key: &str) -> Option<String> {
+    project[key]
+        .as_str()
+        .filter(|s| !s.is_empty())
+        .map(String::from)
+}
+
+fn project_targets(project: &Value) -> Vec<String> {
+    project["targets"]
+        .as_array()
+        .map(|a| {
+            a.iter()
+                .filter_map(|t| t.as_str().map(String::from))
+                .collect()
+        })
+        .unwrap_or_default()
+}
+
+fn build_macos_app(name: &str, build_dir: &Path, target: Option<(&str, &str)>) {
     let app_name = format!("{}.app", name);
     let app_path = build_dir.join(&app_name);
     let contents_path = app_path.join("Contents");
@@ -43,10 +105,11 @@ fn build_macos_app(name: &str, build_dir: &Path) {
 
     // Build the Go binary into the bundle
     let binary_path = macos_path.join(name);
-    run_go_build(&binary_path);
+    run_go_build(&binary_path, target);
 
     // Copy assets into Resources
     copy_assets(&resources_path.join("assets"));
+    copy_project_file(&resources_path);
 
     // Create Info.plist
     let plist = format!(
@@ -73,22 +136,35 @@ fn build_macos_app(name: &str, build_dir: &Path) {
     fs::write(contents_path.join("Info.plist"), plist).expect("Failed to write Info.plist");
 
     println!("\nBuild complete!");
-    println!("Created: build/{app_name}");
+    println!("Created: {}", app_path.display());
     println!("Double-click to run or drag to Applications!");
 }
 
-#[cfg(not(target_os = "macos"))]
-fn build_binary(name: &str, build_dir: &Path) {
-    let output_path = build_dir.join(name);
-    run_go_build(&output_path);
+fn build_binary(name: &str, build_dir: &Path, target: Option<(&str, &str)>) {
+    let binary = match target {
+        Some(("windows", _)) => format!("{name}.exe"),
+        _ => name.to_string(),
+    };
+    let output_path = build_dir.join(&binary);
+    run_go_build(&output_path, target);
     copy_assets(&build_dir.join("assets"));
+    copy_project_file(build_dir);
 
     println!("\nBuild complete!");
-    println!("Run with: cd build && ./{name}");
+    println!("Run with: cd {} && ./{binary}", build_dir.display());
 }
 
-fn run_go_build(output_path: &Path) {
-    let status = Command::new("go")
+fn run_go_build(output_path: &Path, target: Option<(&str, &str)>) {
+    let mut cmd = Command::new("go");
+    // raylib is linked through cgo, which Go turns off when cross-compiling
+    cmd.env("CGO_ENABLED", "1");
+    if let Some((goos, goarch)) = target {
+        cmd.env("GOOS", goos).env("GOARCH", goarch);
+        if (goos, goarch) != host_target() {
+            cmd.env("CC", cross_compiler(goos, goarch));
+        }
+    }
+    let status = cmd
         .args([
             "build",
             "-tags",
@@ -117,6 +193,40 @@ fn run_go_build(output_path: &Path) {
     }
 }
 
+/// GOOS/GOARCH of the machine running the build
+fn host_target() -> (&'static str, &'static str) {
+    let goos = match std::env::consts::OS {
+        "macos" => "darwin",
+        os => os,
+    };
+    let goarch = match std::env::consts::ARCH {
+        "x86_64" => "amd64",
+        "aarch64" => "arm64",
+        "x86" => "386",
+        arch => arch,
+    };
+    (goos, goarch)
+}
+
+/// C cross compiler for a non-native target, from MIRGO_CC_<GOOS>_<GOARCH>
+/// (e.g. MIRGO_CC_WINDOWS_AMD64=x86_64-w64-mingw32-gcc). Exits if it isn't set,
+/// since cgo can't build raylib for another platform with the host compiler.
+fn cross_compiler(goos: &str, goarch: &str) -> String {
+    let var = format!("MIRGO_CC_{}_{}", goos.to_uppercase(), goarch.to_uppercase());
+    match std::env::var(&var) {
+        Ok(cc) if !cc.is_empty() => cc,
+        _ => {
+            eprintln!(
+                "Cannot build {goos}/{goarch} on this machine: raylib needs a C compiler for that target."
+            );
+            eprintln!(
+                "Set {var} to one (e.g. a mingw or zig cc wrapper), or build on a {goos}/{goarch} machine."
+            );
+            process::exit(1);
+        }
+    }
+}
+
 fn copy_assets(dst: &Path) {
     let assets_src = Path::new("assets");
 
@@ -129,6 +239,17 @@ fn copy_assets(dst: &Path) {
     }
 }
 
+/// Copies project.json next to the built binary so the game opens the same default scene
+fn copy_project_file(dst_dir: &Path) {
+    let src = Path::new("project.json");
+    if src.exists() {
+        if let Err(e) = fs::copy(src, dst_dir.join("project.json")) {
+            eprintln!("Error copying project.json: {e}");
+            process::exit(1);
+        }
+    }
+}
+
 fn copy_dir_recursive(src: &Path, dst: &Path) -> std::io::Result<()> {
     if !dst.exists() {
         fs::create_dir_all(dst)?;
@@ -1,3 +1,4 @@
 pub mod build;
 pub mod flipnormals;
 pub mod newscript;
+pub mod recalcnormals;
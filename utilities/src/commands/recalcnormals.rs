@@ -0,0 +1,546 @@
+use std::collections::HashMap;
+use std::fs;
+use std::path::{Path, PathBuf};
+use std::process;
+
+use serde_json::{Value, json};
+
+const FLOAT: u64 = 5126;
+
+/// Recomputes the normals of every triangle primitive in a GLTF model from its
+/// face geometry. Faces meeting at a position are averaged when the angle
+/// between them is at most `smoothing_angle` degrees, so 0 gives flat faces
+/// and 180 smooths everything. A shared vertex on a harder edge is split into
+/// one copy per side, which adds new accessors for the primitive's attributes
+/// and indices at the end of the buffer.
+pub fn run(gltf_path: &str, smoothing_angle: f32) {
+    let path = resolve_gltf(gltf_path);
+
+    let content = match fs::read_to_string(&path) {
+        Ok(c) => c,
+        Err(e) => {
+            eprintln!("Error reading file: {e}");
+            process::exit(1);
+        }
+    };
+
+    let mut gltf: Value = match serde_json::from_str(&content) {
+        Ok(v) => v,
+        Err(e) => {
+            eprintln!("Error parsing GLTF JSON: {e}");
+            process::exit(1);
+        }
+    };
+
+    let Some(uri) = gltf
+        .pointer("/buffers/0/uri")
+        .and_then(|u| u.as_str())
+        .map(str::to_string)
+    else {
+        eprintln!("Error: no buffer URI found in GLTF");
+        process::exit(1);
+    };
+    let bin_path = path.parent().unwrap_or(Path::new(".")).join(uri);
+
+    let mut bin_data = match fs::read(&bin_path) {
+        Ok(d) => d,
+        Err(e) => {
+            eprintln!("Error reading binary file {}: {e}", bin_path.display());
+            process::exit(1);
+        }
+    };
+
+    let cos_threshold = smoothing_angle.clamp(0.0, 180.0).to_radians().cos();
+    let mut normals_written = 0;
+    let mut json_changed = false;
+
+    let mesh_count = gltf["meshes"].as_array().map_or(0, |m| m.len());
+    for m in 0..mesh_count {
+        let prim_count = gltf["meshes"][m]["primitives"]
+            .as_array()
+            .map_or(0, |p| p.len());
+        for p in 0..prim_count {
+            let primitive = &gltf["meshes"][m]["primitives"][p];
+            // Only triangle lists (mode 4, the default)
+            if primitive.get("mode").and_then(|v| v.as_u64()).unwrap_or(4) != 4 {
+                continue;
+            }
+            let Some(position_idx) = primitive["attributes"]["POSITION"].as_u64() else {
+                continue;
+            };
+            let Some(positions) = read_vec3(&gltf, position_idx as usize, &bin_data) else {
+                eprintln!("Warning: POSITION accessor is not VEC3 float, skipping");
+                continue;
+            };
+            let indices = match primitive.get("indices").and_then(|v| v.as_u64()) {
+                Some(idx) => match read_indices(&gltf, idx as usize, &bin_data) {
+                    Some(i) => i,
+                    None => {
+                        eprintln!("Warning: unsupported index accessor, skipping");
+                        continue;
+                    }
+                },
+                None => (0..positions.len() as u32).collect(),
+            };
+
+            let corner_normals = compute_corner_normals(&positions, &indices, cos_threshold);
+            let split = split_vertices(positions.len(), &indices, &corner_normals);
+
+            if split.sources.len() > positions.len() {
+                if primitive.get("targets").is_some() {
+                    eprintln!("Warning: mesh {m} has morph targets, hard edges not split");
+                } else {
+                    let attributes = primitive["attributes"].clone();
+                    if !write_split(&mut gltf, m, p, &attributes, &split, &mut bin_data) {
+                        eprintln!("Warning: unsupported vertex attribute in mesh {m}, skipping");
+                        continue;
+                    }
+                    json_changed = true;
+                    normals_written += split.normals.len();
+                    continue;
+                }
+            }
+            let normals = &split.normals[..positions.len()];
+
+            match primitive["attributes"]["NORMAL"].as_u64() {
+                Some(normal_idx) => {
+                    if !write_vec3(&gltf, normal_idx as usize, normals, &mut bin_data) {
+                        eprintln!("Warning: NORMAL accessor is not VEC3 float, skipping");
+                        continue;
+                    }
+                }
+                None => {
+                    // No normals yet: append them to the buffer as a new accessor
+                    let accessor = append_vec3(&mut gltf, normals, &mut bin_data);
+                    gltf["meshes"][m]["primitives"][p]["attributes"]["NORMAL"] = json!(accessor);
+                    json_changed = true;
+                }
+            }
+            normals_written += normals.len();
+        }
+    }
+
+    if normals_written == 0 {
+        println!("No triangle meshes found in {gltf_path}");
+        return;
+    }
+
+    if let Err(e) = fs::write(&bin_path, &bin_data) {
+        eprintln!("Error writing binary file: {e}");
+        process::exit(1);
+    }
+    if json_changed {
+        let text = serde_json::to_string_pretty(&gltf).unwrap_or_default();
+        if let Err(e) = fs::write(&path, text) {
+            eprintln!("Error writing GLTF file: {e}");
+            process::exit(1);
+        }
+    }
+
+    println!(
+        "Recalculated {normals_written} normals in {} (smoothing angle {smoothing_angle}°)",
+        bin_path.display()
+    );
+}
+
+/// Returns the .gltf file at path, or the first one inside path if it is a directory
+fn resolve_gltf(gltf_path: &str) -> PathBuf {
+    let path = Path::new(gltf_path).to_path_buf();
+    if !path.exists() {
+        eprintln!("Error: file not found: {gltf_path}");
+        process::exit(1);
+    }
+    if !path.is_dir() {
+        return path;
+    }
+
+    let found = fs::read_dir(&path).ok().and_then(|entries| {
+        entries
+            .flatten()
+            .map(|e| e.path())
+            .find(|p| p.extension().map(|e| e == "gltf").unwrap_or(false))
+    });
+    match found {
+        Some(p) => {
+            println!("Found GLTF file: {}", p.display());
+            p
+        }
+        None => {
+            eprintln!("Error: no .gltf file found in directory {gltf_path}");
+            process::exit(1);
+        }
+    }
+}
+
+/// Computes a normal for every triangle corner (every entry of indices): the
+/// area-weighted sum of the faces at the corner's position that are within
+/// the smoothing angle of the corner's own face
+fn compute_corner_normals(
+    positions: &[[f32; 3]],
+    indices: &[u32],
+    cos_threshold: f32,
+) -> Vec<[f32; 3]> {
+    let corner_position = |c: usize| {
+        positions
+            .get(indices[c] as usize)
+            .copied()
+            .unwrap_or_default()
+    };
+
+    let mut face_normals = Vec::with_capacity(indices.len() / 3);
+    for f in 0..indices.len() / 3 {
+        let [a, b, c] = [0, 1, 2].map(|k| corner_position(f * 3 + k));
+        // Cross product length is twice the area, so bigger faces weigh more
+        face_normals.push(cross(sub(b, a), sub(c, a)));
+    }
+    let face_dirs: Vec<[f32; 3]> = face_normals.iter().map(|&n| normalize(n)).collect();
+
+    // Faces touching each position, welding nearly identical positions
+    let mut position_faces: HashMap<[i32; 3], Vec<usize>> = HashMap::new();
+    for c in 0..face_normals.len() * 3 {
+        let faces = position_faces
+            .entry(position_key(corner_position(c)))
+            .or_default();
+        if faces.last() != Some(&(c / 3)) {
+            faces.push(c / 3);
+        }
+    }
+
+    (0..face_normals.len() * 3)
+        .map(|c| {
+            let own = face_dirs[c / 3];
+            let sum = position_faces[&position_key(corner_position(c))]
+                .iter()
+                .filter(|&&f| dot(face_dirs[f], own) >= cos_threshold - 1e-4)
+                .fold([0.0; 3], |n, &f| add(n, face_normals[f]));
+            normalize(sum)
+        })
+        .collect()
+}
+
+/// Vertices after splitting the ones whose corners need different normals
+struct SplitVertices {
+    sources: Vec<u32>,      // original vertex each output vertex copies
+    normals: Vec<[f32; 3]>, // normal of each output vertex
+    indices: Vec<u32>,      // indices rewritten to the output vertices
+}
+
+/// Gives every vertex the normal of its corners. A vertex whose corners
+/// disagree (it sits on a hard edge) keeps the first normal and gets a new
+/// copy, appended after the original vertices, for each other one.
+fn split_vertices(
+    vertex_count: usize,
+    indices: &[u32],
+    corner_normals: &[[f32; 3]],
+) -> SplitVertices {
+    let mut split = SplitVertices {
+        sources: (0..vertex_count as u32).collect(),
+        normals: vec![[0.0, 1.0, 0.0]; vertex_count],
+        indices: Vec::with_capacity(indices.len()),
+    };
+    let mut assigned = vec![false; vertex_count];
+    let mut copies: HashMap<(u32, [i32; 3]), u32> = HashMap::new();
+    for (&v, &n) in indices.iter().zip(corner_normals) {
+        if v as usize >= vertex_count {
+            split.indices.push(v);
+            continue;
+        }
+        let key = (v, position_key(n));
+        let out = if let Some(&out) = copies.get(&key) {
+            out
+        } else if !assigned[v as usize] {
+            assigned[v as usize] = true;
+            split.normals[v as usize] = n;
+            v
+        } else {
+            split.sources.push(v);
+            split.normals.push(n);
+            split.sources.len() as u32 - 1
+        };
+        copies.insert(key, out);
+        split.indices.push(out);
+    }
+    split
+}
+
+/// Replaces a primitive's attributes and indices with split copies: every
+/// attribute but NORMAL is copied per output vertex, the normals and indices
+/// are written fresh. Returns false, changing nothing, if an attribute can't
+/// be read.
+fn write_split(
+    gltf: &mut Value,
+    mesh: usize,
+    primitive: usize,
+    attributes: &Value,
+    split: &SplitVertices,
+    bin_data: &mut Vec<u8>,
+) -> bool {
+    let Some(attributes) = attributes.as_object() else {
+        return false;
+    };
+    let mut copied = Vec::new();
+    for (name, accessor) in attributes {
+        if name == "NORMAL" {
+            continue;
+        }
+        let Some(elements) = accessor
+            .as_u64()
+            .and_then(|idx| read_elements(gltf, idx as usize, bin_data))
+        else {
+            return false;
+        };
+        let mut data = Vec::with_capacity(split.sources.len() * elements.size);
+        for &v in &split.sources {
+            let offset = v as usize * elements.size;
+            data.extend_from_slice(&elements.data[offset..offset + elements.size]);
+        }
+        copied.push((name.clone(), data, elements));
+    }
+
+    for (name, data, mut elements) in copied {
+        elements.fields["count"] = json!(split.sources.len());
+        let accessor = append_accessor(gltf, bin_data, &data, elements.size, true, elements.fields);
+        gltf["meshes"][mesh]["primitives"][primitive]["attributes"][name.as_str()] =
+            json!(accessor);
+    }
+    let normals = append_vec3(gltf, &split.normals, bin_data);
+    gltf["meshes"][mesh]["primitives"][primitive]["attributes"]["NORMAL"] = json!(normals);
+
+    // Short indices while they fit, as the loader prefers them
+    let (component_type, size) = if split.sources.len() <= u16::MAX as usize {
+        (5123, 2)
+    } else {
+        (5125, 4)
+    };
+    let mut data = Vec::with_capacity(split.indices.len() * size);
+    for &i in &split.indices {
+        if size == 2 {
+            data.extend_from_slice(&(i as u16).to_le_bytes());
+        } else {
+            data.extend_from_slice(&i.to_le_bytes());
+        }
+    }
+    let fields =
+        json!({"componentType": component_type, "count": split.indices.len(), "type": "SCALAR"});
+    let indices = append_accessor(gltf, bin_data, &data, size, false, fields);
+    gltf["meshes"][mesh]["primitives"][primitive]["indices"] = json!(indices);
+    true
+}
+
+/// Quantizes a position so that nearly identical vertices weld together
+fn position_key(p: [f32; 3]) -> [i32; 3] {
+    p.map(|c| (c * 10000.0).round() as i32)
+}
+
+/// Byte offset, element stride and count of an accessor of the given type
+fn accessor_layout(
+    gltf: &Value,
+    accessor_idx: usize,
+    element_size: usize,
+) -> Option<(usize, usize, usize)> {
+    let accessor = gltf["accessors"].get(accessor_idx)?;
+    let count = accessor["count"].as_u64()? as usize;
+    let view = gltf["bufferViews"].get(accessor["bufferView"].as_u64()? as usize)?;
+    let start = view["byteOffset"].as_u64().unwrap_or(0) as usize
+        + accessor["byteOffset"].as_u64().unwrap_or(0) as usize;
+    let stride = view["byteStride"].as_u64().unwrap_or(element_size as u64) as usize;
+    Some((start, stride, count))
+}
+
+/// An accessor's elements packed tightly, and the accessor fields a copy
+/// needs besides its count
+struct Elements {
+    data: Vec<u8>,
+    size: usize,
+    fields: Value,
+}
+
+/// Reads every element of a (non-sparse) accessor as raw bytes
+fn read_elements(gltf: &Value, accessor_idx: usize, bin_data: &[u8]) -> Option<Elements> {
+    let accessor = gltf["accessors"].get(accessor_idx)?;
+    if accessor.get("sparse").is_some() {
+        return None;
+    }
+    let component_size = match accessor["componentType"].as_u64()? {
+        5120 | 5121 => 1,
+        5122 | 5123 => 2,
+        5125 | 5126 => 4,
+        _ => return None,
+    };
+    let components = match accessor["type"].as_str()? {
+        "SCALAR" => 1,
+        "VEC2" => 2,
+        "VEC3" => 3,
+        "VEC4" | "MAT2" => 4,
+        "MAT3" => 9,
+        "MAT4" => 16,
+        _ => return None,
+    };
+    let size = component_size * components;
+    let (start, stride, count) = accessor_layout(gltf, accessor_idx, size)?;
+    let mut data = Vec::with_capacity(count * size);
+    for i in 0..count {
+        let offset = start + i * stride;
+        data.extend_from_slice(bin_data.get(offset..offset + size)?);
+    }
+
+    let mut fields = json!({});
+    for key in ["componentType", "type", "normalized", "min", "max"] {
+        if let Some(v) = accessor.get(key) {
+            fields[key] = v.clone();
+        }
+    }
+    Some(Elements { data, size, fields })
+}
+
+fn is_vec3_float(gltf: &Value, accessor_idx: usize) -> bool {
+    let accessor = &gltf["accessors"][accessor_idx];
+    accessor["type"].as_str() == Some("VEC3") && accessor["componentType"].as_u64() == Some(FLOAT)
+}
+
+fn read_vec3(gltf: &Value, accessor_idx: usize, bin_data: &[u8]) -> Option<Vec<[f32; 3]>> {
+    if !is_vec3_float(gltf, accessor_idx) {
+        return None;
+    }
+    let (start, stride, count) = accessor_layout(gltf, accessor_idx, 12)?;
+    (0..count)
+        .map(|i| {
+            let offset = start + i * stride;
+            let bytes = bin_data.get(offset..offset + 12)?;
+            Some([0, 1, 2].map(|j| f32::from_le_bytes(bytes[j * 4..j * 4 + 4].try_into().unwrap())))
+        })
+        .collect()
+}
+
+fn read_indices(gltf: &Value, accessor_idx: usize, bin_data: &[u8]) -> Option<Vec<u32>> {
+    let accessor = gltf["accessors"].get(accessor_idx)?;
+    let size = match accessor["componentType"].as_u64()? {
+        5121 => 1, // unsigned byte
+        5123 => 2, // unsigned short
+        5125 => 4, // unsigned int
+        _ => return None,
+    };
+    let (start, stride, count) = accessor_layout(gltf, accessor_idx, size)?;
+    (0..count)
+        .map(|i| {
+            let offset = start + i * stride;
+            let bytes = bin_data.get(offset..offset + size)?;
+            Some(match size {
+                1 => bytes[0] as u32,
+                2 => u16::from_le_bytes([bytes[0], bytes[1]]) as u32,
+                _ => u32::from_le_bytes([bytes[0], bytes[1], bytes[2], bytes[3]]),
+            })
+        })
+        .collect()
+}
+
+/// Overwrites an existing VEC3 float accessor. Returns false if the layout doesn't match.
+fn write_vec3(gltf: &Value, accessor_idx: usize, values: &[[f32; 3]], bin_data: &mut [u8]) -> bool {
+    if !is_vec3_float(gltf, accessor_idx) {
+        return false;
+    }
+    let Some((start, stride, count)) = accessor_layout(gltf, accessor_idx, 12) else {
+        return false;
+    };
+    for (i, v) in values.iter().take(count).enumerate() {
+        let offset = start + i * stride;
+        let Some(dst) = bin_data.get_mut(offset..offset + 12) else {
+            eprintln!("Warning: buffer overflow at normal {i}");
+            return false;
+        };
+        for (j, c) in v.iter().enumerate() {
+            dst[j * 4..j * 4 + 4].copy_from_slice(&c.to_le_bytes());
+        }
+    }
+    true
+}
+
+/// Appends values to the end of buffer 0 as a new buffer view and accessor,
+/// returning the accessor index
+fn append_vec3(gltf: &mut Value, values: &[[f32; 3]], bin_data: &mut Vec<u8>) -> usize {
+    let data: Vec<u8> = values
+        .iter()
+        .flat_map(|v| v.iter().flat_map(|c| c.to_le_bytes()))
+        .collect();
+    let fields = json!({"componentType": FLOAT, "count": values.len(), "type": "VEC3"});
+    append_accessor(gltf, bin_data, &data, 12, true, fields)
+}
+
+/// Appends tightly packed elements to the end of buffer 0 as a new buffer view
+/// and an accessor with the given fields, returning the accessor index. Vertex
+/// attribute elements are padded to 4 bytes, as GLTF requires.
+fn append_accessor(
+    gltf: &mut Value,
+    bin_data: &mut Vec<u8>,
+    data: &[u8],
+    element_size: usize,
+    vertex_attribute: bool,
+    mut fields: Value,
+) -> usize {
+    // Buffer views must start 4-byte aligned for float data
+    while bin_data.len() % 4 != 0 {
+        bin_data.push(0);
+    }
+    let offset = bin_data.len();
+    let stride = if vertex_attribute {
+        element_size.next_multiple_of(4)
+    } else {
+        element_size
+    };
+    for element in data.chunks_exact(element_size) {
+        bin_data.extend_from_slice(element);
+        bin_data.resize(bin_data.len() + stride - element_size, 0);
+    }
+
+    let mut view = json!({
+        "buffer": 0,
+        "byteOffset": offset,
+        "byteLength": bin_data.len() - offset,
+    });
+    if stride != element_size {
+        view["byteStride"] = json!(stride);
+    }
+    let views = gltf["bufferViews"]
+        .as_array_mut()
+        .expect("GLTF has buffer views");
+    views.push(view);
+    let view_idx = views.len() - 1;
+
+    fields["bufferView"] = json!(view_idx);
+    let accessors = gltf["accessors"]
+        .as_array_mut()
+        .expect("GLTF has accessors");
+    accessors.push(fields);
+    let accessor_idx = accessors.len() - 1;
+
+    gltf["buffers"][0]["byteLength"] = json!(bin_data.len());
+    accessor_idx
+}
+
+fn add(a: [f32; 3], b: [f32; 3]) -> [f32; 3] {
+    [a[0] + b[0], a[1] + b[1], a[2] + b[2]]
+}
+
+fn sub(a: [f32; 3], b: [f32; 3]) -> [f32; 3] {
+    [a[0] - b[0], a[1] - b[1], a[2] - b[2]]
+}
+
+fn cross(a: [f32; 3], b: [f32; 3]) -> [f32; 3] {
+    [
+        a[1] * b[2] - a[2] * b[1],
+        a[2] * b[0] - a[0] * b[2],
+        a[0] * b[1] - a[1] * b[0],
+    ]
+}
+
+fn dot(a: [f32; 3], b: [f32; 3]) -> f32 {
+    a[0] * b[0] + a[1] * b[1] + a[2] * b[2]
+}
+
+fn normalize(v: [f32; 3]) -> [f32; 3] {
+    let len = dot(v, v).sqrt();
+    if len < 1e-12 {
+        return [0.0, 1.0, 0.0];
+    }
+    [v[0] / len, v[1] / len, v[2] / len]
+}
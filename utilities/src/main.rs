@@ -28,9 +28,16 @@ fn main() {
             }
             commands::flipnormals::run(&args[2]);
         }
+        "recalcnormals" => {
+            if args.len() < 3 {
+                eprintln!("Usage: mirgo-utils recalcnormals <path/to/file.gltf> [--angle <degrees>]");
+                eprintln!("Example: mirgo-utils recalcnormals assets/models/duck.gltf --angle 60");
+                process::exit(1);
+            }
+            commands::recalcnormals::run(&args[2], parse_angle(&args[3..]));
+        }
         "build" => {
-            let output_name = args.get(2).map(|s| s.as_str());
-            commands::build::run(output_name);
+            commands::build::run(parse_build_args(&args[2..]));
         }
         "help" | "--help" | "-h" => {
             print_usage();
@@ -48,6 +55,51 @@ fn print_usage() {
     eprintln!("Commands:");
     eprintln!("  newscript <Name>    Create a new Go script component");
     eprintln!("  flipnormals <path>  Flip normals in a GLTF model");
-    eprintln!("  build [name]        Build game as macOS .app bundle");
+    eprintln!("  recalcnormals <path> [--angle <deg>]");
+    eprintln!("                      Recompute GLTF normals from the faces (default");
+    eprintln!("                      smoothing angle 60; 0 = hard, 180 = smooth)");
+    eprintln!("  build [name] [--out <dir>] [--target <os/arch>]...");
+    eprintln!("                      Build the game (defaults from project.json;");
+    eprintln!("                      darwin targets are packaged as .app bundles)");
     eprintln!("  help                Show this help message");
 }
+
+fn parse_build_args(args: &[String]) -> commands::build::BuildOptions {
+    let mut opts = commands::build::BuildOptions {
+        name: None,
+        out: None,
+        targets: Vec::new(),
+    };
+    let mut iter = args.iter();
+    while let Some(arg) = iter.next() {
+        match arg.as_str() {
+            "--out" | "--target" => {
+                let Some(value) = iter.next() else {
+                    eprintln!("Missing value for {arg}");
+                    process::exit(1);
+                };
+                if arg == "--out" {
+                    opts.out = Some(value.clone());
+                } else {
+                    opts.targets.push(value.clone());
+                }
+            }
+            _ => opts.name = Some(arg.clone()),
+        }
+    }
+    opts
+}
+
+fn parse_angle(args: &[String]) -> f32 {
+    match args {
+        [] => 60.0,
+        [flag, value] if flag == "--angle" => value.parse().unwrap_or_else(|_| {
+            eprintln!("Invalid angle: {value}");
+            process::exit(1);
+        }),
+        _ => {
+            eprintln!("Usage: mirgo-utils recalcnormals <path/to/file.gltf> [--angle <degrees>]");
+            process::exit(1);
+        }
+    }
+}